@@ -77,7 +77,8 @@ func TestServe(t *testing.T) {
 					Name:    &taskName,
 					Enabled: config.Bool(true),
 				}, nil).
-					On("Events", mock.Anything, taskName).Return(map[string][]event.Event{}, nil)
+					On("Events", mock.Anything, taskName).Return(map[string][]event.Event{}, nil).
+					On("TaskDamping", mock.Anything, taskName).Return(nil, nil)
 			},
 			http.StatusOK,
 			`{"task_b":{"task_name":"task_b","status":"unknown","enabled":true,"events_url":"","providers":null,"services":null}}
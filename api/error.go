@@ -1,5 +1,18 @@
 package api
 
+import "fmt"
+
+// TaskNotFoundError is the error returned by the controller when a task does
+// not exist, which the API returns as 404 Not Found
+type TaskNotFoundError struct {
+	Name string
+}
+
+func (e TaskNotFoundError) Error() string {
+	return fmt.Sprintf("a task with name '%s' does not exist or has not been "+
+		"initialized yet", e.Name)
+}
+
 // ErrorObject is the object to represent an error object from the API server
 type ErrorObject struct {
 	Message string `json:"message"`
@@ -102,10 +102,18 @@ type ClientInterface interface {
 	CreateTask(ctx context.Context, params *CreateTaskParams, body CreateTaskJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// DeleteTaskByName request
-	DeleteTaskByName(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+	DeleteTaskByName(ctx context.Context, name string, params *DeleteTaskByNameParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetTaskByName request
 	GetTaskByName(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTaskOutputsByName request
+	GetTaskOutputsByName(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TriggerTaskByName request with any body
+	TriggerTaskByNameWithBody(ctx context.Context, name string, params *TriggerTaskByNameParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TriggerTaskByName(ctx context.Context, name string, params *TriggerTaskByNameParams, body TriggerTaskByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -156,8 +164,8 @@ func (c *Client) CreateTask(ctx context.Context, params *CreateTaskParams, body
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteTaskByName(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteTaskByNameRequest(c.Server, name)
+func (c *Client) DeleteTaskByName(ctx context.Context, name string, params *DeleteTaskByNameParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteTaskByNameRequest(c.Server, name, params)
 	if err != nil {
 		return nil, err
 	}
@@ -180,6 +188,42 @@ func (c *Client) GetTaskByName(ctx context.Context, name string, reqEditors ...R
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetTaskOutputsByName(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTaskOutputsByNameRequest(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TriggerTaskByNameWithBody(ctx context.Context, name string, params *TriggerTaskByNameParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTriggerTaskByNameRequestWithBody(c.Server, name, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TriggerTaskByName(ctx context.Context, name string, params *TriggerTaskByNameParams, body TriggerTaskByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTriggerTaskByNameRequest(c.Server, name, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 // NewGetHealthRequest generates requests for GetHealth
 func NewGetHealthRequest(server string) (*http.Request, error) {
 	var err error
@@ -295,7 +339,7 @@ func NewCreateTaskRequestWithBody(server string, params *CreateTaskParams, conte
 }
 
 // NewDeleteTaskByNameRequest generates requests for DeleteTaskByName
-func NewDeleteTaskByNameRequest(server string, name string) (*http.Request, error) {
+func NewDeleteTaskByNameRequest(server string, name string, params *DeleteTaskByNameParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -320,6 +364,42 @@ func NewDeleteTaskByNameRequest(server string, name string) (*http.Request, erro
 		return nil, err
 	}
 
+	queryValues := queryURL.Query()
+
+	if params.Destroy != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "destroy", runtime.ParamLocationQuery, *params.Destroy); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Run != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "run", runtime.ParamLocationQuery, *params.Run); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
 	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -362,6 +442,96 @@ func NewGetTaskByNameRequest(server string, name string) (*http.Request, error)
 	return req, nil
 }
 
+// NewGetTaskOutputsByNameRequest generates requests for GetTaskOutputsByName
+func NewGetTaskOutputsByNameRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/tasks/%s/outputs", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTriggerTaskByNameRequest calls the generic TriggerTaskByName builder with application/json body
+func NewTriggerTaskByNameRequest(server string, name string, params *TriggerTaskByNameParams, body TriggerTaskByNameJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTriggerTaskByNameRequestWithBody(server, name, params, "application/json", bodyReader)
+}
+
+// NewTriggerTaskByNameRequestWithBody generates requests for TriggerTaskByName with any type of body
+func NewTriggerTaskByNameRequestWithBody(server string, name string, params *TriggerTaskByNameParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/tasks/%s/trigger", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	var headerParam0 string
+
+	headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-CTS-Signature", runtime.ParamLocationHeader, params.XCTSSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-CTS-Signature", headerParam0)
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -417,10 +587,18 @@ type ClientWithResponsesInterface interface {
 	CreateTaskWithResponse(ctx context.Context, params *CreateTaskParams, body CreateTaskJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateTaskResponse, error)
 
 	// DeleteTaskByName request
-	DeleteTaskByNameWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*DeleteTaskByNameResponse, error)
+	DeleteTaskByNameWithResponse(ctx context.Context, name string, params *DeleteTaskByNameParams, reqEditors ...RequestEditorFn) (*DeleteTaskByNameResponse, error)
 
 	// GetTaskByName request
 	GetTaskByNameWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetTaskByNameResponse, error)
+
+	// GetTaskOutputsByName request
+	GetTaskOutputsByNameWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetTaskOutputsByNameResponse, error)
+
+	// TriggerTaskByName request with any body
+	TriggerTaskByNameWithBodyWithResponse(ctx context.Context, name string, params *TriggerTaskByNameParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TriggerTaskByNameResponse, error)
+
+	TriggerTaskByNameWithResponse(ctx context.Context, name string, params *TriggerTaskByNameParams, body TriggerTaskByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*TriggerTaskByNameResponse, error)
 }
 
 type GetHealthResponse struct {
@@ -496,6 +674,7 @@ func (r CreateTaskResponse) StatusCode() int {
 type DeleteTaskByNameResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
+	JSON200      *TaskDeleteResponse
 	JSON202      *TaskDeleteResponse
 	JSONDefault  *ErrorResponse
 }
@@ -539,6 +718,52 @@ func (r GetTaskByNameResponse) StatusCode() int {
 	return 0
 }
 
+type GetTaskOutputsByNameResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TaskOutputsResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTaskOutputsByNameResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTaskOutputsByNameResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TriggerTaskByNameResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *TaskTriggerResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r TriggerTaskByNameResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TriggerTaskByNameResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // GetHealthWithResponse request returning *GetHealthResponse
 func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
 	rsp, err := c.GetHealth(ctx, reqEditors...)
@@ -575,8 +800,8 @@ func (c *ClientWithResponses) CreateTaskWithResponse(ctx context.Context, params
 }
 
 // DeleteTaskByNameWithResponse request returning *DeleteTaskByNameResponse
-func (c *ClientWithResponses) DeleteTaskByNameWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*DeleteTaskByNameResponse, error) {
-	rsp, err := c.DeleteTaskByName(ctx, name, reqEditors...)
+func (c *ClientWithResponses) DeleteTaskByNameWithResponse(ctx context.Context, name string, params *DeleteTaskByNameParams, reqEditors ...RequestEditorFn) (*DeleteTaskByNameResponse, error) {
+	rsp, err := c.DeleteTaskByName(ctx, name, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -592,6 +817,32 @@ func (c *ClientWithResponses) GetTaskByNameWithResponse(ctx context.Context, nam
 	return ParseGetTaskByNameResponse(rsp)
 }
 
+// GetTaskOutputsByNameWithResponse request returning *GetTaskOutputsByNameResponse
+func (c *ClientWithResponses) GetTaskOutputsByNameWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetTaskOutputsByNameResponse, error) {
+	rsp, err := c.GetTaskOutputsByName(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTaskOutputsByNameResponse(rsp)
+}
+
+// TriggerTaskByNameWithBodyWithResponse request with arbitrary body returning *TriggerTaskByNameResponse
+func (c *ClientWithResponses) TriggerTaskByNameWithBodyWithResponse(ctx context.Context, name string, params *TriggerTaskByNameParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TriggerTaskByNameResponse, error) {
+	rsp, err := c.TriggerTaskByNameWithBody(ctx, name, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTriggerTaskByNameResponse(rsp)
+}
+
+func (c *ClientWithResponses) TriggerTaskByNameWithResponse(ctx context.Context, name string, params *TriggerTaskByNameParams, body TriggerTaskByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*TriggerTaskByNameResponse, error) {
+	rsp, err := c.TriggerTaskByName(ctx, name, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTriggerTaskByNameResponse(rsp)
+}
+
 // ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
 func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -712,6 +963,13 @@ func ParseDeleteTaskByNameResponse(rsp *http.Response) (*DeleteTaskByNameRespons
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TaskDeleteResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
 		var dest TaskDeleteResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -763,3 +1021,69 @@ func ParseGetTaskByNameResponse(rsp *http.Response) (*GetTaskByNameResponse, err
 
 	return response, nil
 }
+
+// ParseGetTaskOutputsByNameResponse parses an HTTP response from a GetTaskOutputsByNameWithResponse call
+func ParseGetTaskOutputsByNameResponse(rsp *http.Response) (*GetTaskOutputsByNameResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTaskOutputsByNameResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TaskOutputsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTriggerTaskByNameResponse parses an HTTP response from a TriggerTaskByNameWithResponse call
+func ParseTriggerTaskByNameResponse(rsp *http.Response) (*TriggerTaskByNameResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TriggerTaskByNameResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest TaskTriggerResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
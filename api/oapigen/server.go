@@ -31,10 +31,16 @@ type ServerInterface interface {
 	CreateTask(w http.ResponseWriter, r *http.Request, params CreateTaskParams)
 	// Marks a task for deletion
 	// (DELETE /v1/tasks/{name})
-	DeleteTaskByName(w http.ResponseWriter, r *http.Request, name string)
+	DeleteTaskByName(w http.ResponseWriter, r *http.Request, name string, params DeleteTaskByNameParams)
 	// Gets a task by name
 	// (GET /v1/tasks/{name})
 	GetTaskByName(w http.ResponseWriter, r *http.Request, name string)
+	// Gets the outputs of a task
+	// (GET /v1/tasks/{name}/outputs)
+	GetTaskOutputsByName(w http.ResponseWriter, r *http.Request, name string)
+	// Triggers a task with a webhook condition
+	// (POST /v1/tasks/{name}/trigger)
+	TriggerTaskByName(w http.ResponseWriter, r *http.Request, name string, params TriggerTaskByNameParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -122,8 +128,33 @@ func (siw *ServerInterfaceWrapper) DeleteTaskByName(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteTaskByNameParams
+
+	// ------------- Optional query parameter "destroy" -------------
+	if paramValue := r.URL.Query().Get("destroy"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "destroy", r.URL.Query(), &params.Destroy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "destroy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "run" -------------
+	if paramValue := r.URL.Query().Get("run"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "run", r.URL.Query(), &params.Run)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "run", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteTaskByName(w, r, name)
+		siw.Handler.DeleteTaskByName(w, r, name, params)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -159,6 +190,86 @@ func (siw *ServerInterfaceWrapper) GetTaskByName(w http.ResponseWriter, r *http.
 	handler(w, r.WithContext(ctx))
 }
 
+// GetTaskOutputsByName operation middleware
+func (siw *ServerInterfaceWrapper) GetTaskOutputsByName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameter("simple", false, "name", chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTaskOutputsByName(w, r, name)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// TriggerTaskByName operation middleware
+func (siw *ServerInterfaceWrapper) TriggerTaskByName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameter("simple", false, "name", chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TriggerTaskByNameParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CTS-Signature" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CTS-Signature")]; found {
+		var XCTSSignature string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CTS-Signature", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-CTS-Signature", runtime.ParamLocationHeader, valueList[0], &XCTSSignature)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CTS-Signature", Err: err})
+			return
+		}
+
+		params.XCTSSignature = XCTSSignature
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CTS-Signature is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CTS-Signature", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TriggerTaskByName(w, r, name, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -287,6 +398,12 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v1/tasks/{name}", wrapper.GetTaskByName)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tasks/{name}/outputs", wrapper.GetTaskOutputsByName)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tasks/{name}/trigger", wrapper.TriggerTaskByName)
+	})
 
 	return r
 }
@@ -294,59 +411,92 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xaa2/bRtb+K/NOXmDTru62k1hAP6SOd2tskga2236IDGE4cyhOTc6wM0MrgqH97Yu5",
-	"kCJFyrLcJg3QpkAakXM59/Occ3iPqcxyKUAYjaf3WNMEMuL++X0Rx6A+gOKS2d+EMW64FCT9oGQOynDQ",
-	"eBqTVEMPM9BU8dy+x1N8nQCK3HaUu/0olgoZxRcLUFwskCH6FsEnoIXdMcA9nNfOvMcgSJSCu7Z58i8J",
-	"mAQUMq0buEZhF5IKMa7dvwfoDcSkSI1GRrpdi1RGJN3aTKWI+aJQ4Ck9u76yNMEnkuUp4KlRBfSwWeWA",
-	"pziSMgUi8LqHM/KpTaJlPiOfeFZk5fEyRoZnYElYEm4QiQ0oRBMiFqARUYAYGKAGGIoglgoaskrAyeuP",
-	"YQWfaFyxoo29wXHCxQ5OuPhaOZmMOlhZV09k9CtQY5k7I4akcnEF6o5T0GdSeEvea9VNo2TEEArCgHIm",
-	"WtHB6LhLpIJkoHNCYWu1Z71zh2Qwz8CQ3YTdt3dVR9/jW1jhKb4jaQG4SxAKFvApb9KzhGjwbRc1hYY5",
-	"0fNMsiKFORd5YbyJePqDU1QHBZFtO4m79beCK+vNH0sKbrq09Gi1tK2UlnuRFGiZcJo4y/KmV9mdfeaD",
-	"DgzQRbx5nhDtfjDIFVBirVcHY0Exh7Rhi0QjgrxUkJNKD3Fjw4+yu7U1EIYSUGBXVoQNygPbwY5685yX",
-	"K+yz/1cQ4yl+NtyE52GIzcOd5rzuYSqFLtL57d3eQ9zC//zc2G1fWr72bb4K65qbH0l+B93rbnPYIvAr",
-	"89acmKS5OFv1rQd2rFVAC6Wh4T+B6n0O9Jkc0VF/84Dc37nrLsrb/oKSf6zEzpWS6kAZZaA1WWyxbBKu",
-	"bSAhAoE9E5WrurJcnbRy3U7qLkHnUngxbCGtkviHXNZzGC4Fbeac7dty6VdevGkR629snHWz7uEfgKQm",
-	"OUuA3tapPUCmh7DSSksbWjpkeIgvtJPTZnkjbTzX3yCTEFOlIY1yJe84gwoWXYNSJJYqKzdKUUPNXyiF",
-	"1ePOQ1ns0MxTF+oT0kdje1cC2Vhgw8mi6MURZS9H/Vfx8Un/OD6e9KPJy6gf0Ql5ER+fHo3hBe5hK3Vi",
-	"8BQXBWddgeWyODQjBZQ8DyLeXdxIhYQ0iItYEW1UQU2hoALZS6ijbFZsCioudA60rKjaiSRPidiKm06I",
-	"AwPa9B0yTyUl6TzmKQwWCsBwscElU3QJsQKd2Au1IQYGgwH6yNl3E3YyOj6Njl+y8Qt2So/Z+ITSk9PT",
-	"k1HM2BGDyXH08vTl+MXNTDzmxt0XvTg9Op7QE3p0CicETuLR6OVLApQeTegofjV+NR7H0avx6dHNTMzE",
-	"xnsKDcx5h4bUiy14mnKutgABihhwS2KZpnJpb648bSas5AboErQsFAVEnJB9vcMF497fltwkW0foVRbJ",
-	"VE9noj/8J2KgjZIrRISjRiCqwF6rIE8JhQyEadK95GlqqyH3o3lyIGFqNyD0DB2kSZQV2qCoupl5+lTJ",
-	"3wxvds8wmuHWCTOM7u3F9s9/bWgxIAxq/PkOzYrR6Ij6v/vnP16jZ7aQs/c3ON5s6aMfIE1lD5Gc/1/9",
-	"BSpfLCF6zIvzH6831HGG2n++QzP8WLOdYdR3XAB6fivkUoSyl+R5uvpmc+sz9PwIFcI7KkPEGMWjwoBG",
-	"CWcMRFi6tjr7kBIxRWNrfoSxHhrZf/mdPf84WMtgJrrCj4npXBViXqi0HUjOLfjKFdc2Y6SrAfrp8q0t",
-	"3TeWdZbKgiFVCJ+CqFTKJV1W5R4XUVQhmjV3Ykyup8MhyfOBKU8bcGkfDLNVX6rFcCnVrQN02j5Z6qEq",
-	"hPurTyL6Bv61+IH/ejueHB2fPK58b1cbB8ZdJbfC3rfI//dOir3Yyu3uAgW/t51AjZ4XGtScQcwFsMMr",
-	"/xZJByLvmKetpbPZDNsgYv+PuECBy8E1Weid6L1xxEe8hAj3MMm5lRs3kD1IPlGKrJ5WCPw5/YydlvD0",
-	"kulvW/iSttAlrmuib/cqrdZqo3Wvr2PXIIQG5/bGZoR+jSKiOXVR1gbYst/tjdDbqKVPLYbh0mF46GVj",
-	"q0Wib888DPdQBk8/3vTwHVHcHuaIuSNqjKcl3QNXCFhu70BpT8h4MBqMHDhs2JfvxM7zqvv/ECRvTAp8",
-	"E2ojmz2lwKZ/1BBQVys6KTIikALCLH/IwCcT8iRVPIJNe7mRsWwx7X+Uwm53UOvThkY02D188IC7c+aA",
-	"YiWzEj2KxeMmCbLsu7X5tljM9zbjzqqwyW+nybT7/VtR8CEtbRVq3vy6CC0E/60AZBeUtLb1YZ+87uzq",
-	"bOy4UwpcG3tqucxdo5sV9D/KatUift249+NB4aeCNnNqgdK8gjT7ZFXpxgGsX6ptjTMr79vm801VvPcs",
-	"B156O2kZtE60FaABwgaohQCtCMtVDSRopLvKDegaxuUhYnUbIlpLypuVjp/mXIe+lb0JkTvCU+egS1vi",
-	"uNKrWr99OlP8DlR7HpQSm3eQFTAx3B5WcRi72liDaZqVj2MdZtWIhw+p7uew8B3JGyGyyxhrkjR+/FC2",
-	"VoL9NczSW+MuJp/I2RZMdV5ZxZF6DL7Zke3eQAoGvkCv7Y9pG+5p0VmOwuYDWTEh8z/o1nbNNkVu425a",
-	"vla59rAq9mbmy8KlpSfL5hHa0k8U0ROZdh1Wu6PKAvuZ2k4MBzK5Ixcc1kFuRfKzEGw8JnDDXv1VRPFW",
-	"S5gsQJh5LmUalLWHs9d2PbLr0cUby5IG8ztY8qTbX1Unz4ZnsEzOPHEzPEDn3IG6BrEWztUeOETj2uNe",
-	"+TZWP3jmRYwiaRLXINRger7d17zCkFvQyCZ8YCDoFowjdll/PDnqymlbpD1CtO8DJiMbEf+15WtT73yz",
-	"obMsKCmYWwoeIeTzJsm/W8ADdEaE98cI0AwryKSBGbbSqwmjjis2i7bMyS7u7CPuR6V/Y8ndbYM6aDyk",
-	"XdP1yVhuhVnBVQ8hrYGHiofVO7NVpVOb9JRUWUK5iGVoUxhCTdmYcIGF942UKReLPpUK2tS8/nCB3kha",
-	"ZCCMTzLu8ys3tutXUu9frQTtuVeZdHMQPzKz6zUA+ug3oPcXr9HrDxc3z8vW8XK5HPhh4YDLIZNUDwUn",
-	"Q5Lzb3APp5xCwASB4Hcf3vYngxF6G970sOt5V63oBTdJEQ2ozIYJ0QmnUuVDf0G/su6+Xgk6jFIZDTPC",
-	"xfDtxdn5+6tz5wHcOK2fXV9ZQnFnd0TmIEjO8RQfBePIiUmcbod342Hihtf21wI6Bntuqu3ns36l1fTZ",
-	"9RV2B/tMfsHwFP8bjJ+Du/m4h0fuksloVKozjA5JnqfcNwaGv+rQh3LoZR+26Zq0r9stKisPrgPBK28m",
-	"oTnypxBSiIqUdQ/rIsuIWnmZlVS6YWHhepNk4ZpwQTGuA2cVVaHATj1dglEc7kA3rNmaOElTP6PvUtnr",
-	"NL0O7z6b0pqIuUNKbgFSgQP2OfTV/JSkg4afBHzK/YAVqu8stjRVl2SpJf/7Zt3DudRd/uPmpBoRJGDp",
-	"druhWFMRftG17/HlRJEMjO+KttosPI5BWSBk87R2ClaFEFwsBuiqyHOpjHbDMSGX4XNCVQhda/xlGTCb",
-	"v9LVTBDhJ2lh/B420IpmplZ+0uY+QbRR3aY4vxiYmwYzrilRDNhMhL4BCFZ2F2pjfcc2tzz8VoBabZrB",
-	"tqTr1dQIoshcW0Au3Q53Qq1KqXLaTVVFfi/Z6g8117Ic32GsbuDphITrZZVRBaw/syPt8yNU3u7RxkYB",
-	"Pa9Eixo86c7PJqPxn0Ner2pD16j52ry+7bwdnl8Pz8N7a9RrHwZSMB3Q+x1Rt/ZEzcUiNPadF7v1NmZH",
-	"xGJF6csTV16UKMrDV1+/8DRFEcyEv8aupxC+hLIqLmNCR7DxzTOrjO9X733r7cGQU9Zf5WfIgbHgzO7T",
-	"wsqXQyuv6RIN597XTPde3XCgySPsoTbeqjdZHvfJ1Lp3gIVv9R532XlG1G34+r/U7Ndo4aU1tsywM8Ud",
-	"ijwaRr7brruAydPts8QRX9BCv3iI/+qRUlD5CgV5t4Jm+GyyW6U2zHUWbW6OD6oqpO5zJY2kMl1Ph8P7",
-	"RGqznt5bDLTGW+OTpEJnQVz+OzH32IE3tfX61cnJqzDbczc039oKzg2/PVYJP11d57i7Wf8vAAD//+6j",
-	"naGfNgAA",
+	"H4sIAAAAAAAC/+x8e3PcNpL4V8GP2arN7m/eethSVf5wZN9Zt7bjsrTJ1Xl0syDYnEFEAgwAzmhONffZ",
+	"r/Aghw/MS5ZkpxKnKvaQaKC70d3oF3gfEJ5mnAFTMji/DySZQYrNP3/M4xjERxCUR/o3jiKqKGc4+Sh4",
+	"BkJRkMF5jBMJnSACSQTN9PvgPLieAQoNOMoMPIq5QErQ6RQEZVOksLxFcAck1xC9oBNklTnvA2A4TMAs",
+	"W5/5lxmoGQikWitQiRwU4gJFVJp/99BriHGeKIkUN1DThIc4aQATzmI6zQVYTC+urzROcIfTLIHgXIkc",
+	"OoFaZhCcByHnCWAWrDpBiu/aKGriU3xH0zwtpucxUjQFjcICU4VwrEAgMsNsChJhASgCBURBhEKIuYAa",
+	"r2Zg+PU4pAQnMihJkUqvYCihbAMllH2rlIwGHlJW5RMe/gpEaeIusMIJn16BmFMC8oIzK8k7pboulBFW",
+	"mABTIPSvNR4RGfpYuh4ua+M/O4CIjIKbTkAVpGZAawL3AAuBl/o3wynIDBNoLG956UOB8QgmKSi8mVLP",
+	"uuXU98EtLIPzYI6THAIfZzMsVMnKvVDKoMk+kuRSgegORr7xAqZwl9UhFhD2/u4bnEuYYDlJeZQnMKEs",
+	"y5WVaouO0+NyIrfLTb02q/6WUwGR3i2HwY1PsPaWpLZikQIWcYYWM0pmRhmstpSqop9ZOwk9dBmvn8+w",
+	"ND8iyAQQrBVOOvlGMYWkpj5YIowsV5DhSgdRpS2m0NASmAafgQA9skSsV0zYts/EatSkGKGf/UVAHJwH",
+	"3/XXJ0rfHSf9jRq46gQWzwkwJegeM5nRb+zg5jwyTya38z2mkHnyj59r0DOlsl2Ab6+vP9aAKFPA9I+d",
+	"aF+WI2sTaAXdCftBD6qB6Td6M3dBXrlxdeA998y7WQsIZ5zf7oL9xQ6rgK786uPbyye1yreURfWBlE0F",
+	"SNmdYgULvPQBacvrBdo0+DAz7bNx/12g9Wx2znDmZtc2vTdrXhZL/rlRWzbqAAY3DNLTOiZAeNQg+lfJ",
+	"2ePw6AEuAVaz+uB02dWeh3cHSC4k1KTd8WSXuD+R2hjst+3qs2nMH3Rf992P1zjN9GqHu2qRhdTBTxHp",
+	"KF64W4gyqTAzflc9yMXWU1tQNUN47Z2t/auWW5Xiu4nMs0yAlJSziQ61DgswZ1iVOBLMUAiomPCAkGw4",
+	"MNNZDzOlbCKVjqYNPo1oMt0QTVZBarsaHJmwrU1SgxTNNp4rxGBR5bqJOjfTYfagQrGDrCM99CKtZgLk",
+	"jCdRDd2BD1WWpyGItTxEHmEwW8EAIoN2CAjuCEAEkdfNL6jV5FE27aH/AsGLLIbdhxK/GjEnJSWUKZiC",
+	"qJEyyUBoC7KbJDcQT+GbIGs0aNPlcyHfCMHFgWY1BSnxtGH51IxKRCXCDIGeExWjdp3qxbibTdh9Aplx",
+	"Zm1bHREokN/mSlsK3aIg1YRGu0A+2ZGXr1vI2hVrc92sOkE9sDmMmzPAkUtxPCTD8CpXMy7o/2C7dPAj",
+	"YKEliN8C86YctDiIOU78drGWnApBLQAYctSW2aZ/fnpXNwinA6+DKCEBorjwL/UfVz99+IjVrJvQW60G",
+	"hdW2Z4OGRBiZ1AmKBU+RcjBIOIGo4/CXnj7ue5mAmN6BFx9NHM+VHx330hhAwGRWUL2R6KGfaJXIfeLg",
+	"63dXenQukroe6Thanvf7NMNpzz3uEZ72cUb724h7EudMo+dTTE3Bw/2yP0X+T5H/cpE/QFT1yoe7rdfv",
+	"rsokoJE1w6iqZGCGDPIFp5pJvgkBoerE9rV/3Ve8T3AvA68jRfCk7fqv4UAo7y5sWwuE2rRapVS0s15j",
+	"0tneFW5huWkB7QOBmHhSC4399gHPQdB4uc2Q+XH1mYG3gBM1u5gBua36FAcYrkMcjlYWfO0xeHDz5Tef",
+	"NNiNadIa+hqkosyI+gecAvrhBzQOcEbHweOEwI92RG1h37NlC56fgT7CDyG3beHWw2vVje/l32ycUsQh",
+	"EmWCz2kE5Xl4DULgmIu0AOSsUo9+pkpLVZC2FFtwQrGEre7GNn2usnjl42KCQ0gSiGp4S8QrPLiFpU4f",
+	"LJHBpYOgN+2hf1XxR+OysDAO0DgIMbkFFslx8K8eeqMPZ+8yJuSqP7HCYdMmnEH9pRagHro0cG5Po2JP",
+	"3UC3XXMsqD4ZkBbTyBWq9QtDQi/wCOMXVKIaTD64FtWA37ca1QB7SD2qMcX+FakG4KFlpQb43JrQ7bA/",
+	"60E1QJ9NadTLnt2KvgeFe8Dm1nxmgkfj4KtU55/uvGoJwB+VyT7mrNMvNYzD8PSIRC8G3Zfx8Un3OD4e",
+	"dcPRi7AbkhE+jY/PjoZwGnQCfShhFZwHeU4jH0Wf8kNl2iVBJ+4E2txVxQViXCHKYoGlEjlRuYAy+7qA",
+	"antPlK87uSiTGZAip932u7MEN8oGViZ7CqTqmvxzwglOJjFNoDcVADpbWHYXnKNPEAuQM72gVFhBr9dD",
+	"n2n0wyg6GRyfhccvouFpdEaOo+EJISdnZyeDOIqOIhgdhy/OXgxPb8ZsnxU3L3R6dnQ8Iifk6AxOMJzE",
+	"g8GLFxgIORqRQfxy+HI4jMOXw7OjmzEbs7VzkUuIzKFjo2OIikNLGE9kCgwEVmCGxDxJ+EKvXDoiY6Y5",
+	"10OfQPJcEEDYMNk2WlEWUeuOmKOyPoVcpiFP5PmYdfv/H0UgleBLhJnBhiEiQC8rIEswgRSYquO9oEmC",
+	"MhDmR31mh8K5BkDoO3TQTqI0lwqF5cqRxU8U9I2DNbRxI1ozjAN0rxfWf/5Xe14KmEK1Pz+gcT4YHBH7",
+	"/+6bn67Rdzr01evXKF6DdNFbSBLeQTij/6/6AhUvFhDu8+LNT9dr7GiE2n+0udpXbMcB6hoqAH1/y/iC",
+	"OTcGZ1my/Nt61e/Q90coZ0XWHislaJgrkGhGowiYG7rSe/YxwewcDU34H0UdNND/spAd+9hJS2/srRyq",
+	"mExEziYuDVI3JG+0Tc8ElYA4S5Y9nVjQjuRasi4SnkdI5MzVqriwuaGodM2NRRE5q6duiiQLzrKeKmbr",
+	"Ua4f9NNll4tpf8HFrQlIpH6ykH2RM/O/Lg7Ja/i36Vv66+1wdHR8sl9w0m6fOdDuima19O/I/vfeV5Vt",
+	"RNwG2hdrf2kfI1FykksQkwhiyiA6/HhsoRSta6vb/LeiBFvrjvw6zZQ+n2I8HgcKpNJ/I8qQY3TvGk+9",
+	"GSs6ZVyArnSqXE7cMbm1hL0pMWVC6QZhCwiDjo6+n7pL9Btr4rTsrLExyLCU+nXVIiywYPbZMyZqfNHL",
+	"N6B9v39d+lMDHlEDfEJyjeXtTlGtxD2kerpUg3u3U7XtWbUSS69QiCUl5jQPKll6q3pWMzV+Ytp3i/bd",
+	"w6KLL9CgFzYbZl3m4PzzTScoEjsGmTkWw+C8wLtn8nEu7y4tIsPeoDcwW1bTKnvVYJKV11u2nVu1qzCr",
+	"Tp03O9I7697ZGoN89axZnmKGBOBI04cU3CnnjxFBQ1g369SkADPkfhTMbmu89egmnE0iSEB5+6w2XLQp",
+	"/UGzfCM0TDHDU5sZXPd86ACj/EUlskvW+zs29ujVbv7UzPXmi0DFQp77P7YO6QIqNt3rVg/cZVzChOdK",
+	"50G33EHiiPDEVD1LLKwM/1UiC22LobKSfSxwlTkhIGWcJ8nS+PMUoh76ya6JUixuTZ6ZSaroHAoGFylO",
+	"ASZQd8u3rjmJHBCNHQ5ycjs3BTmzLKj99qHQUJ+c6hhNmZsLsTeZXlvBr+LtlrHGWX1ARruozLURzRn9",
+	"LbcZ4Go6u46ffvLKh1KDff4lbAIX/eNnZFhsy716E7I8TKic7RQNxfeWjk82OpCoLqF1coiSffeiv5G0",
+	"ikn1bjCVSjOsGGY4KOs1lb8W9QuUy0aD3eeDjuvibJlkgitOeFI/+OYDb7egM/DFthpnyl6nKaYpXhXz",
+	"lxWBDgJqFHg+0KZiPuyhn91s86EOim0BAUcpZah0DTrInfAoAxAdNDPVWER0OVbqNACdzpTsFMshhacm",
+	"Fo8iAVKC7JjsywVnDIhCEShME1NkMZ0LzUa7HnLCVRtNGUnyyB4Euptcg7tueD17x96Tc0CZ4HdUr2v5",
+	"U1b2yrU0PnkmlQCcNoRo7nUcy7h7QnQUPynj7V0KWxoIE/3/UoLV5iyPbJ9Ewh0mqrrp5ZRagXIJhvRa",
+	"32HDNEWCzkFYvhbzUGkZbspSOOFsKqljbzGkcQnR8NLM1HGXtqi0WT4aG5PcNLDO//C1BlT9mO1VDzvw",
+	"Pc5qrs39brWoYO+UtabDVnVbtzETrECuuX0gZY00hrHO5XlS9Z1uNnipr42/8AwtDg/sqewEIt/p+eks",
+	"/YE9FJp25wA8lPg5sIKYtmxcvq7VdM3gtTy4M8Nm+UvHQgt9w908w6fxkJDuCR6E3dEJHHVPgRx1R/iM",
+	"vIyGx/g0Ptpymm6myOflXc+gcVzyuH0C1RC8D+Y0M71eWjx7w4E3ZH6MZtrKHGvyNm2sm+bADVUuaNtq",
+	"XPWYJm4GcDMuv3/V6jycN3uo4bVtZH8gox5ZuDYhKZ8TPctvA1+6drs53/T2DiRyg9twWKNQqyZxUeuJ",
+	"XLvhVS+iWbAoXR2EpeSE1utu9gbNtbtCoFdBeI5pYsJ4Ew/nsjq+ObvzTdqdP1NtzzPOE69Rb1H2So9H",
+	"erw29qYpV30BSetQvqxIaosLmsixRW4c9NAb603XkEW89sBEYKYLym6+9im2znkZo5CrmYl0JaiOLVvW",
+	"l1D4FiTKBBCIgJH6MRBgPaw7HHkPowZqe7D2g4sh8ZrFf2z+Kq24awAfl0sMdCZ+Hya/qaP8xQzuoQvM",
+	"rD6GgMaBgJQrGAeaexVmVP3f9aCGOOnB24OijY55i86Gp74xlvkqVmhTOKCNPFY0TNa4Hx4ZtGx8Nbg5",
+	"pAjiuxKZaWaWYZV1bbWANxsFW86jB6tGo9lhJ2y7/V0CEaB0WRpn2c6YaeMd1tZ3FQ7Dy2Lhjw7sO80i",
+	"27NuOCXplGGT6eVx/U5J46ZfD1XmMIYowiZ+oKy8/NHIMcgjIo7Us10FcrS3uboyjZMxd0UPhYkqyhzm",
+	"GKFdxXlC2bRLuIC27L36eIlec5KnwNT6moXNC3ZLHeteLRmxCZqUm+6d2DR6UZOQAfTZAqAPl6/Qq4+X",
+	"N98XDQ+LxaJn20l1t0PEiewzivXdkr8FnSChBJwH6BB+//Fdd9QboHfujbuwUjZQTKma5aG5nzLDckYJ",
+	"F1nfLtAtbVlXLhnphwkP+ymmrP/u8uLNh6s39kaOMtt3cX2lEQ28tRaeAcMZ1bd9nSnQIm1ksD8f9m3u",
+	"TP+a+sTRXHGwSTg7UkvfxfVVYCa2fttlFJwH/w7KXooIOkEpZXq+0WBQbKdreDNJVJu27ptL8eXHznb2",
+	"2XquXazaBS/NDyodwksrJnHR1PoVEMlZicqqE8g8TbFYWp4VWCJXaOwESldlzz8H9rmt5+mNKn1+7z59",
+	"AiUozEHWpFmLOE4S23jv27JXSXLt3j3ZptXjIw+XzAAkHAXRU+xX/favB4d/MrjLbJoFyks3jZ2qcrLY",
+	"JftbX9zNuPTpj+nukwiby/N69Ji1NsIOurYVwwwLnILtCPjcnO411dVPYMp4ZfYzByJnzFzovsqzjAsl",
+	"9RPE+MJlRXXnVaWikaYQUawgWY6ZTjvrwa5p1AGQEudILM17A2nOcCqLwRCZrHVEJcEi0u2DLnsFNhlu",
+	"S5RlM6ohm2oafstBLNelZZ1l6FS2EViemmQlXxgIM0Nw0zqbVjdlYuNHHi0fVVyLDNEGYTXlRsOkoHqw",
+	"6bNw9cSKtEuPyhPe+pbrDejYTTSlSoO60bPRYPh10OuU9bUKNt+a1reV16P5VfPcv9dCvbJmYF3cry75",
+	"HotbPaPuIEkqH8ow47XNDrGECHEbjOrpSp/ZOnauqJIkKIQxs8vo8QTc9Sa9xaVN+Cmz/miyLHoH5Jbm",
+	"gTGrdg8Un/bw9A94jJgtFehN/nH5wRYatpqyIoovPq3nGOaMhHG7SxvBcNpWtZrR2FVCXnXuH62ros2X",
+	"ch9MNO/isE41wGm1fTSuA7vS1WYz6Saomcq2m/3w86J+BAhQuXDHhu57R4qPmUNh7+YT98ERK9nVD9yM",
+	"WVk+L+c0HzDR23r4SbHrjHhCc9yoj+1nlAuavcbZCZI1zqM9kK1UW6rZ5P3uuKw6j0+sa5ipGrVv0bgX",
+	"hrhlgb3e3aFOd82+bzbpPp/84Sa0cKGfzIjefH3v5psPEtyWL5Hj9x7+Qr9SkN0haGq/KmzZvYZrFWTT",
+	"3TRmts2l7Gsy9l/n0yt+/gw3W5vKlj7t9etppeJCuwy1pOdfpS1mH9w/5/ppvK6FUwo345fpRllcj7n4",
+	"3epJszVhk7oUtH77alNteyhFcU/tcQlQjac/CHclZNn4DKD7gG7lK4DGwXanaHk/TideK7n6MXOp1bri",
+	"bZjMfHIn5NGyGF7MTqWdzGhx8bKYpLwwX+2kWmu3R0UciQ8/OwomPpv/rbnz9v2ri+7V21ejk9N6frvK",
+	"KcO9XBYeZI37Y7aJ/R1EmbsuqQ9mNA7kDI9OTn+wVxFncIciOgWpzG9dY1r7nfajUmvy/7N7cX3VvSoQ",
+	"3JMTbr2jeIBPyXE4hAE5iV/GZ/GIjDAcR+QkPA7jF/EoPMZH8VF4Fr2EITkhZ/EoHJKj6BhO4lP8IniE",
+	"zEfFR6Sp+d6evjFwPuyNekc1H9BnSAopzvAy4Tjyy2QHYR1jSIWG6D39cY+8yLfl2za7TDZmfuy4b9OS",
+	"7mvnfHa1/LqSz1isu6sbVRRzlwhEWdm4Lzp+V+f9/v2MS7U6v9dB5ipodFnOSkvteGivG5vHJpsqGq9f",
+	"npy8dP3SntZk85WOThkSup/6L0vdzer/BgA4jrVwX2UAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
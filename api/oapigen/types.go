@@ -25,8 +25,11 @@ type BufferPeriod struct {
 // CatalogServicesCondition defines model for CatalogServicesCondition.
 type CatalogServicesCondition struct {
 	Datacenter       *string                            `json:"datacenter,omitempty"`
+	Datacenters      *[]string                          `json:"datacenters,omitempty"`
 	Namespace        *string                            `json:"namespace,omitempty"`
 	NodeMeta         *CatalogServicesCondition_NodeMeta `json:"node_meta,omitempty"`
+	Partition        *string                            `json:"partition,omitempty"`
+	Peer             *string                            `json:"peer,omitempty"`
 	Regexp           string                             `json:"regexp"`
 	UseAsModuleInput *bool                              `json:"use_as_module_input,omitempty"`
 }
@@ -39,15 +42,41 @@ type CatalogServicesCondition_NodeMeta struct {
 // The condition on which to trigger the task to execute. If the task has the deprecated services field configured as a module input, it is represented here as condition.services.
 type Condition struct {
 	CatalogServices *CatalogServicesCondition `json:"catalog_services,omitempty"`
+	ConfigEntries   *ConfigEntriesCondition   `json:"config_entries,omitempty"`
 	ConsulKv        *ConsulKVCondition        `json:"consul_kv,omitempty"`
+	Http            *HTTPCondition            `json:"http,omitempty"`
+	Intentions      *IntentionsCondition      `json:"intentions,omitempty"`
+	Nodes           *NodesCondition           `json:"nodes,omitempty"`
 	Schedule        *ScheduleCondition        `json:"schedule,omitempty"`
 	Services        *ServicesCondition        `json:"services,omitempty"`
+	Webhook         *WebhookCondition         `json:"webhook,omitempty"`
+}
+
+// ConfigEntriesCondition defines model for ConfigEntriesCondition.
+type ConfigEntriesCondition struct {
+	Datacenter       *string `json:"datacenter,omitempty"`
+	Kind             string  `json:"kind"`
+	Name             *string `json:"name,omitempty"`
+	Namespace        *string `json:"namespace,omitempty"`
+	Regexp           *string `json:"regexp,omitempty"`
+	UseAsModuleInput *bool   `json:"use_as_module_input,omitempty"`
+}
+
+// ConfigEntriesModuleInput defines model for ConfigEntriesModuleInput.
+type ConfigEntriesModuleInput struct {
+	Datacenter *string `json:"datacenter,omitempty"`
+	Kind       string  `json:"kind"`
+	Name       *string `json:"name,omitempty"`
+	Namespace  *string `json:"namespace,omitempty"`
+	Regexp     *string `json:"regexp,omitempty"`
 }
 
 // ConsulKVCondition defines model for ConsulKVCondition.
 type ConsulKVCondition struct {
 	Datacenter       *string `json:"datacenter,omitempty"`
+	Decode           *string `json:"decode,omitempty"`
 	Namespace        *string `json:"namespace,omitempty"`
+	Partition        *string `json:"partition,omitempty"`
 	Path             string  `json:"path"`
 	Recurse          *bool   `json:"recurse,omitempty"`
 	UseAsModuleInput *bool   `json:"use_as_module_input,omitempty"`
@@ -56,11 +85,28 @@ type ConsulKVCondition struct {
 // ConsulKVModuleInput defines model for ConsulKVModuleInput.
 type ConsulKVModuleInput struct {
 	Datacenter *string `json:"datacenter,omitempty"`
+	Decode     *string `json:"decode,omitempty"`
 	Namespace  *string `json:"namespace,omitempty"`
+	Partition  *string `json:"partition,omitempty"`
 	Path       string  `json:"path"`
 	Recurse    *bool   `json:"recurse,omitempty"`
 }
 
+// The damping of changes to service instances for triggering a task with a services condition.
+type Damping struct {
+	// The maximum period of time that changes can be suppressed before triggering the task. Defaults to 10 times the min_stable_time.
+	MaxSuppressionTime *string `json:"max_suppression_time,omitempty"`
+
+	// The period of time without new changes to wait before triggering the task for suppressed changes.
+	MinStableTime *string `json:"min_stable_time,omitempty"`
+
+	// The number of changed service instances that needs to be exceeded to trigger the task without waiting. Zero disables the threshold.
+	Threshold *int `json:"threshold,omitempty"`
+
+	// The percentage of changed service instances that needs to be exceeded to trigger the task without waiting. Zero disables the threshold.
+	ThresholdPercent *int `json:"threshold_percent,omitempty"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Message string `json:"message"`
@@ -72,15 +118,125 @@ type ErrorResponse struct {
 	RequestId RequestID `json:"request_id"`
 }
 
+// HTTPCondition defines model for HTTPCondition.
+type HTTPCondition struct {
+	Headers *HTTPCondition_Headers `json:"headers,omitempty"`
+
+	// The time to wait between requests to the URL.
+	Interval *string `json:"interval,omitempty"`
+
+	// The JSONPath-like expression to select a value from the JSON response.
+	Selector *string `json:"selector,omitempty"`
+
+	// The timeout for each request to the URL.
+	Timeout *string `json:"timeout,omitempty"`
+
+	// The TLS configuration for requests to an https URL.
+	Tls              *HTTPTLS `json:"tls,omitempty"`
+	Url              string   `json:"url"`
+	UseAsModuleInput *bool    `json:"use_as_module_input,omitempty"`
+}
+
+// HTTPCondition_Headers defines model for HTTPCondition.Headers.
+type HTTPCondition_Headers struct {
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// HTTPModuleInput defines model for HTTPModuleInput.
+type HTTPModuleInput struct {
+	Headers *HTTPModuleInput_Headers `json:"headers,omitempty"`
+
+	// The time to wait between requests to the URL.
+	Interval *string `json:"interval,omitempty"`
+
+	// The JSONPath-like expression to select a value from the JSON response.
+	Selector *string `json:"selector,omitempty"`
+
+	// The timeout for each request to the URL.
+	Timeout *string `json:"timeout,omitempty"`
+
+	// The TLS configuration for requests to an https URL.
+	Tls *HTTPTLS `json:"tls,omitempty"`
+	Url string   `json:"url"`
+}
+
+// HTTPModuleInput_Headers defines model for HTTPModuleInput.Headers.
+type HTTPModuleInput_Headers struct {
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// The TLS configuration for requests to an https URL.
+type HTTPTLS struct {
+	CaCert     *string `json:"ca_cert,omitempty"`
+	CaPath     *string `json:"ca_path,omitempty"`
+	Cert       *string `json:"cert,omitempty"`
+	Enabled    *bool   `json:"enabled,omitempty"`
+	Key        *string `json:"key,omitempty"`
+	ServerName *string `json:"server_name,omitempty"`
+	Verify     *bool   `json:"verify,omitempty"`
+}
+
 // HealthCheckResponse defines model for HealthCheckResponse.
 type HealthCheckResponse struct {
 	Error *Error `json:"error,omitempty"`
 }
 
+// IntentionsCondition defines model for IntentionsCondition.
+type IntentionsCondition struct {
+	Datacenter       *string `json:"datacenter,omitempty"`
+	Filter           *string `json:"filter,omitempty"`
+	Namespace        *string `json:"namespace,omitempty"`
+	UseAsModuleInput *bool   `json:"use_as_module_input,omitempty"`
+}
+
+// IntentionsModuleInput defines model for IntentionsModuleInput.
+type IntentionsModuleInput struct {
+	Datacenter *string `json:"datacenter,omitempty"`
+	Filter     *string `json:"filter,omitempty"`
+	Namespace  *string `json:"namespace,omitempty"`
+}
+
 // The additional module input(s) that the tasks provides to the Terraform module on execution. If the task has the deprecated services field configured as a module input, it is represented here as module_input.services.
 type ModuleInput struct {
-	ConsulKv *ConsulKVModuleInput `json:"consul_kv,omitempty"`
-	Services *ServicesModuleInput `json:"services,omitempty"`
+	// The labelled module inputs of the task keyed by alias, e.g. `module_input "services" "backends"`. Each labelled module input is a module input object with one module input type. It is provided to the module as the variable named after the alias.
+	Aliases       *ModuleInput_Aliases      `json:"aliases,omitempty"`
+	ConfigEntries *ConfigEntriesModuleInput `json:"config_entries,omitempty"`
+	ConsulKv      *ConsulKVModuleInput      `json:"consul_kv,omitempty"`
+	Http          *HTTPModuleInput          `json:"http,omitempty"`
+	Intentions    *IntentionsModuleInput    `json:"intentions,omitempty"`
+	Nodes         *NodesModuleInput         `json:"nodes,omitempty"`
+	Services      *ServicesModuleInput      `json:"services,omitempty"`
+	Vault         *VaultModuleInput         `json:"vault,omitempty"`
+}
+
+// The labelled module inputs of the task keyed by alias, e.g. `module_input "services" "backends"`. Each labelled module input is a module input object with one module input type. It is provided to the module as the variable named after the alias.
+type ModuleInput_Aliases struct {
+	AdditionalProperties map[string]ModuleInput `json:"-"`
+}
+
+// NodesCondition defines model for NodesCondition.
+type NodesCondition struct {
+	Datacenter       *string                  `json:"datacenter,omitempty"`
+	Filter           *string                  `json:"filter,omitempty"`
+	NodeMeta         *NodesCondition_NodeMeta `json:"node_meta,omitempty"`
+	UseAsModuleInput *bool                    `json:"use_as_module_input,omitempty"`
+}
+
+// NodesCondition_NodeMeta defines model for NodesCondition.NodeMeta.
+type NodesCondition_NodeMeta struct {
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// NodesModuleInput defines model for NodesModuleInput.
+type NodesModuleInput struct {
+	Datacenter *string                    `json:"datacenter,omitempty"`
+	Filter     *string                    `json:"filter,omitempty"`
+	NodeMeta   *NodesModuleInput_NodeMeta `json:"node_meta,omitempty"`
+}
+
+// NodesModuleInput_NodeMeta defines model for NodesModuleInput.NodeMeta.
+type NodesModuleInput_NodeMeta struct {
+	AdditionalProperties map[string]string `json:"-"`
 }
 
 // RequestID defines model for RequestID.
@@ -104,12 +260,20 @@ type ScheduleCondition struct {
 // ServicesCondition defines model for ServicesCondition.
 type ServicesCondition struct {
 	CtsUserDefinedMeta *ServicesCondition_CtsUserDefinedMeta `json:"cts_user_defined_meta,omitempty"`
-	Datacenter         *string                               `json:"datacenter,omitempty"`
-	Filter             *string                               `json:"filter,omitempty"`
-	Names              *[]string                             `json:"names,omitempty"`
-	Namespace          *string                               `json:"namespace,omitempty"`
-	Regexp             *string                               `json:"regexp,omitempty"`
-	UseAsModuleInput   *bool                                 `json:"use_as_module_input,omitempty"`
+
+	// The damping of changes to service instances for triggering a task with a services condition.
+	Damping             *Damping  `json:"damping,omitempty"`
+	Datacenter          *string   `json:"datacenter,omitempty"`
+	Datacenters         *[]string `json:"datacenters,omitempty"`
+	Filter              *string   `json:"filter,omitempty"`
+	IgnoreStatusChanges *bool     `json:"ignore_status_changes,omitempty"`
+	Names               *[]string `json:"names,omitempty"`
+	Namespace           *string   `json:"namespace,omitempty"`
+	Partition           *string   `json:"partition,omitempty"`
+	Peer                *string   `json:"peer,omitempty"`
+	Regexp              *string   `json:"regexp,omitempty"`
+	Status              *string   `json:"status,omitempty"`
+	UseAsModuleInput    *bool     `json:"use_as_module_input,omitempty"`
 }
 
 // ServicesCondition_CtsUserDefinedMeta defines model for ServicesCondition.CtsUserDefinedMeta.
@@ -121,10 +285,14 @@ type ServicesCondition_CtsUserDefinedMeta struct {
 type ServicesModuleInput struct {
 	CtsUserDefinedMeta *ServicesModuleInput_CtsUserDefinedMeta `json:"cts_user_defined_meta,omitempty"`
 	Datacenter         *string                                 `json:"datacenter,omitempty"`
+	Datacenters        *[]string                               `json:"datacenters,omitempty"`
 	Filter             *string                                 `json:"filter,omitempty"`
 	Names              *[]string                               `json:"names,omitempty"`
 	Namespace          *string                                 `json:"namespace,omitempty"`
+	Partition          *string                                 `json:"partition,omitempty"`
+	Peer               *string                                 `json:"peer,omitempty"`
 	Regexp             *string                                 `json:"regexp,omitempty"`
+	Status             *string                                 `json:"status,omitempty"`
 }
 
 // ServicesModuleInput_CtsUserDefinedMeta defines model for ServicesModuleInput.CtsUserDefinedMeta.
@@ -143,9 +311,15 @@ type Task struct {
 	// The human readable text to describe the task.
 	Description *string `json:"description,omitempty"`
 
+	// Whether to destroy the infrastructure managed by the task when the task is deleted.
+	DestroyOnDelete *bool `json:"destroy_on_delete,omitempty"`
+
 	// Whether the task is enabled or disabled from executing.
 	Enabled *bool `json:"enabled,omitempty"`
 
+	// Whether to collect the task module's output values after the task is successfully applied. Outputs marked sensitive by the module are not collected. Defaults to true if outputs_kv_path is set.
+	ExposeOutputs *bool `json:"expose_outputs,omitempty"`
+
 	// The location of the Terraform module.
 	Module string `json:"module"`
 
@@ -155,13 +329,19 @@ type Task struct {
 	// The unique name of the task.
 	Name string `json:"name"`
 
+	// The Consul KV path prefix to publish the task module's output values to after the task is successfully applied. Requires expose_outputs.
+	OutputsKvPath *string `json:"outputs_kv_path,omitempty"`
+
 	// The list of provider names that the task's module uses.
 	Providers *[]string `json:"providers,omitempty"`
 
+	// The version of the definition protocol of the services variable, either v0 or v1. Version v1 adds the admin partition, cluster peer, health checks, weights, service tagged addresses, and Connect details of each service instance. The Connect details include the kind of gateway and, for Connect proxies, the destination service and upstreams.
+	ServicesProtocol *string `json:"services_protocol,omitempty"`
+
 	// Enterprise only. Configuration values to use for the Terraform Cloud workspace associated with the task. This is only available when used with the Terraform Cloud driver.
 	TerraformCloudWorkspace *TerraformCloudWorkspace `json:"terraform_cloud_workspace,omitempty"`
 
-	// Deprecated, use task.terraform_cloud_workspace.terraform_version instead. Enterprise only. The version of Terraform to use for the Terraform Cloud workspace associated with the task. This is only available when used with the Terraform Cloud driver. Defaults to the latest compatible version if not set.
+	// The exact version of Terraform to use for the task with the Terraform driver. The version is installed alongside the version configured for the driver, which is used if not set.
 	TerraformVersion *string `json:"terraform_version,omitempty"`
 
 	// The map of variables that are provided to the task's module.
@@ -175,6 +355,22 @@ type Task struct {
 type TaskDeleteResponse struct {
 	Error     *Error    `json:"error,omitempty"`
 	RequestId RequestID `json:"request_id"`
+	Run       *Run      `json:"run,omitempty"`
+}
+
+// TaskOutputsResponse defines model for TaskOutputsResponse.
+type TaskOutputsResponse struct {
+	// The ID of the task event that the outputs were collected for.
+	EventId *string `json:"event_id,omitempty"`
+
+	// The output values of the task's module.
+	Outputs   TaskOutputsResponse_Outputs `json:"outputs"`
+	RequestId RequestID                   `json:"request_id"`
+}
+
+// The output values of the task's module.
+type TaskOutputsResponse_Outputs struct {
+	AdditionalProperties map[string]interface{} `json:"-"`
 }
 
 // TaskRequest defines model for TaskRequest.
@@ -190,6 +386,11 @@ type TaskResponse struct {
 	Task      *Task     `json:"task,omitempty"`
 }
 
+// TaskTriggerResponse defines model for TaskTriggerResponse.
+type TaskTriggerResponse struct {
+	RequestId RequestID `json:"request_id"`
+}
+
 // TasksResponse defines model for TasksResponse.
 type TasksResponse struct {
 	RequestId RequestID `json:"request_id"`
@@ -216,6 +417,18 @@ type VariableMap struct {
 	AdditionalProperties map[string]string `json:"-"`
 }
 
+// VaultModuleInput defines model for VaultModuleInput.
+type VaultModuleInput struct {
+	Path string `json:"path"`
+}
+
+// WebhookCondition defines model for WebhookCondition.
+type WebhookCondition struct {
+	// The secret to verify the signature of requests to trigger the task. The secret is redacted in responses.
+	Secret           string `json:"secret"`
+	UseAsModuleInput *bool  `json:"use_as_module_input,omitempty"`
+}
+
 // CreateTaskJSONBody defines parameters for CreateTask.
 type CreateTaskJSONBody = TaskRequest
 
@@ -230,9 +443,37 @@ type CreateTaskParams struct {
 // CreateTaskParamsRun defines parameters for CreateTask.
 type CreateTaskParamsRun string
 
+// DeleteTaskByNameParams defines parameters for DeleteTaskByName.
+type DeleteTaskByNameParams struct {
+	// Whether to destroy the infrastructure managed by the task before the task is
+	// deleted. If not set, the task's destroy_on_delete configuration is used.
+	Destroy *bool `form:"destroy,omitempty" json:"destroy,omitempty"`
+
+	// Different modes for running. Supports run inspect which returns the plan to
+	// destroy the infrastructure managed by the task without deleting the task.
+	// Requires destroy to be true.
+	Run *DeleteTaskByNameParamsRun `form:"run,omitempty" json:"run,omitempty"`
+}
+
+// DeleteTaskByNameParamsRun defines parameters for DeleteTaskByName.
+type DeleteTaskByNameParamsRun string
+
+// TriggerTaskByNameJSONBody defines parameters for TriggerTaskByName.
+type TriggerTaskByNameJSONBody = interface{}
+
+// TriggerTaskByNameParams defines parameters for TriggerTaskByName.
+type TriggerTaskByNameParams struct {
+	// The HMAC-SHA256 signature of the request body using the secret of the
+	// task's webhook condition, in the format "sha256=<hex digest>".
+	XCTSSignature string `json:"X-CTS-Signature"`
+}
+
 // CreateTaskJSONRequestBody defines body for CreateTask for application/json ContentType.
 type CreateTaskJSONRequestBody = CreateTaskJSONBody
 
+// TriggerTaskByNameJSONRequestBody defines body for TriggerTaskByName for application/json ContentType.
+type TriggerTaskByNameJSONRequestBody = TriggerTaskByNameJSONBody
+
 // Getter for additional properties for CatalogServicesCondition_NodeMeta. Returns the specified
 // element and whether it was found
 func (a CatalogServicesCondition_NodeMeta) Get(fieldName string) (value string, found bool) {
@@ -286,6 +527,271 @@ func (a CatalogServicesCondition_NodeMeta) MarshalJSON() ([]byte, error) {
 	return json.Marshal(object)
 }
 
+// Getter for additional properties for HTTPCondition_Headers. Returns the specified
+// element and whether it was found
+func (a HTTPCondition_Headers) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for HTTPCondition_Headers
+func (a *HTTPCondition_Headers) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for HTTPCondition_Headers to handle AdditionalProperties
+func (a *HTTPCondition_Headers) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for HTTPCondition_Headers to handle AdditionalProperties
+func (a HTTPCondition_Headers) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// Getter for additional properties for HTTPModuleInput_Headers. Returns the specified
+// element and whether it was found
+func (a HTTPModuleInput_Headers) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for HTTPModuleInput_Headers
+func (a *HTTPModuleInput_Headers) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for HTTPModuleInput_Headers to handle AdditionalProperties
+func (a *HTTPModuleInput_Headers) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for HTTPModuleInput_Headers to handle AdditionalProperties
+func (a HTTPModuleInput_Headers) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// Getter for additional properties for ModuleInput_Aliases. Returns the specified
+// element and whether it was found
+func (a ModuleInput_Aliases) Get(fieldName string) (value ModuleInput, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for ModuleInput_Aliases
+func (a *ModuleInput_Aliases) Set(fieldName string, value ModuleInput) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]ModuleInput)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for ModuleInput_Aliases to handle AdditionalProperties
+func (a *ModuleInput_Aliases) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]ModuleInput)
+		for fieldName, fieldBuf := range object {
+			var fieldVal ModuleInput
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for ModuleInput_Aliases to handle AdditionalProperties
+func (a ModuleInput_Aliases) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// Getter for additional properties for NodesCondition_NodeMeta. Returns the specified
+// element and whether it was found
+func (a NodesCondition_NodeMeta) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for NodesCondition_NodeMeta
+func (a *NodesCondition_NodeMeta) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for NodesCondition_NodeMeta to handle AdditionalProperties
+func (a *NodesCondition_NodeMeta) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for NodesCondition_NodeMeta to handle AdditionalProperties
+func (a NodesCondition_NodeMeta) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// Getter for additional properties for NodesModuleInput_NodeMeta. Returns the specified
+// element and whether it was found
+func (a NodesModuleInput_NodeMeta) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for NodesModuleInput_NodeMeta
+func (a *NodesModuleInput_NodeMeta) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for NodesModuleInput_NodeMeta to handle AdditionalProperties
+func (a *NodesModuleInput_NodeMeta) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for NodesModuleInput_NodeMeta to handle AdditionalProperties
+func (a NodesModuleInput_NodeMeta) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
 // Getter for additional properties for ServicesCondition_CtsUserDefinedMeta. Returns the specified
 // element and whether it was found
 func (a ServicesCondition_CtsUserDefinedMeta) Get(fieldName string) (value string, found bool) {
@@ -392,6 +898,59 @@ func (a ServicesModuleInput_CtsUserDefinedMeta) MarshalJSON() ([]byte, error) {
 	return json.Marshal(object)
 }
 
+// Getter for additional properties for TaskOutputsResponse_Outputs. Returns the specified
+// element and whether it was found
+func (a TaskOutputsResponse_Outputs) Get(fieldName string) (value interface{}, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for TaskOutputsResponse_Outputs
+func (a *TaskOutputsResponse_Outputs) Set(fieldName string, value interface{}) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]interface{})
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for TaskOutputsResponse_Outputs to handle AdditionalProperties
+func (a *TaskOutputsResponse_Outputs) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]interface{})
+		for fieldName, fieldBuf := range object {
+			var fieldVal interface{}
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for TaskOutputsResponse_Outputs to handle AdditionalProperties
+func (a TaskOutputsResponse_Outputs) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
 // Getter for additional properties for VariableMap. Returns the specified
 // element and whether it was found
 func (a VariableMap) Get(fieldName string) (value string, found bool) {
@@ -2,8 +2,10 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
+	"sort"
 	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
@@ -13,6 +15,9 @@ import (
 	ctyjson "github.com/zclconf/go-cty/cty/json"
 )
 
+// redactMessage replaces sensitive values of a task in API responses
+const redactMessage = "(redacted)"
+
 // TaskRequest is a wrapper around the generated TaskRequest
 // this allows for the task request to be extended
 type TaskRequest oapigen.TaskRequest
@@ -67,12 +72,16 @@ func readToVariablesMap(filename string, reader io.Reader, variables map[string]
 // ToTaskConfig converts a TaskRequest object to a Config TaskConfig object.
 func (tr TaskRequest) ToTaskConfig() (config.TaskConfig, error) {
 	tc := config.TaskConfig{
-		Description: tr.Task.Description,
-		Name:        &tr.Task.Name,
-		Module:      &tr.Task.Module,
-		Version:     tr.Task.Version,
-		Enabled:     tr.Task.Enabled,
+		Description:     tr.Task.Description,
+		Name:            &tr.Task.Name,
+		Module:          &tr.Task.Module,
+		Version:         tr.Task.Version,
+		Enabled:         tr.Task.Enabled,
+		DestroyOnDelete: tr.Task.DestroyOnDelete,
+		ExposeOutputs:   tr.Task.ExposeOutputs,
+		OutputsKVPath:   tr.Task.OutputsKvPath,
 	}
+	tc.ServicesProtocol = tr.Task.ServicesProtocol
 
 	if tr.Task.Providers != nil {
 		tc.Providers = *tr.Task.Providers
@@ -80,34 +89,9 @@ func (tr TaskRequest) ToTaskConfig() (config.TaskConfig, error) {
 
 	// Convert module input
 	if tr.Task.ModuleInput != nil {
-		inputs := make(config.ModuleInputConfigs, 0)
-		if tr.Task.ModuleInput.Services != nil {
-			input := &config.ServicesModuleInputConfig{
-				ServicesMonitorConfig: config.ServicesMonitorConfig{
-					Regexp:     tr.Task.ModuleInput.Services.Regexp,
-					Datacenter: tr.Task.ModuleInput.Services.Datacenter,
-					Namespace:  tr.Task.ModuleInput.Services.Namespace,
-					Filter:     tr.Task.ModuleInput.Services.Filter,
-				},
-			}
-			if tr.Task.ModuleInput.Services.Names != nil {
-				input.Names = *tr.Task.ModuleInput.Services.Names
-			}
-			if tr.Task.ModuleInput.Services.CtsUserDefinedMeta != nil {
-				input.CTSUserDefinedMeta = tr.Task.ModuleInput.Services.CtsUserDefinedMeta.AdditionalProperties
-			}
-			inputs = append(inputs, input)
-		}
-		if tr.Task.ModuleInput.ConsulKv != nil {
-			input := &config.ConsulKVModuleInputConfig{
-				ConsulKVMonitorConfig: config.ConsulKVMonitorConfig{
-					Datacenter: tr.Task.ModuleInput.ConsulKv.Datacenter,
-					Recurse:    tr.Task.ModuleInput.ConsulKv.Recurse,
-					Path:       &tr.Task.ModuleInput.ConsulKv.Path,
-					Namespace:  tr.Task.ModuleInput.ConsulKv.Namespace,
-				},
-			}
-			inputs = append(inputs, input)
+		inputs, err := moduleInputConfigsFromRequest(*tr.Task.ModuleInput, nil)
+		if err != nil {
+			return config.TaskConfig{}, err
 		}
 		tc.ModuleInputs = &inputs
 	}
@@ -118,19 +102,46 @@ func (tr TaskRequest) ToTaskConfig() (config.TaskConfig, error) {
 			ServicesMonitorConfig: config.ServicesMonitorConfig{
 				Datacenter: tr.Task.Condition.Services.Datacenter,
 				Namespace:  tr.Task.Condition.Services.Namespace,
+				Partition:  tr.Task.Condition.Services.Partition,
+				Peer:       tr.Task.Condition.Services.Peer,
 				Filter:     tr.Task.Condition.Services.Filter,
+				Status:     tr.Task.Condition.Services.Status,
 			},
-			UseAsModuleInput: tr.Task.Condition.Services.UseAsModuleInput,
+			UseAsModuleInput:    tr.Task.Condition.Services.UseAsModuleInput,
+			IgnoreStatusChanges: tr.Task.Condition.Services.IgnoreStatusChanges,
 		}
 		if tr.Task.Condition.Services.Names != nil && len(*tr.Task.Condition.Services.Names) > 0 {
 			cond.Names = *tr.Task.Condition.Services.Names
 		} else {
 			cond.Regexp = tr.Task.Condition.Services.Regexp
 		}
+		if tr.Task.Condition.Services.Datacenters != nil {
+			cond.Datacenters = *tr.Task.Condition.Services.Datacenters
+		}
 		if tr.Task.Condition.Services.CtsUserDefinedMeta != nil {
 			cond.ServicesMonitorConfig.CTSUserDefinedMeta =
 				tr.Task.Condition.Services.CtsUserDefinedMeta.AdditionalProperties
 		}
+		if damping := tr.Task.Condition.Services.Damping; damping != nil {
+			cond.Damping = &config.DampingConfig{
+				Threshold:        damping.Threshold,
+				ThresholdPercent: damping.ThresholdPercent,
+			}
+			if damping.MinStableTime != nil {
+				min, err := time.ParseDuration(*damping.MinStableTime)
+				if err != nil {
+					return config.TaskConfig{}, err
+				}
+				cond.Damping.MinStableTime = &min
+			}
+			if damping.MaxSuppressionTime != nil {
+				max, err := time.ParseDuration(*damping.MaxSuppressionTime)
+				if err != nil {
+					return config.TaskConfig{}, err
+				}
+				cond.Damping.MaxSuppressionTime = &max
+			}
+		}
 		tc.Condition = cond
 	} else if tr.Task.Condition.ConsulKv != nil {
 		tc.Condition = &config.ConsulKVConditionConfig{
@@ -139,6 +150,8 @@ func (tr TaskRequest) ToTaskConfig() (config.TaskConfig, error) {
 				Recurse:    tr.Task.Condition.ConsulKv.Recurse,
 				Path:       &tr.Task.Condition.ConsulKv.Path,
 				Namespace:  tr.Task.Condition.ConsulKv.Namespace,
+				Partition:  tr.Task.Condition.ConsulKv.Partition,
+				Decode:     tr.Task.Condition.ConsulKv.Decode,
 			},
 			UseAsModuleInput: tr.Task.Condition.ConsulKv.UseAsModuleInput,
 		}
@@ -149,12 +162,70 @@ func (tr TaskRequest) ToTaskConfig() (config.TaskConfig, error) {
 				UseAsModuleInput: tr.Task.Condition.CatalogServices.UseAsModuleInput,
 				Datacenter:       tr.Task.Condition.CatalogServices.Datacenter,
 				Namespace:        tr.Task.Condition.CatalogServices.Namespace,
+				Partition:        tr.Task.Condition.CatalogServices.Partition,
+				Peer:             tr.Task.Condition.CatalogServices.Peer,
 			},
 		}
+		if tr.Task.Condition.CatalogServices.Datacenters != nil {
+			cond.Datacenters = *tr.Task.Condition.CatalogServices.Datacenters
+		}
 		if tr.Task.Condition.CatalogServices.NodeMeta != nil {
 			cond.NodeMeta = tr.Task.Condition.CatalogServices.NodeMeta.AdditionalProperties
 		}
 		tc.Condition = cond
+	} else if tr.Task.Condition.Nodes != nil {
+		cond := &config.NodesConditionConfig{
+			NodesMonitorConfig: config.NodesMonitorConfig{
+				Datacenter: tr.Task.Condition.Nodes.Datacenter,
+				Filter:     tr.Task.Condition.Nodes.Filter,
+			},
+			UseAsModuleInput: tr.Task.Condition.Nodes.UseAsModuleInput,
+		}
+		if tr.Task.Condition.Nodes.NodeMeta != nil {
+			cond.NodeMeta = tr.Task.Condition.Nodes.NodeMeta.AdditionalProperties
+		}
+		tc.Condition = cond
+	} else if tr.Task.Condition.Intentions != nil {
+		tc.Condition = &config.IntentionsConditionConfig{
+			IntentionsMonitorConfig: config.IntentionsMonitorConfig{
+				Datacenter: tr.Task.Condition.Intentions.Datacenter,
+				Namespace:  tr.Task.Condition.Intentions.Namespace,
+				Filter:     tr.Task.Condition.Intentions.Filter,
+			},
+			UseAsModuleInput: tr.Task.Condition.Intentions.UseAsModuleInput,
+		}
+	} else if tr.Task.Condition.ConfigEntries != nil {
+		tc.Condition = &config.ConfigEntriesConditionConfig{
+			ConfigEntriesMonitorConfig: config.ConfigEntriesMonitorConfig{
+				Kind:       &tr.Task.Condition.ConfigEntries.Kind,
+				Name:       tr.Task.Condition.ConfigEntries.Name,
+				Regexp:     tr.Task.Condition.ConfigEntries.Regexp,
+				Datacenter: tr.Task.Condition.ConfigEntries.Datacenter,
+				Namespace:  tr.Task.Condition.ConfigEntries.Namespace,
+			},
+			UseAsModuleInput: tr.Task.Condition.ConfigEntries.UseAsModuleInput,
+		}
+	} else if tr.Task.Condition.Http != nil {
+		var headers map[string]string
+		if tr.Task.Condition.Http.Headers != nil {
+			headers = tr.Task.Condition.Http.Headers.AdditionalProperties
+		}
+		m, err := httpMonitorConfigFromRequest(tr.Task.Condition.Http.Url,
+			headers, tr.Task.Condition.Http.Interval,
+			tr.Task.Condition.Http.Timeout, tr.Task.Condition.Http.Selector,
+			tr.Task.Condition.Http.Tls)
+		if err != nil {
+			return config.TaskConfig{}, err
+		}
+		tc.Condition = &config.HTTPConditionConfig{
+			HTTPMonitorConfig: m,
+			UseAsModuleInput:  tr.Task.Condition.Http.UseAsModuleInput,
+		}
+	} else if tr.Task.Condition.Webhook != nil {
+		tc.Condition = &config.WebhookConditionConfig{
+			Secret:           &tr.Task.Condition.Webhook.Secret,
+			UseAsModuleInput: tr.Task.Condition.Webhook.UseAsModuleInput,
+		}
 	} else if tr.Task.Condition.Schedule != nil {
 		tc.Condition = &config.ScheduleConditionConfig{
 			Cron: &tr.Task.Condition.Schedule.Cron,
@@ -207,6 +278,141 @@ func (tr TaskRequest) ToTaskConfig() (config.TaskConfig, error) {
 	return tc, nil
 }
 
+// moduleInputConfigsFromRequest converts the module input of a task request to
+// the module input configs. The module inputs of the aliases are converted
+// with their alias. Aliased module inputs are expected to have one module
+// input type and no aliases of their own.
+func moduleInputConfigsFromRequest(mi oapigen.ModuleInput, alias *string) (config.ModuleInputConfigs, error) {
+	inputs := make(config.ModuleInputConfigs, 0)
+	if mi.Services != nil {
+		input := &config.ServicesModuleInputConfig{
+			Alias: alias,
+			ServicesMonitorConfig: config.ServicesMonitorConfig{
+				Regexp:     mi.Services.Regexp,
+				Datacenter: mi.Services.Datacenter,
+				Namespace:  mi.Services.Namespace,
+				Partition:  mi.Services.Partition,
+				Peer:       mi.Services.Peer,
+				Filter:     mi.Services.Filter,
+				Status:     mi.Services.Status,
+			},
+		}
+		if mi.Services.Names != nil {
+			input.Names = *mi.Services.Names
+		}
+		if mi.Services.Datacenters != nil {
+			input.Datacenters = *mi.Services.Datacenters
+		}
+		if mi.Services.CtsUserDefinedMeta != nil {
+			input.CTSUserDefinedMeta = mi.Services.CtsUserDefinedMeta.AdditionalProperties
+		}
+		inputs = append(inputs, input)
+	}
+	if mi.ConsulKv != nil {
+		input := &config.ConsulKVModuleInputConfig{
+			Alias: alias,
+			ConsulKVMonitorConfig: config.ConsulKVMonitorConfig{
+				Datacenter: mi.ConsulKv.Datacenter,
+				Recurse:    mi.ConsulKv.Recurse,
+				Path:       &mi.ConsulKv.Path,
+				Namespace:  mi.ConsulKv.Namespace,
+				Partition:  mi.ConsulKv.Partition,
+				Decode:     mi.ConsulKv.Decode,
+			},
+		}
+		inputs = append(inputs, input)
+	}
+	if mi.Intentions != nil {
+		input := &config.IntentionsModuleInputConfig{
+			Alias: alias,
+			IntentionsMonitorConfig: config.IntentionsMonitorConfig{
+				Datacenter: mi.Intentions.Datacenter,
+				Namespace:  mi.Intentions.Namespace,
+				Filter:     mi.Intentions.Filter,
+			},
+		}
+		inputs = append(inputs, input)
+	}
+	if mi.ConfigEntries != nil {
+		input := &config.ConfigEntriesModuleInputConfig{
+			Alias: alias,
+			ConfigEntriesMonitorConfig: config.ConfigEntriesMonitorConfig{
+				Kind:       &mi.ConfigEntries.Kind,
+				Name:       mi.ConfigEntries.Name,
+				Regexp:     mi.ConfigEntries.Regexp,
+				Datacenter: mi.ConfigEntries.Datacenter,
+				Namespace:  mi.ConfigEntries.Namespace,
+			},
+		}
+		inputs = append(inputs, input)
+	}
+	if mi.Vault != nil {
+		input := &config.VaultModuleInputConfig{
+			Path:  &mi.Vault.Path,
+			Alias: alias,
+		}
+		inputs = append(inputs, input)
+	}
+	if mi.Http != nil {
+		var headers map[string]string
+		if mi.Http.Headers != nil {
+			headers = mi.Http.Headers.AdditionalProperties
+		}
+		m, err := httpMonitorConfigFromRequest(mi.Http.Url,
+			headers, mi.Http.Interval,
+			mi.Http.Timeout, mi.Http.Selector,
+			mi.Http.Tls)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, &config.HTTPModuleInputConfig{
+			Alias:             alias,
+			HTTPMonitorConfig: m,
+		})
+	}
+	if mi.Nodes != nil {
+		input := &config.NodesModuleInputConfig{
+			Alias: alias,
+			NodesMonitorConfig: config.NodesMonitorConfig{
+				Datacenter: mi.Nodes.Datacenter,
+				Filter:     mi.Nodes.Filter,
+			},
+		}
+		if mi.Nodes.NodeMeta != nil {
+			input.NodeMeta = mi.Nodes.NodeMeta.AdditionalProperties
+		}
+		inputs = append(inputs, input)
+	}
+
+	if mi.Aliases == nil {
+		return inputs, nil
+	}
+	if alias != nil {
+		return nil, fmt.Errorf("module input alias %q cannot have aliases", *alias)
+	}
+
+	aliases := make([]string, 0, len(mi.Aliases.AdditionalProperties))
+	for name := range mi.Aliases.AdditionalProperties {
+		aliases = append(aliases, name)
+	}
+	sort.Strings(aliases)
+
+	for _, name := range aliases {
+		aliased, err := moduleInputConfigsFromRequest(
+			mi.Aliases.AdditionalProperties[name], config.String(name))
+		if err != nil {
+			return nil, err
+		}
+		if len(aliased) != 1 {
+			return nil, fmt.Errorf("module input alias %q must have one "+
+				"module input type, found %d", name, len(aliased))
+		}
+		inputs = append(inputs, aliased...)
+	}
+
+	return inputs, nil
+}
+
 // String writes out the task request in an easily readable way
 // useful for logging
 func (tr TaskRequest) String() string {
@@ -220,6 +426,7 @@ func tasksResponseFromTaskConfigs(tcs config.TaskConfigs, requestID oapigen.Requ
 	tasks := make([]oapigen.Task, len(tcs))
 	for i, tc := range tcs {
 		tasks[i] = oapigenTaskFromConfigTask(*tc)
+		redactTaskSecrets(&tasks[i])
 	}
 
 	return TasksResponse{
@@ -232,6 +439,7 @@ type TaskResponse oapigen.TaskResponse
 
 func taskResponseFromTaskConfig(tc config.TaskConfig, requestID oapigen.RequestID) TaskResponse {
 	task := oapigenTaskFromConfigTask(tc)
+	redactTaskSecrets(&task)
 
 	tr := TaskResponse{
 		RequestId: requestID,
@@ -245,12 +453,24 @@ func (tresp TaskResponse) String() string {
 	return string(data)
 }
 
+// redactTaskSecrets redacts the secrets of a task for API responses. Task
+// requests are not redacted since they are sent to create the task.
+func redactTaskSecrets(task *oapigen.Task) {
+	if task.Condition.Webhook != nil {
+		task.Condition.Webhook.Secret = redactMessage
+	}
+}
+
 func oapigenTaskFromConfigTask(tc config.TaskConfig) oapigen.Task {
 	task := oapigen.Task{
-		Description: tc.Description,
-		Version:     tc.Version,
-		Enabled:     tc.Enabled,
+		Description:     tc.Description,
+		Version:         tc.Version,
+		Enabled:         tc.Enabled,
+		DestroyOnDelete: tc.DestroyOnDelete,
+		ExposeOutputs:   tc.ExposeOutputs,
+		OutputsKvPath:   tc.OutputsKVPath,
 	}
+	task.ServicesProtocol = tc.ServicesProtocol
 
 	if tc.Name != nil {
 		task.Name = *tc.Name
@@ -273,37 +493,19 @@ func oapigenTaskFromConfigTask(tc config.TaskConfig) oapigen.Task {
 	if tc.ModuleInputs != nil {
 		task.ModuleInput = new(oapigen.ModuleInput)
 		for _, moduleInput := range *tc.ModuleInputs {
-			switch input := moduleInput.(type) {
-			case *config.ServicesModuleInputConfig:
-				if len(input.Names) > 0 {
-					task.ModuleInput.Services = &oapigen.ServicesModuleInput{
-						Names:      &input.Names,
-						Datacenter: input.Datacenter,
-						Namespace:  input.Namespace,
-						Filter:     input.Filter,
-						CtsUserDefinedMeta: &oapigen.ServicesModuleInput_CtsUserDefinedMeta{
-							AdditionalProperties: input.CTSUserDefinedMeta,
-						},
-					}
-				} else {
-					task.ModuleInput.Services = &oapigen.ServicesModuleInput{
-						Regexp:     input.Regexp,
-						Datacenter: input.Datacenter,
-						Namespace:  input.Namespace,
-						Filter:     input.Filter,
-						CtsUserDefinedMeta: &oapigen.ServicesModuleInput_CtsUserDefinedMeta{
-							AdditionalProperties: input.CTSUserDefinedMeta,
-						},
-					}
-				}
-			case *config.ConsulKVModuleInputConfig:
-				task.ModuleInput.ConsulKv = &oapigen.ConsulKVModuleInput{
-					Datacenter: input.Datacenter,
-					Recurse:    input.Recurse,
-					Path:       *input.Path,
-					Namespace:  input.Namespace,
-				}
+			// labelled module inputs are represented by their alias
+			alias := moduleInput.VariableName()
+			if alias == moduleInput.VariableType() {
+				setOapigenModuleInput(task.ModuleInput, moduleInput)
+				continue
 			}
+
+			if task.ModuleInput.Aliases == nil {
+				task.ModuleInput.Aliases = &oapigen.ModuleInput_Aliases{}
+			}
+			aliased := oapigen.ModuleInput{}
+			setOapigenModuleInput(&aliased, moduleInput)
+			task.ModuleInput.Aliases.Set(alias, aliased)
 		}
 	}
 
@@ -312,17 +514,38 @@ func oapigenTaskFromConfigTask(tc config.TaskConfig) oapigen.Task {
 		services := &oapigen.ServicesCondition{
 			Datacenter: cond.Datacenter,
 			Namespace:  cond.Namespace,
+			Partition:  cond.Partition,
+			Peer:       cond.Peer,
 			Filter:     cond.Filter,
+			Status:     cond.Status,
 			CtsUserDefinedMeta: &oapigen.ServicesCondition_CtsUserDefinedMeta{
 				AdditionalProperties: cond.CTSUserDefinedMeta,
 			},
-			UseAsModuleInput: cond.UseAsModuleInput,
+			UseAsModuleInput:    cond.UseAsModuleInput,
+			IgnoreStatusChanges: cond.IgnoreStatusChanges,
 		}
 		if len(cond.Names) > 0 {
 			services.Names = &cond.Names
 		} else {
 			services.Regexp = cond.Regexp
 		}
+		if len(cond.Datacenters) > 0 {
+			services.Datacenters = &cond.Datacenters
+		}
+		if cond.Damping != nil {
+			services.Damping = &oapigen.Damping{
+				Threshold:        cond.Damping.Threshold,
+				ThresholdPercent: cond.Damping.ThresholdPercent,
+			}
+			if cond.Damping.MinStableTime != nil {
+				min := cond.Damping.MinStableTime.String()
+				services.Damping.MinStableTime = &min
+			}
+			if cond.Damping.MaxSuppressionTime != nil {
+				max := cond.Damping.MaxSuppressionTime.String()
+				services.Damping.MaxSuppressionTime = &max
+			}
+		}
 		task.Condition.Services = services
 	case *config.CatalogServicesConditionConfig:
 		task.Condition.CatalogServices = &oapigen.CatalogServicesCondition{
@@ -330,16 +553,65 @@ func oapigenTaskFromConfigTask(tc config.TaskConfig) oapigen.Task {
 			UseAsModuleInput: cond.UseAsModuleInput,
 			Datacenter:       cond.Datacenter,
 			Namespace:        cond.Namespace,
+			Partition:        cond.Partition,
+			Peer:             cond.Peer,
 			NodeMeta: &oapigen.CatalogServicesCondition_NodeMeta{
 				AdditionalProperties: cond.NodeMeta,
 			},
 		}
+		if len(cond.Datacenters) > 0 {
+			task.Condition.CatalogServices.Datacenters = &cond.Datacenters
+		}
 	case *config.ConsulKVConditionConfig:
 		task.Condition.ConsulKv = &oapigen.ConsulKVCondition{
 			Datacenter:       cond.Datacenter,
 			Recurse:          cond.Recurse,
 			Path:             *cond.Path,
 			Namespace:        cond.Namespace,
+			Partition:        cond.Partition,
+			Decode:           cond.Decode,
+			UseAsModuleInput: cond.UseAsModuleInput,
+		}
+	case *config.NodesConditionConfig:
+		task.Condition.Nodes = &oapigen.NodesCondition{
+			Datacenter: cond.Datacenter,
+			Filter:     cond.Filter,
+			NodeMeta: &oapigen.NodesCondition_NodeMeta{
+				AdditionalProperties: cond.NodeMeta,
+			},
+			UseAsModuleInput: cond.UseAsModuleInput,
+		}
+	case *config.IntentionsConditionConfig:
+		task.Condition.Intentions = &oapigen.IntentionsCondition{
+			Datacenter:       cond.Datacenter,
+			Namespace:        cond.Namespace,
+			Filter:           cond.Filter,
+			UseAsModuleInput: cond.UseAsModuleInput,
+		}
+	case *config.ConfigEntriesConditionConfig:
+		task.Condition.ConfigEntries = &oapigen.ConfigEntriesCondition{
+			Kind:             *cond.Kind,
+			Name:             cond.Name,
+			Regexp:           cond.Regexp,
+			Datacenter:       cond.Datacenter,
+			Namespace:        cond.Namespace,
+			UseAsModuleInput: cond.UseAsModuleInput,
+		}
+	case *config.HTTPConditionConfig:
+		task.Condition.Http = &oapigen.HTTPCondition{
+			Url: *cond.URL,
+			Headers: &oapigen.HTTPCondition_Headers{
+				AdditionalProperties: cond.Headers,
+			},
+			Interval:         durationStringPtr(cond.Interval),
+			Timeout:          durationStringPtr(cond.Timeout),
+			Selector:         cond.Selector,
+			Tls:              oapigenHTTPTLSFromConfig(cond.TLS),
+			UseAsModuleInput: cond.UseAsModuleInput,
+		}
+	case *config.WebhookConditionConfig:
+		task.Condition.Webhook = &oapigen.WebhookCondition{
+			Secret:           config.StringVal(cond.Secret),
 			UseAsModuleInput: cond.UseAsModuleInput,
 		}
 	case *config.ScheduleConditionConfig:
@@ -397,3 +669,156 @@ func oapigenTaskFromConfigTask(tc config.TaskConfig) oapigen.Task {
 
 	return task
 }
+
+// setOapigenModuleInput sets the module input config as its module input type
+// of the module input object
+func setOapigenModuleInput(mi *oapigen.ModuleInput, moduleInput config.ModuleInputConfig) {
+	switch input := moduleInput.(type) {
+	case *config.ServicesModuleInputConfig:
+		if len(input.Names) > 0 {
+			mi.Services = &oapigen.ServicesModuleInput{
+				Names:      &input.Names,
+				Datacenter: input.Datacenter,
+				Namespace:  input.Namespace,
+				Partition:  input.Partition,
+				Peer:       input.Peer,
+				Filter:     input.Filter,
+				Status:     input.Status,
+				CtsUserDefinedMeta: &oapigen.ServicesModuleInput_CtsUserDefinedMeta{
+					AdditionalProperties: input.CTSUserDefinedMeta,
+				},
+			}
+		} else {
+			mi.Services = &oapigen.ServicesModuleInput{
+				Regexp:     input.Regexp,
+				Datacenter: input.Datacenter,
+				Namespace:  input.Namespace,
+				Partition:  input.Partition,
+				Peer:       input.Peer,
+				Filter:     input.Filter,
+				Status:     input.Status,
+				CtsUserDefinedMeta: &oapigen.ServicesModuleInput_CtsUserDefinedMeta{
+					AdditionalProperties: input.CTSUserDefinedMeta,
+				},
+			}
+		}
+		if len(input.Datacenters) > 0 {
+			mi.Services.Datacenters = &input.Datacenters
+		}
+	case *config.ConsulKVModuleInputConfig:
+		mi.ConsulKv = &oapigen.ConsulKVModuleInput{
+			Datacenter: input.Datacenter,
+			Recurse:    input.Recurse,
+			Path:       *input.Path,
+			Namespace:  input.Namespace,
+			Partition:  input.Partition,
+			Decode:     input.Decode,
+		}
+	case *config.IntentionsModuleInputConfig:
+		mi.Intentions = &oapigen.IntentionsModuleInput{
+			Datacenter: input.Datacenter,
+			Namespace:  input.Namespace,
+			Filter:     input.Filter,
+		}
+	case *config.ConfigEntriesModuleInputConfig:
+		mi.ConfigEntries = &oapigen.ConfigEntriesModuleInput{
+			Kind:       *input.Kind,
+			Name:       input.Name,
+			Regexp:     input.Regexp,
+			Datacenter: input.Datacenter,
+			Namespace:  input.Namespace,
+		}
+	case *config.VaultModuleInputConfig:
+		mi.Vault = &oapigen.VaultModuleInput{
+			Path: *input.Path,
+		}
+	case *config.HTTPModuleInputConfig:
+		mi.Http = &oapigen.HTTPModuleInput{
+			Url: *input.URL,
+			Headers: &oapigen.HTTPModuleInput_Headers{
+				AdditionalProperties: input.Headers,
+			},
+			Interval: durationStringPtr(input.Interval),
+			Timeout:  durationStringPtr(input.Timeout),
+			Selector: input.Selector,
+			Tls:      oapigenHTTPTLSFromConfig(input.TLS),
+		}
+	case *config.NodesModuleInputConfig:
+		mi.Nodes = &oapigen.NodesModuleInput{
+			Datacenter: input.Datacenter,
+			Filter:     input.Filter,
+			NodeMeta: &oapigen.NodesModuleInput_NodeMeta{
+				AdditionalProperties: input.NodeMeta,
+			},
+		}
+	}
+}
+
+// httpMonitorConfigFromRequest converts the fields of an http condition or
+// module_input of a task request to the http monitor configuration
+func httpMonitorConfigFromRequest(url string, headers map[string]string,
+	interval, timeout, selector *string, tls *oapigen.HTTPTLS) (config.HTTPMonitorConfig, error) {
+
+	m := config.HTTPMonitorConfig{
+		URL:      config.String(url),
+		Headers:  headers,
+		Selector: selector,
+	}
+
+	if interval != nil {
+		d, err := time.ParseDuration(*interval)
+		if err != nil {
+			return config.HTTPMonitorConfig{}, err
+		}
+		m.Interval = &d
+	}
+
+	if timeout != nil {
+		d, err := time.ParseDuration(*timeout)
+		if err != nil {
+			return config.HTTPMonitorConfig{}, err
+		}
+		m.Timeout = &d
+	}
+
+	if tls != nil {
+		m.TLS = &config.TLSConfig{
+			CACert:     tls.CaCert,
+			CAPath:     tls.CaPath,
+			Cert:       tls.Cert,
+			Enabled:    tls.Enabled,
+			Key:        tls.Key,
+			ServerName: tls.ServerName,
+			Verify:     tls.Verify,
+		}
+	}
+
+	return m, nil
+}
+
+// oapigenHTTPTLSFromConfig converts the TLS configuration of an http monitor
+// to the API representation
+func oapigenHTTPTLSFromConfig(tls *config.TLSConfig) *oapigen.HTTPTLS {
+	if tls == nil {
+		return nil
+	}
+
+	return &oapigen.HTTPTLS{
+		CaCert:     tls.CACert,
+		CaPath:     tls.CAPath,
+		Cert:       tls.Cert,
+		Enabled:    tls.Enabled,
+		Key:        tls.Key,
+		ServerName: tls.ServerName,
+		Verify:     tls.Verify,
+	}
+}
+
+// durationStringPtr returns the string representation of a duration, or nil
+// when the duration is not set
+func durationStringPtr(d *time.Duration) *string {
+	if d == nil {
+		return nil
+	}
+	return config.String(d.String())
+}
@@ -140,15 +140,19 @@ func TestRequest_oapigenTaskFromConfigTask(t *testing.T) {
 		{
 			name: "basic_fields_filled",
 			taskConfig: config.TaskConfig{
-				Description:  config.String("test-description"),
-				Name:         config.String("test-name"),
-				Providers:    []string{"test-provider-1", "test-provider-2"},
-				Module:       config.String("path"),
-				Version:      config.String("test-version"),
-				BufferPeriod: config.DefaultBufferPeriodConfig(),
-				Enabled:      config.Bool(true),
-				Condition:    config.EmptyConditionConfig(),
-				ModuleInputs: config.DefaultModuleInputConfigs(),
+				Description:      config.String("test-description"),
+				Name:             config.String("test-name"),
+				Providers:        []string{"test-provider-1", "test-provider-2"},
+				Module:           config.String("path"),
+				Version:          config.String("test-version"),
+				BufferPeriod:     config.DefaultBufferPeriodConfig(),
+				Enabled:          config.Bool(true),
+				DestroyOnDelete:  config.Bool(true),
+				ExposeOutputs:    config.Bool(true),
+				OutputsKVPath:    config.String("cts/outputs"),
+				Condition:        config.EmptyConditionConfig(),
+				ServicesProtocol: config.String(config.ServicesProtocolV1),
+				ModuleInputs:     config.DefaultModuleInputConfigs(),
 
 				// Enterprise
 				DeprecatedTFVersion: config.String("1.0.0"),
@@ -169,10 +173,14 @@ func TestRequest_oapigenTaskFromConfigTask(t *testing.T) {
 					Max:     config.String("20s"),
 					Min:     config.String("5s"),
 				},
-				Enabled:     config.Bool(true),
-				Condition:   oapigen.Condition{},
-				ModuleInput: &oapigen.ModuleInput{},
-				Providers:   &[]string{"test-provider-1", "test-provider-2"},
+				Enabled:          config.Bool(true),
+				DestroyOnDelete:  config.Bool(true),
+				ExposeOutputs:    config.Bool(true),
+				OutputsKvPath:    config.String("cts/outputs"),
+				Condition:        oapigen.Condition{},
+				ServicesProtocol: config.String(config.ServicesProtocolV1),
+				ModuleInput:      &oapigen.ModuleInput{},
+				Providers:        &[]string{"test-provider-1", "test-provider-2"},
 
 				// Enterprise
 				TerraformVersion: config.String("1.0.0"),
@@ -193,9 +201,17 @@ func TestRequest_oapigenTaskFromConfigTask(t *testing.T) {
 						Datacenter:         config.String("dc"),
 						Namespace:          config.String("ns"),
 						Filter:             config.String("filter"),
+						Status:             config.String("warning"),
 						CTSUserDefinedMeta: map[string]string{"key": "value"},
 					},
-					UseAsModuleInput: config.Bool(false),
+					UseAsModuleInput:    config.Bool(false),
+					IgnoreStatusChanges: config.Bool(true),
+					Damping: &config.DampingConfig{
+						Threshold:          config.Int(5),
+						ThresholdPercent:   config.Int(20),
+						MinStableTime:      config.TimeDuration(time.Minute),
+						MaxSuppressionTime: config.TimeDuration(5 * time.Minute),
+					},
 				},
 			},
 			expected: oapigen.Task{
@@ -205,10 +221,18 @@ func TestRequest_oapigenTaskFromConfigTask(t *testing.T) {
 						Datacenter: config.String("dc"),
 						Namespace:  config.String("ns"),
 						Filter:     config.String("filter"),
+						Status:     config.String("warning"),
 						CtsUserDefinedMeta: &oapigen.ServicesCondition_CtsUserDefinedMeta{
 							AdditionalProperties: map[string]string{"key": "value"},
 						},
-						UseAsModuleInput: config.Bool(false),
+						UseAsModuleInput:    config.Bool(false),
+						IgnoreStatusChanges: config.Bool(true),
+						Damping: &oapigen.Damping{
+							Threshold:          config.Int(5),
+							ThresholdPercent:   config.Int(20),
+							MinStableTime:      config.String("1m0s"),
+							MaxSuppressionTime: config.String("5m0s"),
+						},
 					},
 				},
 			},
@@ -220,7 +244,10 @@ func TestRequest_oapigenTaskFromConfigTask(t *testing.T) {
 					ServicesMonitorConfig: config.ServicesMonitorConfig{
 						Names:              []string{"api", "web"},
 						Datacenter:         config.String(""),
+						Datacenters:        []string{"dc1", "dc2"},
 						Namespace:          config.String(""),
+						Partition:          config.String("default"),
+						Peer:               config.String("cluster-02"),
 						Filter:             config.String(""),
 						CTSUserDefinedMeta: map[string]string{},
 					},
@@ -230,10 +257,13 @@ func TestRequest_oapigenTaskFromConfigTask(t *testing.T) {
 			expected: oapigen.Task{
 				Condition: oapigen.Condition{
 					Services: &oapigen.ServicesCondition{
-						Names:      &[]string{"api", "web"},
-						Datacenter: config.String(""),
-						Namespace:  config.String(""),
-						Filter:     config.String(""),
+						Names:       &[]string{"api", "web"},
+						Datacenter:  config.String(""),
+						Datacenters: &[]string{"dc1", "dc2"},
+						Namespace:   config.String(""),
+						Partition:   config.String("default"),
+						Peer:        config.String("cluster-02"),
+						Filter:      config.String(""),
 						CtsUserDefinedMeta: &oapigen.ServicesCondition_CtsUserDefinedMeta{
 							AdditionalProperties: map[string]string{},
 						},
@@ -284,6 +314,7 @@ func TestRequest_oapigenTaskFromConfigTask(t *testing.T) {
 						Recurse:    config.Bool(true),
 						Datacenter: config.String("dc2"),
 						Namespace:  config.String("ns2"),
+						Decode:     config.String("json"),
 					},
 					UseAsModuleInput: config.Bool(true),
 				},
@@ -295,6 +326,191 @@ func TestRequest_oapigenTaskFromConfigTask(t *testing.T) {
 						Recurse:          config.Bool(true),
 						Datacenter:       config.String("dc2"),
 						Namespace:        config.String("ns2"),
+						Decode:           config.String("json"),
+						UseAsModuleInput: config.Bool(true),
+					},
+				},
+			},
+		},
+		{
+			name: "with_nodes_condition",
+			taskConfig: config.TaskConfig{
+				Condition: &config.NodesConditionConfig{
+					NodesMonitorConfig: config.NodesMonitorConfig{
+						Datacenter: config.String("dc2"),
+						NodeMeta:   map[string]string{"key": "value"},
+						Filter:     config.String("Meta.env == \"prod\""),
+					},
+					UseAsModuleInput: config.Bool(true),
+				},
+			},
+			expected: oapigen.Task{
+				Condition: oapigen.Condition{
+					Nodes: &oapigen.NodesCondition{
+						Datacenter: config.String("dc2"),
+						NodeMeta: &oapigen.NodesCondition_NodeMeta{
+							AdditionalProperties: map[string]string{"key": "value"},
+						},
+						Filter:           config.String("Meta.env == \"prod\""),
+						UseAsModuleInput: config.Bool(true),
+					},
+				},
+			},
+		},
+		{
+			name: "with_intentions_condition",
+			taskConfig: config.TaskConfig{
+				Condition: &config.IntentionsConditionConfig{
+					IntentionsMonitorConfig: config.IntentionsMonitorConfig{
+						Datacenter: config.String("dc2"),
+						Namespace:  config.String("ns2"),
+						Filter:     config.String("DestinationName == \"api\""),
+					},
+					UseAsModuleInput: config.Bool(false),
+				},
+				ModuleInputs: &config.ModuleInputConfigs{
+					&config.IntentionsModuleInputConfig{
+						IntentionsMonitorConfig: config.IntentionsMonitorConfig{
+							Datacenter: config.String("dc1"),
+						},
+					},
+				},
+			},
+			expected: oapigen.Task{
+				Condition: oapigen.Condition{
+					Intentions: &oapigen.IntentionsCondition{
+						Datacenter:       config.String("dc2"),
+						Namespace:        config.String("ns2"),
+						Filter:           config.String("DestinationName == \"api\""),
+						UseAsModuleInput: config.Bool(false),
+					},
+				},
+				ModuleInput: &oapigen.ModuleInput{
+					Intentions: &oapigen.IntentionsModuleInput{
+						Datacenter: config.String("dc1"),
+					},
+				},
+			},
+		},
+		{
+			name: "with_config_entries_condition",
+			taskConfig: config.TaskConfig{
+				Condition: &config.ConfigEntriesConditionConfig{
+					ConfigEntriesMonitorConfig: config.ConfigEntriesMonitorConfig{
+						Kind:       config.String("ingress-gateway"),
+						Regexp:     config.String("^ingress-"),
+						Datacenter: config.String("dc2"),
+					},
+					UseAsModuleInput: config.Bool(false),
+				},
+				ModuleInputs: &config.ModuleInputConfigs{
+					&config.ConfigEntriesModuleInputConfig{
+						ConfigEntriesMonitorConfig: config.ConfigEntriesMonitorConfig{
+							Kind: config.String("service-defaults"),
+							Name: config.String("api"),
+						},
+					},
+					&config.VaultModuleInputConfig{
+						Path: config.String("secret/my-app"),
+					},
+					// labelled module inputs are represented by their alias
+					&config.VaultModuleInputConfig{
+						Path:  config.String("secret/other-app"),
+						Alias: config.String("other_app"),
+					},
+				},
+			},
+			expected: oapigen.Task{
+				Condition: oapigen.Condition{
+					ConfigEntries: &oapigen.ConfigEntriesCondition{
+						Kind:             "ingress-gateway",
+						Regexp:           config.String("^ingress-"),
+						Datacenter:       config.String("dc2"),
+						UseAsModuleInput: config.Bool(false),
+					},
+				},
+				ModuleInput: &oapigen.ModuleInput{
+					ConfigEntries: &oapigen.ConfigEntriesModuleInput{
+						Kind: "service-defaults",
+						Name: config.String("api"),
+					},
+					Vault: &oapigen.VaultModuleInput{
+						Path: "secret/my-app",
+					},
+					Aliases: &oapigen.ModuleInput_Aliases{
+						AdditionalProperties: map[string]oapigen.ModuleInput{
+							"other_app": {
+								Vault: &oapigen.VaultModuleInput{
+									Path: "secret/other-app",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with_http_condition",
+			taskConfig: config.TaskConfig{
+				Condition: &config.HTTPConditionConfig{
+					HTTPMonitorConfig: config.HTTPMonitorConfig{
+						URL:      config.String("https://ipam.example.com/api/prefixes"),
+						Headers:  map[string]string{"Authorization": "Bearer token"},
+						Interval: config.TimeDuration(30 * time.Second),
+						Selector: config.String("$.data"),
+						TLS: &config.TLSConfig{
+							Enabled: config.Bool(true),
+							CACert:  config.String("ca.pem"),
+						},
+					},
+					UseAsModuleInput: config.Bool(false),
+				},
+				ModuleInputs: &config.ModuleInputConfigs{
+					&config.HTTPModuleInputConfig{
+						HTTPMonitorConfig: config.HTTPMonitorConfig{
+							URL:     config.String("http://ipam.example.com/api/sites"),
+							Timeout: config.TimeDuration(5 * time.Second),
+						},
+					},
+				},
+			},
+			expected: oapigen.Task{
+				Condition: oapigen.Condition{
+					Http: &oapigen.HTTPCondition{
+						Url: "https://ipam.example.com/api/prefixes",
+						Headers: &oapigen.HTTPCondition_Headers{
+							AdditionalProperties: map[string]string{"Authorization": "Bearer token"},
+						},
+						Interval: config.String("30s"),
+						Selector: config.String("$.data"),
+						Tls: &oapigen.HTTPTLS{
+							Enabled: config.Bool(true),
+							CaCert:  config.String("ca.pem"),
+						},
+						UseAsModuleInput: config.Bool(false),
+					},
+				},
+				ModuleInput: &oapigen.ModuleInput{
+					Http: &oapigen.HTTPModuleInput{
+						Url:     "http://ipam.example.com/api/sites",
+						Headers: &oapigen.HTTPModuleInput_Headers{},
+						Timeout: config.String("5s"),
+					},
+				},
+			},
+		},
+		{
+			name: "with_webhook_condition",
+			taskConfig: config.TaskConfig{
+				Condition: &config.WebhookConditionConfig{
+					Secret:           config.String("s3cr3t"),
+					UseAsModuleInput: config.Bool(true),
+				},
+			},
+			expected: oapigen.Task{
+				Condition: oapigen.Condition{
+					Webhook: &oapigen.WebhookCondition{
+						Secret:           "s3cr3t",
 						UseAsModuleInput: config.Bool(true),
 					},
 				},
@@ -330,6 +546,7 @@ func TestRequest_oapigenTaskFromConfigTask(t *testing.T) {
 							Recurse:    config.Bool(false),
 							Datacenter: config.String("dc"),
 							Namespace:  config.String("ns"),
+							Decode:     config.String("hcl"),
 						},
 					},
 				},
@@ -350,6 +567,7 @@ func TestRequest_oapigenTaskFromConfigTask(t *testing.T) {
 						Recurse:    config.Bool(false),
 						Datacenter: config.String("dc"),
 						Namespace:  config.String("ns"),
+						Decode:     config.String("hcl"),
 					},
 				},
 			},
@@ -500,6 +718,56 @@ func TestTaskRequest_ToTaskConfig(t *testing.T) {
 				Module: config.String("path"),
 			},
 		},
+		{
+			name: "module_input_aliases",
+			request: &TaskRequest{
+				Task: oapigen.Task{
+					Name:   "test-name",
+					Module: "path",
+					Condition: oapigen.Condition{
+						Services: &oapigen.ServicesCondition{
+							Names: &[]string{"api"},
+						},
+					},
+					ModuleInput: &oapigen.ModuleInput{
+						ConsulKv: &oapigen.ConsulKVModuleInput{
+							Path: "app/config",
+						},
+						Aliases: &oapigen.ModuleInput_Aliases{
+							AdditionalProperties: map[string]oapigen.ModuleInput{
+								"other_config": {
+									ConsulKv: &oapigen.ConsulKVModuleInput{
+										Path: "other/config",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			taskConfigExpected: config.TaskConfig{
+				Name: config.String("test-name"),
+				Condition: &config.ServicesConditionConfig{
+					ServicesMonitorConfig: config.ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				Module: config.String("path"),
+				ModuleInputs: &config.ModuleInputConfigs{
+					&config.ConsulKVModuleInputConfig{
+						ConsulKVMonitorConfig: config.ConsulKVMonitorConfig{
+							Path: config.String("app/config"),
+						},
+					},
+					&config.ConsulKVModuleInputConfig{
+						Alias: config.String("other_config"),
+						ConsulKVMonitorConfig: config.ConsulKVMonitorConfig{
+							Path: config.String("other/config"),
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "basic_fields_filled",
 			request: &TaskRequest{
@@ -519,7 +787,8 @@ func TestTaskRequest_ToTaskConfig(t *testing.T) {
 						Max:     config.String("5m"),
 						Min:     config.String("30s"),
 					},
-					Enabled: config.Bool(true),
+					Enabled:          config.Bool(true),
+					ServicesProtocol: config.String(config.ServicesProtocolV1),
 
 					// Enterprise
 					TerraformVersion: config.String("1.0.0"),
@@ -547,7 +816,8 @@ func TestTaskRequest_ToTaskConfig(t *testing.T) {
 					Max:     config.TimeDuration(5 * time.Minute),
 					Min:     config.TimeDuration(30 * time.Second),
 				},
-				Enabled: config.Bool(true),
+				Enabled:          config.Bool(true),
+				ServicesProtocol: config.String(config.ServicesProtocolV1),
 
 				// Enterprise
 				DeprecatedTFVersion: config.String("1.0.0"),
@@ -675,6 +945,31 @@ func TestTaskRequest_ToTaskConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with_catalog_services_condition_datacenters",
+			request: &TaskRequest{
+				Task: oapigen.Task{
+					Name:   "task",
+					Module: "path",
+					Condition: oapigen.Condition{
+						CatalogServices: &oapigen.CatalogServicesCondition{
+							Regexp:      ".*",
+							Datacenters: &[]string{"all"},
+						},
+					},
+				},
+			},
+			taskConfigExpected: config.TaskConfig{
+				Name:   config.String("task"),
+				Module: config.String("path"),
+				Condition: &config.CatalogServicesConditionConfig{
+					CatalogServicesMonitorConfig: config.CatalogServicesMonitorConfig{
+						Regexp:      config.String(".*"),
+						Datacenters: []string{"all"},
+					},
+				},
+			},
+		},
 		{
 			name: "with_consul_kv_condition",
 			request: &TaskRequest{
@@ -692,6 +987,7 @@ func TestTaskRequest_ToTaskConfig(t *testing.T) {
 							Recurse:          config.Bool(true),
 							Datacenter:       config.String("dc2"),
 							Namespace:        config.String("ns2"),
+							Decode:           config.String("yaml"),
 							UseAsModuleInput: config.Bool(true),
 						},
 					},
@@ -713,11 +1009,189 @@ func TestTaskRequest_ToTaskConfig(t *testing.T) {
 						Recurse:    config.Bool(true),
 						Datacenter: config.String("dc2"),
 						Namespace:  config.String("ns2"),
+						Decode:     config.String("yaml"),
 					},
 					UseAsModuleInput: config.Bool(true),
 				},
 			},
 		},
+		{
+			name: "with_nodes_condition",
+			request: &TaskRequest{
+				Task: oapigen.Task{
+					Name:   "task",
+					Module: "path",
+					ModuleInput: &oapigen.ModuleInput{
+						Nodes: &oapigen.NodesModuleInput{
+							Datacenter: config.String("dc1"),
+						},
+					},
+					Condition: oapigen.Condition{
+						Nodes: &oapigen.NodesCondition{
+							Datacenter: config.String("dc2"),
+							NodeMeta: &oapigen.NodesCondition_NodeMeta{
+								AdditionalProperties: map[string]string{"key": "value"},
+							},
+							Filter:           config.String("Meta.env == \"prod\""),
+							UseAsModuleInput: config.Bool(true),
+						},
+					},
+				},
+			},
+			taskConfigExpected: config.TaskConfig{
+				Name: config.String("task"),
+				ModuleInputs: &config.ModuleInputConfigs{
+					&config.NodesModuleInputConfig{
+						NodesMonitorConfig: config.NodesMonitorConfig{
+							Datacenter: config.String("dc1"),
+						},
+					},
+				},
+				Module: config.String("path"),
+				Condition: &config.NodesConditionConfig{
+					NodesMonitorConfig: config.NodesMonitorConfig{
+						Datacenter: config.String("dc2"),
+						NodeMeta:   map[string]string{"key": "value"},
+						Filter:     config.String("Meta.env == \"prod\""),
+					},
+					UseAsModuleInput: config.Bool(true),
+				},
+			},
+		},
+		{
+			name: "with_intentions_condition",
+			request: &TaskRequest{
+				Task: oapigen.Task{
+					Name:   "task",
+					Module: "path",
+					Condition: oapigen.Condition{
+						Intentions: &oapigen.IntentionsCondition{
+							Datacenter: config.String("dc2"),
+							Filter:     config.String("DestinationName == \"api\""),
+						},
+					},
+				},
+			},
+			taskConfigExpected: config.TaskConfig{
+				Name:   config.String("task"),
+				Module: config.String("path"),
+				Condition: &config.IntentionsConditionConfig{
+					IntentionsMonitorConfig: config.IntentionsMonitorConfig{
+						Datacenter: config.String("dc2"),
+						Filter:     config.String("DestinationName == \"api\""),
+					},
+				},
+			},
+		},
+		{
+			name: "with_config_entries_condition",
+			request: &TaskRequest{
+				Task: oapigen.Task{
+					Name:   "task",
+					Module: "path",
+					Condition: oapigen.Condition{
+						ConfigEntries: &oapigen.ConfigEntriesCondition{
+							Kind: "terminating-gateway",
+							Name: config.String("terminating"),
+						},
+					},
+					ModuleInput: &oapigen.ModuleInput{
+						Vault: &oapigen.VaultModuleInput{
+							Path: "secret/my-app",
+						},
+					},
+				},
+			},
+			taskConfigExpected: config.TaskConfig{
+				Name:   config.String("task"),
+				Module: config.String("path"),
+				Condition: &config.ConfigEntriesConditionConfig{
+					ConfigEntriesMonitorConfig: config.ConfigEntriesMonitorConfig{
+						Kind: config.String("terminating-gateway"),
+						Name: config.String("terminating"),
+					},
+				},
+				ModuleInputs: &config.ModuleInputConfigs{
+					&config.VaultModuleInputConfig{
+						Path: config.String("secret/my-app"),
+					},
+				},
+			},
+		},
+		{
+			name: "with_http_condition",
+			request: &TaskRequest{
+				Task: oapigen.Task{
+					Name:   "task",
+					Module: "path",
+					Condition: oapigen.Condition{
+						Http: &oapigen.HTTPCondition{
+							Url: "https://ipam.example.com/api/prefixes",
+							Headers: &oapigen.HTTPCondition_Headers{
+								AdditionalProperties: map[string]string{"Authorization": "Bearer token"},
+							},
+							Interval: config.String("30s"),
+							Timeout:  config.String("5s"),
+							Selector: config.String("$.data"),
+							Tls: &oapigen.HTTPTLS{
+								Verify: config.Bool(false),
+							},
+						},
+					},
+					ModuleInput: &oapigen.ModuleInput{
+						Http: &oapigen.HTTPModuleInput{
+							Url: "http://ipam.example.com/api/sites",
+						},
+					},
+				},
+			},
+			taskConfigExpected: config.TaskConfig{
+				Name:   config.String("task"),
+				Module: config.String("path"),
+				Condition: &config.HTTPConditionConfig{
+					HTTPMonitorConfig: config.HTTPMonitorConfig{
+						URL:      config.String("https://ipam.example.com/api/prefixes"),
+						Headers:  map[string]string{"Authorization": "Bearer token"},
+						Interval: config.TimeDuration(30 * time.Second),
+						Timeout:  config.TimeDuration(5 * time.Second),
+						Selector: config.String("$.data"),
+						TLS: &config.TLSConfig{
+							Verify: config.Bool(false),
+						},
+					},
+				},
+				ModuleInputs: &config.ModuleInputConfigs{
+					&config.HTTPModuleInputConfig{
+						HTTPMonitorConfig: config.HTTPMonitorConfig{
+							URL: config.String("http://ipam.example.com/api/sites"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with_webhook_condition",
+			request: &TaskRequest{
+				Task: oapigen.Task{
+					Name:   "task",
+					Module: "path",
+					Condition: oapigen.Condition{
+						Webhook: &oapigen.WebhookCondition{
+							Secret:           "s3cr3t",
+							UseAsModuleInput: config.Bool(false),
+						},
+					},
+				},
+			},
+			taskConfigExpected: config.TaskConfig{
+				Name:   config.String("task"),
+				Module: config.String("path"),
+				Condition: &config.WebhookConditionConfig{
+					Secret:           config.String("s3cr3t"),
+					UseAsModuleInput: config.Bool(false),
+				},
+			},
+		},
 		{
 			name: "with_schedule_condition",
 			request: &TaskRequest{
@@ -765,6 +1239,7 @@ func TestTaskRequest_ToTaskConfig(t *testing.T) {
 							Recurse:    config.Bool(true),
 							Datacenter: config.String("dc"),
 							Namespace:  config.String("ns"),
+							Decode:     config.String("json"),
 						},
 					},
 				},
@@ -787,6 +1262,7 @@ func TestTaskRequest_ToTaskConfig(t *testing.T) {
 							Recurse:    config.Bool(true),
 							Datacenter: config.String("dc"),
 							Namespace:  config.String("ns"),
+							Decode:     config.String("json"),
 						},
 					},
 				},
@@ -862,6 +1338,55 @@ func TestTaskRequest_ToRequestTaskConfig_Error(t *testing.T) {
 			},
 			contains: "invalid duration",
 		},
+		{
+			name: "invalid http interval",
+			request: &TaskRequest{
+				Task: oapigen.Task{
+					Name: "test-name",
+					Condition: oapigen.Condition{
+						Http: &oapigen.HTTPCondition{
+							Url:      "http://ipam.example.com",
+							Interval: config.String("invalid"),
+						},
+					},
+				},
+			},
+			contains: "invalid duration",
+		},
+		{
+			name: "module input alias without type",
+			request: &TaskRequest{
+				Task: oapigen.Task{
+					Name: "test-name",
+					ModuleInput: &oapigen.ModuleInput{
+						Aliases: &oapigen.ModuleInput_Aliases{
+							AdditionalProperties: map[string]oapigen.ModuleInput{
+								"other_config": {},
+							},
+						},
+					},
+				},
+			},
+			contains: "must have one module input type",
+		},
+		{
+			name: "nested module input aliases",
+			request: &TaskRequest{
+				Task: oapigen.Task{
+					Name: "test-name",
+					ModuleInput: &oapigen.ModuleInput{
+						Aliases: &oapigen.ModuleInput_Aliases{
+							AdditionalProperties: map[string]oapigen.ModuleInput{
+								"other_config": {
+									Aliases: &oapigen.ModuleInput_Aliases{},
+								},
+							},
+						},
+					},
+				},
+			},
+			contains: "cannot have aliases",
+		},
 	}
 
 	for _, tc := range cases {
@@ -937,3 +1462,31 @@ func TestTaskResponse_taskResponseFromTaskConfig(t *testing.T) {
 	actual := taskResponseFromTaskConfig(tc.taskConfig, uuid.MustParse("e9926514-79b8-a8fc-8761-9b6aaccf1e15"))
 	assert.Equal(t, tc.expectedResponse, actual)
 }
+
+func TestTaskResponse_taskResponseFromTaskConfig_RedactSecrets(t *testing.T) {
+	requestID := uuid.MustParse("e9926514-79b8-a8fc-8761-9b6aaccf1e15")
+	taskConfig := config.TaskConfig{
+		Condition: &config.WebhookConditionConfig{
+			Secret:           config.String("s3cr3t"),
+			UseAsModuleInput: config.Bool(true),
+		},
+	}
+	expected := oapigen.Condition{
+		Webhook: &oapigen.WebhookCondition{
+			Secret:           "(redacted)",
+			UseAsModuleInput: config.Bool(true),
+		},
+	}
+
+	actual := taskResponseFromTaskConfig(taskConfig, requestID)
+	assert.Equal(t, expected, actual.Task.Condition)
+
+	actualTasks := tasksResponseFromTaskConfigs(
+		config.TaskConfigs{&taskConfig}, requestID)
+	assert.Equal(t, expected, (*actualTasks.Tasks)[0].Condition)
+
+	// task requests are not redacted
+	req, err := TaskRequestFromTaskConfig(taskConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", req.Task.Condition.Webhook.Secret)
+}
@@ -17,12 +17,18 @@ type Server interface {
 	Task(ctx context.Context, taskName string) (config.TaskConfig, error)
 	TaskCreate(context.Context, config.TaskConfig) (config.TaskConfig, error)
 	TaskCreateAndRun(context.Context, config.TaskConfig) (config.TaskConfig, error)
+	// TaskDamping returns nil if the task does not have damping configured
+	TaskDamping(ctx context.Context, taskName string) (*event.DampingStatus, error)
 	TaskDelete(ctx context.Context, taskName string) error
+	TaskDeleteAndDestroy(ctx context.Context, taskName string) error
 	// TODO: update signatures to return a new run object
 	TaskInspect(context.Context, config.TaskConfig) (bool, string, string, error)
+	TaskInspectDestroy(ctx context.Context, taskName string) (bool, string, string, error)
 	// TODO: update signature with an update config object since only a subset of
 	// options can be changed and determine the location of sharable objects
 	// across packages
 	TaskUpdate(ctx context.Context, updateConf config.TaskConfig, runOp string) (bool, string, string, error)
+	TaskOutputs(ctx context.Context, taskName string) (*event.Event, error)
+	TaskTrigger(ctx context.Context, taskName string, payload interface{}) error
 	Tasks(context.Context) config.TaskConfigs
 }
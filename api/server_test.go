@@ -117,7 +117,8 @@ func TestStatus(t *testing.T) {
 			taskName: es,
 		}
 		ctrl.On("Task", mock.Anything, taskName).Return(conf, nil).
-			On("Events", mock.Anything, taskName).Return(eventResp, nil)
+			On("Events", mock.Anything, taskName).Return(eventResp, nil).
+			On("TaskDamping", mock.Anything, taskName).Return(nil, nil)
 	}
 	ctrl.On("Tasks", mock.Anything).Return(confs)
 	ctrl.On("Events", mock.Anything, "").Return(events, nil)
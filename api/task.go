@@ -14,10 +14,11 @@ import (
 )
 
 const (
-	updateTaskSubsystemName = "updatetask"
-	createTaskSubsystemName = "createtask"
-	deleteTaskSubsystemName = "deletetask"
-	getTaskSubsystemName    = "gettask"
+	updateTaskSubsystemName  = "updatetask"
+	createTaskSubsystemName  = "createtask"
+	deleteTaskSubsystemName  = "deletetask"
+	getTaskSubsystemName     = "gettask"
+	triggerTaskSubsystemName = "triggertask"
 
 	taskPath = "tasks"
 
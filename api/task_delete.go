@@ -1,6 +1,8 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
@@ -8,8 +10,9 @@ import (
 )
 
 // DeleteTaskByName deletes an existing task and its events asynchronously. Does not delete
-// until the task is inactive and not running.
-func (h *TaskLifeCycleHandler) DeleteTaskByName(w http.ResponseWriter, r *http.Request, name string) {
+// until the task is inactive and not running. If requested, the infrastructure managed by
+// the task is destroyed before the task is deleted.
+func (h *TaskLifeCycleHandler) DeleteTaskByName(w http.ResponseWriter, r *http.Request, name string, params oapigen.DeleteTaskByNameParams) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
@@ -26,7 +29,31 @@ func (h *TaskLifeCycleHandler) DeleteTaskByName(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	err = h.ctrl.TaskDelete(ctx, name)
+	destroy := params.Destroy != nil && *params.Destroy
+	if params.Run != nil && *params.Run != "" {
+		if *params.Run != RunOptionInspect {
+			err = fmt.Errorf("unsupported run option '%s' for deleting a task, "+
+				"only '%s' is supported", *params.Run, RunOptionInspect)
+			sendError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		if !destroy {
+			err = fmt.Errorf("run option '%s' for deleting a task requires "+
+				"destroy to be true", RunOptionInspect)
+			sendError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		logger.Trace("run inspect option")
+		h.inspectDestroyTask(w, r, name)
+		return
+	}
+
+	if destroy {
+		logger.Trace("destroy option")
+		err = h.ctrl.TaskDeleteAndDestroy(ctx, name)
+	} else {
+		err = h.ctrl.TaskDelete(ctx, name)
+	}
 	if err != nil {
 		sendError(w, r, http.StatusInternalServerError, err)
 		return
@@ -37,3 +64,35 @@ func (h *TaskLifeCycleHandler) DeleteTaskByName(w http.ResponseWriter, r *http.R
 
 	logger.Trace("task deleted", "delete_task_response", resp)
 }
+
+// inspectDestroyTask inspects the changes to destroy the infrastructure managed
+// by an existing task. The task is not deleted.
+func (h *TaskLifeCycleHandler) inspectDestroyTask(w http.ResponseWriter, r *http.Request, name string) {
+	ctx := r.Context()
+	logger := logging.FromContext(ctx).Named(deleteTaskSubsystemName).With("task_name", name)
+
+	changes, plan, runUrl, err := h.ctrl.TaskInspectDestroy(ctx, name)
+	if err != nil {
+		logger.Error("error inspecting destroy for task", "error", err)
+		if errors.As(err, &TaskNotFoundError{}) {
+			sendError(w, r, http.StatusNotFound, err)
+			return
+		}
+		sendError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	resp := oapigen.TaskDeleteResponse{
+		RequestId: requestIDFromContext(ctx),
+		Run: &oapigen.Run{
+			Plan:           &plan,
+			ChangesPresent: &changes,
+		},
+	}
+	if runUrl != "" {
+		resp.Run.TfcRunUrl = &runUrl
+	}
+
+	writeResponse(w, r, http.StatusOK, resp)
+	logger.Trace("task destroy inspection complete", "delete_task_response", resp)
+}
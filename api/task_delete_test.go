@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
 	"github.com/hashicorp/consul-terraform-sync/config"
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
 	"github.com/stretchr/testify/assert"
@@ -16,13 +17,18 @@ import (
 func TestTaskLifeCycleHandler_DeleteTaskByName(t *testing.T) {
 	t.Parallel()
 	taskName := "task"
+	destroy := true
+	runInspect := oapigen.DeleteTaskByNameParamsRun(RunOptionInspect)
+	runNow := oapigen.DeleteTaskByNameParamsRun(RunOptionNow)
 	cases := []struct {
 		name       string
+		params     oapigen.DeleteTaskByNameParams
 		mockServer func(*mocks.Server)
 		statusCode int
 	}{
 		{
 			"happy_path",
+			oapigen.DeleteTaskByNameParams{},
 			func(ctrl *mocks.Server) {
 				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
 				ctrl.On("TaskDelete", mock.Anything, taskName).Return(nil)
@@ -31,6 +37,7 @@ func TestTaskLifeCycleHandler_DeleteTaskByName(t *testing.T) {
 		},
 		{
 			"task_not_found",
+			oapigen.DeleteTaskByNameParams{},
 			func(ctrl *mocks.Server) {
 				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, fmt.Errorf("DNE"))
 			},
@@ -38,6 +45,7 @@ func TestTaskLifeCycleHandler_DeleteTaskByName(t *testing.T) {
 		},
 		{
 			"task_errored",
+			oapigen.DeleteTaskByNameParams{},
 			func(ctrl *mocks.Server) {
 				err := fmt.Errorf("task deletion error")
 				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
@@ -45,6 +53,61 @@ func TestTaskLifeCycleHandler_DeleteTaskByName(t *testing.T) {
 			},
 			http.StatusInternalServerError,
 		},
+		{
+			"destroy",
+			oapigen.DeleteTaskByNameParams{Destroy: &destroy},
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskDeleteAndDestroy", mock.Anything, taskName).Return(nil)
+			},
+			http.StatusAccepted,
+		},
+		{
+			"destroy_inspect",
+			oapigen.DeleteTaskByNameParams{Destroy: &destroy, Run: &runInspect},
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskInspectDestroy", mock.Anything, taskName).
+					Return(true, "plan", "", nil)
+			},
+			http.StatusOK,
+		},
+		{
+			"destroy_inspect_errored",
+			oapigen.DeleteTaskByNameParams{Destroy: &destroy, Run: &runInspect},
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskInspectDestroy", mock.Anything, taskName).
+					Return(false, "", "", fmt.Errorf("plan error"))
+			},
+			http.StatusBadRequest,
+		},
+		{
+			"destroy_inspect_not_found",
+			oapigen.DeleteTaskByNameParams{Destroy: &destroy, Run: &runInspect},
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskInspectDestroy", mock.Anything, taskName).
+					Return(false, "", "", TaskNotFoundError{Name: taskName})
+			},
+			http.StatusNotFound,
+		},
+		{
+			"inspect_without_destroy",
+			oapigen.DeleteTaskByNameParams{Run: &runInspect},
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+			},
+			http.StatusBadRequest,
+		},
+		{
+			"unsupported_run_option",
+			oapigen.DeleteTaskByNameParams{Destroy: &destroy, Run: &runNow},
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+			},
+			http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range cases {
@@ -58,8 +121,9 @@ func TestTaskLifeCycleHandler_DeleteTaskByName(t *testing.T) {
 			require.NoError(t, err)
 			resp := httptest.NewRecorder()
 
-			handler.DeleteTaskByName(resp, req, taskName)
+			handler.DeleteTaskByName(resp, req, taskName, tc.params)
 			assert.Equal(t, tc.statusCode, resp.Code)
+			ctrl.AssertExpectations(t)
 		})
 	}
 }
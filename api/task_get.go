@@ -3,6 +3,7 @@ package api
 import (
 	"net/http"
 
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
 	"github.com/hashicorp/consul-terraform-sync/logging"
 )
 
@@ -48,3 +49,40 @@ func (h *TaskLifeCycleHandler) GetTaskByName(w http.ResponseWriter, r *http.Requ
 
 	logger.Trace("task retrieved", "get_task_response", resp)
 }
+
+// GetTaskOutputsByName retrieves the output values of a task's module from the
+// task's latest run that recorded outputs
+func (h *TaskLifeCycleHandler) GetTaskOutputsByName(w http.ResponseWriter, r *http.Request, name string) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	ctx := r.Context()
+	requestID := requestIDFromContext(ctx)
+	logger := logging.FromContext(ctx).Named(getTaskSubsystemName).With("task_name", name)
+	logger.Trace("get task outputs request")
+
+	// Retrieve outputs if the task exists
+	ev, err := h.ctrl.TaskOutputs(ctx, name)
+	if err != nil {
+		logger.Trace("task not found", "error", err)
+		sendError(w, r, http.StatusNotFound, err)
+		return
+	}
+
+	resp := oapigen.TaskOutputsResponse{
+		RequestId: requestID,
+		Outputs: oapigen.TaskOutputsResponse_Outputs{
+			AdditionalProperties: make(map[string]interface{}),
+		},
+	}
+	if ev != nil {
+		eventID := ev.ID
+		resp.EventId = &eventID
+		for k, v := range ev.Outputs {
+			resp.Outputs.Set(k, v)
+		}
+	}
+	writeResponse(w, r, http.StatusOK, resp)
+
+	logger.Trace("task outputs retrieved", "event_id", resp.EventId)
+}
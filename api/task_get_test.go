@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
 	"github.com/hashicorp/consul-terraform-sync/config"
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -105,3 +106,75 @@ func TestTaskLifeCycleHandler_GetAllTasks(t *testing.T) {
 	assert.ElementsMatch(t, *expectedTasksResponse.Tasks, *actual.Tasks)
 	assert.ElementsMatch(t, expectedTasksResponse.RequestId, reqID)
 }
+
+func TestTaskLifeCycleHandler_GetTaskOutputsByName(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		mockServer func(*mocks.Server)
+		statusCode int
+		expected   map[string]interface{}
+		eventID    *string
+	}{
+		{
+			name: "happy_path",
+			mockServer: func(ctrl *mocks.Server) {
+				ev := &event.Event{
+					ID: "123",
+					Outputs: map[string]json.RawMessage{
+						"ids":  json.RawMessage(`["a","b"]`),
+						"name": json.RawMessage(`"test"`),
+					},
+				}
+				ctrl.On("TaskOutputs", mock.Anything, testTaskName).Return(ev, nil)
+			},
+			statusCode: http.StatusOK,
+			expected: map[string]interface{}{
+				"ids":  []interface{}{"a", "b"},
+				"name": "test",
+			},
+			eventID: config.String("123"),
+		},
+		{
+			name: "no_outputs",
+			mockServer: func(ctrl *mocks.Server) {
+				ctrl.On("TaskOutputs", mock.Anything, testTaskName).Return(nil, nil)
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "not_found",
+			mockServer: func(ctrl *mocks.Server) {
+				ctrl.On("TaskOutputs", mock.Anything, testTaskName).Return(nil, fmt.Errorf("DNE"))
+			},
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := new(mocks.Server)
+			tc.mockServer(ctrl)
+			handler := NewTaskLifeCycleHandler(ctrl)
+
+			path := fmt.Sprintf("/v1/tasks/%s/outputs", testTaskName)
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			handler.GetTaskOutputsByName(resp, req, testTaskName)
+			assert.Equal(t, tc.statusCode, resp.Code)
+
+			if tc.statusCode != http.StatusOK {
+				return
+			}
+
+			var actual oapigen.TaskOutputsResponse
+			err = json.NewDecoder(resp.Body).Decode(&actual)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual.Outputs.AdditionalProperties)
+			assert.Equal(t, tc.eventID, actual.EventId)
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/logging"
@@ -23,6 +25,11 @@ type TaskStatus struct {
 	EventsURL string        `json:"events_url"`
 	Events    []event.Event `json:"events,omitempty"`
 
+	// Damping is only set for tasks with damping configured for the services
+	// condition. It reports changes that are suppressed and pending to
+	// trigger the task.
+	Damping *DampingStatus `json:"damping,omitempty"`
+
 	// Providers and Services are deprecated in v0.5. These are configuration
 	// details about the task rather than status information. Users should
 	// switch to using the Get Task API to request the task's provider and
@@ -34,6 +41,14 @@ type TaskStatus struct {
 	Services  []string `json:"services"`
 }
 
+// DampingStatus is the status of the changes suppressed for a task
+type DampingStatus struct {
+	PendingChanges          int        `json:"pending_changes"`
+	SuppressedNotifications int        `json:"suppressed_notifications"`
+	PendingSince            *time.Time `json:"pending_since,omitempty"`
+	NextTrigger             *time.Time `json:"next_trigger,omitempty"`
+}
+
 // taskStatusHandler handles the task status endpoint
 type taskStatusHandler struct {
 	ctrl    Server
@@ -108,6 +123,7 @@ func (h *taskStatusHandler) getTaskStatus(w http.ResponseWriter, r *http.Request
 		if include {
 			status.Events = events
 		}
+		status.Damping = h.dampingStatus(ctx, taskName)
 		statuses[taskName] = status
 	}
 
@@ -121,7 +137,9 @@ func (h *taskStatusHandler) getTaskStatus(w http.ResponseWriter, r *http.Request
 				jsonErrorResponse(ctx, w, http.StatusNotFound, err)
 				return
 			}
-			statuses[taskName] = makeTaskStatusUnknown(task)
+			status := makeTaskStatusUnknown(task)
+			status.Damping = h.dampingStatus(ctx, taskName)
+			statuses[taskName] = status
 		}
 	}
 
@@ -131,7 +149,9 @@ func (h *taskStatusHandler) getTaskStatus(w http.ResponseWriter, r *http.Request
 		tasks := h.ctrl.Tasks(ctx)
 		for _, task := range tasks {
 			if _, ok := data[*task.Name]; !ok {
-				statuses[*task.Name] = makeTaskStatusUnknown(*task)
+				status := makeTaskStatusUnknown(*task)
+				status.Damping = h.dampingStatus(ctx, *task.Name)
+				statuses[*task.Name] = status
 			}
 		}
 	}
@@ -141,6 +161,39 @@ func (h *taskStatusHandler) getTaskStatus(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// dampingStatus returns the status of the changes suppressed for a task.
+// Returns nil if the task does not have damping configured.
+func (h *taskStatusHandler) dampingStatus(ctx context.Context, taskName string) *DampingStatus {
+	ds, err := h.ctrl.TaskDamping(ctx, taskName)
+	if err != nil {
+		logging.FromContext(ctx).Named(taskStatusSubsystemName).Trace(
+			"error getting task damping status", "error", err)
+		return nil
+	}
+	return makeDampingStatus(ds)
+}
+
+// makeDampingStatus converts the status of a task's damper
+func makeDampingStatus(ds *event.DampingStatus) *DampingStatus {
+	if ds == nil {
+		return nil
+	}
+
+	status := &DampingStatus{
+		PendingChanges:          ds.PendingChanges,
+		SuppressedNotifications: ds.SuppressedNotifications,
+	}
+	if !ds.PendingSince.IsZero() {
+		since := ds.PendingSince
+		status.PendingSince = &since
+	}
+	if !ds.NextTrigger.IsZero() {
+		next := ds.NextTrigger
+		status.NextTrigger = &next
+	}
+	return status
+}
+
 // makeTaskStatus takes event data for a task and returns a task status
 func makeTaskStatus(events []event.Event, task config.TaskConfig,
 	version string) TaskStatus {
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/config"
 	serverMocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
@@ -73,6 +74,7 @@ func TestTaskStatus_ServeHTTP(t *testing.T) {
 		On("Task", mock.Anything, "task_nonexistent").Return(config.TaskConfig{}, fmt.Errorf("DNE"))
 	ctrl.On("Events", mock.Anything, "").Return(events, nil)
 	ctrl.On("Tasks", mock.Anything).Return(confs)
+	ctrl.On("TaskDamping", mock.Anything, mock.Anything).Return(nil, nil)
 
 	handler := newTaskStatusHandler(ctrl, "v1")
 
@@ -312,6 +314,45 @@ func TestTaskStatus_ServeHTTP(t *testing.T) {
 
 }
 
+func TestTaskStatus_ServeHTTP_Damping(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	next := since.Add(time.Minute)
+
+	conf := createTaskConf("task_a", true)
+	events := map[string][]event.Event{"task_a": {{Success: true}}}
+
+	ctrl := new(serverMocks.Server)
+	ctrl.On("Events", mock.Anything, "task_a").Return(events, nil).
+		On("Task", mock.Anything, "task_a").Return(conf, nil).
+		On("TaskDamping", mock.Anything, "task_a").Return(&event.DampingStatus{
+		PendingChanges:          3,
+		SuppressedNotifications: 2,
+		PendingSince:            since,
+		NextTrigger:             next,
+	}, nil)
+
+	handler := newTaskStatusHandler(ctrl, "v1")
+	req, err := http.NewRequest(http.MethodGet, "/v1/status/tasks/task_a", nil)
+	require.NoError(t, err)
+	resp := httptest.NewRecorder()
+
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var actual map[string]TaskStatus
+	err = json.NewDecoder(resp.Body).Decode(&actual)
+	require.NoError(t, err)
+
+	assert.Equal(t, &DampingStatus{
+		PendingChanges:          3,
+		SuppressedNotifications: 2,
+		PendingSince:            &since,
+		NextTrigger:             &next,
+	}, actual["task_a"].Damping)
+}
+
 func TestTaskStatus_MakeStatus(t *testing.T) {
 	enabledTask := createTaskConf("test_task", true)
 	disabledTask := createTaskConf("test_task", false)
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+)
+
+// signaturePrefix is the prefix of the HMAC-SHA256 signature in the
+// X-CTS-Signature header of a request to trigger a task
+const signaturePrefix = "sha256="
+
+// maxTriggerBodySize is the maximum size in bytes of the body of a request to
+// trigger a task. The body is read into memory to verify the signature.
+const maxTriggerBodySize = 1 << 20 // 1 MiB
+
+// TriggerTaskByName triggers a task with a webhook condition. The request body
+// is verified with the signature header using the secret of the condition and
+// the decoded body is made available to the task's module.
+func (h *TaskLifeCycleHandler) TriggerTaskByName(w http.ResponseWriter, r *http.Request, name string, params oapigen.TriggerTaskByNameParams) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	ctx := r.Context()
+	requestID := requestIDFromContext(ctx)
+	logger := logging.FromContext(ctx).Named(triggerTaskSubsystemName).With("task_name", name)
+	logger.Trace("trigger task request")
+
+	taskConfig, err := h.ctrl.Task(ctx, name)
+	if err != nil {
+		logger.Trace("task not found", "error", err)
+		sendError(w, r, http.StatusNotFound, err)
+		return
+	}
+
+	cond, ok := taskConfig.Condition.(*config.WebhookConditionConfig)
+	if !ok {
+		err = fmt.Errorf("task '%s' cannot be triggered, only tasks with a "+
+			"webhook condition can be triggered", name)
+		sendError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxTriggerBodySize))
+	if err != nil {
+		logger.Trace("unable to read request body", "error", err)
+		err = fmt.Errorf("unable to read request body, the body must not "+
+			"exceed %d bytes: %s", maxTriggerBodySize, err)
+		sendError(w, r, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+
+	if !validSignature(config.StringVal(cond.Secret), body, params.XCTSSignature) {
+		logger.Trace("invalid signature")
+		err = fmt.Errorf("invalid signature for task '%s'", name)
+		sendError(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	var payload interface{}
+	if err = json.Unmarshal(body, &payload); err != nil {
+		logger.Trace("invalid request body", "error", err)
+		err = fmt.Errorf("unable to decode request body: %s", err)
+		sendError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = h.ctrl.TaskTrigger(ctx, name, payload); err != nil {
+		logger.Trace("unable to trigger task", "error", err)
+		sendError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	resp := oapigen.TaskTriggerResponse{RequestId: requestID}
+	writeResponse(w, r, http.StatusAccepted, resp)
+
+	logger.Trace("task triggered", "trigger_task_response", resp)
+}
+
+// validSignature returns whether the signature is the HMAC-SHA256 of the body
+// signed with the secret, in the format "sha256=<hex digest>". The secret is
+// required to be non-empty by the webhook condition configuration.
+func validSignature(secret string, body []byte, signature string) bool {
+	if secret == "" || !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	actual, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), actual)
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLifeCycleHandler_TriggerTaskByName(t *testing.T) {
+	t.Parallel()
+	taskName := "task"
+	secret := "s3cr3t"
+	body := `{"image": "web:1.2.3"}`
+	tooLarge := fmt.Sprintf(`{"image": "%s"}`,
+		strings.Repeat("a", maxTriggerBodySize))
+	webhookTask := config.TaskConfig{
+		Name:      config.String(taskName),
+		Condition: &config.WebhookConditionConfig{Secret: config.String(secret)},
+	}
+
+	cases := []struct {
+		name       string
+		body       string
+		signature  string
+		mockServer func(*mocks.Server)
+		statusCode int
+	}{
+		{
+			"happy_path",
+			body,
+			testSignature(secret, body),
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(webhookTask, nil)
+				ctrl.On("TaskTrigger", mock.Anything, taskName,
+					map[string]interface{}{"image": "web:1.2.3"}).Return(nil)
+			},
+			http.StatusAccepted,
+		},
+		{
+			"task_not_found",
+			body,
+			testSignature(secret, body),
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, fmt.Errorf("DNE"))
+			},
+			http.StatusNotFound,
+		},
+		{
+			"not_webhook_condition",
+			body,
+			testSignature(secret, body),
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{
+					Condition: &config.ScheduleConditionConfig{},
+				}, nil)
+			},
+			http.StatusBadRequest,
+		},
+		{
+			"invalid_signature",
+			body,
+			testSignature("wrong", body),
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(webhookTask, nil)
+			},
+			http.StatusUnauthorized,
+		},
+		{
+			"invalid_signature_format",
+			body,
+			"s3cr3t",
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(webhookTask, nil)
+			},
+			http.StatusUnauthorized,
+		},
+		{
+			"invalid_json",
+			`{"image":`,
+			testSignature(secret, `{"image":`),
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(webhookTask, nil)
+			},
+			http.StatusBadRequest,
+		},
+		{
+			"body_too_large",
+			tooLarge,
+			testSignature(secret, tooLarge),
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(webhookTask, nil)
+			},
+			http.StatusRequestEntityTooLarge,
+		},
+		{
+			"task_trigger_errored",
+			body,
+			testSignature(secret, body),
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(webhookTask, nil)
+				ctrl.On("TaskTrigger", mock.Anything, taskName, mock.Anything).
+					Return(fmt.Errorf("task trigger error"))
+			},
+			http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := new(mocks.Server)
+			tc.mockServer(ctrl)
+			handler := NewTaskLifeCycleHandler(ctrl)
+
+			path := fmt.Sprintf("/v1/tasks/%s/trigger", taskName)
+			req, err := http.NewRequest(http.MethodPost, path,
+				bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			params := oapigen.TriggerTaskByNameParams{XCTSSignature: tc.signature}
+			handler.TriggerTaskByName(resp, req, taskName, params)
+			assert.Equal(t, tc.statusCode, resp.Code)
+			ctrl.AssertExpectations(t)
+		})
+	}
+}
+
+func testSignature(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -3,6 +3,8 @@ package client
 import (
 	"context"
 	"io"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
 )
 
 //go:generate mockery --name=Client --filename=client.go  --output=../mocks/client
@@ -25,9 +27,27 @@ type Client interface {
 	// Plan makes a request to generate a plan of proposed changes
 	Plan(ctx context.Context) (bool, error)
 
+	// Destroy makes a request to destroy all managed resources
+	Destroy(ctx context.Context) error
+
+	// PlanDestroy makes a request to generate a plan to destroy all managed
+	// resources
+	PlanDestroy(ctx context.Context) (bool, error)
+
+	// Output makes a request to retrieve the output values of the root module
+	// from the latest state
+	Output(ctx context.Context) (map[string]tfexec.OutputMeta, error)
+
 	// Validate verifies that the generated configurations are valid
 	Validate(ctx context.Context) error
 
 	// GoString defines the printable version of the client
 	GoString() string
 }
+
+// WorkspaceSelector is implemented by clients that can select the workspace of
+// an environment that was already initialized without initializing it again.
+type WorkspaceSelector interface {
+	// SelectWorkspace creates the workspace if needed and selects it
+	SelectWorkspace(ctx context.Context) error
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/api"
@@ -19,6 +20,10 @@ import (
 const (
 	ConsulDefaultMaxRetry = 8 // to be consistent with hcat retries
 	consulSubsystemName   = "consul"
+
+	// permissionDeniedMsg is the error message returned by Consul when the ACL
+	// token does not have the permissions required for a request
+	permissionDeniedMsg = "Permission denied"
 )
 
 var regexUnexpectedResponseCode = regexp.MustCompile("Unexpected response code: ([0-9]{3})")
@@ -46,11 +51,28 @@ func (e *NonEnterpriseConsulError) Unwrap() error {
 // accessing a Consul resource
 type MissingConsulACLError struct {
 	Err error
+
+	// Partition and Peer are the admin partition and cluster peer of the
+	// denied request, if any. They are included in the error to help identify
+	// which ACL policy is missing the required permissions.
+	Partition string
+	Peer      string
 }
 
 // Error returns an error string
 func (e *MissingConsulACLError) Error() string {
-	return fmt.Sprintf("missing required Consul ACL: %v", e.Err)
+	var scope []string
+	if e.Partition != "" {
+		scope = append(scope, fmt.Sprintf("partition %q", e.Partition))
+	}
+	if e.Peer != "" {
+		scope = append(scope, fmt.Sprintf("peer %q", e.Peer))
+	}
+	if len(scope) == 0 {
+		return fmt.Sprintf("missing required Consul ACL: %v", e.Err)
+	}
+	return fmt.Sprintf("missing required Consul ACL for %s: %v",
+		strings.Join(scope, " and "), e.Err)
 }
 
 // Unwrap returns the underlying error
@@ -77,6 +99,7 @@ type ConsulClientInterface interface {
 	Lock(l *consulapi.Lock, stopCh <-chan struct{}) (<-chan struct{}, error)
 	Unlock(l *consulapi.Lock) error
 	KVGet(ctx context.Context, key string, q *consulapi.QueryOptions) (*consulapi.KVPair, *consulapi.QueryMeta, error)
+	KVTxn(ctx context.Context, ops consulapi.KVTxnOps, q *consulapi.QueryOptions) (bool, *consulapi.KVTxnResponse, *consulapi.QueryMeta, error)
 }
 
 // ConsulClient is a client to the Consul API
@@ -202,7 +225,11 @@ func (c *ConsulClient) RegisterService(ctx context.Context, r *consulapi.AgentSe
 			// does not have the correct ACLs to access this resource in Consul
 			// and wrap in the appropriate error
 			if statusCode == http.StatusForbidden {
-				err = &MissingConsulACLError{Err: err}
+				aclErr := &MissingConsulACLError{Err: err}
+				if r != nil {
+					aclErr.Partition = r.Partition
+				}
+				err = aclErr
 			}
 
 			// non-retryable errors allows for termination of retries
@@ -237,7 +264,7 @@ func (c *ConsulClient) DeregisterService(ctx context.Context, serviceID string,
 			// does not have the correct ACLs to access this resource in Consul
 			// and wrap in the appropriate error
 			if statusCode == http.StatusForbidden {
-				err = &MissingConsulACLError{Err: err}
+				err = newQueryACLError(err, q)
 			}
 
 			// non-retryable errors allows for termination of retries
@@ -274,7 +301,11 @@ func (c *ConsulClient) SessionCreate(ctx context.Context, se *consulapi.SessionE
 			// does not have the correct ACLs to access this resource in Consul
 			// and wrap in the appropriate error
 			if statusCode == http.StatusForbidden {
-				err = &MissingConsulACLError{Err: err}
+				aclErr := &MissingConsulACLError{Err: err}
+				if q != nil {
+					aclErr.Partition = q.Partition
+				}
+				err = aclErr
 			}
 
 			// non-retryable errors allows for termination of retries
@@ -326,7 +357,7 @@ func (c *ConsulClient) KVGet(ctx context.Context, key string, q *consulapi.Query
 			// does not have the correct ACLs to access this resource in Consul
 			// and wrap in the appropriate error
 			if statusCode == http.StatusForbidden {
-				err = &MissingConsulACLError{Err: err}
+				err = newQueryACLError(err, q)
 			}
 
 			// non-retryable errors allows for termination of retries
@@ -347,6 +378,61 @@ func (c *ConsulClient) KVGet(ctx context.Context, key string, q *consulapi.Query
 	return kv, meta, err
 }
 
+// KVTxn executes the KV operations atomically as a single transaction. The
+// returned boolean is false if the transaction was rolled back, in which case
+// the response contains the errors of the failed operations. A transaction
+// that is denied due to missing ACL permissions returns an error.
+func (c *ConsulClient) KVTxn(ctx context.Context, ops consulapi.KVTxnOps, q *consulapi.QueryOptions) (bool, *consulapi.KVTxnResponse, *consulapi.QueryMeta, error) {
+	c.logger.Debug("executing KV transaction", "operations", len(ops))
+	desc := "KVTxn"
+	var ok bool
+	var resp *consulapi.KVTxnResponse
+	var meta *consulapi.QueryMeta
+	f := func(context.Context) error {
+		var err error
+		ok, resp, meta, err = c.KV().Txn(ops, q)
+		if err != nil {
+			// The transaction endpoint does not include the response code in
+			// the error. Only permission errors are not retried.
+			if strings.Contains(err.Error(), permissionDeniedMsg) {
+				err = newQueryACLError(err, q)
+				return &retry.NonRetryableError{Err: err}
+			}
+			return err
+		}
+
+		if !ok && resp != nil {
+			for _, txnErr := range resp.Errors {
+				if strings.Contains(txnErr.What, permissionDeniedMsg) {
+					err = fmt.Errorf("operation %d of KV transaction failed: %s",
+						txnErr.OpIndex, txnErr.What)
+					err = newQueryACLError(err, q)
+					return &retry.NonRetryableError{Err: err}
+				}
+			}
+		}
+		return nil
+	}
+
+	err := c.retry.Do(ctx, f, desc)
+	if err != nil {
+		return false, nil, nil, err
+	}
+
+	return ok, resp, meta, nil
+}
+
+// newQueryACLError wraps an error of a request that was denied due to missing
+// ACL permissions, including the partition and peer of the query options.
+func newQueryACLError(err error, q *consulapi.QueryOptions) error {
+	aclErr := &MissingConsulACLError{Err: err}
+	if q != nil {
+		aclErr.Partition = q.Partition
+		aclErr.Peer = q.Peer
+	}
+	return aclErr
+}
+
 func getResponseCodeFromError(ctx context.Context, err error) int {
 	// Extract the unexpected response substring
 	s := regexUnexpectedResponseCode.FindString(err.Error())
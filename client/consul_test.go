@@ -246,6 +246,12 @@ func TestMissingConsulACLError_Error(t *testing.T) {
 
 	assert.True(t, errors.As(&err, &missingConsulACLError))
 	assert.Equal(t, "missing required Consul ACL: some error", err.Error())
+
+	err = MissingConsulACLError{Err: errors.New("some error"), Partition: "foo"}
+	assert.Equal(t, `missing required Consul ACL for partition "foo": some error`, err.Error())
+
+	err = MissingConsulACLError{Err: errors.New("some error"), Partition: "foo", Peer: "bar"}
+	assert.Equal(t, `missing required Consul ACL for partition "foo" and peer "bar": some error`, err.Error())
 }
 
 func TestMissingConsulACLError_Unwrap(t *testing.T) {
@@ -344,6 +350,7 @@ func TestKVGet(t *testing.T) {
 		isNonRetryableError bool
 		isMissingAClError   bool
 		query               *consulapi.QueryOptions
+		rawQuery            string
 	}{
 		{
 			name:         "success",
@@ -382,6 +389,15 @@ func TestKVGet(t *testing.T) {
 			isNonRetryableError: true,
 			isMissingAClError:   true,
 		},
+		{
+			name:                "acl_error_partition_peer",
+			responseCode:        http.StatusForbidden,
+			expectErr:           true,
+			isNonRetryableError: true,
+			isMissingAClError:   true,
+			query:               &consulapi.QueryOptions{Partition: "foo", Peer: "bar"},
+			rawQuery:            "?partition=foo&peer=bar",
+		},
 	}
 
 	for _, tc := range cases {
@@ -390,7 +406,7 @@ func TestKVGet(t *testing.T) {
 			// Configure Consul client with intercepts
 			intercepts := []*testutils.HttpIntercept{
 				{
-					Path:               "/v1/kv/" + key,
+					Path:               "/v1/kv/" + key + tc.rawQuery,
 					ResponseStatusCode: tc.responseCode,
 					ResponseData:       []byte(tc.responseBody),
 				},
@@ -398,10 +414,93 @@ func TestKVGet(t *testing.T) {
 			c := newTestConsulClient(t, testutils.NewHttpClient(t, intercepts), 1)
 
 			// Get KV pair
-			_, meta, err := c.KVGet(context.Background(), key, nil)
+			_, meta, err := c.KVGet(context.Background(), key, tc.query)
 			if !tc.expectErr {
 				require.NoError(t, err)
 				assert.NotNil(t, meta)
+			} else {
+				assert.Error(t, err)
+				// Verify the error types
+				assert.Equal(t, tc.isNonRetryableError, errors.As(err, &nonRetryableError))
+				assert.Equal(t, tc.isMissingAClError, errors.As(err, &missingConsulACLError))
+				if tc.query != nil && tc.isMissingAClError {
+					assert.Equal(t, tc.query.Partition, missingConsulACLError.Partition)
+					assert.Equal(t, tc.query.Peer, missingConsulACLError.Peer)
+				}
+			}
+		})
+	}
+}
+
+func TestKVTxn(t *testing.T) {
+	t.Parallel()
+
+	var nonRetryableError *retry.NonRetryableError
+	var missingConsulACLError *MissingConsulACLError
+	cases := []struct {
+		name                string
+		responseCode        int
+		responseBody        string
+		expectErr           bool
+		expectOk            bool
+		isNonRetryableError bool
+		isMissingAClError   bool
+	}{
+		{
+			name:         "success",
+			responseCode: http.StatusOK,
+			responseBody: `{"Results": [], "Errors": null}`,
+			expectOk:     true,
+		},
+		{
+			name:         "rolled_back",
+			responseCode: http.StatusConflict,
+			responseBody: `{"Results": null, "Errors": [{"OpIndex": 0, "What": "error"}]}`,
+			// do not expect error since KV().Txn() does not error on rollback
+		},
+		{
+			name:         "retryable_error",
+			responseCode: http.StatusInternalServerError,
+			expectErr:    true,
+		},
+		{
+			name:                "acl_error",
+			responseCode:        http.StatusForbidden,
+			responseBody:        "Permission denied",
+			expectErr:           true,
+			isNonRetryableError: true,
+			isMissingAClError:   true,
+		},
+		{
+			name:                "acl_error_rolled_back",
+			responseCode:        http.StatusConflict,
+			responseBody:        `{"Results": null, "Errors": [{"OpIndex": 0, "What": "Permission denied"}]}`,
+			expectErr:           true,
+			isNonRetryableError: true,
+			isMissingAClError:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Configure Consul client with intercepts
+			intercepts := []*testutils.HttpIntercept{
+				{
+					Path:               "/v1/txn",
+					ResponseStatusCode: tc.responseCode,
+					ResponseData:       []byte(tc.responseBody),
+				},
+			}
+			c := newTestConsulClient(t, testutils.NewHttpClient(t, intercepts), 1)
+
+			ops := consulapi.KVTxnOps{
+				&consulapi.KVTxnOp{Verb: consulapi.KVSet, Key: "test", Value: []byte("test")},
+			}
+			ok, resp, _, err := c.KVTxn(context.Background(), ops, nil)
+			if !tc.expectErr {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectOk, ok)
+				assert.NotNil(t, resp)
 			} else {
 				assert.Error(t, err)
 				// Verify the error types
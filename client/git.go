@@ -0,0 +1,390 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+	"github.com/hashicorp/terraform-exec/tfexec"
+)
+
+var _ Client = (*Git)(nil)
+
+const (
+	gitSubsystemName = "git"
+
+	gitFilePerms = 0644
+	gitDirPerms  = 0750
+)
+
+var (
+	// gitOutputFiles are the files of a task's generated root module that are
+	// committed. Provider configuration files are excluded since they may
+	// contain credentials.
+	gitOutputFiles = []string{
+		tftmpl.RootFilename,
+		tftmpl.RootJSONFilename,
+		tftmpl.VarsFilename,
+		tftmpl.ModuleVarsFilename,
+		tftmpl.TFVarsFilename,
+		tftmpl.TFVarsJSONFilename,
+	}
+
+	// gitRepoLocks serializes git operations by repository path, since tasks
+	// share the repository
+	gitRepoLocks sync.Map
+)
+
+// Git is a client that commits the generated root module of a task to a local
+// git repository instead of running Terraform, so that the changes can be
+// reviewed and applied by an external pipeline. The files of each task are
+// written to a directory named after the task within the repository.
+type Git struct {
+	repoPath    string
+	remote      string
+	branch      string
+	authorName  string
+	authorEmail string
+	workingDir  string
+	workspace   string
+	env         map[string]string
+	logger      logging.Logger
+}
+
+// GitConfig configures the git client
+type GitConfig struct {
+	RepoPath    string
+	Remote      string
+	Branch      string
+	AuthorName  string
+	AuthorEmail string
+	WorkingDir  string
+	Workspace   string
+}
+
+// NewGit creates a new git client
+func NewGit(config *GitConfig) (*Git, error) {
+	if config == nil {
+		return nil, errors.New("GitConfig cannot be nil - no meaningful default values")
+	}
+
+	if config.RepoPath == "" {
+		return nil, errors.New("path of the git repository is required")
+	}
+	repoPath, err := filepath.Abs(config.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logging.Global().Named(loggingSystemName).Named(gitSubsystemName).With(
+		"workspace", config.Workspace, "repository", repoPath)
+
+	return &Git{
+		repoPath:    repoPath,
+		remote:      config.Remote,
+		branch:      config.Branch,
+		authorName:  config.AuthorName,
+		authorEmail: config.AuthorEmail,
+		workingDir:  config.WorkingDir,
+		workspace:   config.Workspace,
+		logger:      logger,
+	}, nil
+}
+
+// SetEnv sets the environment for git commands in addition to the
+// environment of the process
+func (g *Git) SetEnv(env map[string]string) error {
+	g.env = make(map[string]string, len(env))
+	for k, v := range env {
+		g.env[k] = v
+	}
+	return nil
+}
+
+// SetStdout is a no-op since git output is only logged
+func (g *Git) SetStdout(io.Writer) {}
+
+// Init initializes the git repository if it does not exist
+func (g *Git) Init(ctx context.Context) error {
+	unlock := g.lock()
+	defer unlock()
+
+	if _, err := os.Stat(filepath.Join(g.repoPath, ".git")); err == nil {
+		return nil
+	}
+
+	g.logger.Info("initializing git repository")
+	if err := os.MkdirAll(g.repoPath, gitDirPerms); err != nil {
+		return err
+	}
+	_, err := g.git(ctx, "init", "--quiet", "--initial-branch="+g.branch)
+	return err
+}
+
+// Apply writes the generated root module to the task's directory in the
+// repository and commits the changes. The commit is pushed to the remote if
+// one is configured.
+func (g *Git) Apply(ctx context.Context) error {
+	unlock := g.lock()
+	defer unlock()
+
+	if err := g.writeFiles(); err != nil {
+		return fmt.Errorf("error writing files to the git repository: %s", err)
+	}
+
+	if _, err := g.git(ctx, "add", "--all", "--", g.workspace); err != nil {
+		return err
+	}
+
+	return g.commit(ctx, fmt.Sprintf("Update task %s", g.workspace))
+}
+
+// Plan returns whether the generated root module differs from the task's
+// files committed to the repository
+func (g *Git) Plan(context.Context) (bool, error) {
+	unlock := g.lock()
+	defer unlock()
+
+	for _, name := range gitOutputFiles {
+		generated, err := readOptionalFile(filepath.Join(g.workingDir, name))
+		if err != nil {
+			return false, err
+		}
+		committed, err := readOptionalFile(filepath.Join(g.taskDir(), name))
+		if err != nil {
+			return false, err
+		}
+		if !bytes.Equal(generated, committed) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Destroy removes the task's directory from the repository and commits the
+// change
+func (g *Git) Destroy(ctx context.Context) error {
+	unlock := g.lock()
+	defer unlock()
+
+	if _, err := os.Stat(g.taskDir()); os.IsNotExist(err) {
+		return nil
+	}
+
+	if err := os.RemoveAll(g.taskDir()); err != nil {
+		return err
+	}
+	if _, err := g.git(ctx, "add", "--all", "--", g.workspace); err != nil {
+		return err
+	}
+
+	return g.commit(ctx, fmt.Sprintf("Destroy task %s", g.workspace))
+}
+
+// PlanDestroy returns whether the task has files in the repository to remove
+func (g *Git) PlanDestroy(context.Context) (bool, error) {
+	_, err := os.Stat(g.taskDir())
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Output returns no outputs since the root module is not applied by CTS
+func (g *Git) Output(context.Context) (map[string]tfexec.OutputMeta, error) {
+	return map[string]tfexec.OutputMeta{}, nil
+}
+
+// Validate is a no-op, the generated root module is validated by the pipeline
+// that applies it
+func (g *Git) Validate(context.Context) error {
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (g *Git) GoString() string {
+	if g == nil {
+		return "(*Git)(nil)"
+	}
+
+	return fmt.Sprintf("&Git{"+
+		"RepoPath:%s, "+
+		"Branch:%s, "+
+		"WorkingDir:%s, "+
+		"WorkSpace:%s"+
+		"}",
+		g.repoPath,
+		g.branch,
+		g.workingDir,
+		g.workspace,
+	)
+}
+
+// taskDir returns the directory of the task's files in the repository
+func (g *Git) taskDir() string {
+	return filepath.Join(g.repoPath, g.workspace)
+}
+
+// writeFiles copies the generated root module files to the task's directory
+// in the repository. Files that are no longer generated are removed.
+func (g *Git) writeFiles() error {
+	if err := os.MkdirAll(g.taskDir(), gitDirPerms); err != nil {
+		return err
+	}
+
+	for _, name := range gitOutputFiles {
+		dst := filepath.Join(g.taskDir(), name)
+		content, err := readOptionalFile(filepath.Join(g.workingDir, name))
+		if err != nil {
+			return err
+		}
+		if content == nil {
+			if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		if err := os.WriteFile(dst, content, gitFilePerms); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commit commits the staged changes of the task's directory with details of
+// the task run from the context, and pushes the commit if a remote is
+// configured. Nothing is committed if there are no changes.
+func (g *Git) commit(ctx context.Context, subject string) error {
+	_, err := g.git(ctx, "diff", "--cached", "--quiet", "--", g.workspace)
+	if err == nil {
+		g.logger.Info("no changes to commit")
+		return nil
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		return err
+	}
+
+	var body []string
+	if ev, ok := event.FromContext(ctx); ok {
+		body = append(body, fmt.Sprintf("Event ID: %s", ev.ID))
+		if ev.Trigger != "" {
+			body = append(body, fmt.Sprintf("Trigger: %s", ev.Trigger))
+		}
+	}
+
+	args := []string{"commit", "--quiet", "-m", subject}
+	if len(body) > 0 {
+		args = append(args, "-m", strings.Join(body, "\n"))
+	}
+	args = append(args, "--", g.workspace)
+	if _, err := g.git(ctx, args...); err != nil {
+		return err
+	}
+	g.logger.Info("committed changes", "message", subject)
+
+	if g.remote == "" {
+		return nil
+	}
+	_, err = g.git(ctx, "push", "--quiet", g.remote, "HEAD:refs/heads/"+g.branch)
+	if err != nil {
+		return err
+	}
+	g.logger.Info("pushed changes", "branch", g.branch)
+	return nil
+}
+
+// git runs the git command in the repository
+func (g *Git) git(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = g.repoPath
+	cmd.Env = g.environ()
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	g.logger.Trace("running git command", "args", args)
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		return "", &gitError{args: args, err: err, msg: msg}
+	}
+	return stdout.String(), nil
+}
+
+// environ returns the environment for git commands, which is the environment
+// of the process overlaid with the environment set for the client. It sets the
+// author and committer of commits.
+func (g *Git) environ() []string {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if i := strings.Index(kv, "="); i > 0 {
+			env[kv[:i]] = kv[i+1:]
+		}
+	}
+	for k, v := range g.env {
+		env[k] = v
+	}
+	env["GIT_AUTHOR_NAME"] = g.authorName
+	env["GIT_AUTHOR_EMAIL"] = g.authorEmail
+	env["GIT_COMMITTER_NAME"] = g.authorName
+	env["GIT_COMMITTER_EMAIL"] = g.authorEmail
+
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	environ := make([]string, len(keys))
+	for i, k := range keys {
+		environ[i] = fmt.Sprintf("%s=%s", k, env[k])
+	}
+	return environ
+}
+
+// lock locks the repository and returns the function to unlock it
+func (g *Git) lock() func() {
+	mu, _ := gitRepoLocks.LoadOrStore(g.repoPath, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// gitError is the error of a failed git command, which unwraps to the error
+// of the command
+type gitError struct {
+	args []string
+	err  error
+	msg  string
+}
+
+func (e *gitError) Error() string {
+	if e.msg == "" {
+		return fmt.Sprintf("error running git %s: %s", e.args[0], e.err)
+	}
+	return fmt.Sprintf("error running git %s: %s: %s", e.args[0], e.err, e.msg)
+}
+
+func (e *gitError) Unwrap() error {
+	return e.err
+}
+
+// readOptionalFile reads the file, returning nil content if the file does not
+// exist
+func readOptionalFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return content, err
+}
@@ -0,0 +1,178 @@
+package client
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGit(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewGit(nil)
+	assert.Error(t, err)
+
+	_, err = NewGit(&GitConfig{Workspace: "task"})
+	assert.Error(t, err)
+
+	g, err := NewGit(&GitConfig{RepoPath: "repo", Workspace: "task"})
+	require.NoError(t, err)
+	assert.True(t, filepath.IsAbs(g.repoPath))
+}
+
+func TestGit_environ(t *testing.T) {
+	t.Setenv("CTS_GIT_TEST_PROCESS", "process")
+	t.Setenv("CTS_GIT_TEST_OVERLAY", "process")
+
+	g, err := NewGit(&GitConfig{
+		RepoPath:    "repo",
+		Workspace:   "task",
+		AuthorName:  "cts",
+		AuthorEmail: "cts@example.com",
+	})
+	require.NoError(t, err)
+	require.NoError(t, g.SetEnv(map[string]string{
+		"CTS_GIT_TEST_OVERLAY": "client",
+		"GIT_AUTHOR_NAME":      "other",
+	}))
+
+	env := g.environ()
+	assert.Contains(t, env, "CTS_GIT_TEST_PROCESS=process")
+	assert.Contains(t, env, "CTS_GIT_TEST_OVERLAY=client")
+	assert.Contains(t, env, "GIT_AUTHOR_NAME=cts")
+	assert.NotContains(t, env, "CTS_GIT_TEST_OVERLAY=process")
+}
+
+func TestGit_Apply(t *testing.T) {
+	t.Parallel()
+	requireGit(t)
+
+	ctx := context.Background()
+	remote := t.TempDir()
+	runTestGit(t, remote, "init", "--quiet", "--bare")
+
+	g, wd := newTestGit(t)
+	g.remote = remote
+	writeTestFile(t, wd, "main.tf", "module \"task\" {}\n")
+	writeTestFile(t, wd, "variables.tf", "variable \"services\" {}\n")
+	writeTestFile(t, wd, "terraform.tfvars", "services = {}\n")
+	writeTestFile(t, wd, "providers.auto.tfvars", "secret = \"s3cr3t\"\n")
+
+	require.NoError(t, g.Init(ctx))
+
+	changes, err := g.Plan(ctx)
+	require.NoError(t, err)
+	assert.True(t, changes)
+
+	ev := &event.Event{ID: "event-1", Trigger: "services condition"}
+	require.NoError(t, g.Apply(event.NewContext(ctx, ev)))
+
+	msg := runTestGit(t, g.repoPath, "log", "-1", "--format=%B")
+	assert.Contains(t, msg, "Update task task")
+	assert.Contains(t, msg, "Event ID: event-1")
+	assert.Contains(t, msg, "Trigger: services condition")
+
+	author := runTestGit(t, g.repoPath, "log", "-1", "--format=%an <%ae>")
+	assert.Equal(t, "CTS <cts@example.com>", strings.TrimSpace(author))
+
+	files := runTestGit(t, g.repoPath, "ls-files")
+	assert.Equal(t, "task/main.tf\ntask/terraform.tfvars\ntask/variables.tf\n", files)
+
+	pushed := runTestGit(t, remote, "log", "-1", "--format=%s", "main")
+	assert.Equal(t, "Update task task\n", pushed)
+
+	changes, err = g.Plan(ctx)
+	require.NoError(t, err)
+	assert.False(t, changes)
+
+	t.Run("no_changes", func(t *testing.T) {
+		require.NoError(t, g.Apply(ctx))
+		count := runTestGit(t, g.repoPath, "rev-list", "--count", "HEAD")
+		assert.Equal(t, "1\n", count)
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		writeTestFile(t, wd, "terraform.tfvars", "services = { api = {} }\n")
+		changes, err := g.Plan(ctx)
+		require.NoError(t, err)
+		assert.True(t, changes)
+
+		require.NoError(t, g.Apply(ctx))
+		count := runTestGit(t, g.repoPath, "rev-list", "--count", "HEAD")
+		assert.Equal(t, "2\n", count)
+	})
+
+	t.Run("destroy", func(t *testing.T) {
+		changes, err := g.PlanDestroy(ctx)
+		require.NoError(t, err)
+		assert.True(t, changes)
+
+		require.NoError(t, g.Destroy(ctx))
+		msg := runTestGit(t, g.repoPath, "log", "-1", "--format=%s")
+		assert.Equal(t, "Destroy task task\n", msg)
+		assert.NoDirExists(t, filepath.Join(g.repoPath, "task"))
+
+		changes, err = g.PlanDestroy(ctx)
+		require.NoError(t, err)
+		assert.False(t, changes)
+	})
+}
+
+func TestGit_Init(t *testing.T) {
+	t.Parallel()
+	requireGit(t)
+
+	ctx := context.Background()
+	g, _ := newTestGit(t)
+	g.branch = "cts"
+
+	require.NoError(t, g.Init(ctx))
+	assert.DirExists(t, filepath.Join(g.repoPath, ".git"))
+	branch := runTestGit(t, g.repoPath, "symbolic-ref", "--short", "HEAD")
+	assert.Equal(t, "cts\n", branch)
+
+	// existing repository is reused
+	require.NoError(t, g.Init(ctx))
+}
+
+// newTestGit returns a git client for a new repository path and the task's
+// working directory
+func newTestGit(t *testing.T) (*Git, string) {
+	wd := t.TempDir()
+	g, err := NewGit(&GitConfig{
+		RepoPath:    filepath.Join(t.TempDir(), "repo"),
+		Branch:      "main",
+		AuthorName:  "CTS",
+		AuthorEmail: "cts@example.com",
+		WorkingDir:  wd,
+		Workspace:   "task",
+	})
+	require.NoError(t, err)
+	return g, wd
+}
+
+func requireGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found on $PATH")
+	}
+}
+
+func runTestGit(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return string(out)
+}
+
+func writeTestFile(t *testing.T, dir, name, content string) {
+	err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+	require.NoError(t, err)
+}
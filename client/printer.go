@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/terraform-exec/tfexec"
 )
 
 var _ Client = (*Printer)(nil)
@@ -82,6 +83,24 @@ func (p *Printer) Plan(context.Context) (bool, error) {
 	return true, nil
 }
 
+// Destroy logs out 'destroy'
+func (p *Printer) Destroy(context.Context) error {
+	p.logger.Info("destroying workspace")
+	return nil
+}
+
+// PlanDestroy logs out 'plan destroy'
+func (p *Printer) PlanDestroy(context.Context) (bool, error) {
+	p.logger.Info("planning destroy for workspace")
+	return true, nil
+}
+
+// Output logs out 'output'
+func (p *Printer) Output(context.Context) (map[string]tfexec.OutputMeta, error) {
+	p.logger.Info("retrieving outputs for workspace")
+	return map[string]tfexec.OutputMeta{}, nil
+}
+
 // Validate logs out 'validate'
 func (p *Printer) Validate(context.Context) error {
 	p.logger.Info("validating workspace")
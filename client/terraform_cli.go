@@ -15,7 +15,8 @@ import (
 )
 
 var (
-	_ Client = (*TerraformCLI)(nil)
+	_ Client            = (*TerraformCLI)(nil)
+	_ WorkspaceSelector = (*TerraformCLI)(nil)
 
 	wsFailedToSelectRegexp = regexp.MustCompile(`Failed to select workspace`)
 	wsDoesNotExistRegexp   = regexp.MustCompile(`workspace ".*" does not exist`)
@@ -41,6 +42,10 @@ type TerraformCLIConfig struct {
 	ExecPath   string
 	WorkingDir string
 	Workspace  string
+
+	// BinaryName is the file name of the binary within ExecPath. Defaults to
+	// "terraform".
+	BinaryName string
 }
 
 // NewTerraformCLI creates a terraform-exec client and configures and
@@ -50,7 +55,11 @@ func NewTerraformCLI(config *TerraformCLIConfig) (*TerraformCLI, error) {
 		return nil, errors.New("TerraformCLIConfig cannot be nil - no meaningful default values")
 	}
 
-	tfPath := filepath.Join(config.ExecPath, "terraform")
+	binaryName := config.BinaryName
+	if binaryName == "" {
+		binaryName = "terraform"
+	}
+	tfPath := filepath.Join(config.ExecPath, binaryName)
 	tf, err := tfexec.NewTerraform(config.WorkingDir, tfPath)
 	if err != nil {
 		return nil, err
@@ -132,6 +141,19 @@ TF_INIT_AGAIN:
 		return err
 	}
 
+	return t.selectWorkspace(ctx, wsCreated)
+}
+
+// SelectWorkspace executes the cli commands `terraform workspace new` and
+// `terraform workspace select` for the workspace without `terraform init`.
+// This is used when the working directory is already initialized.
+func (t *TerraformCLI) SelectWorkspace(ctx context.Context) error {
+	return t.selectWorkspace(ctx, false)
+}
+
+// selectWorkspace creates the workspace if it was not already created and
+// selects it
+func (t *TerraformCLI) selectWorkspace(ctx context.Context, wsCreated bool) error {
 	logws := t.logger.With("workspace", t.workspace)
 	if !wsCreated {
 		err := t.tf.WorkspaceNew(ctx, t.workspace)
@@ -165,6 +187,23 @@ func (t *TerraformCLI) Plan(ctx context.Context) (bool, error) {
 	return t.tf.Plan(ctx)
 }
 
+// Destroy executes the cli command `terraform destroy` for a given workspace
+func (t *TerraformCLI) Destroy(ctx context.Context) error {
+	return t.tf.Destroy(ctx)
+}
+
+// PlanDestroy executes the cli command `terraform plan -destroy` for a given
+// workspace
+func (t *TerraformCLI) PlanDestroy(ctx context.Context) (bool, error) {
+	return t.tf.Plan(ctx, tfexec.Destroy(true))
+}
+
+// Output executes the cli command `terraform output -json` for a given
+// workspace
+func (t *TerraformCLI) Output(ctx context.Context) (map[string]tfexec.OutputMeta, error) {
+	return t.tf.Output(ctx)
+}
+
 // Validate verifies the generated configuration files
 func (t *TerraformCLI) Validate(ctx context.Context) error {
 	output, err := t.tf.Validate(ctx)
@@ -78,6 +78,16 @@ func TestNewTerraformCLI(t *testing.T) {
 				Workspace:  "my-workspace",
 			},
 		},
+		{
+			"happy path with binary name",
+			false,
+			&TerraformCLIConfig{
+				ExecPath:   "path/to/tofu",
+				WorkingDir: "./",
+				Workspace:  "my-workspace",
+				BinaryName: "tofu",
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -157,6 +167,56 @@ func TestTerraformCLIInit(t *testing.T) {
 	}
 }
 
+func TestTerraformCLISelectWorkspace(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		expectError bool
+		wsNewErr    error
+		wsSelectErr error
+	}{
+		{
+			"happy path",
+			false,
+			nil,
+			nil,
+		},
+		{
+			"workspace-new: already exists",
+			false,
+			&tfexec.ErrWorkspaceExists{Name: "workspace-name"},
+			nil,
+		},
+		{
+			"workspace-select error",
+			true,
+			nil,
+			errors.New("workspace-select error"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(mocks.TerraformExec)
+			m.On("WorkspaceNew", mock.Anything, mock.Anything).Return(tc.wsNewErr)
+			m.On("WorkspaceSelect", mock.Anything, mock.Anything).Return(tc.wsSelectErr)
+
+			client := NewTestTerraformCLI(&TerraformCLIConfig{}, m)
+			err := client.SelectWorkspace(context.Background())
+			m.AssertNotCalled(t, "Init", mock.Anything)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			m.AssertExpectations(t)
+		})
+	}
+}
+
 func TestTerraformCLIInit_HandleWorkspaceError(t *testing.T) {
 
 	t.Parallel()
@@ -51,6 +51,9 @@ func Commands(writer io.Writer, errorWriter io.Writer) map[string]cli.CommandFac
 		cmdStartName: func() (cli.Command, error) {
 			return newStartCommand(m), nil
 		},
+		cmdMirrorName: func() (cli.Command, error) {
+			return newMirrorCommand(m), nil
+		},
 	}
 
 	return all
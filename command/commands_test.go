@@ -18,6 +18,7 @@ func Test_Commands(t *testing.T) {
 		cmdTaskDisableName: &taskDisableCommand{},
 		cmdTaskDeleteName:  &taskDeleteCommand{},
 		cmdStartName:       &startCommand{},
+		cmdMirrorName:      &mirrorCommand{},
 	}
 
 	assert.Equal(t, len(expectedCommands), len(cf))
@@ -51,6 +51,7 @@ const (
 	FlagSSLVerify  = "ssl-verify"
 
 	FlagAutoApprove = "auto-approve"
+	FlagDestroy     = "destroy"
 )
 
 func (m *meta) defaultFlagSet(name string) *flag.FlagSet {
@@ -233,12 +234,27 @@ func (m *meta) requestUserApprovalEnable(taskName string) (int, bool) {
 func (m *meta) requestUserApprovalDelete(taskName string) (int, bool) {
 	m.UI.Info(fmt.Sprintf("Do you want to delete '%s'?", taskName))
 	m.UI.Output(" - This action cannot be undone.")
-	m.UI.Output(" - Deleting a task will not destroy the infrastructure managed by the task.")
+	m.UI.Output(" - Deleting a task will not destroy the infrastructure managed by the task,")
+	m.UI.Output("   unless the task is configured with destroy_on_delete.")
 	m.UI.Output(" - If the task is not running, it will be deleted immediately.")
 	m.UI.Output(" - If the task is running, it will be deleted once it has completed.")
 	return m.requestUserApproval(taskName, "deleting")
 }
 
+// requestUserApprovalDeleteAndDestroy prints a prompt for user approval of
+// destroying the infrastructure managed by a task and deleting the task, and
+// waits for the user input. It returns an exit code and boolean describing if
+// the user approved.
+func (m *meta) requestUserApprovalDeleteAndDestroy(taskName string) (int, bool) {
+	m.UI.Info(fmt.Sprintf("Do you want to destroy the infrastructure managed by '%s' and delete the task?", taskName))
+	m.UI.Output(" - This action cannot be undone.")
+	m.UI.Output(" - Deleting the task will destroy the infrastructure described above.")
+	m.UI.Output(" - If the destroy fails, the task will not be deleted.")
+	m.UI.Output(" - If the task is not running, it will be destroyed and deleted immediately.")
+	m.UI.Output(" - If the task is running, it will be destroyed and deleted once it has completed.")
+	return m.requestUserApproval(taskName, "deleting")
+}
+
 // requestUserApprovalCreate prints a prompt for user approval of deleting a task
 // and waits for the user input. It returns an exit code and boolean describing
 // if the user approved.
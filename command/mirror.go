@@ -0,0 +1,175 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/driver"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/posener/complete"
+)
+
+const (
+	cmdMirrorName = "mirror"
+
+	flagPlatform = "platform"
+)
+
+// mirrorCommand handles the `mirror` command
+type mirrorCommand struct {
+	meta
+	flags *flag.FlagSet
+
+	configFiles *config.FlagAppendSliceValue
+	platforms   *config.FlagAppendSliceValue
+}
+
+func newMirrorCommand(m meta) *mirrorCommand {
+	flags := flag.NewFlagSet(cmdMirrorName, flag.ContinueOnError)
+	flags.SetOutput(m.writer)
+
+	var configFiles, platforms config.FlagAppendSliceValue
+	flags.Var(&configFiles, flagConfigDir,
+		"A directory to load files for configuring Consul-Terraform-Sync. "+
+			"\n\t\tThis option can be specified multiple times to load different "+
+			"\n\t\tdirectories.")
+	flags.Var(&configFiles, flagConfigFiles,
+		"A file to load for configuring Consul-Terraform-Sync. This option "+
+			"\n\t\tcan be specified multiple times to load different "+
+			"\n\t\tconfiguration files.")
+	flags.Var(&platforms, flagPlatform,
+		"The target platform to mirror providers for, in the format os_arch "+
+			"\n\t\t(eg. linux_amd64). This option can be specified multiple times. "+
+			"\n\t\tDefaults to the platform of the current machine.")
+
+	m.flags = flags
+	return &mirrorCommand{
+		meta:        m,
+		flags:       flags,
+		configFiles: &configFiles,
+		platforms:   &platforms,
+	}
+}
+
+// Name returns the subcommand
+func (c *mirrorCommand) Name() string {
+	return cmdMirrorName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *mirrorCommand) Help() string {
+	var options []string
+	c.flags.VisitAll(func(f *flag.Flag) {
+		options = append(options, fmt.Sprintf("  -%s %s\n      %s\n",
+			f.Name, templateDefaultValue(f.Value),
+			strings.ReplaceAll(f.Usage, "\n\t\t", "\n      ")))
+	})
+
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync mirror [-help] [options]
+
+  Mirror downloads the modules of the configured tasks and the providers they
+  require into the module_dir and filesystem_mirror directories of the
+  Terraform driver's mirror configuration. Run this command on a machine with
+  internet access and copy the directories to hosts without internet access
+  that are configured with the same mirror.
+
+Options:
+%s
+Example:
+
+  $ consul-terraform-sync mirror -config-file=config.hcl -platform=linux_amd64
+  ==> Mirrored modules and providers for 2 task(s)
+`, strings.Join(options, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *mirrorCommand) Synopsis() string {
+	return "Populates the module and provider mirrors for offline use"
+}
+
+// AutocompleteFlags returns a mapping of supported flags and autocomplete
+// options for this command. The map key for the Flags map should be the
+// complete flag such as "-foo" or "--foo".
+func (c *mirrorCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		fmt.Sprintf("-%s", flagConfigDir): complete.PredictDirs("*"),
+		fmt.Sprintf("-%s", flagConfigFiles): complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		fmt.Sprintf("-%s", flagPlatform): complete.PredictNothing,
+	}
+}
+
+// AutocompleteArgs returns the argument predictorClient for this command.
+// Since argument completion is not supported, this returns
+// complete.PredictNothing.
+func (c *mirrorCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+// Run runs the command
+func (c *mirrorCommand) Run(args []string) int {
+	c.flags.Usage = func() { c.meta.UI.Output(c.Help()) }
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	if len(*c.configFiles) == 0 {
+		c.UI.Error("unable to mirror modules and providers")
+		c.UI.Output("no config file provided")
+		c.UI.Output(fmt.Sprintf("For additional help try 'consul-terraform-sync %s --help'",
+			cmdMirrorName))
+		return ExitCodeRequiredFlagsError
+	}
+
+	conf, err := config.BuildConfig(*c.configFiles)
+	if err == nil {
+		err = conf.Finalize()
+	}
+	if err == nil {
+		err = conf.Validate()
+	}
+	if err != nil {
+		c.UI.Error("unable to mirror modules and providers")
+		c.UI.Output(fmt.Sprintf("error loading configuration: %s", err))
+		return ExitCodeConfigError
+	}
+
+	if err := logging.Setup(&logging.Config{
+		Level:  config.StringVal(conf.LogLevel),
+		Writer: os.Stderr,
+	}); err != nil {
+		c.UI.Error(fmt.Sprintf("error setting up logging: %s", err))
+		return ExitCodeConfigError
+	}
+
+	if conf.Driver.Terraform == nil {
+		c.UI.Error("unable to mirror modules and providers")
+		c.UI.Output("the mirror command is only supported for the Terraform driver")
+		return ExitCodeConfigError
+	}
+
+	ctx := context.Background()
+	if err := driver.InstallTerraform(ctx, conf.Driver.Terraform); err != nil {
+		c.UI.Error("unable to mirror modules and providers")
+		c.UI.Output(fmt.Sprintf("error installing Terraform: %s", err))
+		return ExitCodeDriverError
+	}
+
+	if err := driver.PopulateMirror(ctx, conf, *c.platforms); err != nil {
+		c.UI.Error("unable to mirror modules and providers")
+		c.UI.Output(err.Error())
+		return ExitCodeDriverError
+	}
+
+	c.UI.Info(fmt.Sprintf("Mirrored modules and providers for %d task(s)",
+		len(*conf.Tasks)))
+	return ExitCodeOK
+}
@@ -263,7 +263,9 @@ func (c *startCommand) Run(args []string) int {
 	}
 
 	// Set up controller
-	conf.ClientType = config.String(*c.clientType)
+	if *c.clientType != "" {
+		conf.ClientType = config.String(*c.clientType)
+	}
 	var ctrl controller.Controller
 	switch {
 	case *c.isInspect:
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/consul-terraform-sync/api"
 	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	"github.com/mitchellh/go-wordwrap"
@@ -18,6 +19,7 @@ const cmdTaskDeleteName = "task delete"
 type taskDeleteCommand struct {
 	meta
 	autoApprove *bool
+	destroy     *bool
 	flags       *flag.FlagSet
 
 	predictorClient oapigen.ClientWithResponsesInterface
@@ -28,9 +30,12 @@ func newTaskDeleteCommand(m meta) *taskDeleteCommand {
 	flags := m.defaultFlagSet(cmdTaskDeleteName)
 	flags.SetOutput(m.writer)
 	a := flags.Bool(FlagAutoApprove, false, "Skip interactive approval of deleting a task")
+	d := flags.Bool(FlagDestroy, false, "Destroy the infrastructure managed by the task "+
+		"before deleting the task")
 	return &taskDeleteCommand{
 		meta:        m,
 		autoApprove: a,
+		destroy:     d,
 		flags:       flags,
 	}
 }
@@ -50,6 +55,11 @@ Usage: consul-terraform-sync task delete [-help] [options] <task name>
   then it is deleted immediately. Otherwise, it will be deleted once the task
   is complete.
 
+  When the -destroy flag is set, the infrastructure managed by the task is
+  destroyed before the task is deleted. The plan to destroy the infrastructure
+  is inspected and displayed for approval first. If the destroy fails, the task
+  is not deleted.
+
 Options:
 %s
 
@@ -58,7 +68,8 @@ Example:
   $ consul-terraform-sync task delete my_task
   ==> Do you want to delete 'my_task'?
        - This action cannot be undone.
-       - Deleting a task will not destroy the infrastructure managed by the task.
+       - Deleting a task will not destroy the infrastructure managed by the task,
+         unless the task is configured with destroy_on_delete.
        - If the task is not running, it will be deleted immediately.
        - If the task is running, it will be deleted once it has completed.
       Only 'yes' will be accepted to approve, enter 'no' or leave blank to reject.
@@ -68,6 +79,31 @@ Example:
   ==> Marking task 'my_task' for deletion...
 
   ==> Task 'my_task' has been marked for deletion and will be deleted when not running.
+
+  $ consul-terraform-sync task delete -destroy my_task
+  ==> Inspecting changes to destroy resources managed by task 'my_task'...
+
+      Generating plan that Consul-Terraform-Sync will use Terraform to execute
+
+      Request ID: 1da3e8e0-87c3-069b-51a6-46903e794a76
+      Plan:
+      ...
+      Plan: 0 to add, 0 to change, 1 to destroy.
+
+  ==> Do you want to destroy the infrastructure managed by 'my_task' and delete the task?
+       - This action cannot be undone.
+       - Deleting the task will destroy the infrastructure described above.
+       - If the destroy fails, the task will not be deleted.
+       - If the task is not running, it will be destroyed and deleted immediately.
+       - If the task is running, it will be destroyed and deleted once it has completed.
+      Only 'yes' will be accepted to approve, enter 'no' or leave blank to reject.
+
+  Enter a value: yes
+
+  ==> Marking task 'my_task' for destroy and deletion...
+
+  ==> Task 'my_task' has been marked for deletion and will be destroyed and
+      deleted when not running.
 `, strings.Join(c.meta.helpOptions, "\n"))
 	return strings.TrimSpace(helpText)
 }
@@ -84,6 +120,7 @@ func (c *taskDeleteCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.meta.autoCompleteFlags(),
 		complete.Flags{
 			fmt.Sprintf("-%s", FlagAutoApprove): complete.PredictNothing,
+			fmt.Sprintf("-%s", FlagDestroy):     complete.PredictNothing,
 		})
 }
 
@@ -144,6 +181,10 @@ func (c *taskDeleteCommand) Run(args []string) int {
 		return ExitCodeError
 	}
 
+	if *c.destroy {
+		return c.deleteAndDestroy(client, taskName)
+	}
+
 	if !*c.autoApprove {
 		if exitCode, approved := c.meta.requestUserApprovalDelete(taskName); !approved {
 			return exitCode
@@ -151,7 +192,7 @@ func (c *taskDeleteCommand) Run(args []string) int {
 	}
 
 	c.UI.Info(fmt.Sprintf("Marking task '%s' for deletion...\n", taskName))
-	resp, err := client.DeleteTaskByName(context.Background(), taskName)
+	resp, err := client.DeleteTaskByName(context.Background(), taskName, nil)
 	if resp != nil {
 		defer resp.Body.Close()
 	}
@@ -170,3 +211,69 @@ func (c *taskDeleteCommand) Run(args []string) int {
 
 	return ExitCodeOK
 }
+
+// deleteAndDestroy inspects the plan to destroy the infrastructure managed by
+// the task, requests approval, and then marks the task for destroy and deletion.
+func (c *taskDeleteCommand) deleteAndDestroy(client *api.TaskLifecycleClient, taskName string) int {
+	ctx := context.Background()
+	destroy := true
+
+	// First inspect the plan to destroy
+	c.UI.Info(fmt.Sprintf("Inspecting changes to destroy resources managed "+
+		"by task '%s'...\n", taskName))
+	c.UI.Output("Generating plan that Consul-Terraform-Sync will use Terraform to execute\n")
+
+	runInspect := oapigen.DeleteTaskByNameParamsRun(api.RunOptionInspect)
+	inspectResp, err := client.DeleteTaskByNameWithResponse(ctx, taskName,
+		&oapigen.DeleteTaskByNameParams{Destroy: &destroy, Run: &runInspect})
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to generate destroy plan for '%s'", taskName))
+		err = processEOFError(client.Scheme(), err)
+
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	if inspectResp.JSON200 == nil || inspectResp.JSON200.Run == nil {
+		c.UI.Error(fmt.Sprintf("Error: received nil response with status %s", inspectResp.Status()))
+		return ExitCodeError
+	}
+
+	taskResp := inspectResp.JSON200
+	c.UI.Output(fmt.Sprintf("Request ID: %s", taskResp.RequestId))
+	if taskResp.Run.Plan != nil {
+		c.UI.Output(fmt.Sprintf("Plan: \n%s", *taskResp.Run.Plan))
+	}
+	if taskResp.Run.TfcRunUrl != nil {
+		c.UI.Output(fmt.Sprintf("Terraform Cloud Run URL: %s\n", *taskResp.Run.TfcRunUrl))
+	}
+
+	if !*c.autoApprove {
+		if exitCode, approved := c.meta.requestUserApprovalDeleteAndDestroy(taskName); !approved {
+			return exitCode
+		}
+	}
+
+	c.UI.Info(fmt.Sprintf("Marking task '%s' for destroy and deletion...\n", taskName))
+	resp, err := client.DeleteTaskByName(ctx, taskName,
+		&oapigen.DeleteTaskByNameParams{Destroy: &destroy})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to delete '%s'", taskName))
+		err = processEOFError(client.Scheme(), err)
+
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	c.UI.Info(fmt.Sprintf("Task '%s' has been marked for deletion "+
+		"and will be destroyed and deleted when not running.", taskName))
+
+	return ExitCodeOK
+}
@@ -63,6 +63,26 @@ func conditionToTypeFunc() mapstructure.DecodeHookFunc {
 			var config ConsulKVConditionConfig
 			return decodeConditionToType(c, &config)
 		}
+		if c, ok := conditions[nodesType]; ok {
+			var config NodesConditionConfig
+			return decodeConditionToType(c, &config)
+		}
+		if c, ok := conditions[intentionsType]; ok {
+			var config IntentionsConditionConfig
+			return decodeConditionToType(c, &config)
+		}
+		if c, ok := conditions[configEntriesType]; ok {
+			var config ConfigEntriesConditionConfig
+			return decodeConditionToType(c, &config)
+		}
+		if c, ok := conditions[httpType]; ok {
+			var config HTTPConditionConfig
+			return decodeConditionToType(c, &config)
+		}
+		if c, ok := conditions[webhookType]; ok {
+			var config WebhookConditionConfig
+			return decodeConditionToType(c, &config)
+		}
 		if c, ok := conditions[scheduleType]; ok {
 			var config ScheduleConditionConfig
 			return decodeConditionToType(c, &config)
@@ -81,6 +101,7 @@ func decodeConditionToType(data interface{}, monitor MonitorConfig) (MonitorConf
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
 			decode.HookWeakDecodeFromSlice,
+			mapstructure.StringToTimeDurationHookFunc(),
 		),
 		WeaklyTypedInput: true,
 		ErrorUnused:      false,
@@ -159,6 +159,8 @@ func TestCatalogServicesConditionConfig_Finalize(t *testing.T) {
 					UseAsModuleInput: Bool(true),
 					Datacenter:       String(""),
 					Namespace:        String(""),
+					Partition:        String(""),
+					Peer:             String(""),
 					NodeMeta:         map[string]string{},
 				},
 			},
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ ConditionConfig = (*ConfigEntriesConditionConfig)(nil)
+
+// ConfigEntriesConditionConfig configures a condition configuration block
+// of type 'config-entries'. A config-entries condition is triggered by changes
+// that occur to Consul configuration entries.
+type ConfigEntriesConditionConfig struct {
+	ConfigEntriesMonitorConfig `mapstructure:",squash"`
+
+	UseAsModuleInput *bool `mapstructure:"use_as_module_input"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *ConfigEntriesConditionConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o ConfigEntriesConditionConfig
+	o.UseAsModuleInput = BoolCopy(c.UseAsModuleInput)
+
+	m, ok := c.ConfigEntriesMonitorConfig.Copy().(*ConfigEntriesMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	o.ConfigEntriesMonitorConfig = *m
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *ConfigEntriesConditionConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*ConfigEntriesConditionConfig)
+	if !ok {
+		return nil
+	}
+
+	r2 := r.(*ConfigEntriesConditionConfig)
+
+	if o2.UseAsModuleInput != nil {
+		r2.UseAsModuleInput = BoolCopy(o2.UseAsModuleInput)
+	}
+
+	mm, ok := c.ConfigEntriesMonitorConfig.Merge(&o2.ConfigEntriesMonitorConfig).(*ConfigEntriesMonitorConfig)
+	if !ok {
+		return nil
+	}
+	r2.ConfigEntriesMonitorConfig = *mm
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *ConfigEntriesConditionConfig) Finalize() {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.UseAsModuleInput == nil {
+		c.UseAsModuleInput = Bool(true)
+	}
+
+	c.ConfigEntriesMonitorConfig.Finalize()
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *ConfigEntriesConditionConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	return c.ConfigEntriesMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *ConfigEntriesConditionConfig) GoString() string {
+	if c == nil {
+		return "(*ConfigEntriesConditionConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&ConfigEntriesConditionConfig{"+
+		"%s, "+
+		"UseAsModuleInput:%v"+
+		"}",
+		c.ConfigEntriesMonitorConfig.GoString(),
+		BoolVal(c.UseAsModuleInput),
+	)
+}
@@ -0,0 +1,312 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigEntriesConditionConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &ConfigEntriesConditionConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *ConfigEntriesConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&ConfigEntriesConditionConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kind:       String("ingress-gateway"),
+					Regexp:     String("^ingress-"),
+					Datacenter: String("dc2"),
+					Namespace:  String("ns2"),
+				},
+				UseAsModuleInput: Bool(true),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestConfigEntriesConditionConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *ConfigEntriesConditionConfig
+		b    *ConfigEntriesConditionConfig
+		r    *ConfigEntriesConditionConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&ConfigEntriesConditionConfig{},
+			&ConfigEntriesConditionConfig{},
+		},
+		{
+			"nil_b",
+			&ConfigEntriesConditionConfig{},
+			nil,
+			&ConfigEntriesConditionConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&ConfigEntriesConditionConfig{},
+			&ConfigEntriesConditionConfig{},
+			&ConfigEntriesConditionConfig{},
+		},
+		{
+			"use_as_module_input_overrides",
+			&ConfigEntriesConditionConfig{UseAsModuleInput: Bool(true)},
+			&ConfigEntriesConditionConfig{UseAsModuleInput: Bool(false)},
+			&ConfigEntriesConditionConfig{UseAsModuleInput: Bool(false)},
+		},
+		{
+			"use_as_module_input_empty_one",
+			&ConfigEntriesConditionConfig{UseAsModuleInput: Bool(true)},
+			&ConfigEntriesConditionConfig{},
+			&ConfigEntriesConditionConfig{UseAsModuleInput: Bool(true)},
+		},
+		{
+			"kind_overrides",
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Kind: String("same")}},
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Kind: String("different")}},
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Kind: String("different")}},
+		},
+		{
+			"name_empty_one",
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Name: String("same")}},
+			&ConfigEntriesConditionConfig{},
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Name: String("same")}},
+		},
+		{
+			"regexp_overrides",
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Regexp: String("same")}},
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Regexp: String("different")}},
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Regexp: String("different")}},
+		},
+		{
+			"datacenter_overrides",
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Datacenter: String("same")}},
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Datacenter: String("different")}},
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Datacenter: String("different")}},
+		},
+		{
+			"namespace_empty_two",
+			&ConfigEntriesConditionConfig{},
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Namespace: String("same")}},
+			&ConfigEntriesConditionConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Namespace: String("same")}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestConfigEntriesConditionConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *ConfigEntriesConditionConfig
+		r    *ConfigEntriesConditionConfig
+	}{
+		{
+			"empty",
+			&ConfigEntriesConditionConfig{},
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kind:       String(""),
+					Name:       String(""),
+					Regexp:     nil,
+					Datacenter: String(""),
+					Namespace:  String(""),
+				},
+				UseAsModuleInput: Bool(true),
+			},
+		},
+		{
+			"use_as_module_input_configured",
+			&ConfigEntriesConditionConfig{UseAsModuleInput: Bool(false)},
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kind:       String(""),
+					Name:       String(""),
+					Regexp:     nil,
+					Datacenter: String(""),
+					Namespace:  String(""),
+				},
+				UseAsModuleInput: Bool(false),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestConfigEntriesConditionConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		c         *ConfigEntriesConditionConfig
+	}{
+		{
+			"happy_path",
+			false,
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kind:       String("service-defaults"),
+					Name:       String("api"),
+					Datacenter: String("dc2"),
+				},
+				UseAsModuleInput: Bool(true),
+			},
+		},
+		{
+			"happy_path_regexp",
+			false,
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kind:   String("ingress-gateway"),
+					Name:   String(""),
+					Regexp: String("^ingress-"),
+				},
+			},
+		},
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"missing_kind",
+			true,
+			&ConfigEntriesConditionConfig{},
+		},
+		{
+			"name_and_regexp",
+			true,
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kind:   String("service-defaults"),
+					Name:   String("api"),
+					Regexp: String("api"),
+				},
+			},
+		},
+		{
+			"invalid_regexp",
+			true,
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kind:   String("service-defaults"),
+					Regexp: String("*"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigEntriesConditionConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *ConfigEntriesConditionConfig
+		expected string
+	}{
+		{
+			"configured",
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kind:       String("ingress-gateway"),
+					Name:       String("ingress"),
+					Datacenter: String("dc"),
+					Namespace:  String("ns"),
+				},
+				UseAsModuleInput: Bool(true),
+			},
+			"&ConfigEntriesConditionConfig{" +
+				"&ConfigEntriesMonitorConfig{" +
+				"Kind:ingress-gateway, " +
+				"Name:ingress, " +
+				"Regexp:, " +
+				"Datacenter:dc, " +
+				"Namespace:ns" +
+				"}, " +
+				"UseAsModuleInput:true" +
+				"}",
+		},
+		{
+			"nil",
+			nil,
+			"(*ConfigEntriesConditionConfig)(nil)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.i.GoString())
+		})
+	}
+}
@@ -265,6 +265,8 @@ func TestConsulKVConditionConfig_Finalize(t *testing.T) {
 					Recurse:    Bool(false),
 					Datacenter: String(""),
 					Namespace:  String(""),
+					Partition:  String(""),
+					Decode:     String(""),
 				},
 				UseAsModuleInput: Bool(true),
 			},
@@ -344,11 +346,32 @@ func TestConsulKVConditionConfig_Validate(t *testing.T) {
 				UseAsModuleInput: Bool(true),
 			},
 		},
+		{
+			"decode",
+			false,
+			&ConsulKVConditionConfig{
+				ConsulKVMonitorConfig: ConsulKVMonitorConfig{
+					Path:    String("key-path"),
+					Recurse: Bool(true),
+					Decode:  String(DecodeYAML),
+				},
+			},
+		},
 		{
 			"nil_path",
 			true,
 			&ConsulKVConditionConfig{},
 		},
+		{
+			"invalid_decode",
+			true,
+			&ConsulKVConditionConfig{
+				ConsulKVMonitorConfig: ConsulKVMonitorConfig{
+					Path:   String("key-path"),
+					Decode: String("toml"),
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
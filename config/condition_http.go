@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ ConditionConfig = (*HTTPConditionConfig)(nil)
+
+// HTTPConditionConfig configures a condition configuration block
+// of type 'http'. An http condition is triggered by changes to the content of
+// the JSON response polled from a URL.
+type HTTPConditionConfig struct {
+	HTTPMonitorConfig `mapstructure:",squash"`
+
+	UseAsModuleInput *bool `mapstructure:"use_as_module_input"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *HTTPConditionConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o HTTPConditionConfig
+	o.UseAsModuleInput = BoolCopy(c.UseAsModuleInput)
+
+	m, ok := c.HTTPMonitorConfig.Copy().(*HTTPMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	o.HTTPMonitorConfig = *m
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *HTTPConditionConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*HTTPConditionConfig)
+	if !ok {
+		return nil
+	}
+
+	r2 := r.(*HTTPConditionConfig)
+
+	if o2.UseAsModuleInput != nil {
+		r2.UseAsModuleInput = BoolCopy(o2.UseAsModuleInput)
+	}
+
+	mm, ok := c.HTTPMonitorConfig.Merge(&o2.HTTPMonitorConfig).(*HTTPMonitorConfig)
+	if !ok {
+		return nil
+	}
+	r2.HTTPMonitorConfig = *mm
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *HTTPConditionConfig) Finalize() {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.UseAsModuleInput == nil {
+		c.UseAsModuleInput = Bool(true)
+	}
+
+	c.HTTPMonitorConfig.Finalize()
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *HTTPConditionConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	return c.HTTPMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *HTTPConditionConfig) GoString() string {
+	if c == nil {
+		return "(*HTTPConditionConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&HTTPConditionConfig{"+
+		"%s, "+
+		"UseAsModuleInput:%v"+
+		"}",
+		c.HTTPMonitorConfig.GoString(),
+		BoolVal(c.UseAsModuleInput),
+	)
+}
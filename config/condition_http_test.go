@@ -0,0 +1,345 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPConditionConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &HTTPConditionConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *HTTPConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&HTTPConditionConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&HTTPConditionConfig{
+				HTTPMonitorConfig: HTTPMonitorConfig{
+					URL:      String("https://ipam.example.com/api/prefixes"),
+					Headers:  map[string]string{"Authorization": "Bearer token"},
+					Interval: TimeDuration(30 * time.Second),
+					Timeout:  TimeDuration(5 * time.Second),
+					Selector: String("$.data"),
+					TLS: &TLSConfig{
+						Enabled: Bool(true),
+						CACert:  String("ca.pem"),
+					},
+				},
+				UseAsModuleInput: Bool(true),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestHTTPConditionConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *HTTPConditionConfig
+		b    *HTTPConditionConfig
+		r    *HTTPConditionConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&HTTPConditionConfig{},
+			&HTTPConditionConfig{},
+		},
+		{
+			"nil_b",
+			&HTTPConditionConfig{},
+			nil,
+			&HTTPConditionConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&HTTPConditionConfig{},
+			&HTTPConditionConfig{},
+			&HTTPConditionConfig{},
+		},
+		{
+			"use_as_module_input_overrides",
+			&HTTPConditionConfig{UseAsModuleInput: Bool(true)},
+			&HTTPConditionConfig{UseAsModuleInput: Bool(false)},
+			&HTTPConditionConfig{UseAsModuleInput: Bool(false)},
+		},
+		{
+			"url_overrides",
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{URL: String("http://same")}},
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{URL: String("http://different")}},
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{URL: String("http://different")}},
+		},
+		{
+			"headers_merge",
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{Headers: map[string]string{"a": "1", "b": "2"}}},
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{Headers: map[string]string{"b": "3", "c": "4"}}},
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{Headers: map[string]string{"a": "1", "b": "3", "c": "4"}}},
+		},
+		{
+			"interval_overrides",
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{Interval: TimeDuration(time.Second)}},
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{Interval: TimeDuration(time.Minute)}},
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{Interval: TimeDuration(time.Minute)}},
+		},
+		{
+			"timeout_empty_one",
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{Timeout: TimeDuration(time.Second)}},
+			&HTTPConditionConfig{},
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{Timeout: TimeDuration(time.Second)}},
+		},
+		{
+			"selector_empty_two",
+			&HTTPConditionConfig{},
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{Selector: String("$.data")}},
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{Selector: String("$.data")}},
+		},
+		{
+			"tls_merge",
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{TLS: &TLSConfig{CACert: String("ca.pem")}}},
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{TLS: &TLSConfig{Verify: Bool(false)}}},
+			&HTTPConditionConfig{HTTPMonitorConfig: HTTPMonitorConfig{TLS: &TLSConfig{CACert: String("ca.pem"), Verify: Bool(false)}}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestHTTPConditionConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	finalizedTLS := DefaultTLSConfig()
+	finalizedTLS.Finalize()
+
+	cases := []struct {
+		name string
+		i    *HTTPConditionConfig
+		r    *HTTPConditionConfig
+	}{
+		{
+			"empty",
+			&HTTPConditionConfig{},
+			&HTTPConditionConfig{
+				HTTPMonitorConfig: HTTPMonitorConfig{
+					URL:      String(""),
+					Headers:  map[string]string{},
+					Interval: TimeDuration(DefaultHTTPInterval),
+					Timeout:  TimeDuration(DefaultHTTPTimeout),
+					Selector: String(""),
+					TLS:      finalizedTLS,
+				},
+				UseAsModuleInput: Bool(true),
+			},
+		},
+		{
+			"use_as_module_input_configured",
+			&HTTPConditionConfig{UseAsModuleInput: Bool(false)},
+			&HTTPConditionConfig{
+				HTTPMonitorConfig: HTTPMonitorConfig{
+					URL:      String(""),
+					Headers:  map[string]string{},
+					Interval: TimeDuration(DefaultHTTPInterval),
+					Timeout:  TimeDuration(DefaultHTTPTimeout),
+					Selector: String(""),
+					TLS:      finalizedTLS,
+				},
+				UseAsModuleInput: Bool(false),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestHTTPConditionConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		c         *HTTPConditionConfig
+	}{
+		{
+			"happy_path",
+			false,
+			&HTTPConditionConfig{
+				HTTPMonitorConfig: HTTPMonitorConfig{
+					URL:      String("https://ipam.example.com/api/prefixes"),
+					Interval: TimeDuration(time.Minute),
+					Timeout:  TimeDuration(time.Second),
+					Selector: String("$.data.prefixes[0]"),
+				},
+				UseAsModuleInput: Bool(true),
+			},
+		},
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"missing_url",
+			true,
+			&HTTPConditionConfig{},
+		},
+		{
+			"invalid_scheme",
+			true,
+			&HTTPConditionConfig{
+				HTTPMonitorConfig: HTTPMonitorConfig{
+					URL: String("ftp://ipam.example.com"),
+				},
+			},
+		},
+		{
+			"missing_host",
+			true,
+			&HTTPConditionConfig{
+				HTTPMonitorConfig: HTTPMonitorConfig{
+					URL: String("http:///api"),
+				},
+			},
+		},
+		{
+			"invalid_interval",
+			true,
+			&HTTPConditionConfig{
+				HTTPMonitorConfig: HTTPMonitorConfig{
+					URL:      String("http://ipam.example.com"),
+					Interval: TimeDuration(0),
+				},
+			},
+		},
+		{
+			"invalid_timeout",
+			true,
+			&HTTPConditionConfig{
+				HTTPMonitorConfig: HTTPMonitorConfig{
+					URL:     String("http://ipam.example.com"),
+					Timeout: TimeDuration(-1 * time.Second),
+				},
+			},
+		},
+		{
+			"invalid_selector",
+			true,
+			&HTTPConditionConfig{
+				HTTPMonitorConfig: HTTPMonitorConfig{
+					URL:      String("http://ipam.example.com"),
+					Selector: String("$.data["),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHTTPConditionConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *HTTPConditionConfig
+		expected string
+	}{
+		{
+			"configured",
+			&HTTPConditionConfig{
+				HTTPMonitorConfig: HTTPMonitorConfig{
+					URL: String("https://ipam.example.com"),
+					Headers: map[string]string{
+						"X-Key":         "secret",
+						"Authorization": "Bearer token",
+					},
+					Interval: TimeDuration(time.Minute),
+					Timeout:  TimeDuration(time.Second),
+					Selector: String("$.data"),
+				},
+				UseAsModuleInput: Bool(true),
+			},
+			"&HTTPConditionConfig{" +
+				"&HTTPMonitorConfig{" +
+				"URL:https://ipam.example.com, " +
+				"Headers:[Authorization X-Key], " +
+				"Interval:1m0s, " +
+				"Timeout:1s, " +
+				"Selector:$.data, " +
+				"TLS:(*TLSConfig)(nil)" +
+				"}, " +
+				"UseAsModuleInput:true" +
+				"}",
+		},
+		{
+			"nil",
+			nil,
+			"(*HTTPConditionConfig)(nil)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.i.GoString())
+		})
+	}
+}
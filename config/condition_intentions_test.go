@@ -0,0 +1,222 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntentionsConditionConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &IntentionsConditionConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *IntentionsConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&IntentionsConditionConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&IntentionsConditionConfig{
+				IntentionsMonitorConfig: IntentionsMonitorConfig{
+					Datacenter: String("dc2"),
+					Namespace:  String("ns2"),
+					Filter:     String("DestinationName == \"api\""),
+				},
+				UseAsModuleInput: Bool(true),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestIntentionsConditionConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *IntentionsConditionConfig
+		b    *IntentionsConditionConfig
+		r    *IntentionsConditionConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&IntentionsConditionConfig{},
+			&IntentionsConditionConfig{},
+		},
+		{
+			"nil_b",
+			&IntentionsConditionConfig{},
+			nil,
+			&IntentionsConditionConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&IntentionsConditionConfig{},
+			&IntentionsConditionConfig{},
+			&IntentionsConditionConfig{},
+		},
+		{
+			"use_as_module_input_overrides",
+			&IntentionsConditionConfig{UseAsModuleInput: Bool(true)},
+			&IntentionsConditionConfig{UseAsModuleInput: Bool(false)},
+			&IntentionsConditionConfig{UseAsModuleInput: Bool(false)},
+		},
+		{
+			"use_as_module_input_empty_one",
+			&IntentionsConditionConfig{UseAsModuleInput: Bool(true)},
+			&IntentionsConditionConfig{},
+			&IntentionsConditionConfig{UseAsModuleInput: Bool(true)},
+		},
+		{
+			"datacenter_overrides",
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Datacenter: String("same")}},
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Datacenter: String("different")}},
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Datacenter: String("different")}},
+		},
+		{
+			"namespace_empty_two",
+			&IntentionsConditionConfig{},
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Namespace: String("same")}},
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Namespace: String("same")}},
+		},
+		{
+			"filter_overrides",
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Filter: String("same")}},
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Filter: String("different")}},
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Filter: String("different")}},
+		},
+		{
+			"filter_empty_one",
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Filter: String("same")}},
+			&IntentionsConditionConfig{},
+			&IntentionsConditionConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Filter: String("same")}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestIntentionsConditionConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *IntentionsConditionConfig
+		r    *IntentionsConditionConfig
+	}{
+		{
+			"empty",
+			&IntentionsConditionConfig{},
+			&IntentionsConditionConfig{
+				IntentionsMonitorConfig: IntentionsMonitorConfig{
+					Datacenter: String(""),
+					Namespace:  String(""),
+					Filter:     String(""),
+				},
+				UseAsModuleInput: Bool(true),
+			},
+		},
+		{
+			"use_as_module_input_configured",
+			&IntentionsConditionConfig{UseAsModuleInput: Bool(false)},
+			&IntentionsConditionConfig{
+				IntentionsMonitorConfig: IntentionsMonitorConfig{
+					Datacenter: String(""),
+					Namespace:  String(""),
+					Filter:     String(""),
+				},
+				UseAsModuleInput: Bool(false),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestIntentionsConditionConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *IntentionsConditionConfig
+		expected string
+	}{
+		{
+			"configured",
+			&IntentionsConditionConfig{
+				IntentionsMonitorConfig: IntentionsMonitorConfig{
+					Datacenter: String("dc"),
+					Namespace:  String("ns"),
+					Filter:     String("filter"),
+				},
+				UseAsModuleInput: Bool(true),
+			},
+			"&IntentionsConditionConfig{" +
+				"&IntentionsMonitorConfig{" +
+				"Datacenter:dc, " +
+				"Namespace:ns, " +
+				"Filter:filter" +
+				"}, " +
+				"UseAsModuleInput:true" +
+				"}",
+		},
+		{
+			"nil",
+			nil,
+			"(*IntentionsConditionConfig)(nil)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.i.GoString())
+		})
+	}
+}
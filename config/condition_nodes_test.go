@@ -0,0 +1,257 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodesConditionConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &NodesConditionConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *NodesConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&NodesConditionConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&NodesConditionConfig{
+				NodesMonitorConfig: NodesMonitorConfig{
+					Datacenter: String("dc2"),
+					NodeMeta:   map[string]string{"key": "value"},
+					Filter:     String("Meta.env == \"prod\""),
+				},
+				UseAsModuleInput: Bool(true),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestNodesConditionConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *NodesConditionConfig
+		b    *NodesConditionConfig
+		r    *NodesConditionConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&NodesConditionConfig{},
+			&NodesConditionConfig{},
+		},
+		{
+			"nil_b",
+			&NodesConditionConfig{},
+			nil,
+			&NodesConditionConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&NodesConditionConfig{},
+			&NodesConditionConfig{},
+			&NodesConditionConfig{},
+		},
+		{
+			"use_as_module_input_overrides",
+			&NodesConditionConfig{UseAsModuleInput: Bool(true)},
+			&NodesConditionConfig{UseAsModuleInput: Bool(false)},
+			&NodesConditionConfig{UseAsModuleInput: Bool(false)},
+		},
+		{
+			"use_as_module_input_empty_one",
+			&NodesConditionConfig{UseAsModuleInput: Bool(true)},
+			&NodesConditionConfig{},
+			&NodesConditionConfig{UseAsModuleInput: Bool(true)},
+		},
+		{
+			"use_as_module_input_empty_two",
+			&NodesConditionConfig{},
+			&NodesConditionConfig{UseAsModuleInput: Bool(true)},
+			&NodesConditionConfig{UseAsModuleInput: Bool(true)},
+		},
+		{
+			"datacenter_overrides",
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Datacenter: String("same")}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Datacenter: String("different")}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Datacenter: String("different")}},
+		},
+		{
+			"datacenter_empty_one",
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Datacenter: String("same")}},
+			&NodesConditionConfig{},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Datacenter: String("same")}},
+		},
+		{
+			"filter_overrides",
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Filter: String("same")}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Filter: String("different")}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Filter: String("different")}},
+		},
+		{
+			"filter_empty_two",
+			&NodesConditionConfig{},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Filter: String("same")}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{Filter: String("same")}},
+		},
+		{
+			"node_meta_merges",
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{
+				NodeMeta: map[string]string{"foo": "bar", "key": "value"}}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{
+				NodeMeta: map[string]string{"key": "new", "env": "prod"}}},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{
+				NodeMeta: map[string]string{"foo": "bar", "key": "new", "env": "prod"}}},
+		},
+		{
+			"node_meta_empty_one",
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{
+				NodeMeta: map[string]string{"key": "value"}}},
+			&NodesConditionConfig{},
+			&NodesConditionConfig{NodesMonitorConfig: NodesMonitorConfig{
+				NodeMeta: map[string]string{"key": "value"}}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestNodesConditionConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *NodesConditionConfig
+		r    *NodesConditionConfig
+	}{
+		{
+			"empty",
+			&NodesConditionConfig{},
+			&NodesConditionConfig{
+				NodesMonitorConfig: NodesMonitorConfig{
+					Datacenter: String(""),
+					NodeMeta:   map[string]string{},
+					Filter:     String(""),
+				},
+				UseAsModuleInput: Bool(true),
+			},
+		},
+		{
+			"use_as_module_input_configured",
+			&NodesConditionConfig{UseAsModuleInput: Bool(false)},
+			&NodesConditionConfig{
+				NodesMonitorConfig: NodesMonitorConfig{
+					Datacenter: String(""),
+					NodeMeta:   map[string]string{},
+					Filter:     String(""),
+				},
+				UseAsModuleInput: Bool(false),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestNodesConditionConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		c         *NodesConditionConfig
+	}{
+		{
+			"happy_path",
+			false,
+			&NodesConditionConfig{
+				NodesMonitorConfig: NodesMonitorConfig{
+					Datacenter: String("dc2"),
+					NodeMeta:   map[string]string{"key": "value"},
+					Filter:     String("Meta.env == \"prod\""),
+				},
+				UseAsModuleInput: Bool(true),
+			},
+		},
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"empty",
+			false,
+			&NodesConditionConfig{},
+		},
+		{
+			"empty_node_meta_key",
+			true,
+			&NodesConditionConfig{
+				NodesMonitorConfig: NodesMonitorConfig{
+					NodeMeta: map[string]string{"": "value"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -17,6 +17,16 @@ type ServicesConditionConfig struct {
 	// UseAsModuleInput was previously named SourceIncludesVar - deprecated v0.5
 	UseAsModuleInput            *bool `mapstructure:"use_as_module_input"`
 	DeprecatedSourceIncludesVar *bool `mapstructure:"source_includes_var"`
+
+	// IgnoreStatusChanges suppresses triggering the task when only the health
+	// status of the selected service instances changes. The task is triggered
+	// when instances are added or removed or their other information changes.
+	IgnoreStatusChanges *bool `mapstructure:"ignore_status_changes"`
+
+	// Damping suppresses triggering the task for changes to the service
+	// instances until a threshold of changed instances is exceeded or the
+	// changes are stable. Damping is disabled when not configured.
+	Damping *DampingConfig `mapstructure:"damping"`
 }
 
 // Copy returns a deep copy of this configuration.
@@ -28,6 +38,8 @@ func (c *ServicesConditionConfig) Copy() MonitorConfig {
 	var o ServicesConditionConfig
 	o.UseAsModuleInput = BoolCopy(c.UseAsModuleInput)
 	o.DeprecatedSourceIncludesVar = BoolCopy(c.DeprecatedSourceIncludesVar)
+	o.IgnoreStatusChanges = BoolCopy(c.IgnoreStatusChanges)
+	o.Damping = c.Damping.Copy()
 
 	svc, ok := c.ServicesMonitorConfig.Copy().(*ServicesMonitorConfig)
 	if !ok {
@@ -68,6 +80,12 @@ func (c *ServicesConditionConfig) Merge(o MonitorConfig) MonitorConfig {
 	if o2.DeprecatedSourceIncludesVar != nil {
 		r2.DeprecatedSourceIncludesVar = BoolCopy(o2.DeprecatedSourceIncludesVar)
 	}
+	if o2.IgnoreStatusChanges != nil {
+		r2.IgnoreStatusChanges = BoolCopy(o2.IgnoreStatusChanges)
+	}
+	if o2.Damping != nil {
+		r2.Damping = r2.Damping.Merge(o2.Damping)
+	}
 
 	merged, ok := c.ServicesMonitorConfig.Merge(&o2.ServicesMonitorConfig).(*ServicesMonitorConfig)
 	if !ok {
@@ -99,6 +117,10 @@ func (c *ServicesConditionConfig) Finalize() {
 	if c.UseAsModuleInput == nil {
 		c.UseAsModuleInput = Bool(true)
 	}
+	if c.IgnoreStatusChanges == nil {
+		c.IgnoreStatusChanges = Bool(false)
+	}
+	c.Damping.Finalize()
 
 	c.ServicesMonitorConfig.Finalize()
 }
@@ -113,6 +135,10 @@ func (c *ServicesConditionConfig) Validate() error {
 		return fmt.Errorf("error validating `condition \"services\"` block: %s",
 			err)
 	}
+	if err := c.Damping.Validate(); err != nil {
+		return fmt.Errorf("error validating `condition \"services\"` block: %s",
+			err)
+	}
 	return nil
 }
 
@@ -124,9 +150,13 @@ func (c *ServicesConditionConfig) GoString() string {
 
 	return fmt.Sprintf("&ServicesConditionConfig{"+
 		"%s, "+
-		"UseAsModuleInput:%v"+
+		"UseAsModuleInput:%v, "+
+		"IgnoreStatusChanges:%v, "+
+		"Damping:%s"+
 		"}",
 		c.ServicesMonitorConfig.GoString(),
 		BoolVal(c.UseAsModuleInput),
+		BoolVal(c.IgnoreStatusChanges),
+		c.Damping.GoString(),
 	)
 }
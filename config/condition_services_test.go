@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -42,6 +43,11 @@ func TestServicesConditionConfig_Copy(t *testing.T) {
 				},
 				UseAsModuleInput:            Bool(false),
 				DeprecatedSourceIncludesVar: Bool(false),
+				IgnoreStatusChanges:         Bool(true),
+				Damping: &DampingConfig{
+					Threshold:     Int(5),
+					MinStableTime: TimeDuration(time.Minute),
+				},
 			},
 		},
 	}
@@ -140,6 +146,25 @@ func TestServicesConditionConfig_Merge(t *testing.T) {
 			&ServicesConditionConfig{UseAsModuleInput: Bool(true)},
 			&ServicesConditionConfig{UseAsModuleInput: Bool(true)},
 		},
+		{
+			"ignore_status_changes_overrides",
+			&ServicesConditionConfig{IgnoreStatusChanges: Bool(false)},
+			&ServicesConditionConfig{IgnoreStatusChanges: Bool(true)},
+			&ServicesConditionConfig{IgnoreStatusChanges: Bool(true)},
+		},
+		{
+			"ignore_status_changes_empty_one",
+			&ServicesConditionConfig{IgnoreStatusChanges: Bool(true)},
+			&ServicesConditionConfig{},
+			&ServicesConditionConfig{IgnoreStatusChanges: Bool(true)},
+		},
+		{
+			"damping_merges",
+			&ServicesConditionConfig{Damping: &DampingConfig{Threshold: Int(5)}},
+			&ServicesConditionConfig{Damping: &DampingConfig{ThresholdPercent: Int(10)}},
+			&ServicesConditionConfig{Damping: &DampingConfig{
+				Threshold: Int(5), ThresholdPercent: Int(10)}},
+		},
 		{
 			"happy_path",
 			&ServicesConditionConfig{
@@ -213,10 +238,39 @@ func TestServicesConditionConfig_Finalize(t *testing.T) {
 					Names:              []string{},
 					Datacenter:         String(""),
 					Namespace:          String(""),
+					Partition:          String(""),
+					Peer:               String(""),
+					Filter:             String(""),
+					Status:             String(HealthPassing),
+					CTSUserDefinedMeta: map[string]string{},
+				},
+				UseAsModuleInput:    Bool(true),
+				IgnoreStatusChanges: Bool(false),
+			},
+		},
+		{
+			"damping",
+			&ServicesConditionConfig{Damping: &DampingConfig{}},
+			&ServicesConditionConfig{
+				ServicesMonitorConfig: ServicesMonitorConfig{
+					Regexp:             nil,
+					Names:              []string{},
+					Datacenter:         String(""),
+					Namespace:          String(""),
+					Partition:          String(""),
+					Peer:               String(""),
 					Filter:             String(""),
+					Status:             String(HealthPassing),
 					CTSUserDefinedMeta: map[string]string{},
 				},
-				UseAsModuleInput: Bool(true),
+				UseAsModuleInput:    Bool(true),
+				IgnoreStatusChanges: Bool(false),
+				Damping: &DampingConfig{
+					Threshold:          Int(0),
+					ThresholdPercent:   Int(0),
+					MinStableTime:      TimeDuration(DefaultDampingMinStableTime),
+					MaxSuppressionTime: TimeDuration(10 * DefaultDampingMinStableTime),
+				},
 			},
 		},
 	}
@@ -299,6 +353,16 @@ func TestServicesConditionConfig_Validate(t *testing.T) {
 				},
 			},
 		},
+		{
+			"invalid_damping",
+			true,
+			&ServicesConditionConfig{
+				ServicesMonitorConfig: ServicesMonitorConfig{
+					Regexp: String(".*"),
+				},
+				Damping: &DampingConfig{ThresholdPercent: Int(101)},
+			},
+		},
 		{
 			"nil",
 			false,
@@ -343,11 +407,14 @@ func TestServicesCondition_GoString(t *testing.T) {
 						"key": "value",
 					},
 				},
-				UseAsModuleInput: Bool(false),
+				UseAsModuleInput:    Bool(false),
+				IgnoreStatusChanges: Bool(true),
 			},
 			"&ServicesConditionConfig{&ServicesMonitorConfig{Regexp:^api$, Names:[], " +
-				"Datacenter:dc, Namespace:namespace, Filter:filter, " +
-				"CTSUserDefinedMeta:map[key:value]}, UseAsModuleInput:false}",
+				"Datacenter:dc, Datacenters:[], Namespace:namespace, Partition:, Peer:, " +
+				"Filter:filter, Status:, " +
+				"CTSUserDefinedMeta:map[key:value]}, UseAsModuleInput:false, " +
+				"IgnoreStatusChanges:true, Damping:(*DampingConfig)(nil)}",
 		},
 	}
 
@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -24,6 +25,8 @@ func TestCondition_DecodeConfig(t *testing.T) {
 					UseAsModuleInput: Bool(true),
 					Datacenter:       String("dc2"),
 					Namespace:        String("ns2"),
+					Partition:        String("ap2"),
+					Peer:             String("cluster-02"),
 					NodeMeta: map[string]string{
 						"key1": "value1",
 						"key2": "value2",
@@ -40,6 +43,8 @@ task {
 		use_as_module_input = true
 		namespace = "ns2"
 		datacenter = "dc2"
+		partition = "ap2"
+		peer = "cluster-02"
 		node_meta {
 		  "key1" = "value1"
 		  "key2" = "value2"
@@ -68,12 +73,16 @@ task {
 					Names:      []string{},
 					Datacenter: String("dc"),
 					Namespace:  String("namespace"),
+					Partition:  String("ap"),
+					Peer:       String("cluster-02"),
 					Filter:     String("filter"),
+					Status:     String("warning"),
 					CTSUserDefinedMeta: map[string]string{
 						"key": "value",
 					},
 				},
-				UseAsModuleInput: Bool(true),
+				UseAsModuleInput:    Bool(true),
+				IgnoreStatusChanges: Bool(true),
 			},
 			"config.hcl",
 			`
@@ -84,11 +93,85 @@ task {
 		regexp = ".*"
 		datacenter = "dc"
 		namespace = "namespace"
+		partition = "ap"
+		peer = "cluster-02"
 		filter = "filter"
+		status = "warning"
+		ignore_status_changes = true
 		cts_user_defined_meta {
 			key = "value"
 		}
 	}
+}`,
+		},
+		{
+			"services: damping",
+			false,
+			&ServicesConditionConfig{
+				ServicesMonitorConfig: ServicesMonitorConfig{
+					Regexp:             nil,
+					Names:              []string{"api"},
+					Datacenter:         String(""),
+					Namespace:          String(""),
+					Partition:          String(""),
+					Peer:               String(""),
+					Filter:             String(""),
+					Status:             String(HealthPassing),
+					CTSUserDefinedMeta: map[string]string{},
+				},
+				UseAsModuleInput:    Bool(true),
+				IgnoreStatusChanges: Bool(false),
+				Damping: &DampingConfig{
+					Threshold:          Int(5),
+					ThresholdPercent:   Int(20),
+					MinStableTime:      TimeDuration(time.Minute),
+					MaxSuppressionTime: TimeDuration(5 * time.Minute),
+				},
+			},
+			"config.hcl",
+			`
+task {
+	name = "services_condition_task"
+	module = "..."
+	condition "services" {
+		names = ["api"]
+		damping {
+			threshold = 5
+			threshold_percent = 20
+			min_stable_time = "1m"
+			max_suppression_time = "5m"
+		}
+	}
+}`,
+		},
+		{
+			"services: datacenters",
+			false,
+			&ServicesConditionConfig{
+				ServicesMonitorConfig: ServicesMonitorConfig{
+					Regexp:             nil,
+					Names:              []string{"api"},
+					Datacenter:         String(""),
+					Datacenters:        []string{"dc1", "dc2"},
+					Namespace:          String(""),
+					Partition:          String(""),
+					Peer:               String(""),
+					Filter:             String(""),
+					Status:             String(HealthPassing),
+					CTSUserDefinedMeta: map[string]string{},
+				},
+				UseAsModuleInput:    Bool(true),
+				IgnoreStatusChanges: Bool(false),
+			},
+			"config.hcl",
+			`
+task {
+	name = "services_condition_task"
+	module = "..."
+	condition "services" {
+		names = ["api"]
+		datacenters = ["dc1", "dc2"]
+	}
 }`,
 		},
 		{
@@ -160,7 +243,9 @@ task {
 					Path:       String("key-path"),
 					Datacenter: String("dc2"),
 					Namespace:  String("ns2"),
+					Partition:  String("ap2"),
 					Recurse:    Bool(true),
+					Decode:     String("yaml"),
 				},
 				UseAsModuleInput: Bool(true),
 			},
@@ -174,7 +259,140 @@ task {
 		use_as_module_input = true
 		namespace = "ns2"
 		datacenter = "dc2"
+		partition = "ap2"
 		recurse = true
+		decode = "yaml"
+	}
+}`,
+		},
+		{
+			"nodes: happy path",
+			false,
+			&NodesConditionConfig{
+				NodesMonitorConfig: NodesMonitorConfig{
+					Datacenter: String("dc2"),
+					NodeMeta:   map[string]string{"key": "value"},
+					Filter:     String("Meta.env == \"prod\""),
+				},
+				UseAsModuleInput: Bool(false),
+			},
+			"config.hcl",
+			`
+task {
+	name = "condition_task"
+	module = "..."
+	condition "nodes" {
+		datacenter = "dc2"
+		filter = "Meta.env == \"prod\""
+		node_meta {
+			key = "value"
+		}
+		use_as_module_input = false
+	}
+}`,
+		},
+		{
+			"intentions: happy path",
+			false,
+			&IntentionsConditionConfig{
+				IntentionsMonitorConfig: IntentionsMonitorConfig{
+					Datacenter: String("dc2"),
+					Namespace:  String("ns2"),
+					Filter:     String("DestinationName == \"api\""),
+				},
+				UseAsModuleInput: Bool(true),
+			},
+			"config.hcl",
+			`
+task {
+	name = "condition_task"
+	module = "..."
+	condition "intentions" {
+		datacenter = "dc2"
+		namespace = "ns2"
+		filter = "DestinationName == \"api\""
+	}
+}`,
+		},
+		{
+			"config-entries: happy path",
+			false,
+			&ConfigEntriesConditionConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kind:       String("ingress-gateway"),
+					Name:       String(""),
+					Regexp:     String("^ingress-"),
+					Datacenter: String("dc2"),
+					Namespace:  String(""),
+				},
+				UseAsModuleInput: Bool(true),
+			},
+			"config.hcl",
+			`
+task {
+	name = "condition_task"
+	module = "..."
+	condition "config-entries" {
+		kind = "ingress-gateway"
+		regexp = "^ingress-"
+		datacenter = "dc2"
+	}
+}`,
+		},
+		{
+			"http: happy path",
+			false,
+			&HTTPConditionConfig{
+				HTTPMonitorConfig: HTTPMonitorConfig{
+					URL:      String("https://ipam.example.com/api/prefixes"),
+					Headers:  map[string]string{"Authorization": "Bearer token"},
+					Interval: TimeDuration(30 * time.Second),
+					Timeout:  TimeDuration(DefaultHTTPTimeout),
+					Selector: String("$.data"),
+					TLS: &TLSConfig{
+						CACert:     String("ca.pem"),
+						CAPath:     String(""),
+						Cert:       String(""),
+						Enabled:    Bool(true),
+						Key:        String(""),
+						ServerName: String(""),
+						Verify:     Bool(true),
+					},
+				},
+				UseAsModuleInput: Bool(true),
+			},
+			"config.hcl",
+			`
+task {
+	name = "condition_task"
+	module = "..."
+	condition "http" {
+		url = "https://ipam.example.com/api/prefixes"
+		headers = {
+			Authorization = "Bearer token"
+		}
+		interval = "30s"
+		selector = "$.data"
+		tls {
+			ca_cert = "ca.pem"
+		}
+	}
+}`,
+		},
+		{
+			"webhook: happy path",
+			false,
+			&WebhookConditionConfig{
+				Secret:           String("s3cr3t"),
+				UseAsModuleInput: Bool(true),
+			},
+			"config.hcl",
+			`
+task {
+	name = "condition_task"
+	module = "..."
+	condition "webhook" {
+		secret = "s3cr3t"
 	}
 }`,
 		},
@@ -200,6 +418,8 @@ task {
 					UseAsModuleInput: Bool(true),
 					Datacenter:       String("dc2"),
 					Namespace:        String("ns2"),
+					Partition:        String(""),
+					Peer:             String(""),
 					NodeMeta: map[string]string{
 						"key1": "value1",
 						"key2": "value2",
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+)
+
+const webhookType = "webhook"
+
+var _ ConditionConfig = (*WebhookConditionConfig)(nil)
+
+// WebhookConditionConfig configures a condition configuration block of type
+// 'webhook'. A webhook condition is triggered by a request to the trigger task
+// API `POST /v1/tasks/:task_name/trigger` that is signed with the secret. The
+// JSON body of the request is the value of the webhook variable.
+type WebhookConditionConfig struct {
+	// Secret is the key used to verify the HMAC-SHA256 signature of the
+	// body of trigger requests.
+	Secret *string `mapstructure:"secret"`
+
+	UseAsModuleInput *bool `mapstructure:"use_as_module_input"`
+}
+
+func (c *WebhookConditionConfig) VariableType() string {
+	return "webhook"
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *WebhookConditionConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o WebhookConditionConfig
+	o.Secret = StringCopy(c.Secret)
+	o.UseAsModuleInput = BoolCopy(c.UseAsModuleInput)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *WebhookConditionConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*WebhookConditionConfig)
+	if !ok {
+		return r
+	}
+
+	r2 := r.(*WebhookConditionConfig)
+
+	if o2.Secret != nil {
+		r2.Secret = StringCopy(o2.Secret)
+	}
+
+	if o2.UseAsModuleInput != nil {
+		r2.UseAsModuleInput = BoolCopy(o2.UseAsModuleInput)
+	}
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *WebhookConditionConfig) Finalize() {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.Secret == nil {
+		c.Secret = String("")
+	}
+
+	if c.UseAsModuleInput == nil {
+		c.UseAsModuleInput = Bool(true)
+	}
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *WebhookConditionConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	if c.Secret == nil || *c.Secret == "" {
+		return fmt.Errorf("secret is required for webhook condition")
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+// Sensitive information is redacted.
+func (c *WebhookConditionConfig) GoString() string {
+	if c == nil {
+		return "(*WebhookConditionConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&WebhookConditionConfig{"+
+		"Secret:%s, "+
+		"UseAsModuleInput:%v"+
+		"}",
+		sensitiveGoString(c.Secret),
+		BoolVal(c.UseAsModuleInput),
+	)
+}
@@ -0,0 +1,229 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookConditionConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &WebhookConditionConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *WebhookConditionConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&WebhookConditionConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&WebhookConditionConfig{
+				Secret:           String("s3cr3t"),
+				UseAsModuleInput: Bool(false),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestWebhookConditionConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *WebhookConditionConfig
+		b    *WebhookConditionConfig
+		r    *WebhookConditionConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&WebhookConditionConfig{},
+			&WebhookConditionConfig{},
+		},
+		{
+			"nil_b",
+			&WebhookConditionConfig{},
+			nil,
+			&WebhookConditionConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&WebhookConditionConfig{},
+			&WebhookConditionConfig{},
+			&WebhookConditionConfig{},
+		},
+		{
+			"secret_overrides",
+			&WebhookConditionConfig{Secret: String("same")},
+			&WebhookConditionConfig{Secret: String("different")},
+			&WebhookConditionConfig{Secret: String("different")},
+		},
+		{
+			"secret_empty_one",
+			&WebhookConditionConfig{Secret: String("same")},
+			&WebhookConditionConfig{},
+			&WebhookConditionConfig{Secret: String("same")},
+		},
+		{
+			"use_as_module_input_empty_two",
+			&WebhookConditionConfig{},
+			&WebhookConditionConfig{UseAsModuleInput: Bool(false)},
+			&WebhookConditionConfig{UseAsModuleInput: Bool(false)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestWebhookConditionConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *WebhookConditionConfig
+		r    *WebhookConditionConfig
+	}{
+		{
+			"empty",
+			&WebhookConditionConfig{},
+			&WebhookConditionConfig{
+				Secret:           String(""),
+				UseAsModuleInput: Bool(true),
+			},
+		},
+		{
+			"use_as_module_input_configured",
+			&WebhookConditionConfig{UseAsModuleInput: Bool(false)},
+			&WebhookConditionConfig{
+				Secret:           String(""),
+				UseAsModuleInput: Bool(false),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestWebhookConditionConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		c         *WebhookConditionConfig
+	}{
+		{
+			"happy_path",
+			false,
+			&WebhookConditionConfig{
+				Secret:           String("s3cr3t"),
+				UseAsModuleInput: Bool(true),
+			},
+		},
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"missing_secret",
+			true,
+			&WebhookConditionConfig{},
+		},
+		{
+			"empty_secret",
+			true,
+			&WebhookConditionConfig{Secret: String("")},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWebhookConditionConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *WebhookConditionConfig
+		expected string
+	}{
+		{
+			"configured",
+			&WebhookConditionConfig{
+				Secret:           String("s3cr3t"),
+				UseAsModuleInput: Bool(true),
+			},
+			"&WebhookConditionConfig{" +
+				"Secret:(redacted), " +
+				"UseAsModuleInput:true" +
+				"}",
+		},
+		{
+			"nil",
+			nil,
+			"(*WebhookConditionConfig)(nil)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.i.GoString())
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/consul-terraform-sync/internal/decode"
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	goVersion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl"
 	"github.com/mitchellh/mapstructure"
 )
@@ -138,6 +139,10 @@ func (c *Config) Merge(o *Config) *Config {
 		r.LogLevel = StringCopy(o.LogLevel)
 	}
 
+	if o.ClientType != nil {
+		r.ClientType = StringCopy(o.ClientType)
+	}
+
 	if o.Port != nil {
 		r.Port = IntCopy(o.Port)
 	}
@@ -303,10 +308,26 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.validateClientType(); err != nil {
+		return err
+	}
+
 	if err := c.validateDynamicConfigs(); err != nil {
 		return err
 	}
 
+	for _, t := range *c.Tasks {
+		if err := c.ValidateTaskDriver(t); err != nil {
+			return err
+		}
+		if err := c.ValidateTaskVault(t); err != nil {
+			return err
+		}
+		if err := c.ValidateTaskHTTP(t); err != nil {
+			return err
+		}
+	}
+
 	if err := c.TLS.Validate(); err != nil {
 		return err
 	}
@@ -350,6 +371,127 @@ func (c *Config) validateTaskProvider() error {
 	return nil
 }
 
+// ValidateTaskDriver checks that the options configured for a task are
+// supported by the configured driver. Tasks created by the API are validated
+// separately from the configuration, so this is exported for them.
+func (c *Config) ValidateTaskDriver(t *TaskConfig) error {
+	if c.Driver == nil {
+		return nil
+	}
+
+	var driver string
+	switch {
+	case c.Driver.Exec != nil:
+		driver = "exec"
+	case c.Driver.Webhook != nil:
+		driver = "webhook"
+	case c.Driver.Terraform != nil:
+		return c.validateTaskTerraformVersion(t)
+	default:
+		return nil
+	}
+
+	if BoolVal(t.DestroyOnDelete) {
+		return fmt.Errorf("destroy_on_delete for task %q is not supported "+
+			"by the %s driver", StringVal(t.Name), driver)
+	}
+	if StringVal(t.DeprecatedTFVersion) != "" {
+		return fmt.Errorf("terraform_version for task %q is not supported "+
+			"by the %s driver", StringVal(t.Name), driver)
+	}
+	if StringVal(t.FileFormat) != "" {
+		return fmt.Errorf("file_format for task %q is not supported "+
+			"by the %s driver", StringVal(t.Name), driver)
+	}
+	return nil
+}
+
+// validateTaskTerraformVersion checks that the Terraform version configured
+// for a task is supported for the binary of the Terraform driver
+func (c *Config) validateTaskTerraformVersion(t *TaskConfig) error {
+	version := StringVal(t.DeprecatedTFVersion)
+	if version == "" {
+		return nil
+	}
+
+	tfConf := c.Driver.Terraform
+	if tfConf.IsOpenTofu() {
+		return fmt.Errorf("terraform_version for task %q is not supported "+
+			"with OpenTofu", StringVal(t.Name))
+	}
+
+	v, err := goVersion.NewSemver(version)
+	if err != nil {
+		return fmt.Errorf("invalid terraform_version for task %q: %s",
+			StringVal(t.Name), err)
+	}
+
+	constraint, constraintStr := tfConf.VersionConstraint()
+	if !constraint.Check(v) {
+		return fmt.Errorf("%s version for task %q is not supported by "+
+			"Consul-Terraform-Sync, try updating to a different version (%s): %s",
+			tfConf.ProductName(), StringVal(t.Name), constraintStr, version)
+	}
+	return nil
+}
+
+// validateClientType checks that the driver is configured for the client type
+func (c *Config) validateClientType() error {
+	if StringVal(c.ClientType) != GitClientType {
+		return nil
+	}
+
+	if c.Driver.Terraform == nil {
+		return fmt.Errorf("client_type %q is only supported by the Terraform "+
+			"driver", GitClientType)
+	}
+	if gitOutput := c.Driver.Terraform.GitOutput; gitOutput == nil ||
+		StringVal(gitOutput.Path) == "" {
+		return fmt.Errorf("client_type %q requires the path of the "+
+			"git_output block of the Terraform driver", GitClientType)
+	}
+	return nil
+}
+
+// ValidateTaskVault checks that a task configured with a vault module_input
+// is supported by the configuration. Tasks created by the API are validated
+// separately from the configuration, so this is exported for them.
+func (c *Config) ValidateTaskVault(t *TaskConfig) error {
+	if !hasVaultModuleInput(t) {
+		return nil
+	}
+
+	// The secrets are read using the Vault client
+	if c.Vault == nil || !BoolVal(c.Vault.Enabled) {
+		return fmt.Errorf("task %q has a vault module_input: missing Vault "+
+			"configuration", StringVal(t.Name))
+	}
+
+	// The rendered input variables are committed by the git client, which
+	// would expose the secret data
+	if StringVal(c.ClientType) == GitClientType {
+		return fmt.Errorf("task %q has a vault module_input which is not "+
+			"supported by client_type %q", StringVal(t.Name), GitClientType)
+	}
+
+	return nil
+}
+
+// ValidateTaskHTTP checks that a task configured with http headers is
+// supported by the configuration. Tasks created by the API are validated
+// separately from the configuration, so this is exported for them.
+func (c *Config) ValidateTaskHTTP(t *TaskConfig) error {
+	// The headers are written to the template of the input variables, which
+	// is committed by the git client and would expose the header values, e.g.
+	// authorization tokens
+	if StringVal(c.ClientType) == GitClientType && hasHTTPHeaders(t) {
+		return fmt.Errorf("task %q has http headers which are not supported "+
+			"by client_type %q", StringVal(t.Name), GitClientType)
+	}
+
+	return nil
+}
+
 // GoString defines the printable version of this struct.
 func (c *Config) GoString() string {
 	if c == nil {
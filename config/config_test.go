@@ -125,7 +125,7 @@ var (
 				},
 				ModuleInputs: &ModuleInputConfigs{
 					&ConsulKVModuleInputConfig{
-						ConsulKVMonitorConfig{
+						ConsulKVMonitorConfig: ConsulKVMonitorConfig{
 							Path:       String("key-path"),
 							Recurse:    Bool(true),
 							Datacenter: String("dc2"),
@@ -369,14 +369,29 @@ func TestConfig_Finalize(t *testing.T) {
 	expected.Driver.consul = expected.Consul
 	expected.Driver.Terraform.Version = String("")
 	expected.Driver.Terraform.PersistLog = Bool(false)
+	expected.Driver.Terraform.Flavor = String(TerraformFlavorTerraform)
+	expected.Driver.Terraform.BinaryName = String(DefaultTerraformBinaryName)
+	expected.Driver.Terraform.ArchivePath = String("")
+	expected.Driver.Terraform.PluginCacheDir = String("")
+	expected.Driver.Terraform.Mirror = DefaultTerraformMirrorConfig()
+	expected.Driver.Terraform.Checksums = []string{}
+	expected.Driver.Terraform.ChecksumsFile = String("")
+	expected.Driver.Terraform.FileFormat = String(FileFormatHCL)
+	expected.Driver.Terraform.GitOutput = DefaultTerraformGitOutputConfig()
 	backend := expected.Driver.Terraform.Backend["consul"].(map[string]interface{})
 	backend["scheme"] = "https"
 	backend["ca_file"] = "ca_cert"
 	backend["key_file"] = "key"
 	(*expected.Tasks)[0].Enabled = Bool(true)
+	(*expected.Tasks)[0].DestroyOnDelete = Bool(false)
+	(*expected.Tasks)[0].OutputsKVPath = String("")
+	(*expected.Tasks)[0].ExposeOutputs = Bool(false)
 	(*expected.Tasks)[0].DeprecatedTFVersion = String("")
 	(*expected.Tasks)[0].TFCWorkspace = DefaultTerraformCloudWorkspaceConfig()
 	(*expected.Tasks)[0].VarFiles = []string{}
+	(*expected.Tasks)[0].ServicesTemplate = String("")
+	(*expected.Tasks)[0].FileFormat = String("")
+	(*expected.Tasks)[0].ServicesProtocol = String(ServicesProtocolV0)
 	(*expected.Tasks)[0].Version = String("")
 	(*expected.Tasks)[0].BufferPeriod = &BufferPeriodConfig{}
 	(*expected.Tasks)[0].BufferPeriod.Enabled = Bool(true)
@@ -384,6 +399,13 @@ func TestConfig_Finalize(t *testing.T) {
 	(*expected.Tasks)[0].BufferPeriod.Max = TimeDuration(60 * time.Second)
 	(*expected.Tasks)[0].Variables = map[string]string{}
 	(*expected.Tasks)[0].WorkingDir = String("working/task")
+	condition := (*expected.Tasks)[0].Condition.(*CatalogServicesConditionConfig)
+	condition.Partition = String("")
+	condition.Peer = String("")
+	moduleInput := (*(*expected.Tasks)[0].ModuleInputs)[0].(*ConsulKVModuleInputConfig)
+	moduleInput.Partition = String("")
+	moduleInput.Decode = String("")
+	moduleInput.Alias = String("")
 	(*expected.DeprecatedServices)[0].ID = String("serviceA")
 	(*expected.DeprecatedServices)[0].Namespace = String("")
 	(*expected.DeprecatedServices)[0].Datacenter = String("")
@@ -439,6 +461,53 @@ func TestConfig_Validate(t *testing.T) {
 	*validMultiTask.TerraformProviders = append(*validMultiTask.TerraformProviders,
 		&TerraformProviderConfig{"Y": map[string]interface{}{}})
 
+	// exec driver configured instead of the Terraform driver
+	validExec := longConfig.Copy()
+	validExec.Driver = &DriverConfig{
+		Exec: &ExecConfig{Command: String("deploy")},
+	}
+
+	// exec driver does not support destroying resources on task deletion
+	execDestroy := validExec.Copy()
+	(*execDestroy.Tasks)[0].DestroyOnDelete = Bool(true)
+
+	// task pinned to its own Terraform version
+	taskTFVersion := valid.Copy()
+	(*taskTFVersion.Tasks)[0].DeprecatedTFVersion = String("1.2.0")
+
+	// only the Terraform driver supports a Terraform version per task
+	execTFVersion := validExec.Copy()
+	(*execTFVersion.Tasks)[0].DeprecatedTFVersion = String("1.2.0")
+
+	openTofuTFVersion := taskTFVersion.Copy()
+	openTofuTFVersion.Driver.Terraform.Flavor = String(TerraformFlavorOpenTofu)
+	openTofuTFVersion.Driver.Terraform.Version = String("")
+
+	// git client requires the git output to be configured
+	gitClient := valid.Copy()
+	gitClient.ClientType = String(GitClientType)
+	gitClient.Driver.Terraform.GitOutput = &TerraformGitOutputConfig{
+		Path: String("cts-output"),
+	}
+
+	gitClientMissingPath := valid.Copy()
+	gitClientMissingPath.ClientType = String(GitClientType)
+
+	gitClientExec := validExec.Copy()
+	gitClientExec.ClientType = String(GitClientType)
+
+	// git client would commit the secret data of a vault module_input
+	gitClientVault := gitClient.Copy()
+	gitClientVault.Vault = &VaultConfig{Address: String("vault.example.com")}
+	gitClientVault.Vault.Finalize()
+	(*gitClientVault.Tasks)[0].ModuleInputs = &ModuleInputConfigs{
+		&VaultModuleInputConfig{Path: String("secret/my-app")},
+	}
+
+	// file_format is only supported by the Terraform driver
+	execFileFormat := validExec.Copy()
+	(*execFileFormat.Tasks)[0].FileFormat = String(FileFormatJSON)
+
 	cases := []struct {
 		name    string
 		i       *Config
@@ -468,6 +537,46 @@ func TestConfig_Validate(t *testing.T) {
 			"autocommitting provider reuse error",
 			autoCommit.Copy(),
 			false,
+		}, {
+			"exec driver valid",
+			validExec.Copy(),
+			true,
+		}, {
+			"exec driver destroy on delete",
+			execDestroy.Copy(),
+			false,
+		}, {
+			"task terraform version",
+			taskTFVersion.Copy(),
+			true,
+		}, {
+			"exec driver task terraform version",
+			execTFVersion.Copy(),
+			false,
+		}, {
+			"opentofu task terraform version",
+			openTofuTFVersion.Copy(),
+			false,
+		}, {
+			"git client",
+			gitClient.Copy(),
+			true,
+		}, {
+			"git client missing path",
+			gitClientMissingPath.Copy(),
+			false,
+		}, {
+			"git client exec driver",
+			gitClientExec.Copy(),
+			false,
+		}, {
+			"git client vault module_input",
+			gitClientVault.Copy(),
+			false,
+		}, {
+			"exec file format",
+			execFileFormat.Copy(),
+			false,
 		},
 	}
 
@@ -571,6 +680,200 @@ func TestConfig_validateDynamicConfig(t *testing.T) {
 	}
 }
 
+func TestConfig_ValidateTaskVault(t *testing.T) {
+	vaultTask := &TaskConfig{
+		Name: String("task"),
+		ModuleInputs: &ModuleInputConfigs{
+			&VaultModuleInputConfig{Path: String("secret/my-app")},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		c       *Config
+		task    *TaskConfig
+		isValid bool
+	}{
+		{
+			"no vault module_input",
+			&Config{},
+			&TaskConfig{Name: String("task")},
+			true,
+		}, {
+			"vault module_input",
+			&Config{
+				Vault: &VaultConfig{Address: String("vault.example.com")},
+			},
+			vaultTask,
+			true,
+		}, {
+			"vault module_input missing vault",
+			&Config{},
+			vaultTask,
+			false,
+		}, {
+			"vault module_input git client",
+			&Config{
+				Vault:      &VaultConfig{Address: String("vault.example.com")},
+				ClientType: String(GitClientType),
+			},
+			vaultTask,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.c.Finalize()
+			err := tc.c.ValidateTaskVault(tc.task)
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateTaskDriver(t *testing.T) {
+	testCases := []struct {
+		name    string
+		c       *Config
+		task    *TaskConfig
+		isValid bool
+	}{
+		{
+			"terraform destroy_on_delete",
+			&Config{Driver: &DriverConfig{Terraform: &TerraformConfig{}}},
+			&TaskConfig{Name: String("task"), DestroyOnDelete: Bool(true)},
+			true,
+		}, {
+			"exec destroy_on_delete",
+			&Config{Driver: &DriverConfig{Exec: &ExecConfig{}}},
+			&TaskConfig{Name: String("task"), DestroyOnDelete: Bool(true)},
+			false,
+		}, {
+			"webhook destroy_on_delete",
+			&Config{Driver: &DriverConfig{Webhook: &WebhookConfig{}}},
+			&TaskConfig{Name: String("task"), DestroyOnDelete: Bool(true)},
+			false,
+		}, {
+			"webhook file_format",
+			&Config{Driver: &DriverConfig{Webhook: &WebhookConfig{}}},
+			&TaskConfig{Name: String("task"), FileFormat: String(FileFormatJSON)},
+			false,
+		}, {
+			"exec defaults",
+			&Config{Driver: &DriverConfig{Exec: &ExecConfig{}}},
+			&TaskConfig{Name: String("task"), DestroyOnDelete: Bool(false)},
+			true,
+		}, {
+			"terraform terraform_version",
+			&Config{Driver: &DriverConfig{Terraform: &TerraformConfig{}}},
+			&TaskConfig{Name: String("task"), DeprecatedTFVersion: String("1.2.0")},
+			true,
+		}, {
+			"terraform unsupported terraform_version",
+			&Config{Driver: &DriverConfig{Terraform: &TerraformConfig{}}},
+			&TaskConfig{Name: String("task"), DeprecatedTFVersion: String("1.5.0")},
+			false,
+		}, {
+			"opentofu terraform_version",
+			&Config{Driver: &DriverConfig{Terraform: &TerraformConfig{
+				Flavor: String(TerraformFlavorOpenTofu),
+			}}},
+			&TaskConfig{Name: String("task"), DeprecatedTFVersion: String("1.2.0")},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.ValidateTaskDriver(tc.task)
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateTaskHTTP(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	testCases := []struct {
+		name    string
+		c       *Config
+		task    *TaskConfig
+		isValid bool
+	}{
+		{
+			"no headers git client",
+			&Config{ClientType: String(GitClientType)},
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &HTTPConditionConfig{
+					HTTPMonitorConfig: HTTPMonitorConfig{URL: String("https://example.com")},
+				},
+			},
+			true,
+		}, {
+			"condition headers",
+			&Config{},
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &HTTPConditionConfig{
+					HTTPMonitorConfig: HTTPMonitorConfig{
+						URL:     String("https://example.com"),
+						Headers: headers,
+					},
+				},
+			},
+			true,
+		}, {
+			"condition headers git client",
+			&Config{ClientType: String(GitClientType)},
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &HTTPConditionConfig{
+					HTTPMonitorConfig: HTTPMonitorConfig{
+						URL:     String("https://example.com"),
+						Headers: headers,
+					},
+				},
+			},
+			false,
+		}, {
+			"module_input headers git client",
+			&Config{ClientType: String(GitClientType)},
+			&TaskConfig{
+				Name: String("task"),
+				ModuleInputs: &ModuleInputConfigs{
+					&HTTPModuleInputConfig{
+						HTTPMonitorConfig: HTTPMonitorConfig{
+							URL:     String("https://example.com"),
+							Headers: headers,
+						},
+					},
+				},
+			},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.ValidateTaskHTTP(tc.task)
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 func TestConfig_BufferPeriod(t *testing.T) {
 	// Tests that global-level and task-level buffer period config are
 	// resolved as expected
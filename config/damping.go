@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultDampingMinStableTime is the default time that changes need to be
+// stable before triggering a task with damping configured.
+var DefaultDampingMinStableTime = 30 * time.Second
+
+// DampingConfig configures damping of the changes that trigger a task with a
+// services condition. Changes to the service instances are suppressed until
+// the number of changed instances exceeds a threshold or until the changes
+// have been stable for a minimum time, for no longer than a maximum time.
+type DampingConfig struct {
+	// Threshold is the number of changed service instances that needs to be
+	// exceeded to trigger the task without waiting. Zero disables the
+	// threshold.
+	Threshold *int `mapstructure:"threshold"`
+
+	// ThresholdPercent is the percentage of changed service instances that
+	// needs to be exceeded to trigger the task without waiting. Zero disables
+	// the threshold.
+	ThresholdPercent *int `mapstructure:"threshold_percent"`
+
+	// MinStableTime is the time without new changes to wait before triggering
+	// the task for suppressed changes.
+	MinStableTime *time.Duration `mapstructure:"min_stable_time"`
+
+	// MaxSuppressionTime is the maximum time that changes can be suppressed
+	// before triggering the task, even if the changes are not yet stable.
+	MaxSuppressionTime *time.Duration `mapstructure:"max_suppression_time"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *DampingConfig) Copy() *DampingConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o DampingConfig
+	o.Threshold = IntCopy(c.Threshold)
+	o.ThresholdPercent = IntCopy(c.ThresholdPercent)
+	o.MinStableTime = TimeDurationCopy(c.MinStableTime)
+	o.MaxSuppressionTime = TimeDurationCopy(c.MaxSuppressionTime)
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *DampingConfig) Merge(o *DampingConfig) *DampingConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Threshold != nil {
+		r.Threshold = IntCopy(o.Threshold)
+	}
+
+	if o.ThresholdPercent != nil {
+		r.ThresholdPercent = IntCopy(o.ThresholdPercent)
+	}
+
+	if o.MinStableTime != nil {
+		r.MinStableTime = TimeDurationCopy(o.MinStableTime)
+	}
+
+	if o.MaxSuppressionTime != nil {
+		r.MaxSuppressionTime = TimeDurationCopy(o.MaxSuppressionTime)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers. Damping is not configured by
+// default, a nil configuration remains nil.
+func (c *DampingConfig) Finalize() {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.Threshold == nil {
+		c.Threshold = Int(0)
+	}
+
+	if c.ThresholdPercent == nil {
+		c.ThresholdPercent = Int(0)
+	}
+
+	if c.MinStableTime == nil {
+		c.MinStableTime = TimeDuration(DefaultDampingMinStableTime)
+	}
+
+	if c.MaxSuppressionTime == nil {
+		c.MaxSuppressionTime = TimeDuration(10 * *c.MinStableTime)
+	}
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *DampingConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	if IntVal(c.Threshold) < 0 || IntVal(c.ThresholdPercent) < 0 {
+		return fmt.Errorf("damping: threshold cannot be negative")
+	}
+
+	if IntVal(c.ThresholdPercent) > 100 {
+		return fmt.Errorf("damping: threshold_percent cannot be greater than 100")
+	}
+
+	if TimeDurationVal(c.MinStableTime) < 0 || TimeDurationVal(c.MaxSuppressionTime) < 0 {
+		return fmt.Errorf("damping: durations cannot be negative")
+	}
+
+	if TimeDurationVal(c.MaxSuppressionTime) < TimeDurationVal(c.MinStableTime) {
+		return fmt.Errorf("damping: min_stable_time must be less than max_suppression_time")
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *DampingConfig) GoString() string {
+	if c == nil {
+		return "(*DampingConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&DampingConfig{"+
+		"Threshold:%d, "+
+		"ThresholdPercent:%d, "+
+		"MinStableTime:%s, "+
+		"MaxSuppressionTime:%s"+
+		"}",
+		IntVal(c.Threshold),
+		IntVal(c.ThresholdPercent),
+		TimeDurationVal(c.MinStableTime),
+		TimeDurationVal(c.MaxSuppressionTime),
+	)
+}
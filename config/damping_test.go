@@ -0,0 +1,255 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDampingConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &DampingConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *DampingConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&DampingConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&DampingConfig{
+				Threshold:          Int(5),
+				ThresholdPercent:   Int(20),
+				MinStableTime:      TimeDuration(time.Minute),
+				MaxSuppressionTime: TimeDuration(5 * time.Minute),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestDampingConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *DampingConfig
+		b    *DampingConfig
+		r    *DampingConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&DampingConfig{},
+			&DampingConfig{},
+		},
+		{
+			"nil_b",
+			&DampingConfig{},
+			nil,
+			&DampingConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&DampingConfig{},
+			&DampingConfig{},
+			&DampingConfig{},
+		},
+		{
+			"threshold_overrides",
+			&DampingConfig{Threshold: Int(5)},
+			&DampingConfig{Threshold: Int(10)},
+			&DampingConfig{Threshold: Int(10)},
+		},
+		{
+			"threshold_percent_empty_one",
+			&DampingConfig{ThresholdPercent: Int(20)},
+			&DampingConfig{},
+			&DampingConfig{ThresholdPercent: Int(20)},
+		},
+		{
+			"durations_merge",
+			&DampingConfig{MinStableTime: TimeDuration(time.Minute)},
+			&DampingConfig{MaxSuppressionTime: TimeDuration(5 * time.Minute)},
+			&DampingConfig{
+				MinStableTime:      TimeDuration(time.Minute),
+				MaxSuppressionTime: TimeDuration(5 * time.Minute),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestDampingConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *DampingConfig
+		r    *DampingConfig
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&DampingConfig{},
+			&DampingConfig{
+				Threshold:          Int(0),
+				ThresholdPercent:   Int(0),
+				MinStableTime:      TimeDuration(DefaultDampingMinStableTime),
+				MaxSuppressionTime: TimeDuration(10 * DefaultDampingMinStableTime),
+			},
+		},
+		{
+			"min_stable_time_configured",
+			&DampingConfig{
+				Threshold:     Int(5),
+				MinStableTime: TimeDuration(time.Minute),
+			},
+			&DampingConfig{
+				Threshold:          Int(5),
+				ThresholdPercent:   Int(0),
+				MinStableTime:      TimeDuration(time.Minute),
+				MaxSuppressionTime: TimeDuration(10 * time.Minute),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestDampingConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *DampingConfig
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		},
+		{
+			"valid",
+			&DampingConfig{
+				Threshold:          Int(5),
+				ThresholdPercent:   Int(20),
+				MinStableTime:      TimeDuration(time.Minute),
+				MaxSuppressionTime: TimeDuration(5 * time.Minute),
+			},
+			true,
+		},
+		{
+			"negative_threshold",
+			&DampingConfig{Threshold: Int(-1)},
+			false,
+		},
+		{
+			"threshold_percent_over_100",
+			&DampingConfig{ThresholdPercent: Int(101)},
+			false,
+		},
+		{
+			"negative_duration",
+			&DampingConfig{MinStableTime: TimeDuration(-time.Second)},
+			false,
+		},
+		{
+			"max_less_than_min",
+			&DampingConfig{
+				MinStableTime:      TimeDuration(time.Minute),
+				MaxSuppressionTime: TimeDuration(time.Second),
+			},
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize()
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestDampingConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *DampingConfig
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"(*DampingConfig)(nil)",
+		},
+		{
+			"fully_configured",
+			&DampingConfig{
+				Threshold:          Int(5),
+				ThresholdPercent:   Int(20),
+				MinStableTime:      TimeDuration(time.Minute),
+				MaxSuppressionTime: TimeDuration(5 * time.Minute),
+			},
+			"&DampingConfig{Threshold:5, ThresholdPercent:20, " +
+				"MinStableTime:1m0s, MaxSuppressionTime:5m0s}",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.i.GoString()
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
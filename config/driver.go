@@ -1,6 +1,9 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // DriverConfig is the configuration for the CTS driver used to execute
 // infrastructure updates.
@@ -8,6 +11,8 @@ type DriverConfig struct {
 	consul *ConsulConfig
 
 	Terraform *TerraformConfig `mapstructure:"terraform"`
+	Exec      *ExecConfig      `mapstructure:"exec"`
+	Webhook   *WebhookConfig   `mapstructure:"webhook"`
 }
 
 // DefaultDriverConfig returns the default configuration struct.
@@ -31,6 +36,14 @@ func (c *DriverConfig) Copy() *DriverConfig {
 		o.Terraform = c.Terraform.Copy()
 	}
 
+	if c.Exec != nil {
+		o.Exec = c.Exec.Copy()
+	}
+
+	if c.Webhook != nil {
+		o.Webhook = c.Webhook.Copy()
+	}
+
 	return &o
 }
 
@@ -60,16 +73,31 @@ func (c *DriverConfig) Merge(o *DriverConfig) *DriverConfig {
 		r.Terraform = r.Terraform.Merge(o.Terraform)
 	}
 
+	if o.Exec != nil {
+		r.Exec = r.Exec.Merge(o.Exec)
+	}
+
+	if o.Webhook != nil {
+		r.Webhook = r.Webhook.Merge(o.Webhook)
+	}
+
 	return r
 }
 
-// Finalize ensures there no nil pointers.
+// Finalize ensures there no nil pointers. The Terraform driver is the default
+// driver when no other driver is configured.
 func (c *DriverConfig) Finalize() {
 	if c == nil {
 		return
 	}
 
+	c.Exec.Finalize()
+	c.Webhook.Finalize()
+
 	if c.Terraform == nil {
+		if c.Exec != nil || c.Webhook != nil {
+			return
+		}
 		c.Terraform = DefaultTerraformConfig()
 	}
 	c.Terraform.Finalize(c.consul)
@@ -81,7 +109,29 @@ func (c *DriverConfig) Validate() error {
 		return fmt.Errorf("missing driver configuration")
 	}
 
-	return c.Terraform.Validate()
+	var drivers []string
+	if c.Terraform != nil {
+		drivers = append(drivers, "terraform")
+	}
+	if c.Exec != nil {
+		drivers = append(drivers, "exec")
+	}
+	if c.Webhook != nil {
+		drivers = append(drivers, "webhook")
+	}
+	if len(drivers) > 1 {
+		return fmt.Errorf("only one driver can be configured, found: %s",
+			strings.Join(drivers, ", "))
+	}
+
+	switch {
+	case c.Exec != nil:
+		return c.Exec.Validate()
+	case c.Webhook != nil:
+		return c.Webhook.Validate()
+	default:
+		return c.Terraform.Validate()
+	}
 }
 
 // GoString defines the printable version of this struct.
@@ -91,8 +141,12 @@ func (c *DriverConfig) GoString() string {
 	}
 
 	return fmt.Sprintf("&DriverConfig{"+
-		"Terraform:%s"+
+		"Terraform:%s, "+
+		"Exec:%s, "+
+		"Webhook:%s"+
 		"}",
 		c.Terraform.GoString(),
+		c.Exec.GoString(),
+		c.Webhook.GoString(),
 	)
 }
@@ -34,6 +34,11 @@ func TestDriverConfig_Copy(t *testing.T) {
 				consul:    &ConsulConfig{Address: String("localhost:8500")},
 				Terraform: &TerraformConfig{Log: Bool(true)},
 			},
+		}, {
+			"exec",
+			&DriverConfig{
+				Exec: &ExecConfig{Command: String("deploy")},
+			},
 		},
 	}
 
@@ -126,6 +131,24 @@ func TestDriverConfig_Merge(t *testing.T) {
 			&DriverConfig{Terraform: &TerraformConfig{Log: Bool(true)}},
 			&DriverConfig{Terraform: &TerraformConfig{Log: Bool(true)}},
 		},
+		{
+			"exec_overrides",
+			&DriverConfig{Exec: &ExecConfig{Command: String("a")}},
+			&DriverConfig{Exec: &ExecConfig{Command: String("b")}},
+			&DriverConfig{Exec: &ExecConfig{Command: String("b")}},
+		},
+		{
+			"exec_empty_one",
+			&DriverConfig{Exec: &ExecConfig{Command: String("a")}},
+			&DriverConfig{},
+			&DriverConfig{Exec: &ExecConfig{Command: String("a")}},
+		},
+		{
+			"webhook_overrides",
+			&DriverConfig{Webhook: &WebhookConfig{URL: String("http://a")}},
+			&DriverConfig{Webhook: &WebhookConfig{URL: String("http://b")}},
+			&DriverConfig{Webhook: &WebhookConfig{URL: String("http://b")}},
+		},
 	}
 
 	for i, tc := range cases {
@@ -162,6 +185,15 @@ func TestDriverConfig_Finalize(t *testing.T) {
 					Path:              String(wd),
 					Backend:           map[string]interface{}{},
 					RequiredProviders: map[string]interface{}{},
+					Flavor:            String(TerraformFlavorTerraform),
+					BinaryName:        String(DefaultTerraformBinaryName),
+					ArchivePath:       String(""),
+					PluginCacheDir:    String(""),
+					Mirror:            DefaultTerraformMirrorConfig(),
+					Checksums:         []string{},
+					ChecksumsFile:     String(""),
+					FileFormat:        String(FileFormatHCL),
+					GitOutput:         DefaultTerraformGitOutputConfig(),
 				},
 			},
 		},
@@ -180,9 +212,42 @@ func TestDriverConfig_Finalize(t *testing.T) {
 					Path:              String(wd),
 					Backend:           map[string]interface{}{},
 					RequiredProviders: map[string]interface{}{},
+					Flavor:            String(TerraformFlavorTerraform),
+					BinaryName:        String(DefaultTerraformBinaryName),
+					ArchivePath:       String(""),
+					PluginCacheDir:    String(""),
+					Mirror:            DefaultTerraformMirrorConfig(),
+					Checksums:         []string{},
+					ChecksumsFile:     String(""),
+					FileFormat:        String(FileFormatHCL),
+					GitOutput:         DefaultTerraformGitOutputConfig(),
 				},
 			},
 		},
+		{
+			"with_exec",
+			&DriverConfig{
+				Exec: &ExecConfig{
+					Command: String("deploy"),
+				},
+			},
+			&DriverConfig{
+				Exec: &ExecConfig{
+					Command: String("deploy"),
+					Args:    []string{},
+					Timeout: TimeDuration(DefaultExecTimeout),
+				},
+			},
+		},
+		{
+			"with_webhook",
+			&DriverConfig{
+				Webhook: &WebhookConfig{},
+			},
+			&DriverConfig{
+				Webhook: DefaultWebhookConfig(),
+			},
+		},
 	}
 
 	for i, tc := range cases {
@@ -217,6 +282,36 @@ func TestDriverConfig_Validate(t *testing.T) {
 			"terraform_invalid",
 			&DriverConfig{Terraform: &TerraformConfig{}},
 			false,
+		}, {
+			"exec_valid",
+			&DriverConfig{Exec: &ExecConfig{Command: String("deploy")}},
+			true,
+		}, {
+			"exec_invalid",
+			&DriverConfig{Exec: &ExecConfig{}},
+			false,
+		}, {
+			"webhook_valid",
+			&DriverConfig{Webhook: &WebhookConfig{URL: String("https://example.com")}},
+			true,
+		}, {
+			"webhook_invalid",
+			&DriverConfig{Webhook: &WebhookConfig{}},
+			false,
+		}, {
+			"exec_and_webhook",
+			&DriverConfig{
+				Exec:    &ExecConfig{Command: String("deploy")},
+				Webhook: &WebhookConfig{URL: String("https://example.com")},
+			},
+			false,
+		}, {
+			"multiple_drivers",
+			&DriverConfig{
+				Terraform: &TerraformConfig{Backend: map[string]interface{}{"consul": nil}},
+				Exec:      &ExecConfig{Command: String("deploy")},
+			},
+			false,
 		},
 	}
 
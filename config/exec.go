@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultExecTimeout is the default maximum amount of time the exec driver
+// waits for the command to complete for a task run.
+const DefaultExecTimeout = 5 * time.Minute
+
+// ExecConfig is the configuration for the exec driver. The exec driver runs
+// the configured command for a task with the task's rendered Consul data as
+// JSON on stdin.
+type ExecConfig struct {
+	// Command is the path to the executable to run for a task.
+	Command *string `mapstructure:"command"`
+
+	// Args are the arguments to pass to the command.
+	Args []string `mapstructure:"args"`
+
+	// Timeout is the maximum amount of time to wait for the command to complete.
+	// The command is killed if it does not complete within the timeout.
+	Timeout *time.Duration `mapstructure:"timeout"`
+}
+
+// DefaultExecConfig returns the default configuration struct.
+func DefaultExecConfig() *ExecConfig {
+	return &ExecConfig{
+		Command: String(""),
+		Args:    []string{},
+		Timeout: TimeDuration(DefaultExecTimeout),
+	}
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *ExecConfig) Copy() *ExecConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o ExecConfig
+
+	if c.Command != nil {
+		o.Command = StringCopy(c.Command)
+	}
+
+	if c.Args != nil {
+		o.Args = make([]string, 0, len(c.Args))
+		o.Args = append(o.Args, c.Args...)
+	}
+
+	if c.Timeout != nil {
+		o.Timeout = TimeDurationCopy(c.Timeout)
+	}
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *ExecConfig) Merge(o *ExecConfig) *ExecConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Command != nil {
+		r.Command = StringCopy(o.Command)
+	}
+
+	if o.Args != nil {
+		// Arguments are positional, so the other configuration's arguments
+		// replace this configuration's arguments rather than being appended.
+		r.Args = make([]string, 0, len(o.Args))
+		r.Args = append(r.Args, o.Args...)
+	}
+
+	if o.Timeout != nil {
+		r.Timeout = TimeDurationCopy(o.Timeout)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *ExecConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.Command == nil {
+		c.Command = String("")
+	}
+
+	if c.Args == nil {
+		c.Args = []string{}
+	}
+
+	if c.Timeout == nil {
+		c.Timeout = TimeDuration(DefaultExecTimeout)
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct.
+func (c *ExecConfig) Validate() error {
+	if c == nil {
+		return fmt.Errorf("missing exec driver configuration")
+	}
+
+	if c.Command == nil || *c.Command == "" {
+		return fmt.Errorf("command for the exec driver is required")
+	}
+
+	if c.Timeout != nil && *c.Timeout <= 0 {
+		return fmt.Errorf("timeout for the exec driver must be greater than "+
+			"zero: %s", *c.Timeout)
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *ExecConfig) GoString() string {
+	if c == nil {
+		return "(*ExecConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&ExecConfig{"+
+		"Command:%s, "+
+		"Args:%v, "+
+		"Timeout:%s"+
+		"}",
+		StringVal(c.Command),
+		c.Args,
+		TimeDurationVal(c.Timeout),
+	)
+}
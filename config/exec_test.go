@@ -0,0 +1,217 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &ExecConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *ExecConfig
+	}{
+		{
+			"nil",
+			nil,
+		}, {
+			"empty",
+			&ExecConfig{},
+		}, {
+			"finalized",
+			finalizedConf,
+		}, {
+			"fully_configured",
+			&ExecConfig{
+				Command: String("/usr/local/bin/deploy"),
+				Args:    []string{"--verbose", "--check"},
+				Timeout: TimeDuration(time.Minute),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestExecConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *ExecConfig
+		b    *ExecConfig
+		r    *ExecConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&ExecConfig{},
+			&ExecConfig{},
+		},
+		{
+			"nil_b",
+			&ExecConfig{},
+			nil,
+			&ExecConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&ExecConfig{},
+			&ExecConfig{},
+			&ExecConfig{},
+		},
+		{
+			"command_overrides",
+			&ExecConfig{Command: String("a")},
+			&ExecConfig{Command: String("b")},
+			&ExecConfig{Command: String("b")},
+		},
+		{
+			"command_empty_one",
+			&ExecConfig{Command: String("a")},
+			&ExecConfig{},
+			&ExecConfig{Command: String("a")},
+		},
+		{
+			"args_overrides",
+			&ExecConfig{Args: []string{"-a", "-b"}},
+			&ExecConfig{Args: []string{"-c"}},
+			&ExecConfig{Args: []string{"-c"}},
+		},
+		{
+			"args_empty_one",
+			&ExecConfig{Args: []string{"-a"}},
+			&ExecConfig{},
+			&ExecConfig{Args: []string{"-a"}},
+		},
+		{
+			"timeout_overrides",
+			&ExecConfig{Timeout: TimeDuration(time.Minute)},
+			&ExecConfig{Timeout: TimeDuration(time.Second)},
+			&ExecConfig{Timeout: TimeDuration(time.Second)},
+		},
+		{
+			"timeout_empty_two",
+			&ExecConfig{},
+			&ExecConfig{Timeout: TimeDuration(time.Second)},
+			&ExecConfig{Timeout: TimeDuration(time.Second)},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestExecConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *ExecConfig
+		r    *ExecConfig
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&ExecConfig{},
+			&ExecConfig{
+				Command: String(""),
+				Args:    []string{},
+				Timeout: TimeDuration(DefaultExecTimeout),
+			},
+		},
+		{
+			"with_command",
+			&ExecConfig{
+				Command: String("deploy"),
+			},
+			&ExecConfig{
+				Command: String("deploy"),
+				Args:    []string{},
+				Timeout: TimeDuration(DefaultExecTimeout),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestExecConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *ExecConfig
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			false,
+		}, {
+			"valid",
+			&ExecConfig{
+				Command: String("deploy"),
+				Timeout: TimeDuration(time.Minute),
+			},
+			true,
+		}, {
+			"missing_command",
+			&ExecConfig{Timeout: TimeDuration(time.Minute)},
+			false,
+		}, {
+			"empty_command",
+			&ExecConfig{Command: String("")},
+			false,
+		}, {
+			"invalid_timeout",
+			&ExecConfig{
+				Command: String("deploy"),
+				Timeout: TimeDuration(0),
+			},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -16,8 +17,22 @@ import (
 // values as passed to the task module's input variable
 type ModuleInputConfig interface {
 	MonitorConfig
+
+	// VariableName returns the name of the Terraform variable that the module
+	// input is rendered as. A labelled module_input block e.g.
+	// `module_input "services" "backends"` is rendered as a variable named
+	// after its alias. Otherwise the variable is named after the variable type.
+	//
+	// Used to ensure requirement that the variables of a task are unique.
+	// Unlike variable types, multiple module_input blocks of the same type can
+	// be configured for a task with different aliases.
+	VariableName() string
 }
 
+// moduleInputAliasRegexp matches a valid alias of a module input, which has
+// to be a valid Terraform variable name
+var moduleInputAliasRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
 // ModuleInputConfigs is a collection of ModuleInputConfig
 type ModuleInputConfigs []ModuleInputConfig
 
@@ -64,19 +79,110 @@ func moduleInputToTypeFunc() mapstructure.DecodeHookFunc {
 			return decodeModuleInputToType(c, &config)
 		}
 
+		if c, ok := moduleInputs[nodesType]; ok {
+			var config NodesModuleInputConfig
+			return decodeModuleInputToType(c, &config)
+		}
+
+		if c, ok := moduleInputs[intentionsType]; ok {
+			var config IntentionsModuleInputConfig
+			return decodeModuleInputToType(c, &config)
+		}
+
+		if c, ok := moduleInputs[configEntriesType]; ok {
+			var config ConfigEntriesModuleInputConfig
+			return decodeModuleInputToType(c, &config)
+		}
+
+		if c, ok := moduleInputs[httpType]; ok {
+			var config HTTPModuleInputConfig
+			return decodeModuleInputToType(c, &config)
+		}
+
+		if c, ok := moduleInputs[vaultType]; ok {
+			var config VaultModuleInputConfig
+			return decodeModuleInputToType(c, &config)
+		}
+
 		return nil, fmt.Errorf("unsupported module_input type: %v", data)
 	}
 }
 
 // decodeModuleInputToType is used by the overall config mapstructure decode hook
 // ModuleInputToTypeFunc in order to convert ModuleInputConfig in the form
-// of an interface into an implementation
+// of an interface into an implementation. A labelled module_input block is
+// decoded with the label as the alias of the module input.
 func decodeModuleInputToType(data interface{}, moduleInput ModuleInputConfig) (ModuleInputConfig, error) {
+	unused, err := decodeModuleInputBody(data, moduleInput)
+	if err != nil {
+		return nil, err
+	}
+
+	// a labelled block decodes as a single unused key, the label, with the
+	// block body as the value
+	// data hcl ex: [map[backends:[map[regexp:.*]]]]
+	// data json ex: map[backends:map[regexp:.*]]
+	if label, body, ok := moduleInputLabel(data); ok &&
+		len(unused) == 1 && unused[0] == label {
+		if _, ok := body["alias"]; ok {
+			return nil, fmt.Errorf("module_input %q is labelled and cannot "+
+				"also configure alias", label)
+		}
+
+		labelled := make(map[string]interface{}, len(body)+1)
+		for k, v := range body {
+			labelled[k] = v
+		}
+		labelled["alias"] = label
+
+		if unused, err = decodeModuleInputBody(labelled, moduleInput); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		err := fmt.Errorf("invalid keys: %s", strings.Join(unused, ", "))
+		logging.Global().Named(logSystemName).Error(
+			"module_input invalid keys", "error", err)
+		return nil, err
+	}
+
+	return moduleInput, nil
+}
+
+// moduleInputLabel returns the label and the block body of a labelled
+// module_input block. Returns false if the data is not a single block.
+func moduleInputLabel(data interface{}) (string, map[string]interface{}, bool) {
+	if hcl, ok := data.([]map[string]interface{}); ok && len(hcl) == 1 {
+		data = hcl[0]
+	}
+
+	m, ok := data.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return "", nil, false
+	}
+
+	for label, v := range m {
+		if hcl, ok := v.([]map[string]interface{}); ok && len(hcl) == 1 {
+			return label, hcl[0], true
+		}
+		if json, ok := v.(map[string]interface{}); ok {
+			return label, json, true
+		}
+	}
+	return "", nil, false
+}
+
+// decodeModuleInputBody decodes the body of a module_input block into the
+// module input and returns the unused keys of the body
+func decodeModuleInputBody(data interface{}, moduleInput ModuleInputConfig) ([]string, error) {
 	var md mapstructure.Metadata
 	logger := logging.Global().Named(logSystemName)
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
 			decode.HookWeakDecodeFromSlice,
+			mapstructure.StringToTimeDurationHookFunc(),
 		),
 		WeaklyTypedInput: true,
 		ErrorUnused:      false,
@@ -93,18 +199,20 @@ func decodeModuleInputToType(data interface{}, moduleInput ModuleInputConfig) (M
 		return nil, err
 	}
 
-	if len(md.Unused) > 0 {
-		sort.Strings(md.Unused)
-		err := fmt.Errorf("invalid keys: %s", strings.Join(md.Unused, ", "))
-		logger.Error("module_input invalid keys", "error", err)
-		return nil, err
-	}
+	return md.Unused, nil
+}
 
-	return moduleInput, nil
+// moduleInputVariableName returns the alias of a module input if configured,
+// or the variable type of the module input otherwise
+func moduleInputVariableName(alias *string, variableType string) string {
+	if alias != nil && *alias != "" {
+		return *alias
+	}
+	return variableType
 }
 
 // isModuleInputNil returns true if the module input is nil and false otherwise
-func isModuleInputNil(si ModuleInputConfig) bool {
+func isModuleInputNil(si MonitorConfig) bool {
 	return isMonitorNil(si)
 }
 
@@ -125,7 +233,9 @@ func (c *ModuleInputConfigs) Copy() *ModuleInputConfigs {
 
 	o := make(ModuleInputConfigs, c.Len())
 	for i, t := range *c {
-		o[i] = t.Copy()
+		if input, ok := t.Copy().(ModuleInputConfig); ok {
+			o[i] = input
+		}
 	}
 	return &o
 }
@@ -176,15 +286,31 @@ func (c *ModuleInputConfigs) Validate(services []string, condition ConditionConf
 
 	logger := logging.Global().Named(logSystemName).Named(taskSubsystemName)
 
-	// Confirm module_inputs's type is unique across module_inputs
+	// Confirm module_inputs's variable is unique across module_inputs. Module
+	// inputs of the same type need different aliases
+	varNames := make(map[string]bool)
 	varTypes := make(map[string]bool)
 	for _, input := range *c {
-		varType := input.VariableType()
-		if ok := varTypes[varType]; ok {
+		varName := input.VariableName()
+		if varName != input.VariableType() {
+			if !moduleInputAliasRegexp.MatchString(varName) {
+				return fmt.Errorf("invalid alias %q for 'module_input' block. "+
+					"alias must be a valid Terraform variable name", varName)
+			}
+			if varName == servicesType {
+				return fmt.Errorf("invalid alias %q for 'module_input' block. "+
+					"the %q variable is reserved", varName, servicesType)
+			}
+		}
+
+		if ok := varNames[varName]; ok {
 			return fmt.Errorf("more than one 'module_input' block for the %q "+
-				"variable. variable types must be unique", varType)
+				"variable. variables must be unique, configure an alias e.g. "+
+				"`module_input \"%s\" \"<alias>\"` to monitor more than "+
+				"one of a type", varName, input.VariableType())
 		}
-		varTypes[varType] = true
+		varNames[varName] = true
+		varTypes[input.VariableType()] = true
 	}
 
 	// Confirm module_input types are different from task.services variable type
@@ -210,7 +336,11 @@ func (c *ModuleInputConfigs) Validate(services []string, condition ConditionConf
 	if condition == nil {
 		return nil
 	}
-	if ok := varTypes[condition.VariableType()]; ok {
+	// Module inputs of the condition's type are not allowed even with an
+	// alias, since the condition's notifier cannot tell apart changes to the
+	// module input from changes to the condition, and module inputs must not
+	// trigger the task
+	if varNames[condition.VariableType()] || varTypes[condition.VariableType()] {
 		err := fmt.Errorf("task's condition block and module_input block "+
 			"both monitor %q variable type. condition and module_input "+
 			"variable type must be unique", condition.VariableType())
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ ModuleInputConfig = (*ConfigEntriesModuleInputConfig)(nil)
+
+// ConfigEntriesModuleInputConfig configures a module_input configuration
+// block of type 'config-entries'. The Consul configuration entries will be
+// used as input for the module variables.
+type ConfigEntriesModuleInputConfig struct {
+	ConfigEntriesMonitorConfig `mapstructure:",squash"`
+
+	// Alias is the label of a labelled module_input block. The module input
+	// is rendered as a Terraform variable named after the alias instead of
+	// the variable type.
+	Alias *string `mapstructure:"alias"`
+}
+
+// VariableName returns the alias of the module input if configured, or the
+// variable type otherwise
+func (c *ConfigEntriesModuleInputConfig) VariableName() string {
+	return moduleInputVariableName(c.Alias, c.VariableType())
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *ConfigEntriesModuleInputConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	svc, ok := c.ConfigEntriesMonitorConfig.Copy().(*ConfigEntriesMonitorConfig)
+	if !ok {
+		return nil
+	}
+	return &ConfigEntriesModuleInputConfig{
+		ConfigEntriesMonitorConfig: *svc,
+		Alias:                      StringCopy(c.Alias),
+	}
+}
+
+// Merge combines all values in this configuration `c` with the values in the other
+// configuration `o`, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *ConfigEntriesModuleInputConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isModuleInputNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isModuleInputNil(o) {
+		return c.Copy()
+	}
+
+	scc, ok := o.(*ConfigEntriesModuleInputConfig)
+	if !ok {
+		return nil
+	}
+
+	merged, ok := c.ConfigEntriesMonitorConfig.Merge(&scc.ConfigEntriesMonitorConfig).(*ConfigEntriesMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	alias := StringCopy(c.Alias)
+	if scc.Alias != nil {
+		alias = StringCopy(scc.Alias)
+	}
+
+	return &ConfigEntriesModuleInputConfig{
+		ConfigEntriesMonitorConfig: *merged,
+		Alias:                      alias,
+	}
+}
+
+// Finalize ensures there are no nil pointers.
+func (c *ConfigEntriesModuleInputConfig) Finalize() {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.Alias == nil {
+		c.Alias = String("")
+	}
+	c.ConfigEntriesMonitorConfig.Finalize()
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *ConfigEntriesModuleInputConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+	return c.ConfigEntriesMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *ConfigEntriesModuleInputConfig) GoString() string {
+	if c == nil {
+		return "(*ConfigEntriesModuleInputConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&ConfigEntriesModuleInputConfig{"+
+		"%s, "+
+		"Alias:%s"+
+		"}",
+		c.ConfigEntriesMonitorConfig.GoString(),
+		StringVal(c.Alias),
+	)
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigEntriesModuleInputConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &ConfigEntriesModuleInputConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *ConfigEntriesModuleInputConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&ConfigEntriesModuleInputConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&ConfigEntriesModuleInputConfig{
+				ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+					Kind:       String("terminating-gateway"),
+					Name:       String("terminating"),
+					Datacenter: String("dc2"),
+					Namespace:  String("ns2"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestConfigEntriesModuleInputConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *ConfigEntriesModuleInputConfig
+		b    *ConfigEntriesModuleInputConfig
+		r    *ConfigEntriesModuleInputConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&ConfigEntriesModuleInputConfig{},
+			&ConfigEntriesModuleInputConfig{},
+		},
+		{
+			"nil_b",
+			&ConfigEntriesModuleInputConfig{},
+			nil,
+			&ConfigEntriesModuleInputConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"kind_overrides",
+			&ConfigEntriesModuleInputConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Kind: String("same")}},
+			&ConfigEntriesModuleInputConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Kind: String("different")}},
+			&ConfigEntriesModuleInputConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Kind: String("different")}},
+		},
+		{
+			"regexp_empty_one",
+			&ConfigEntriesModuleInputConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Regexp: String("same")}},
+			&ConfigEntriesModuleInputConfig{},
+			&ConfigEntriesModuleInputConfig{ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{Regexp: String("same")}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestConfigEntriesModuleInputConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	i := &ConfigEntriesModuleInputConfig{}
+	i.Finalize()
+	assert.Equal(t, &ConfigEntriesModuleInputConfig{
+		ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+			Kind:       String(""),
+			Name:       String(""),
+			Datacenter: String(""),
+			Namespace:  String(""),
+		},
+		Alias: String(""),
+	}, i)
+}
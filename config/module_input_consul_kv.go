@@ -11,6 +11,17 @@ var _ ModuleInputConfig = (*ConsulKVModuleInputConfig)(nil)
 // module variables.
 type ConsulKVModuleInputConfig struct {
 	ConsulKVMonitorConfig `mapstructure:",squash"`
+
+	// Alias is the label of a labelled module_input block. The module input
+	// is rendered as a Terraform variable named after the alias instead of
+	// the variable type.
+	Alias *string `mapstructure:"alias"`
+}
+
+// VariableName returns the alias of the module input if configured, or the
+// variable type otherwise
+func (c *ConsulKVModuleInputConfig) VariableName() string {
+	return moduleInputVariableName(c.Alias, c.VariableType())
 }
 
 // Copy returns a deep copy of this configuration.
@@ -25,6 +36,7 @@ func (c *ConsulKVModuleInputConfig) Copy() MonitorConfig {
 	}
 	return &ConsulKVModuleInputConfig{
 		ConsulKVMonitorConfig: *svc,
+		Alias:                 StringCopy(c.Alias),
 	}
 }
 
@@ -54,8 +66,14 @@ func (c *ConsulKVModuleInputConfig) Merge(o MonitorConfig) MonitorConfig {
 		return nil
 	}
 
+	alias := StringCopy(c.Alias)
+	if scc.Alias != nil {
+		alias = StringCopy(scc.Alias)
+	}
+
 	return &ConsulKVModuleInputConfig{
 		ConsulKVMonitorConfig: *merged,
+		Alias:                 alias,
 	}
 }
 
@@ -64,6 +82,10 @@ func (c *ConsulKVModuleInputConfig) Finalize() {
 	if c == nil { // config not required, return early
 		return
 	}
+
+	if c.Alias == nil {
+		c.Alias = String("")
+	}
 	c.ConsulKVMonitorConfig.Finalize()
 }
 
@@ -83,8 +105,10 @@ func (c *ConsulKVModuleInputConfig) GoString() string {
 	}
 
 	return fmt.Sprintf("&ConsulKVModuleInputConfig{"+
-		"%s"+
+		"%s, "+
+		"Alias:%s"+
 		"}",
 		c.ConsulKVMonitorConfig.GoString(),
+		StringVal(c.Alias),
 	)
 }
@@ -33,7 +33,7 @@ func TestConsulKVModuleInputConfig_Copy(t *testing.T) {
 		{
 			"fully_configured",
 			&ConsulKVModuleInputConfig{
-				ConsulKVMonitorConfig{
+				ConsulKVMonitorConfig: ConsulKVMonitorConfig{
 					Path:       String("key-path"),
 					Recurse:    Bool(true),
 					Datacenter: String("dc2"),
@@ -91,99 +91,99 @@ func TestConsulKVModuleInputConfig_Merge(t *testing.T) {
 		},
 		{
 			"path_overrides",
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Path: String("same")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Path: String("different")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Path: String("different")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("different")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("different")}},
 		},
 		{
 			"path_empty_one",
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Path: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("same")}},
 			&ConsulKVModuleInputConfig{},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Path: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("same")}},
 		},
 		{
 			"path_empty_two",
 			&ConsulKVModuleInputConfig{},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Path: String("same")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Path: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("same")}},
 		},
 		{
 			"path_empty_same",
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Path: String("same")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Path: String("same")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Path: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Path: String("same")}},
 		},
 		{
 			"recurse_overrides",
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Recurse: Bool(true)}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Recurse: Bool(false)}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Recurse: Bool(false)}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Recurse: Bool(true)}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Recurse: Bool(false)}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Recurse: Bool(false)}},
 		},
 		{
 			"recurse_empty_one",
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Recurse: Bool(true)}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Recurse: Bool(true)}},
 			&ConsulKVModuleInputConfig{},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Recurse: Bool(true)}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Recurse: Bool(true)}},
 		},
 		{
 			"recurse_empty_two",
 			&ConsulKVModuleInputConfig{},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Recurse: Bool(true)}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Recurse: Bool(true)}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Recurse: Bool(true)}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Recurse: Bool(true)}},
 		},
 		{
 			"recurse_empty_same",
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Recurse: Bool(true)}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Recurse: Bool(true)}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Recurse: Bool(true)}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Recurse: Bool(true)}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Recurse: Bool(true)}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Recurse: Bool(true)}},
 		},
 		{
 			"datacenter_overrides",
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Datacenter: String("same")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Datacenter: String("different")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Datacenter: String("different")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Datacenter: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Datacenter: String("different")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Datacenter: String("different")}},
 		},
 		{
 			"datacenter_empty_one",
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Datacenter: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Datacenter: String("same")}},
 			&ConsulKVModuleInputConfig{},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Datacenter: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Datacenter: String("same")}},
 		},
 		{
 			"datacenter_empty_two",
 			&ConsulKVModuleInputConfig{},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Datacenter: String("same")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Datacenter: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Datacenter: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Datacenter: String("same")}},
 		},
 		{
 			"datacenter_empty_same",
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Datacenter: String("same")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Datacenter: String("same")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Datacenter: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Datacenter: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Datacenter: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Datacenter: String("same")}},
 		},
 		{
 			"namespace_overrides",
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Namespace: String("same")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Namespace: String("different")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Namespace: String("different")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Namespace: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Namespace: String("different")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Namespace: String("different")}},
 		},
 		{
 			"namespace_empty_one",
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Namespace: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Namespace: String("same")}},
 			&ConsulKVModuleInputConfig{},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Namespace: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Namespace: String("same")}},
 		},
 		{
 			"namespace_empty_two",
 			&ConsulKVModuleInputConfig{},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Namespace: String("same")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Namespace: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Namespace: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Namespace: String("same")}},
 		},
 		{
 			"namespace_empty_same",
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Namespace: String("same")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Namespace: String("same")}},
-			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig{Namespace: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Namespace: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Namespace: String("same")}},
+			&ConsulKVModuleInputConfig{ConsulKVMonitorConfig: ConsulKVMonitorConfig{Namespace: String("same")}},
 		},
 	}
 
@@ -212,12 +212,15 @@ func TestConsulKVModuleInputConfig_Finalize(t *testing.T) {
 			"empty",
 			&ConsulKVModuleInputConfig{},
 			&ConsulKVModuleInputConfig{
-				ConsulKVMonitorConfig{
+				ConsulKVMonitorConfig: ConsulKVMonitorConfig{
 					Path:       String(""),
 					Recurse:    Bool(false),
 					Datacenter: String(""),
 					Namespace:  String(""),
+					Partition:  String(""),
+					Decode:     String(""),
 				},
+				Alias: String(""),
 			},
 		},
 	}
@@ -242,7 +245,7 @@ func TestConsulKVModuleInputConfig_Validate(t *testing.T) {
 			"happy_path",
 			false,
 			&ConsulKVModuleInputConfig{
-				ConsulKVMonitorConfig{
+				ConsulKVMonitorConfig: ConsulKVMonitorConfig{
 					Path:       String("key-path"),
 					Recurse:    Bool(true),
 					Datacenter: String("dc2"),
@@ -250,11 +253,32 @@ func TestConsulKVModuleInputConfig_Validate(t *testing.T) {
 				},
 			},
 		},
+		{
+			"decode",
+			false,
+			&ConsulKVModuleInputConfig{
+				ConsulKVMonitorConfig: ConsulKVMonitorConfig{
+					Path:    String("key-path"),
+					Recurse: Bool(true),
+					Decode:  String(DecodeYAML),
+				},
+			},
+		},
 		{
 			"nil_path",
 			true,
 			&ConsulKVModuleInputConfig{},
 		},
+		{
+			"invalid_decode",
+			true,
+			&ConsulKVModuleInputConfig{
+				ConsulKVMonitorConfig: ConsulKVMonitorConfig{
+					Path:   String("key-path"),
+					Decode: String("toml"),
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -280,11 +304,13 @@ func TestConsulKVModuleInputConfig_GoString(t *testing.T) {
 		{
 			"configured services module_input",
 			&ConsulKVModuleInputConfig{
-				ConsulKVMonitorConfig{
+				ConsulKVMonitorConfig: ConsulKVMonitorConfig{
 					Path:       String("path"),
 					Recurse:    Bool(true),
 					Datacenter: String("dc"),
 					Namespace:  String("ns"),
+					Partition:  String("ap"),
+					Decode:     String("hcl"),
 				},
 			},
 			"&ConsulKVModuleInputConfig{" +
@@ -293,7 +319,10 @@ func TestConsulKVModuleInputConfig_GoString(t *testing.T) {
 				"Recurse:true, " +
 				"Datacenter:dc, " +
 				"Namespace:ns, " +
-				"}" +
+				"Partition:ap, " +
+				"Decode:hcl, " +
+				"}, " +
+				"Alias:" +
 				"}",
 		},
 		{
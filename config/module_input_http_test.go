@@ -0,0 +1,133 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPModuleInputConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &HTTPModuleInputConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *HTTPModuleInputConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&HTTPModuleInputConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&HTTPModuleInputConfig{
+				HTTPMonitorConfig: HTTPMonitorConfig{
+					URL:      String("https://ipam.example.com/api/prefixes"),
+					Headers:  map[string]string{"Authorization": "Bearer token"},
+					Interval: TimeDuration(30 * time.Second),
+					Timeout:  TimeDuration(5 * time.Second),
+					Selector: String("$.data"),
+					TLS:      &TLSConfig{Verify: Bool(false)},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestHTTPModuleInputConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *HTTPModuleInputConfig
+		b    *HTTPModuleInputConfig
+		r    *HTTPModuleInputConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&HTTPModuleInputConfig{},
+			&HTTPModuleInputConfig{},
+		},
+		{
+			"nil_b",
+			&HTTPModuleInputConfig{},
+			nil,
+			&HTTPModuleInputConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"url_overrides",
+			&HTTPModuleInputConfig{HTTPMonitorConfig: HTTPMonitorConfig{URL: String("http://same")}},
+			&HTTPModuleInputConfig{HTTPMonitorConfig: HTTPMonitorConfig{URL: String("http://different")}},
+			&HTTPModuleInputConfig{HTTPMonitorConfig: HTTPMonitorConfig{URL: String("http://different")}},
+		},
+		{
+			"selector_empty_one",
+			&HTTPModuleInputConfig{HTTPMonitorConfig: HTTPMonitorConfig{Selector: String("$.data")}},
+			&HTTPModuleInputConfig{},
+			&HTTPModuleInputConfig{HTTPMonitorConfig: HTTPMonitorConfig{Selector: String("$.data")}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestHTTPModuleInputConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	finalizedTLS := DefaultTLSConfig()
+	finalizedTLS.Finalize()
+
+	i := &HTTPModuleInputConfig{}
+	i.Finalize()
+	assert.Equal(t, &HTTPModuleInputConfig{
+		HTTPMonitorConfig: HTTPMonitorConfig{
+			URL:      String(""),
+			Headers:  map[string]string{},
+			Interval: TimeDuration(DefaultHTTPInterval),
+			Timeout:  TimeDuration(DefaultHTTPTimeout),
+			Selector: String(""),
+			TLS:      finalizedTLS,
+		},
+		Alias: String(""),
+	}, i)
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntentionsModuleInputConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &IntentionsModuleInputConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *IntentionsModuleInputConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&IntentionsModuleInputConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&IntentionsModuleInputConfig{
+				IntentionsMonitorConfig: IntentionsMonitorConfig{
+					Datacenter: String("dc2"),
+					Namespace:  String("ns2"),
+					Filter:     String("DestinationName == \"api\""),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestIntentionsModuleInputConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *IntentionsModuleInputConfig
+		b    *IntentionsModuleInputConfig
+		r    *IntentionsModuleInputConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&IntentionsModuleInputConfig{},
+			&IntentionsModuleInputConfig{},
+		},
+		{
+			"nil_b",
+			&IntentionsModuleInputConfig{},
+			nil,
+			&IntentionsModuleInputConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"datacenter_overrides",
+			&IntentionsModuleInputConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Datacenter: String("same")}},
+			&IntentionsModuleInputConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Datacenter: String("different")}},
+			&IntentionsModuleInputConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Datacenter: String("different")}},
+		},
+		{
+			"filter_empty_one",
+			&IntentionsModuleInputConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Filter: String("same")}},
+			&IntentionsModuleInputConfig{},
+			&IntentionsModuleInputConfig{IntentionsMonitorConfig: IntentionsMonitorConfig{Filter: String("same")}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestIntentionsModuleInputConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	i := &IntentionsModuleInputConfig{}
+	i.Finalize()
+	assert.Equal(t, &IntentionsModuleInputConfig{
+		IntentionsMonitorConfig: IntentionsMonitorConfig{
+			Datacenter: String(""),
+			Namespace:  String(""),
+			Filter:     String(""),
+		},
+		Alias: String(""),
+	}, i)
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+)
+
+var _ ModuleInputConfig = (*NodesModuleInputConfig)(nil)
+
+// NodesModuleInputConfig configures a module_input configuration block of
+// type 'nodes'. The Consul catalog nodes will be used as input for the
+// module variables.
+type NodesModuleInputConfig struct {
+	NodesMonitorConfig `mapstructure:",squash"`
+
+	// Alias is the label of a labelled module_input block. The module input
+	// is rendered as a Terraform variable named after the alias instead of
+	// the variable type.
+	Alias *string `mapstructure:"alias"`
+}
+
+// VariableName returns the alias of the module input if configured, or the
+// variable type otherwise
+func (c *NodesModuleInputConfig) VariableName() string {
+	return moduleInputVariableName(c.Alias, c.VariableType())
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *NodesModuleInputConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	svc, ok := c.NodesMonitorConfig.Copy().(*NodesMonitorConfig)
+	if !ok {
+		return nil
+	}
+	return &NodesModuleInputConfig{
+		NodesMonitorConfig: *svc,
+		Alias:              StringCopy(c.Alias),
+	}
+}
+
+// Merge combines all values in this configuration `c` with the values in the other
+// configuration `o`, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *NodesModuleInputConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isModuleInputNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isModuleInputNil(o) {
+		return c.Copy()
+	}
+
+	scc, ok := o.(*NodesModuleInputConfig)
+	if !ok {
+		return nil
+	}
+
+	merged, ok := c.NodesMonitorConfig.Merge(&scc.NodesMonitorConfig).(*NodesMonitorConfig)
+	if !ok {
+		return nil
+	}
+
+	alias := StringCopy(c.Alias)
+	if scc.Alias != nil {
+		alias = StringCopy(scc.Alias)
+	}
+
+	return &NodesModuleInputConfig{
+		NodesMonitorConfig: *merged,
+		Alias:              alias,
+	}
+}
+
+// Finalize ensures there are no nil pointers.
+func (c *NodesModuleInputConfig) Finalize() {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.Alias == nil {
+		c.Alias = String("")
+	}
+	c.NodesMonitorConfig.Finalize()
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *NodesModuleInputConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+	return c.NodesMonitorConfig.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *NodesModuleInputConfig) GoString() string {
+	if c == nil {
+		return "(*NodesModuleInputConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&NodesModuleInputConfig{"+
+		"%s, "+
+		"Alias:%s"+
+		"}",
+		c.NodesMonitorConfig.GoString(),
+		StringVal(c.Alias),
+	)
+}
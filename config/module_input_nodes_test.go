@@ -0,0 +1,180 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodesModuleInputConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &NodesModuleInputConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *NodesModuleInputConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&NodesModuleInputConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&NodesModuleInputConfig{
+				NodesMonitorConfig: NodesMonitorConfig{
+					Datacenter: String("dc2"),
+					NodeMeta:   map[string]string{"key": "value"},
+					Filter:     String("Meta.env == \"prod\""),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestNodesModuleInputConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *NodesModuleInputConfig
+		b    *NodesModuleInputConfig
+		r    *NodesModuleInputConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&NodesModuleInputConfig{},
+			&NodesModuleInputConfig{},
+		},
+		{
+			"nil_b",
+			&NodesModuleInputConfig{},
+			nil,
+			&NodesModuleInputConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&NodesModuleInputConfig{},
+			&NodesModuleInputConfig{},
+			&NodesModuleInputConfig{},
+		},
+		{
+			"datacenter_overrides",
+			&NodesModuleInputConfig{NodesMonitorConfig: NodesMonitorConfig{Datacenter: String("same")}},
+			&NodesModuleInputConfig{NodesMonitorConfig: NodesMonitorConfig{Datacenter: String("different")}},
+			&NodesModuleInputConfig{NodesMonitorConfig: NodesMonitorConfig{Datacenter: String("different")}},
+		},
+		{
+			"filter_empty_one",
+			&NodesModuleInputConfig{NodesMonitorConfig: NodesMonitorConfig{Filter: String("same")}},
+			&NodesModuleInputConfig{},
+			&NodesModuleInputConfig{NodesMonitorConfig: NodesMonitorConfig{Filter: String("same")}},
+		},
+		{
+			"node_meta_merges",
+			&NodesModuleInputConfig{NodesMonitorConfig: NodesMonitorConfig{
+				NodeMeta: map[string]string{"key": "value"}}},
+			&NodesModuleInputConfig{NodesMonitorConfig: NodesMonitorConfig{
+				NodeMeta: map[string]string{"env": "prod"}}},
+			&NodesModuleInputConfig{NodesMonitorConfig: NodesMonitorConfig{
+				NodeMeta: map[string]string{"key": "value", "env": "prod"}}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestNodesModuleInputConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	n := &NodesModuleInputConfig{}
+	n.Finalize()
+	assert.Equal(t, &NodesModuleInputConfig{
+		NodesMonitorConfig: NodesMonitorConfig{
+			Datacenter: String(""),
+			NodeMeta:   map[string]string{},
+			Filter:     String(""),
+		},
+		Alias: String(""),
+	}, n)
+}
+
+func TestNodesModuleInputConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		n        *NodesModuleInputConfig
+		expected string
+	}{
+		{
+			"configured nodes module_input",
+			&NodesModuleInputConfig{
+				NodesMonitorConfig: NodesMonitorConfig{
+					Datacenter: String("dc"),
+					NodeMeta:   map[string]string{"key": "value"},
+					Filter:     String("filter"),
+				},
+			},
+			"&NodesModuleInputConfig{" +
+				"&NodesMonitorConfig{" +
+				"Datacenter:dc, " +
+				"NodeMeta:map[key:value], " +
+				"Filter:filter" +
+				"}, " +
+				"Alias:" +
+				"}",
+		},
+		{
+			"nil nodes module_input",
+			nil,
+			"(*NodesModuleInputConfig)(nil)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.n.GoString()
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -11,6 +11,17 @@ var _ ModuleInputConfig = (*ServicesModuleInputConfig)(nil)
 // the module variables.
 type ServicesModuleInputConfig struct {
 	ServicesMonitorConfig `mapstructure:",squash"`
+
+	// Alias is the label of a labelled module_input block. The module input
+	// is rendered as a Terraform variable named after the alias instead of
+	// the variable type.
+	Alias *string `mapstructure:"alias"`
+}
+
+// VariableName returns the alias of the module input if configured, or the
+// variable type otherwise
+func (c *ServicesModuleInputConfig) VariableName() string {
+	return moduleInputVariableName(c.Alias, c.VariableType())
 }
 
 // Copy returns a deep copy of this configuration.
@@ -25,6 +36,7 @@ func (c *ServicesModuleInputConfig) Copy() MonitorConfig {
 	}
 	return &ServicesModuleInputConfig{
 		ServicesMonitorConfig: *svc,
+		Alias:                 StringCopy(c.Alias),
 	}
 }
 
@@ -54,8 +66,14 @@ func (c *ServicesModuleInputConfig) Merge(o MonitorConfig) MonitorConfig {
 		return nil
 	}
 
+	alias := StringCopy(c.Alias)
+	if scc.Alias != nil {
+		alias = StringCopy(scc.Alias)
+	}
+
 	return &ServicesModuleInputConfig{
 		ServicesMonitorConfig: *merged,
+		Alias:                 alias,
 	}
 }
 
@@ -64,6 +82,10 @@ func (c *ServicesModuleInputConfig) Finalize() {
 	if c == nil { // config not required, return early
 		return
 	}
+
+	if c.Alias == nil {
+		c.Alias = String("")
+	}
 	c.ServicesMonitorConfig.Finalize()
 }
 
@@ -76,6 +98,13 @@ func (c *ServicesModuleInputConfig) Validate() error {
 	if err := c.ServicesMonitorConfig.Validate(); err != nil {
 		return fmt.Errorf("error validating `module_input \"services\"`: %s", err)
 	}
+
+	// the metadata is only rendered for the services variable
+	if c.VariableName() != c.VariableType() && len(c.CTSUserDefinedMeta) > 0 {
+		return fmt.Errorf("error validating `module_input \"services\" %q`: "+
+			"cts_user_defined_meta is not supported for a labelled "+
+			"module_input", c.VariableName())
+	}
 	return nil
 }
 
@@ -86,8 +115,10 @@ func (c *ServicesModuleInputConfig) GoString() string {
 	}
 
 	return fmt.Sprintf("&ServicesModuleInputConfig{"+
-		"%s"+
+		"%s, "+
+		"Alias:%s"+
 		"}",
 		c.ServicesMonitorConfig.GoString(),
+		StringVal(c.Alias),
 	)
 }
@@ -32,7 +32,7 @@ func TestServicesModuleInputConfig_Copy(t *testing.T) {
 		{
 			"happy_path",
 			&ServicesModuleInputConfig{
-				ServicesMonitorConfig{
+				ServicesMonitorConfig: ServicesMonitorConfig{
 					Regexp:     String("^web.*"),
 					Datacenter: String("dc"),
 					Namespace:  String("namespace"),
@@ -94,7 +94,7 @@ func TestServicesModuleInputConfig_Merge(t *testing.T) {
 		{
 			"happy_path",
 			&ServicesModuleInputConfig{
-				ServicesMonitorConfig{
+				ServicesMonitorConfig: ServicesMonitorConfig{
 					Regexp:             String("regexp"),
 					Datacenter:         String("datacenter_overriden"),
 					Namespace:          nil,
@@ -103,7 +103,7 @@ func TestServicesModuleInputConfig_Merge(t *testing.T) {
 				},
 			},
 			&ServicesModuleInputConfig{
-				ServicesMonitorConfig{
+				ServicesMonitorConfig: ServicesMonitorConfig{
 					Regexp:             nil,
 					Datacenter:         String("datacenter"),
 					Namespace:          String("namespace"),
@@ -112,7 +112,7 @@ func TestServicesModuleInputConfig_Merge(t *testing.T) {
 				},
 			},
 			&ServicesModuleInputConfig{
-				ServicesMonitorConfig{
+				ServicesMonitorConfig: ServicesMonitorConfig{
 					Regexp:             String("regexp"),
 					Datacenter:         String("datacenter"),
 					Namespace:          String("namespace"),
@@ -153,14 +153,18 @@ func TestServicesModuleInputConfig_Finalize(t *testing.T) {
 			"happy_path",
 			&ServicesModuleInputConfig{},
 			&ServicesModuleInputConfig{
-				ServicesMonitorConfig{
+				ServicesMonitorConfig: ServicesMonitorConfig{
 					Regexp:             nil,
 					Names:              []string{},
 					Datacenter:         String(""),
 					Namespace:          String(""),
+					Partition:          String(""),
+					Peer:               String(""),
 					Filter:             String(""),
+					Status:             String(HealthPassing),
 					CTSUserDefinedMeta: map[string]string{},
 				},
+				Alias: String(""),
 			},
 		},
 	}
@@ -185,7 +189,7 @@ func TestServicesModuleInputConfig_Validate(t *testing.T) {
 			"valid",
 			false,
 			&ServicesModuleInputConfig{
-				ServicesMonitorConfig{
+				ServicesMonitorConfig: ServicesMonitorConfig{
 					Regexp: String(".*"),
 				},
 			},
@@ -195,15 +199,36 @@ func TestServicesModuleInputConfig_Validate(t *testing.T) {
 			false,
 			nil,
 		},
+		{
+			"valid_alias",
+			false,
+			&ServicesModuleInputConfig{
+				ServicesMonitorConfig: ServicesMonitorConfig{
+					Regexp: String(".*"),
+				},
+				Alias: String("backends"),
+			},
+		},
 		{
 			"invalid",
 			true,
 			&ServicesModuleInputConfig{
-				ServicesMonitorConfig{
+				ServicesMonitorConfig: ServicesMonitorConfig{
 					Regexp: String("*"),
 				},
 			},
 		},
+		{
+			"invalid_alias_with_meta",
+			true,
+			&ServicesModuleInputConfig{
+				ServicesMonitorConfig: ServicesMonitorConfig{
+					Regexp:             String(".*"),
+					CTSUserDefinedMeta: map[string]string{"key": "value"},
+				},
+				Alias: String("backends"),
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -229,25 +254,34 @@ func TestGoString(t *testing.T) {
 		{
 			"configured services module_input",
 			&ServicesModuleInputConfig{
-				ServicesMonitorConfig{
+				ServicesMonitorConfig: ServicesMonitorConfig{
 					Regexp:     String("^api$"),
 					Datacenter: String("dc2"),
 					Namespace:  String("ns2"),
+					Partition:  String("ap2"),
+					Peer:       String("cluster-02"),
 					Filter:     String("some-filter"),
+					Status:     String("warning"),
 					CTSUserDefinedMeta: map[string]string{
 						"key": "value",
 					},
 				},
+				Alias: String("backends"),
 			},
 			"&ServicesModuleInputConfig{" +
 				"&ServicesMonitorConfig{" +
 				"Regexp:^api$, " +
 				"Names:[], " +
 				"Datacenter:dc2, " +
+				"Datacenters:[], " +
 				"Namespace:ns2, " +
+				"Partition:ap2, " +
+				"Peer:cluster-02, " +
 				"Filter:some-filter, " +
+				"Status:warning, " +
 				"CTSUserDefinedMeta:map[key:value]" +
-				"}" +
+				"}, " +
+				"Alias:backends" +
 				"}",
 		},
 		{
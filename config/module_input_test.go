@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,6 +19,8 @@ task {
 		regexp = ".*"
 		datacenter = "dc2"
 		namespace = "ns2"
+		partition = "ap2"
+		peer = "cluster-02"
 		filter = "some-filter"
 		cts_user_defined_meta {
 			key = "value"
@@ -38,9 +41,77 @@ task {
 	module_input "consul-kv" {
 		path = "key-path"
 		namespace = "ns2"
+		partition = "ap2"
 		datacenter = "dc2"
 		recurse = true
+		decode = "json"
 	}
+}`
+	testModuleInputNodesSuccess = `
+task {
+	name = "module_input_task"
+	module = "..."
+	condition "schedule" {
+		cron = "* * * * * * *"
+	}
+	module_input "nodes" {
+		datacenter = "dc2"
+		filter = "Meta.env == \"prod\""
+		node_meta {
+			key = "value"
+		}
+	}
+}`
+	testModuleInputIntentionsSuccess = `
+task {
+	name = "module_input_task"
+	module = "..."
+	condition "schedule" {
+		cron = "* * * * * * *"
+	}
+	module_input "intentions" {
+		datacenter = "dc2"
+		namespace = "ns2"
+		filter = "DestinationName == \"api\""
+	}
+}`
+	testModuleInputConfigEntriesSuccess = `
+task {
+	name = "module_input_task"
+	module = "..."
+	condition "schedule" {
+		cron = "* * * * * * *"
+	}
+	module_input "config-entries" {
+		kind = "service-defaults"
+		name = "api"
+	}
+}`
+	testModuleInputHTTPSuccess = `
+task {
+	name = "module_input_task"
+	module = "..."
+	condition "schedule" {
+		cron = "* * * * * * *"
+	}
+	module_input "http" {
+		url = "http://ipam.example.com/api/prefixes"
+		timeout = "5s"
+	}
+}`
+	testModuleInputVaultSuccess = `
+task {
+	name = "module_input_task"
+	module = "..."
+	condition "schedule" {
+		cron = "* * * * * * *"
+	}
+	module_input "vault" {
+		path = "secret/my-app"
+	}
+}
+vault {
+	address = "vault.example.com"
 }`
 	testModuleInputsSuccess = `
 task {
@@ -56,6 +127,26 @@ task {
 		path = "my/path"
 	}
 }`
+	testModuleInputsLabelledSuccess = `
+task {
+	name = "module_input_task"
+	module = "..."
+	condition "catalog-services" {
+		regexp = ".*"
+	}
+	module_input "services" "backends" {
+		names = ["web"]
+	}
+	module_input "services" "databases" {
+		names = ["db"]
+	}
+	module_input "services" {
+		names = ["api"]
+		cts_user_defined_meta {
+			key = "value"
+		}
+	}
+}`
 
 	// Errors
 	testModuleInputServicesUnsupportedFieldError = `
@@ -68,6 +159,18 @@ task {
 	condition "schedule" {
 		cron = "* * * * * * *"
 	}
+}`
+	testModuleInputLabelledAliasError = `
+task {
+	name = "module_input_task"
+	module = "..."
+	module_input "services" "backends" {
+		names = ["web"]
+		alias = "databases"
+	}
+	condition "schedule" {
+		cron = "* * * * * * *"
+	}
 }`
 	testModuleInputConsulKVUnsupportedFieldError = `
 task {
@@ -99,14 +202,18 @@ func TestModuleInput_DecodeConfig_Success(t *testing.T) {
 			name: "services",
 			expected: &ModuleInputConfigs{
 				&ServicesModuleInputConfig{
-					ServicesMonitorConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
 						Regexp:             String(".*"),
 						Names:              []string{},
 						Datacenter:         String("dc2"),
 						Namespace:          String("ns2"),
+						Partition:          String("ap2"),
+						Peer:               String("cluster-02"),
 						Filter:             String("some-filter"),
+						Status:             String(HealthPassing),
 						CTSUserDefinedMeta: map[string]string{"key": "value"},
 					},
+					Alias: String(""),
 				},
 			},
 			config: testModuleInputServicesSuccess,
@@ -115,39 +222,172 @@ func TestModuleInput_DecodeConfig_Success(t *testing.T) {
 			name: "consul-kv",
 			expected: &ModuleInputConfigs{
 				&ConsulKVModuleInputConfig{
-					ConsulKVMonitorConfig{
+					ConsulKVMonitorConfig: ConsulKVMonitorConfig{
 						Path:       String("key-path"),
 						Datacenter: String("dc2"),
 						Namespace:  String("ns2"),
+						Partition:  String("ap2"),
 						Recurse:    Bool(true),
+						Decode:     String("json"),
 					},
+					Alias: String(""),
 				},
 			},
 			config: testModuleInputConsulKVSuccess,
 		},
+		{
+			name: "nodes",
+			expected: &ModuleInputConfigs{
+				&NodesModuleInputConfig{
+					NodesMonitorConfig: NodesMonitorConfig{
+						Datacenter: String("dc2"),
+						NodeMeta:   map[string]string{"key": "value"},
+						Filter:     String("Meta.env == \"prod\""),
+					},
+					Alias: String(""),
+				},
+			},
+			config: testModuleInputNodesSuccess,
+		},
+		{
+			name: "intentions",
+			expected: &ModuleInputConfigs{
+				&IntentionsModuleInputConfig{
+					IntentionsMonitorConfig: IntentionsMonitorConfig{
+						Datacenter: String("dc2"),
+						Namespace:  String("ns2"),
+						Filter:     String("DestinationName == \"api\""),
+					},
+					Alias: String(""),
+				},
+			},
+			config: testModuleInputIntentionsSuccess,
+		},
+		{
+			name: "config-entries",
+			expected: &ModuleInputConfigs{
+				&ConfigEntriesModuleInputConfig{
+					ConfigEntriesMonitorConfig: ConfigEntriesMonitorConfig{
+						Kind:       String("service-defaults"),
+						Name:       String("api"),
+						Datacenter: String(""),
+						Namespace:  String(""),
+					},
+					Alias: String(""),
+				},
+			},
+			config: testModuleInputConfigEntriesSuccess,
+		},
+		{
+			name: "http",
+			expected: &ModuleInputConfigs{
+				&HTTPModuleInputConfig{
+					HTTPMonitorConfig: HTTPMonitorConfig{
+						URL:      String("http://ipam.example.com/api/prefixes"),
+						Headers:  map[string]string{},
+						Interval: TimeDuration(DefaultHTTPInterval),
+						Timeout:  TimeDuration(5 * time.Second),
+						Selector: String(""),
+						TLS: &TLSConfig{
+							CACert:     String(""),
+							CAPath:     String(""),
+							Cert:       String(""),
+							Enabled:    Bool(false),
+							Key:        String(""),
+							ServerName: String(""),
+							Verify:     Bool(true),
+						},
+					},
+					Alias: String(""),
+				},
+			},
+			config: testModuleInputHTTPSuccess,
+		},
+		{
+			name: "vault",
+			expected: &ModuleInputConfigs{
+				&VaultModuleInputConfig{
+					Path:  String("secret/my-app"),
+					Alias: String(""),
+				},
+			},
+			config: testModuleInputVaultSuccess,
+		},
 		{
 			name: "multiple unique module_inputs",
 			expected: &ModuleInputConfigs{
 				&ServicesModuleInputConfig{
-					ServicesMonitorConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
 						Names:              []string{"api"},
 						Datacenter:         String(""),
 						Namespace:          String(""),
+						Partition:          String(""),
+						Peer:               String(""),
 						Filter:             String(""),
+						Status:             String(HealthPassing),
 						CTSUserDefinedMeta: map[string]string{},
 					},
+					Alias: String(""),
 				},
 				&ConsulKVModuleInputConfig{
-					ConsulKVMonitorConfig{
+					ConsulKVMonitorConfig: ConsulKVMonitorConfig{
 						Path:       String("my/path"),
 						Recurse:    Bool(false),
 						Datacenter: String(""),
 						Namespace:  String(""),
+						Partition:  String(""),
+						Decode:     String(""),
 					},
+					Alias: String(""),
 				},
 			},
 			config: testModuleInputsSuccess,
 		},
+		{
+			name: "labelled module_inputs",
+			expected: &ModuleInputConfigs{
+				&ServicesModuleInputConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names:              []string{"web"},
+						Datacenter:         String(""),
+						Namespace:          String(""),
+						Partition:          String(""),
+						Peer:               String(""),
+						Filter:             String(""),
+						Status:             String(HealthPassing),
+						CTSUserDefinedMeta: map[string]string{},
+					},
+					Alias: String("backends"),
+				},
+				&ServicesModuleInputConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names:              []string{"db"},
+						Datacenter:         String(""),
+						Namespace:          String(""),
+						Partition:          String(""),
+						Peer:               String(""),
+						Filter:             String(""),
+						Status:             String(HealthPassing),
+						CTSUserDefinedMeta: map[string]string{},
+					},
+					Alias: String("databases"),
+				},
+				&ServicesModuleInputConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names:              []string{"api"},
+						Datacenter:         String(""),
+						Namespace:          String(""),
+						Partition:          String(""),
+						Peer:               String(""),
+						Filter:             String(""),
+						Status:             String(HealthPassing),
+						CTSUserDefinedMeta: map[string]string{"key": "value"},
+					},
+					Alias: String(""),
+				},
+			},
+			config: testModuleInputsLabelledSuccess,
+		},
 	}
 
 	for _, tc := range cases {
@@ -184,6 +424,11 @@ func TestModuleInput_DecodeConfig_Error(t *testing.T) {
 			expected: nil,
 			config:   testModuleInputConsulKVUnsupportedFieldError,
 		},
+		{
+			name:     "labelled with alias field",
+			expected: nil,
+			config:   testModuleInputLabelledAliasError,
+		},
 	}
 
 	for _, tc := range cases {
@@ -248,7 +493,7 @@ func TestModuleInputConfigs_Copy(t *testing.T) {
 			"happy_path",
 			&ModuleInputConfigs{
 				&ServicesModuleInputConfig{
-					ServicesMonitorConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
 						Regexp:     String("^web.*"),
 						Datacenter: String("dc"),
 						Namespace:  String("namespace"),
@@ -259,7 +504,7 @@ func TestModuleInputConfigs_Copy(t *testing.T) {
 					},
 				},
 				&ConsulKVModuleInputConfig{
-					ConsulKVMonitorConfig{
+					ConsulKVMonitorConfig: ConsulKVMonitorConfig{
 						Path:       String("key-path"),
 						Recurse:    Bool(true),
 						Datacenter: String("dc2"),
@@ -269,7 +514,7 @@ func TestModuleInputConfigs_Copy(t *testing.T) {
 			},
 			&ModuleInputConfigs{
 				&ServicesModuleInputConfig{
-					ServicesMonitorConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
 						Regexp:     String("^web.*"),
 						Datacenter: String("dc"),
 						Namespace:  String("namespace"),
@@ -280,7 +525,7 @@ func TestModuleInputConfigs_Copy(t *testing.T) {
 					},
 				},
 				&ConsulKVModuleInputConfig{
-					ConsulKVMonitorConfig{
+					ConsulKVMonitorConfig: ConsulKVMonitorConfig{
 						Path:       String("key-path"),
 						Recurse:    Bool(true),
 						Datacenter: String("dc2"),
@@ -386,14 +631,18 @@ func TestModuleInputConfigs_Finalize(t *testing.T) {
 			},
 			&ModuleInputConfigs{
 				&ServicesModuleInputConfig{
-					ServicesMonitorConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
 						Regexp:             nil,
 						Names:              []string{},
 						Datacenter:         String(""),
 						Namespace:          String(""),
+						Partition:          String(""),
+						Peer:               String(""),
 						Filter:             String(""),
+						Status:             String(HealthPassing),
 						CTSUserDefinedMeta: map[string]string{},
 					},
+					Alias: String(""),
 				},
 			},
 		},
@@ -461,6 +710,77 @@ func TestModuleInputConfigs_Validate(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "valid: module_inputs of same type with aliases",
+			moduleInputs: &ModuleInputConfigs{
+				&ServicesModuleInputConfig{Alias: String("backends")},
+				&ServicesModuleInputConfig{Alias: String("databases")},
+				&ServicesModuleInputConfig{},
+			},
+			valid: true,
+		},
+		{
+			name:     "invalid: services & aliased services module_input configured",
+			services: []string{"api"},
+			moduleInputs: &ModuleInputConfigs{
+				&ServicesModuleInputConfig{Alias: String("backends")},
+			},
+			valid: false,
+		},
+		{
+			name:      "invalid: cond & aliased module_input same type",
+			condition: &ConsulKVConditionConfig{},
+			moduleInputs: &ModuleInputConfigs{
+				&ConsulKVModuleInputConfig{Alias: String("app_config")},
+			},
+			valid: false,
+		},
+		{
+			name:      "invalid: services cond & aliased services module_input",
+			condition: &ServicesConditionConfig{},
+			moduleInputs: &ModuleInputConfigs{
+				&ServicesModuleInputConfig{Alias: String("backends")},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid: aliases not unique",
+			moduleInputs: &ModuleInputConfigs{
+				&ServicesModuleInputConfig{Alias: String("backends")},
+				&ConsulKVModuleInputConfig{Alias: String("backends")},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid: alias same as variable type",
+			moduleInputs: &ModuleInputConfigs{
+				&NodesModuleInputConfig{},
+				&ConsulKVModuleInputConfig{Alias: String("nodes")},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid: alias is not a variable name",
+			moduleInputs: &ModuleInputConfigs{
+				&ConsulKVModuleInputConfig{Alias: String("app config")},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid: alias is reserved services variable",
+			moduleInputs: &ModuleInputConfigs{
+				&ConsulKVModuleInputConfig{Alias: String("services")},
+			},
+			valid: false,
+		},
+		{
+			name:      "invalid: cond & alias same variable",
+			condition: &ConsulKVConditionConfig{},
+			moduleInputs: &ModuleInputConfigs{
+				&NodesModuleInputConfig{Alias: String("consul_kv")},
+			},
+			valid: false,
+		},
 		{
 			name:     "invalid: services & services module_input configured",
 			services: []string{"api"},
@@ -519,9 +839,10 @@ func TestModuleInputConfigs_GoString(t *testing.T) {
 				},
 			},
 			"{&ServicesModuleInputConfig{&ServicesMonitorConfig{Regexp:^api$, Names:[], " +
-				"Datacenter:, Namespace:, Filter:, CTSUserDefinedMeta:map[]}}, " +
+				"Datacenter:, Datacenters:[], Namespace:, Partition:, Peer:, Filter:, Status:, " +
+				"CTSUserDefinedMeta:map[]}, Alias:}, " +
 				"&ConsulKVModuleInputConfig{&ConsulKVMonitorConfig{Path:my/path, " +
-				"Recurse:false, Datacenter:, Namespace:, }}}",
+				"Recurse:false, Datacenter:, Namespace:, Partition:, Decode:, }, Alias:}}",
 		},
 	}
 
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"fmt"
+)
+
+const vaultType = "vault"
+
+var _ ModuleInputConfig = (*VaultModuleInputConfig)(nil)
+
+// VaultModuleInputConfig configures a module_input configuration block of
+// type 'vault'. The data of the Vault secret will be used as input for the
+// module variables. Vault can only be used as a module input and not as a
+// condition, but a change to the secret data will trigger the task.
+type VaultModuleInputConfig struct {
+	// Path is the path of the secret to read from Vault. For the KV secrets
+	// engine version 2, the path can be configured without the "data/" prefix
+	// e.g. "secret/my-app". Dynamic secrets are read from their paths e.g.
+	// "database/creds/my-role".
+	Path *string `mapstructure:"path"`
+
+	// Alias is the label of a labelled module_input block. The module input
+	// is rendered as a Terraform variable named after the alias instead of
+	// the variable type.
+	Alias *string `mapstructure:"alias"`
+}
+
+// VariableType returns the type of variable the module input monitors
+func (c *VaultModuleInputConfig) VariableType() string {
+	return "vault"
+}
+
+// VariableName returns the alias of the module input if configured, or the
+// variable type otherwise
+func (c *VaultModuleInputConfig) VariableName() string {
+	return moduleInputVariableName(c.Alias, c.VariableType())
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *VaultModuleInputConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o VaultModuleInputConfig
+	o.Path = StringCopy(c.Path)
+	o.Alias = StringCopy(c.Alias)
+
+	return &o
+}
+
+// Merge combines all values in this configuration `c` with the values in the other
+// configuration `o`, with values in the other configuration taking precedence.
+func (c *VaultModuleInputConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isModuleInputNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isModuleInputNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*VaultModuleInputConfig)
+	if !ok {
+		return nil
+	}
+
+	r2 := r.(*VaultModuleInputConfig)
+
+	if o2.Path != nil {
+		r2.Path = StringCopy(o2.Path)
+	}
+
+	if o2.Alias != nil {
+		r2.Alias = StringCopy(o2.Alias)
+	}
+
+	return r2
+}
+
+// Finalize ensures there are no nil pointers.
+func (c *VaultModuleInputConfig) Finalize() {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.Path == nil {
+		c.Path = String("")
+	}
+
+	if c.Alias == nil {
+		c.Alias = String("")
+	}
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *VaultModuleInputConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	if c.Path == nil || *c.Path == "" {
+		return fmt.Errorf("path is required for vault module_input")
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *VaultModuleInputConfig) GoString() string {
+	if c == nil {
+		return "(*VaultModuleInputConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&VaultModuleInputConfig{"+
+		"Path:%s, "+
+		"Alias:%s"+
+		"}",
+		StringVal(c.Path),
+		StringVal(c.Alias),
+	)
+}
+
+// hasVaultModuleInput reports whether the task is configured with a vault
+// module_input
+func hasVaultModuleInput(t *TaskConfig) bool {
+	if t == nil || t.ModuleInputs == nil {
+		return false
+	}
+
+	for _, input := range *t.ModuleInputs {
+		if _, ok := input.(*VaultModuleInputConfig); ok {
+			return true
+		}
+	}
+	return false
+}
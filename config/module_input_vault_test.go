@@ -0,0 +1,179 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVaultModuleInputConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &VaultModuleInputConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *VaultModuleInputConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&VaultModuleInputConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&VaultModuleInputConfig{
+				Path: String("secret/my-app"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			if tc.a == nil {
+				// returned nil interface has nil type, which is unequal to tc.a
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.a, r)
+			}
+		})
+	}
+}
+
+func TestVaultModuleInputConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *VaultModuleInputConfig
+		b    *VaultModuleInputConfig
+		r    *VaultModuleInputConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&VaultModuleInputConfig{},
+			&VaultModuleInputConfig{},
+		},
+		{
+			"nil_b",
+			&VaultModuleInputConfig{},
+			nil,
+			&VaultModuleInputConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"path_overrides",
+			&VaultModuleInputConfig{Path: String("same")},
+			&VaultModuleInputConfig{Path: String("different")},
+			&VaultModuleInputConfig{Path: String("different")},
+		},
+		{
+			"path_empty_one",
+			&VaultModuleInputConfig{Path: String("same")},
+			&VaultModuleInputConfig{},
+			&VaultModuleInputConfig{Path: String("same")},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			if tc.r == nil {
+				// returned nil interface has nil type, which is unequal to tc.r
+				assert.Nil(t, r)
+			} else {
+				assert.Equal(t, tc.r, r)
+			}
+		})
+	}
+}
+
+func TestVaultModuleInputConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	i := &VaultModuleInputConfig{}
+	i.Finalize()
+	assert.Equal(t, &VaultModuleInputConfig{
+		Path:  String(""),
+		Alias: String(""),
+	}, i)
+}
+
+func TestVaultModuleInputConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		expectErr bool
+		c         *VaultModuleInputConfig
+	}{
+		{
+			"happy_path",
+			false,
+			&VaultModuleInputConfig{Path: String("secret/my-app")},
+		},
+		{
+			"nil",
+			false,
+			nil,
+		},
+		{
+			"missing_path",
+			true,
+			&VaultModuleInputConfig{Path: String("")},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVaultModuleInputConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		i        *VaultModuleInputConfig
+		expected string
+	}{
+		{
+			"configured",
+			&VaultModuleInputConfig{Path: String("secret/my-app")},
+			"&VaultModuleInputConfig{Path:secret/my-app, Alias:}",
+		},
+		{
+			"nil",
+			nil,
+			"(*VaultModuleInputConfig)(nil)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.i.GoString())
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package config
 
 import (
+	"fmt"
 	"reflect"
 )
 
+// AllDatacenters is the value of a monitor's datacenters field to monitor all
+// datacenters known to the Consul catalog.
+const AllDatacenters = "all"
+
 // MonitorConfig represents the base object for objects like monitor_input and
 // condition, both of which "monitor" an object in order to perform some action
 type MonitorConfig interface {
@@ -38,6 +43,16 @@ func isMonitorNil(c MonitorConfig) bool {
 		result = v == nil
 	case *ConsulKVConditionConfig:
 		result = v == nil
+	case *NodesConditionConfig:
+		result = v == nil
+	case *IntentionsConditionConfig:
+		result = v == nil
+	case *ConfigEntriesConditionConfig:
+		result = v == nil
+	case *HTTPConditionConfig:
+		result = v == nil
+	case *WebhookConditionConfig:
+		result = v == nil
 	case *ScheduleConditionConfig:
 		result = v == nil
 
@@ -46,8 +61,50 @@ func isMonitorNil(c MonitorConfig) bool {
 		result = v == nil
 	case *ConsulKVModuleInputConfig:
 		result = v == nil
+	case *NodesModuleInputConfig:
+		result = v == nil
+	case *IntentionsModuleInputConfig:
+		result = v == nil
+	case *ConfigEntriesModuleInputConfig:
+		result = v == nil
+	case *HTTPModuleInputConfig:
+		result = v == nil
+	case *VaultModuleInputConfig:
+		result = v == nil
 	default:
 		return c == nil || reflect.ValueOf(c).IsNil()
 	}
 	return c == nil || result
 }
+
+// validateDatacenters checks that a monitor configures either a datacenter or
+// a list of datacenters, and that the list either has unique, non-empty
+// datacenters or only "all".
+func validateDatacenters(dc *string, dcs []string) error {
+	if len(dcs) == 0 {
+		return nil
+	}
+
+	if StringVal(dc) != "" {
+		return fmt.Errorf("datacenter and datacenters fields cannot both be " +
+			"configured. include the datacenter in the list of datacenters")
+	}
+
+	seen := make(map[string]bool, len(dcs))
+	for _, d := range dcs {
+		switch {
+		case d == "":
+			return fmt.Errorf("datacenters field includes empty string(s). " +
+				"datacenters cannot be empty")
+		case d == AllDatacenters && len(dcs) > 1:
+			return fmt.Errorf("datacenters field cannot include %q with other "+
+				"datacenters", AllDatacenters)
+		case seen[d]:
+			return fmt.Errorf("datacenters field includes duplicate "+
+				"datacenter %q", d)
+		}
+		seen[d] = true
+	}
+
+	return nil
+}
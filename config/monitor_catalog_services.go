@@ -20,6 +20,17 @@ type CatalogServicesMonitorConfig struct {
 	Namespace  *string           `mapstructure:"namespace"`
 	NodeMeta   map[string]string `mapstructure:"node_meta"`
 
+	// Datacenters configures a list of datacenters to monitor the services in,
+	// or "all" for all datacenters known to the Consul catalog. Either
+	// Datacenter or Datacenters can be configured, not both.
+	Datacenters []string `mapstructure:"datacenters"`
+
+	// Partition is the admin partition of the services (Consul Enterprise
+	// only). Peer is the name of the cluster peer to monitor the imported
+	// services of.
+	Partition *string `mapstructure:"partition"`
+	Peer      *string `mapstructure:"peer"`
+
 	// UseAsModuleInput was previously named SourceIncludesVar - deprecated v0.5
 	UseAsModuleInput            *bool `mapstructure:"use_as_module_input"`
 	DeprecatedSourceIncludesVar *bool `mapstructure:"source_includes_var"`
@@ -40,6 +51,13 @@ func (c *CatalogServicesMonitorConfig) Copy() MonitorConfig {
 	o.Regexp = StringCopy(c.Regexp)
 	o.Datacenter = StringCopy(c.Datacenter)
 	o.Namespace = StringCopy(c.Namespace)
+	o.Partition = StringCopy(c.Partition)
+	o.Peer = StringCopy(c.Peer)
+
+	if c.Datacenters != nil {
+		o.Datacenters = make([]string, 0, len(c.Datacenters))
+		o.Datacenters = append(o.Datacenters, c.Datacenters...)
+	}
 
 	o.UseAsModuleInput = BoolCopy(c.UseAsModuleInput)
 	o.DeprecatedSourceIncludesVar = BoolCopy(c.DeprecatedSourceIncludesVar)
@@ -93,10 +111,20 @@ func (c *CatalogServicesMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
 		r2.Datacenter = StringCopy(o2.Datacenter)
 	}
 
+	r2.Datacenters = mergeSlices(r2.Datacenters, o2.Datacenters)
+
 	if o2.Namespace != nil {
 		r2.Namespace = StringCopy(o2.Namespace)
 	}
 
+	if o2.Partition != nil {
+		r2.Partition = StringCopy(o2.Partition)
+	}
+
+	if o2.Peer != nil {
+		r2.Peer = StringCopy(o2.Peer)
+	}
+
 	if o2.NodeMeta != nil {
 		if r2.NodeMeta == nil {
 			r2.NodeMeta = make(map[string]string)
@@ -141,6 +169,14 @@ func (c *CatalogServicesMonitorConfig) Finalize() {
 		c.Namespace = String("")
 	}
 
+	if c.Partition == nil {
+		c.Partition = String("")
+	}
+
+	if c.Peer == nil {
+		c.Peer = String("")
+	}
+
 	if c.NodeMeta == nil {
 		c.NodeMeta = make(map[string]string)
 	}
@@ -161,6 +197,10 @@ func (c *CatalogServicesMonitorConfig) Validate() error {
 	if _, err := regexp.Compile(StringVal(c.Regexp)); err != nil {
 		return fmt.Errorf("unable to compile catalog-services 'regexp': %s", err)
 	}
+
+	if err := validateDatacenters(c.Datacenter, c.Datacenters); err != nil {
+		return fmt.Errorf("catalog-services: %s", err)
+	}
 	return nil
 }
 
@@ -173,13 +213,19 @@ func (c *CatalogServicesMonitorConfig) GoString() string {
 	return fmt.Sprintf("&CatalogServicesMonitorConfig{"+
 		"Regexp:%s, "+
 		"Datacenter:%v, "+
+		"Datacenters:%s, "+
 		"Namespace:%v, "+
+		"Partition:%v, "+
+		"Peer:%v, "+
 		"NodeMeta:%s, "+
 		"UseAsModuleInput:%v"+
 		"}",
 		StringVal(c.Regexp),
 		StringVal(c.Datacenter),
+		c.Datacenters,
 		StringVal(c.Namespace),
+		StringVal(c.Partition),
+		StringVal(c.Peer),
 		c.NodeMeta,
 		BoolVal(c.UseAsModuleInput),
 	)
@@ -272,6 +272,8 @@ func TestCatalogServicesMonitorConfig_Finalize(t *testing.T) {
 					UseAsModuleInput: Bool(true),
 					Datacenter:       String(""),
 					Namespace:        String(""),
+					Partition:        String(""),
+					Peer:             String(""),
 					NodeMeta:         map[string]string{},
 				},
 			},
@@ -372,6 +374,27 @@ func TestCatalogServicesMonitorConfig_Validate(t *testing.T) {
 				},
 			},
 		},
+		{
+			"valid_datacenters",
+			false,
+			&CatalogServicesConditionConfig{
+				CatalogServicesMonitorConfig{
+					Regexp:      String(""),
+					Datacenters: []string{AllDatacenters},
+				},
+			},
+		},
+		{
+			"invalid_both_datacenter_and_datacenters_configured",
+			true,
+			&CatalogServicesConditionConfig{
+				CatalogServicesMonitorConfig{
+					Regexp:      String(""),
+					Datacenter:  String("dc1"),
+					Datacenters: []string{"dc2"},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
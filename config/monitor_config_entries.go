@@ -0,0 +1,173 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const configEntriesType = "config-entries"
+
+var _ MonitorConfig = (*ConfigEntriesMonitorConfig)(nil)
+
+// ConfigEntriesMonitorConfig configures a configuration block adhering to the
+// monitor interface of type 'config-entries'. A config-entries monitor watches
+// for changes that occur to Consul configuration entries of a kind.
+type ConfigEntriesMonitorConfig struct {
+	// Kind is the kind of configuration entries to monitor e.g.
+	// "service-defaults" or "ingress-gateway".
+	Kind *string `mapstructure:"kind"`
+
+	// Name configures the configuration entry to monitor by its name. Name and
+	// Regexp cannot both be configured. When both are unset, all entries of
+	// the kind are monitored.
+	Name *string `mapstructure:"name"`
+
+	// Regexp configures the configuration entries to monitor by matching on
+	// the entry name. When Regexp is unset, it will retain a nil value even
+	// after Finalize().
+	Regexp *string `mapstructure:"regexp"`
+
+	// Datacenter is the datacenter of the configuration entries.
+	Datacenter *string `mapstructure:"datacenter"`
+
+	// Namespace is the namespace of the configuration entries (Consul
+	// Enterprise only). If not provided, the namespace will be inferred from
+	// the CTS ACL token, or default to the `default` namespace.
+	Namespace *string `mapstructure:"namespace"`
+}
+
+func (c *ConfigEntriesMonitorConfig) VariableType() string {
+	return "config_entries"
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *ConfigEntriesMonitorConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o ConfigEntriesMonitorConfig
+	o.Kind = StringCopy(c.Kind)
+	o.Name = StringCopy(c.Name)
+	o.Regexp = StringCopy(c.Regexp)
+	o.Datacenter = StringCopy(c.Datacenter)
+	o.Namespace = StringCopy(c.Namespace)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *ConfigEntriesMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*ConfigEntriesMonitorConfig)
+	if !ok {
+		return r
+	}
+
+	r2 := r.(*ConfigEntriesMonitorConfig)
+
+	if o2.Kind != nil {
+		r2.Kind = StringCopy(o2.Kind)
+	}
+
+	if o2.Name != nil {
+		r2.Name = StringCopy(o2.Name)
+	}
+
+	if o2.Regexp != nil {
+		r2.Regexp = StringCopy(o2.Regexp)
+	}
+
+	if o2.Datacenter != nil {
+		r2.Datacenter = StringCopy(o2.Datacenter)
+	}
+
+	if o2.Namespace != nil {
+		r2.Namespace = StringCopy(o2.Namespace)
+	}
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers with the _exception_ of Regexp. There
+// is a need to distinguish between nil regex (unconfigured regex) and empty
+// string regex ("" regex pattern) at Validate()
+func (c *ConfigEntriesMonitorConfig) Finalize() {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.Kind == nil {
+		c.Kind = String("")
+	}
+
+	if c.Name == nil {
+		c.Name = String("")
+	}
+
+	if c.Datacenter == nil {
+		c.Datacenter = String("")
+	}
+
+	if c.Namespace == nil {
+		c.Namespace = String("")
+	}
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+// Note, it handles the possibility of nil Regexp value even after Finalize().
+func (c *ConfigEntriesMonitorConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	if c.Kind == nil || *c.Kind == "" {
+		return fmt.Errorf("kind is required for config-entries condition")
+	}
+
+	if c.Regexp != nil {
+		if StringVal(c.Name) != "" {
+			return fmt.Errorf("config-entries 'name' and 'regexp' fields " +
+				"cannot both be configured")
+		}
+		if _, err := regexp.Compile(*c.Regexp); err != nil {
+			return fmt.Errorf("unable to compile config-entries 'regexp': %s", err)
+		}
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *ConfigEntriesMonitorConfig) GoString() string {
+	if c == nil {
+		return "(*ConfigEntriesMonitorConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&ConfigEntriesMonitorConfig{"+
+		"Kind:%s, "+
+		"Name:%s, "+
+		"Regexp:%s, "+
+		"Datacenter:%v, "+
+		"Namespace:%v"+
+		"}",
+		StringVal(c.Kind),
+		StringVal(c.Name),
+		StringVal(c.Regexp),
+		StringVal(c.Datacenter),
+		StringVal(c.Namespace),
+	)
+}
@@ -6,6 +6,14 @@ import (
 
 const consulKVType = "consul-kv"
 
+// Formats that decode the values of a consul-kv monitor into structured
+// objects. An empty format passes the raw string values.
+const (
+	DecodeJSON = "json"
+	DecodeYAML = "yaml"
+	DecodeHCL  = "hcl"
+)
+
 var _ MonitorConfig = (*ConsulKVMonitorConfig)(nil)
 
 // ConsulKVMonitorConfig configures a configuration block adhering to the monitor interface
@@ -16,6 +24,17 @@ type ConsulKVMonitorConfig struct {
 	Recurse    *bool   `mapstructure:"recurse"`
 	Datacenter *string `mapstructure:"datacenter"`
 	Namespace  *string `mapstructure:"namespace"`
+
+	// Partition is the admin partition of the KV path (Consul Enterprise
+	// only). Consul KV is not shared with cluster peers, so there is no peer
+	// option for consul-kv.
+	Partition *string `mapstructure:"partition"`
+
+	// Decode is the format to parse the values with and pass to the module as
+	// structured objects. Supported values are "json", "yaml", and "hcl". When
+	// Recurse is true, the decoded values are nested in a map that mirrors the
+	// key hierarchy. Default is to pass the raw string values.
+	Decode *string `mapstructure:"decode"`
 }
 
 func (c *ConsulKVMonitorConfig) VariableType() string {
@@ -33,6 +52,8 @@ func (c *ConsulKVMonitorConfig) Copy() MonitorConfig {
 	o.Recurse = BoolCopy(c.Recurse)
 	o.Datacenter = StringCopy(c.Datacenter)
 	o.Namespace = StringCopy(c.Namespace)
+	o.Partition = StringCopy(c.Partition)
+	o.Decode = StringCopy(c.Decode)
 
 	return &o
 }
@@ -75,6 +96,14 @@ func (c *ConsulKVMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
 		r2.Namespace = StringCopy(o2.Namespace)
 	}
 
+	if o2.Partition != nil {
+		r2.Partition = StringCopy(o2.Partition)
+	}
+
+	if o2.Decode != nil {
+		r2.Decode = StringCopy(o2.Decode)
+	}
+
 	return r2
 }
 
@@ -100,6 +129,13 @@ func (c *ConsulKVMonitorConfig) Finalize() {
 		c.Namespace = String("")
 	}
 
+	if c.Partition == nil {
+		c.Partition = String("")
+	}
+
+	if c.Decode == nil {
+		c.Decode = String("")
+	}
 }
 
 // Validate validates the values and required options. This method is recommended
@@ -113,6 +149,15 @@ func (c *ConsulKVMonitorConfig) Validate() error {
 		return fmt.Errorf("path is required for consul-kv condition")
 	}
 
+	if c.Decode != nil {
+		switch *c.Decode {
+		case "", DecodeJSON, DecodeYAML, DecodeHCL:
+		default:
+			return fmt.Errorf("invalid decode %q. supported formats are %q, "+
+				"%q, and %q", *c.Decode, DecodeJSON, DecodeYAML, DecodeHCL)
+		}
+	}
+
 	return nil
 }
 
@@ -127,10 +172,14 @@ func (c *ConsulKVMonitorConfig) GoString() string {
 		"Recurse:%v, "+
 		"Datacenter:%v, "+
 		"Namespace:%v, "+
+		"Partition:%v, "+
+		"Decode:%v, "+
 		"}",
 		StringVal(c.Path),
 		BoolVal(c.Recurse),
 		StringVal(c.Datacenter),
 		StringVal(c.Namespace),
+		StringVal(c.Partition),
+		StringVal(c.Decode),
 	)
 }
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/internal/selector"
+)
+
+const (
+	httpType = "http"
+
+	// DefaultHTTPInterval is the default interval that the URL of an http
+	// monitor is polled.
+	DefaultHTTPInterval = 60 * time.Second
+
+	// DefaultHTTPTimeout is the default timeout for each request made by an
+	// http monitor.
+	DefaultHTTPTimeout = 10 * time.Second
+)
+
+var _ MonitorConfig = (*HTTPMonitorConfig)(nil)
+
+// HTTPMonitorConfig configures a configuration block adhering to the monitor
+// interface of type 'http'. An http monitor polls a URL that responds with
+// JSON and watches for changes to the content of the response. This enables
+// sources of truth outside of Consul to be used for tasks.
+type HTTPMonitorConfig struct {
+	// URL is the http or https endpoint that is polled with a GET request.
+	URL *string `mapstructure:"url"`
+
+	// Headers are added to each request e.g. for authorization.
+	Headers map[string]string `mapstructure:"headers"`
+
+	// Interval is the time to wait between requests to the URL.
+	Interval *time.Duration `mapstructure:"interval"`
+
+	// Timeout is the timeout for each request to the URL.
+	Timeout *time.Duration `mapstructure:"timeout"`
+
+	// Selector is a JSONPath-like expression to select a value from the JSON
+	// response e.g. "$.data.prefixes". When unset, the entire response is
+	// used.
+	Selector *string `mapstructure:"selector"`
+
+	// TLS configures the client for requests to an https URL.
+	TLS *TLSConfig `mapstructure:"tls"`
+}
+
+func (c *HTTPMonitorConfig) VariableType() string {
+	return "http"
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *HTTPMonitorConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o HTTPMonitorConfig
+	o.URL = StringCopy(c.URL)
+
+	if c.Headers != nil {
+		o.Headers = make(map[string]string, len(c.Headers))
+		for k, v := range c.Headers {
+			o.Headers[k] = v
+		}
+	}
+
+	o.Interval = TimeDurationCopy(c.Interval)
+	o.Timeout = TimeDurationCopy(c.Timeout)
+	o.Selector = StringCopy(c.Selector)
+
+	if c.TLS != nil {
+		o.TLS = c.TLS.Copy()
+	}
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *HTTPMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*HTTPMonitorConfig)
+	if !ok {
+		return r
+	}
+
+	r2 := r.(*HTTPMonitorConfig)
+
+	if o2.URL != nil {
+		r2.URL = StringCopy(o2.URL)
+	}
+
+	if o2.Headers != nil {
+		if r2.Headers == nil {
+			r2.Headers = make(map[string]string, len(o2.Headers))
+		}
+		for k, v := range o2.Headers {
+			r2.Headers[k] = v
+		}
+	}
+
+	if o2.Interval != nil {
+		r2.Interval = TimeDurationCopy(o2.Interval)
+	}
+
+	if o2.Timeout != nil {
+		r2.Timeout = TimeDurationCopy(o2.Timeout)
+	}
+
+	if o2.Selector != nil {
+		r2.Selector = StringCopy(o2.Selector)
+	}
+
+	if o2.TLS != nil {
+		r2.TLS = r2.TLS.Merge(o2.TLS)
+	}
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *HTTPMonitorConfig) Finalize() {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.URL == nil {
+		c.URL = String("")
+	}
+
+	if c.Headers == nil {
+		c.Headers = make(map[string]string)
+	}
+
+	if c.Interval == nil {
+		c.Interval = TimeDuration(DefaultHTTPInterval)
+	}
+
+	if c.Timeout == nil {
+		c.Timeout = TimeDuration(DefaultHTTPTimeout)
+	}
+
+	if c.Selector == nil {
+		c.Selector = String("")
+	}
+
+	if c.TLS == nil {
+		c.TLS = DefaultTLSConfig()
+	}
+	c.TLS.Finalize()
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *HTTPMonitorConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	if c.URL == nil || *c.URL == "" {
+		return fmt.Errorf("url is required for http condition")
+	}
+
+	u, err := url.Parse(*c.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url for http condition: %s", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url for http condition must use the http or "+
+			"https scheme: %s", *c.URL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("url for http condition is missing the host: %s",
+			*c.URL)
+	}
+
+	if c.Interval != nil && *c.Interval <= 0 {
+		return fmt.Errorf("interval for http condition must be greater "+
+			"than zero: %s", *c.Interval)
+	}
+
+	if c.Timeout != nil && *c.Timeout <= 0 {
+		return fmt.Errorf("timeout for http condition must be greater "+
+			"than zero: %s", *c.Timeout)
+	}
+
+	if c.Selector != nil {
+		if _, err := selector.Parse(*c.Selector); err != nil {
+			return fmt.Errorf("unable to parse http 'selector': %s", err)
+		}
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+// Sensitive information is redacted.
+func (c *HTTPMonitorConfig) GoString() string {
+	if c == nil {
+		return "(*HTTPMonitorConfig)(nil)"
+	}
+
+	// header values may contain credentials, only print the header names
+	headers := make([]string, 0, len(c.Headers))
+	for k := range c.Headers {
+		headers = append(headers, k)
+	}
+	sort.Strings(headers)
+
+	return fmt.Sprintf("&HTTPMonitorConfig{"+
+		"URL:%s, "+
+		"Headers:%v, "+
+		"Interval:%s, "+
+		"Timeout:%s, "+
+		"Selector:%s, "+
+		"TLS:%s"+
+		"}",
+		StringVal(c.URL),
+		headers,
+		TimeDurationVal(c.Interval),
+		TimeDurationVal(c.Timeout),
+		StringVal(c.Selector),
+		c.TLS.GoString(),
+	)
+}
+
+// hasHTTPHeaders reports whether the task is configured with an http
+// condition or module_input that has headers
+func hasHTTPHeaders(t *TaskConfig) bool {
+	if t == nil {
+		return false
+	}
+
+	if c, ok := t.Condition.(*HTTPConditionConfig); ok && len(c.Headers) > 0 {
+		return true
+	}
+
+	if t.ModuleInputs == nil {
+		return false
+	}
+	for _, input := range *t.ModuleInputs {
+		if i, ok := input.(*HTTPModuleInputConfig); ok && len(i.Headers) > 0 {
+			return true
+		}
+	}
+	return false
+}
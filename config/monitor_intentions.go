@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+)
+
+const intentionsType = "intentions"
+
+var _ MonitorConfig = (*IntentionsMonitorConfig)(nil)
+
+// IntentionsMonitorConfig configures a configuration block adhering to the
+// monitor interface of type 'intentions'. An intentions monitor watches for
+// changes that occur to Consul service intentions.
+type IntentionsMonitorConfig struct {
+	// Datacenter is the datacenter of the intentions to monitor.
+	Datacenter *string `mapstructure:"datacenter"`
+
+	// Namespace is the namespace of the intentions to monitor (Consul
+	// Enterprise only). If not provided, the namespace will be inferred from
+	// the CTS ACL token, or default to the `default` namespace.
+	Namespace *string `mapstructure:"namespace"`
+
+	// Filter is used to filter intentions based on a Consul compatible filter
+	// expression.
+	Filter *string `mapstructure:"filter"`
+}
+
+func (c *IntentionsMonitorConfig) VariableType() string {
+	return "intentions"
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *IntentionsMonitorConfig) Copy() MonitorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o IntentionsMonitorConfig
+	o.Datacenter = StringCopy(c.Datacenter)
+	o.Namespace = StringCopy(c.Namespace)
+	o.Filter = StringCopy(c.Filter)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *IntentionsMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
+	if c == nil {
+		if isConditionNil(o) { // o is interface, use isConditionNil()
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if isConditionNil(o) {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+	o2, ok := o.(*IntentionsMonitorConfig)
+	if !ok {
+		return r
+	}
+
+	r2 := r.(*IntentionsMonitorConfig)
+
+	if o2.Datacenter != nil {
+		r2.Datacenter = StringCopy(o2.Datacenter)
+	}
+
+	if o2.Namespace != nil {
+		r2.Namespace = StringCopy(o2.Namespace)
+	}
+
+	if o2.Filter != nil {
+		r2.Filter = StringCopy(o2.Filter)
+	}
+
+	return r2
+}
+
+// Finalize ensures there no nil pointers.
+func (c *IntentionsMonitorConfig) Finalize() {
+	if c == nil { // config not required, return early
+		return
+	}
+
+	if c.Datacenter == nil {
+		c.Datacenter = String("")
+	}
+
+	if c.Namespace == nil {
+		c.Namespace = String("")
+	}
+
+	if c.Filter == nil {
+		c.Filter = String("")
+	}
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *IntentionsMonitorConfig) Validate() error {
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *IntentionsMonitorConfig) GoString() string {
+	if c == nil {
+		return "(*IntentionsMonitorConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&IntentionsMonitorConfig{"+
+		"Datacenter:%v, "+
+		"Namespace:%v, "+
+		"Filter:%s"+
+		"}",
+		StringVal(c.Datacenter),
+		StringVal(c.Namespace),
+		StringVal(c.Filter),
+	)
+}
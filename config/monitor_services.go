@@ -7,6 +7,17 @@ import (
 
 const servicesType = "services"
 
+// Health statuses that select the service instances of a services monitor.
+// Each status includes the instances with a healthier status, e.g. "warning"
+// selects passing and warning instances. "any" also includes instances in
+// maintenance mode.
+const (
+	HealthPassing  = "passing"
+	HealthWarning  = "warning"
+	HealthCritical = "critical"
+	HealthAny      = "any"
+)
+
 var _ MonitorConfig = (*ServicesMonitorConfig)(nil)
 
 // ServicesMonitorConfig configures a configuration block adhering to the
@@ -26,15 +37,35 @@ type ServicesMonitorConfig struct {
 	// Datacenter is the datacenter the service is deployed in.
 	Datacenter *string `mapstricture:"datacenter"`
 
+	// Datacenters configures a list of datacenters to monitor the services in,
+	// or "all" for all datacenters known to the Consul catalog. Either
+	// Datacenter or Datacenters can be configured, not both.
+	Datacenters []string `mapstructure:"datacenters"`
+
 	// Namespace is the namespace of the service (Consul Enterprise only). If
 	// not provided, the namespace will be inferred from the CTS ACL token, or
 	// default to the `default` namespace.
 	Namespace *string `mapstructure:"namespace"`
 
+	// Partition is the admin partition of the service (Consul Enterprise
+	// only). If not provided, the partition will be inferred from the CTS ACL
+	// token, or default to the `default` partition.
+	Partition *string `mapstructure:"partition"`
+
+	// Peer is the name of the cluster peer that the service is imported from.
+	// If not provided, the services registered in the local cluster are
+	// monitored.
+	Peer *string `mapstructure:"peer"`
+
 	// Filter is used to filter nodes based on a Consul compatible filter
 	// expression.
 	Filter *string `mapstructure:"filter"`
 
+	// Status is the health status of the service instances to monitor. Each
+	// status includes the instances with a healthier status. Supported values
+	// are "passing", "warning", "critical", and "any". Default is "passing".
+	Status *string `mapstructure:"status"`
+
 	// CTSUserDefinedMeta is metadata added to a service automated by CTS for
 	// network infrastructure automation.
 	CTSUserDefinedMeta map[string]string `mapstructure:"cts_user_defined_meta"`
@@ -60,10 +91,21 @@ func (c *ServicesMonitorConfig) Copy() MonitorConfig {
 
 	o.Datacenter = StringCopy(c.Datacenter)
 
+	if c.Datacenters != nil {
+		o.Datacenters = make([]string, 0, len(c.Datacenters))
+		o.Datacenters = append(o.Datacenters, c.Datacenters...)
+	}
+
 	o.Namespace = StringCopy(c.Namespace)
 
+	o.Partition = StringCopy(c.Partition)
+
+	o.Peer = StringCopy(c.Peer)
+
 	o.Filter = StringCopy(c.Filter)
 
+	o.Status = StringCopy(c.Status)
+
 	if c.CTSUserDefinedMeta != nil {
 		o.CTSUserDefinedMeta = make(map[string]string)
 		for k, v := range c.CTSUserDefinedMeta {
@@ -107,12 +149,22 @@ func (c *ServicesMonitorConfig) Merge(o MonitorConfig) MonitorConfig {
 	if o2.Datacenter != nil {
 		r2.Datacenter = StringCopy(o2.Datacenter)
 	}
+	r2.Datacenters = mergeSlices(r2.Datacenters, o2.Datacenters)
 	if o2.Namespace != nil {
 		r2.Namespace = StringCopy(o2.Namespace)
 	}
+	if o2.Partition != nil {
+		r2.Partition = StringCopy(o2.Partition)
+	}
+	if o2.Peer != nil {
+		r2.Peer = StringCopy(o2.Peer)
+	}
 	if o2.Filter != nil {
 		r2.Filter = StringCopy(o2.Filter)
 	}
+	if o2.Status != nil {
+		r2.Status = StringCopy(o2.Status)
+	}
 	if o2.CTSUserDefinedMeta != nil {
 		if r2.CTSUserDefinedMeta == nil {
 			r2.CTSUserDefinedMeta = make(map[string]string)
@@ -146,9 +198,18 @@ func (c *ServicesMonitorConfig) Finalize() {
 	if c.Namespace == nil {
 		c.Namespace = String("")
 	}
+	if c.Partition == nil {
+		c.Partition = String("")
+	}
+	if c.Peer == nil {
+		c.Peer = String("")
+	}
 	if c.Filter == nil {
 		c.Filter = String("")
 	}
+	if c.Status == nil {
+		c.Status = String(HealthPassing)
+	}
 	if c.CTSUserDefinedMeta == nil {
 		c.CTSUserDefinedMeta = make(map[string]string)
 	}
@@ -181,6 +242,20 @@ func (c *ServicesMonitorConfig) Validate() error {
 		}
 	}
 
+	if c.Status != nil {
+		switch *c.Status {
+		case HealthPassing, HealthWarning, HealthCritical, HealthAny:
+		default:
+			return fmt.Errorf("invalid status %q. supported statuses are %q, "+
+				"%q, %q, and %q", *c.Status, HealthPassing, HealthWarning,
+				HealthCritical, HealthAny)
+		}
+	}
+
+	if err := validateDatacenters(c.Datacenter, c.Datacenters); err != nil {
+		return err
+	}
+
 	// Check that names does not contain empty strings
 	if namesConfigured {
 		for _, name := range c.Names {
@@ -204,15 +279,23 @@ func (c *ServicesMonitorConfig) GoString() string {
 		"Regexp:%s, "+
 		"Names:%s, "+
 		"Datacenter:%s, "+
+		"Datacenters:%s, "+
 		"Namespace:%s, "+
+		"Partition:%s, "+
+		"Peer:%s, "+
 		"Filter:%s, "+
+		"Status:%s, "+
 		"CTSUserDefinedMeta:%s"+
 		"}",
 		StringVal(c.Regexp),
 		c.Names,
 		StringVal(c.Datacenter),
+		c.Datacenters,
 		StringVal(c.Namespace),
+		StringVal(c.Partition),
+		StringVal(c.Peer),
 		StringVal(c.Filter),
+		StringVal(c.Status),
 		c.CTSUserDefinedMeta,
 	)
 }
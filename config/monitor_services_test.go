@@ -35,7 +35,10 @@ func TestServicesMonitorConfig_Copy(t *testing.T) {
 				Regexp:     String("^web.*"),
 				Datacenter: String("dc"),
 				Namespace:  String("namespace"),
+				Partition:  String("ap"),
+				Peer:       String("cluster-02"),
 				Filter:     String("filter"),
+				Status:     String("warning"),
 				CTSUserDefinedMeta: map[string]string{
 					"key": "value",
 				},
@@ -47,12 +50,22 @@ func TestServicesMonitorConfig_Copy(t *testing.T) {
 				Names:      []string{"web", "api"},
 				Datacenter: String("dc"),
 				Namespace:  String("namespace"),
+				Partition:  String("ap"),
+				Peer:       String("cluster-02"),
 				Filter:     String("filter"),
+				Status:     String("any"),
 				CTSUserDefinedMeta: map[string]string{
 					"key": "value",
 				},
 			},
 		},
+		{
+			"datacenters_configured",
+			&ServicesMonitorConfig{
+				Names:       []string{"web", "api"},
+				Datacenters: []string{"dc1", "dc2"},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -101,6 +114,12 @@ func TestServicesMonitorConfig_Merge(t *testing.T) {
 			&ServicesMonitorConfig{},
 			&ServicesMonitorConfig{},
 		},
+		{
+			"datacenters_merges",
+			&ServicesMonitorConfig{Datacenters: []string{"dc1"}},
+			&ServicesMonitorConfig{Datacenters: []string{"dc2"}},
+			&ServicesMonitorConfig{Datacenters: []string{"dc1", "dc2"}},
+		},
 		{
 			"regexp_overrides",
 			&ServicesMonitorConfig{Regexp: String("same")},
@@ -221,6 +240,18 @@ func TestServicesMonitorConfig_Merge(t *testing.T) {
 			&ServicesMonitorConfig{Filter: String("filter")},
 			&ServicesMonitorConfig{Filter: String("filter")},
 		},
+		{
+			"status_overrides",
+			&ServicesMonitorConfig{Status: String("passing")},
+			&ServicesMonitorConfig{Status: String("critical")},
+			&ServicesMonitorConfig{Status: String("critical")},
+		},
+		{
+			"status_empty_one",
+			&ServicesMonitorConfig{Status: String("warning")},
+			&ServicesMonitorConfig{},
+			&ServicesMonitorConfig{Status: String("warning")},
+		},
 		{
 			"cts_user_defined_meta_overrides",
 			&ServicesMonitorConfig{CTSUserDefinedMeta: map[string]string{"key": "value"}},
@@ -281,7 +312,10 @@ func TestServicesMonitorConfig_Finalize(t *testing.T) {
 				Names:              []string{},
 				Datacenter:         String(""),
 				Namespace:          String(""),
+				Partition:          String(""),
+				Peer:               String(""),
 				Filter:             String(""),
+				Status:             String(HealthPassing),
 				CTSUserDefinedMeta: map[string]string{},
 			},
 		},
@@ -291,6 +325,8 @@ func TestServicesMonitorConfig_Finalize(t *testing.T) {
 				Regexp:     String("^web.*"),
 				Datacenter: String("dc"),
 				Namespace:  String("namespace"),
+				Partition:  String("ap"),
+				Peer:       String("cluster-02"),
 				Filter:     String("filter"),
 				CTSUserDefinedMeta: map[string]string{
 					"key": "value",
@@ -301,7 +337,10 @@ func TestServicesMonitorConfig_Finalize(t *testing.T) {
 				Names:      []string{},
 				Datacenter: String("dc"),
 				Namespace:  String("namespace"),
+				Partition:  String("ap"),
+				Peer:       String("cluster-02"),
 				Filter:     String("filter"),
+				Status:     String(HealthPassing),
 				CTSUserDefinedMeta: map[string]string{
 					"key": "value",
 				},
@@ -313,6 +352,8 @@ func TestServicesMonitorConfig_Finalize(t *testing.T) {
 				Names:      []string{"api"},
 				Datacenter: String("dc"),
 				Namespace:  String("namespace"),
+				Partition:  String("ap"),
+				Peer:       String("cluster-02"),
 				Filter:     String("filter"),
 				CTSUserDefinedMeta: map[string]string{
 					"key": "value",
@@ -323,7 +364,10 @@ func TestServicesMonitorConfig_Finalize(t *testing.T) {
 				Regexp:     nil,
 				Datacenter: String("dc"),
 				Namespace:  String("namespace"),
+				Partition:  String("ap"),
+				Peer:       String("cluster-02"),
 				Filter:     String("filter"),
+				Status:     String(HealthPassing),
 				CTSUserDefinedMeta: map[string]string{
 					"key": "value",
 				},
@@ -373,6 +417,22 @@ func TestServicesMonitorConfig_Validate(t *testing.T) {
 				Regexp: String(""),
 			},
 		},
+		{
+			"valid_status",
+			false,
+			&ServicesMonitorConfig{
+				Names:  []string{"api"},
+				Status: String(HealthWarning),
+			},
+		},
+		{
+			"invalid_status",
+			true,
+			&ServicesMonitorConfig{
+				Names:  []string{"api"},
+				Status: String("maintenance"),
+			},
+		},
 		{
 			"invalid_regexp",
 			true,
@@ -400,6 +460,55 @@ func TestServicesMonitorConfig_Validate(t *testing.T) {
 			true,
 			&ServicesMonitorConfig{},
 		},
+		{
+			"valid_datacenters",
+			false,
+			&ServicesMonitorConfig{
+				Names:       []string{"api"},
+				Datacenters: []string{"dc1", "dc2"},
+			},
+		},
+		{
+			"valid_all_datacenters",
+			false,
+			&ServicesMonitorConfig{
+				Names:       []string{"api"},
+				Datacenters: []string{AllDatacenters},
+			},
+		},
+		{
+			"invalid_both_datacenter_and_datacenters_configured",
+			true,
+			&ServicesMonitorConfig{
+				Names:       []string{"api"},
+				Datacenter:  String("dc1"),
+				Datacenters: []string{"dc2"},
+			},
+		},
+		{
+			"invalid_all_with_other_datacenters",
+			true,
+			&ServicesMonitorConfig{
+				Names:       []string{"api"},
+				Datacenters: []string{"dc1", AllDatacenters},
+			},
+		},
+		{
+			"invalid_empty_string_datacenters",
+			true,
+			&ServicesMonitorConfig{
+				Names:       []string{"api"},
+				Datacenters: []string{"dc1", ""},
+			},
+		},
+		{
+			"invalid_duplicate_datacenters",
+			true,
+			&ServicesMonitorConfig{
+				Names:       []string{"api"},
+				Datacenters: []string{"dc1", "dc1"},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -433,13 +542,16 @@ func TestServicesMonitorConfig_GoString(t *testing.T) {
 				Regexp:     String("^api$"),
 				Datacenter: String("dc"),
 				Namespace:  String("namespace"),
+				Partition:  String("ap"),
+				Peer:       String("cluster-02"),
 				Filter:     String("filter"),
+				Status:     String("passing"),
 				CTSUserDefinedMeta: map[string]string{
 					"key": "value",
 				},
 			},
-			"&ServicesMonitorConfig{Regexp:^api$, Names:[], Datacenter:dc, " +
-				"Namespace:namespace, Filter:filter, " +
+			"&ServicesMonitorConfig{Regexp:^api$, Names:[], Datacenter:dc, Datacenters:[], " +
+				"Namespace:namespace, Partition:ap, Peer:cluster-02, Filter:filter, Status:passing, " +
 				"CTSUserDefinedMeta:map[key:value]}",
 		},
 		{
@@ -448,13 +560,16 @@ func TestServicesMonitorConfig_GoString(t *testing.T) {
 				Names:      []string{"api", "web"},
 				Datacenter: String("dc"),
 				Namespace:  String("namespace"),
+				Partition:  String("ap"),
+				Peer:       String("cluster-02"),
 				Filter:     String("filter"),
+				Status:     String("passing"),
 				CTSUserDefinedMeta: map[string]string{
 					"key": "value",
 				},
 			},
-			"&ServicesMonitorConfig{Regexp:, Names:[api web], Datacenter:dc, " +
-				"Namespace:namespace, Filter:filter, " +
+			"&ServicesMonitorConfig{Regexp:, Names:[api web], Datacenter:dc, Datacenters:[], " +
+				"Namespace:namespace, Partition:ap, Peer:cluster-02, Filter:filter, Status:passing, " +
 				"CTSUserDefinedMeta:map[key:value]}",
 		},
 	}
@@ -7,11 +7,21 @@ import (
 	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
+	goVersion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
 const (
 	taskSubsystemName = "task"
+
+	// ServicesProtocolV0 is the default version of the definition protocol of
+	// the services variable
+	ServicesProtocolV0 = "v0"
+
+	// ServicesProtocolV1 is the version of the definition protocol of the
+	// services variable that adds health checks, weights, service tagged
+	// addresses, and Connect details to each service
+	ServicesProtocolV1 = "v1"
 )
 
 // TaskConfig is the configuration for a CTS task. This block may be
@@ -54,6 +64,23 @@ type TaskConfig struct {
 	// of the files. Duplicate variables are overwritten with the later value.
 	VarFiles []string `mapstructure:"variable_files"`
 
+	// ServicesTemplate is the path to a file containing a Go template that
+	// renders the value of the task module's services input variable in place
+	// of the generated value. The template has the same template functions
+	// as the generated template, so the Consul data can be shaped as the
+	// module expects.
+	ServicesTemplate *string `mapstructure:"services_template"`
+
+	// FileFormat is the format of the generated root module and rendered input
+	// variables for the task, either "hcl" or "json". The format of the
+	// Terraform driver is used if omitted.
+	FileFormat *string `mapstructure:"file_format"`
+
+	// ServicesProtocol is the version of the definition protocol of the task
+	// module's services input variable, either "v0" or "v1". Defaults to "v0"
+	// so that existing modules remain compatible.
+	ServicesProtocol *string `mapstructure:"services_protocol"`
+
 	// TODO: Not supported by config file yet
 	// TODO: Add validation
 	Variables map[string]string
@@ -62,10 +89,15 @@ type TaskConfig struct {
 	// will be used as the default if omitted.
 	Version *string `mapstructure:"version"`
 
-	// The Terraform client version to use for the task when configured with CTS
-	// enterprise and the Terraform Cloud driver. This option is not supported
-	// when using CTS OSS or the Terraform driver.
-	// - Deprecated in 0.6. Use `terraform_cloud_workspace.terraform_version` instead
+	// DeprecatedTFVersion is the exact Terraform version to use for the task
+	// with the Terraform driver, which is installed alongside the version
+	// configured for the driver. The driver's version is used if omitted.
+	//
+	// The option is only deprecated for CTS enterprise and the Terraform Cloud
+	// driver, where it is the Terraform version of the task's workspace, and
+	// keeps its name from then.
+	// - Deprecated in 0.6 for the Terraform Cloud driver. Use
+	//   `terraform_cloud_workspace.terraform_version` instead
 	DeprecatedTFVersion *string `mapstructure:"terraform_version"`
 
 	// The workspace configurations to use for the task when configured with CTS
@@ -80,6 +112,23 @@ type TaskConfig struct {
 	// If not enabled, this task will not make any changes to resources.
 	Enabled *bool `mapstructure:"enabled"`
 
+	// DestroyOnDelete determines if the resources managed by the task are
+	// destroyed when the task is deleted. Disabled by default, which leaves
+	// the infrastructure and state in place on deletion.
+	DestroyOnDelete *bool `mapstructure:"destroy_on_delete"`
+
+	// ExposeOutputs determines if the output values of the task's module are
+	// collected after the task is successfully applied and served by the task
+	// outputs API. Outputs that are marked sensitive by the module are not
+	// exposed. Enabled by default only if OutputsKVPath is set.
+	ExposeOutputs *bool `mapstructure:"expose_outputs"`
+
+	// OutputsKVPath is the Consul KV path prefix to publish the task module's
+	// output values to after the task is successfully applied. Each output is
+	// written as a JSON value to the key <path>/<output name>. Outputs are not
+	// published to Consul KV if the path is not set. Requires ExposeOutputs.
+	OutputsKVPath *string `mapstructure:"outputs_kv_path"`
+
 	// Condition optionally configures a single run condition under which the
 	// task will start executing
 	Condition ConditionConfig `mapstructure:"condition"`
@@ -125,6 +174,12 @@ func (c *TaskConfig) Copy() *TaskConfig {
 		o.VarFiles = append(o.VarFiles, c.VarFiles...)
 	}
 
+	o.ServicesTemplate = StringCopy(c.ServicesTemplate)
+
+	o.FileFormat = StringCopy(c.FileFormat)
+
+	o.ServicesProtocol = StringCopy(c.ServicesProtocol)
+
 	if c.Variables != nil {
 		o.Variables = make(map[string]string)
 		for k, v := range c.Variables {
@@ -144,6 +199,12 @@ func (c *TaskConfig) Copy() *TaskConfig {
 
 	o.Enabled = BoolCopy(c.Enabled)
 
+	o.DestroyOnDelete = BoolCopy(c.DestroyOnDelete)
+
+	o.ExposeOutputs = BoolCopy(c.ExposeOutputs)
+
+	o.OutputsKVPath = StringCopy(c.OutputsKVPath)
+
 	if !isConditionNil(c.Condition) {
 		o.Condition = c.Condition.Copy()
 	}
@@ -201,6 +262,18 @@ func (c *TaskConfig) Merge(o *TaskConfig) *TaskConfig {
 
 	r.VarFiles = mergeSlices(r.VarFiles, o.VarFiles)
 
+	if o.ServicesTemplate != nil {
+		r.ServicesTemplate = StringCopy(o.ServicesTemplate)
+	}
+
+	if o.FileFormat != nil {
+		r.FileFormat = StringCopy(o.FileFormat)
+	}
+
+	if o.ServicesProtocol != nil {
+		r.ServicesProtocol = StringCopy(o.ServicesProtocol)
+	}
+
 	for k, v := range o.Variables {
 		r.Variables[k] = v
 	}
@@ -225,6 +298,18 @@ func (c *TaskConfig) Merge(o *TaskConfig) *TaskConfig {
 		r.Enabled = BoolCopy(o.Enabled)
 	}
 
+	if o.DestroyOnDelete != nil {
+		r.DestroyOnDelete = BoolCopy(o.DestroyOnDelete)
+	}
+
+	if o.ExposeOutputs != nil {
+		r.ExposeOutputs = BoolCopy(o.ExposeOutputs)
+	}
+
+	if o.OutputsKVPath != nil {
+		r.OutputsKVPath = StringCopy(o.OutputsKVPath)
+	}
+
 	if !isConditionNil(o.Condition) {
 		if isConditionNil(r.Condition) {
 			r.Condition = o.Condition.Copy()
@@ -283,6 +368,18 @@ func (c *TaskConfig) Finalize(globalBp *BufferPeriodConfig, wd string) {
 		c.VarFiles = []string{}
 	}
 
+	if c.ServicesTemplate == nil {
+		c.ServicesTemplate = String("")
+	}
+
+	if c.FileFormat == nil {
+		c.FileFormat = String("")
+	}
+
+	if c.ServicesProtocol == nil {
+		c.ServicesProtocol = String(ServicesProtocolV0)
+	}
+
 	if c.Variables == nil {
 		c.Variables = make(map[string]string)
 	}
@@ -323,6 +420,18 @@ func (c *TaskConfig) Finalize(globalBp *BufferPeriodConfig, wd string) {
 		c.Enabled = Bool(true)
 	}
 
+	if c.DestroyOnDelete == nil {
+		c.DestroyOnDelete = Bool(false)
+	}
+
+	if c.OutputsKVPath == nil {
+		c.OutputsKVPath = String("")
+	}
+
+	if c.ExposeOutputs == nil {
+		c.ExposeOutputs = Bool(*c.OutputsKVPath != "")
+	}
+
 	if isConditionNil(c.Condition) {
 		c.Condition = EmptyConditionConfig()
 	}
@@ -375,10 +484,38 @@ func (c *TaskConfig) Validate() error {
 	}
 
 	if c.DeprecatedTFVersion != nil && *c.DeprecatedTFVersion != "" {
-		return fmt.Errorf("unsupported configuration 'terraform_version' for "+
-			"task %q. This option is available for Consul-Terraform-Sync Enterprise "+
-			"when using the Terraform Cloud driver, or configure the Terraform client "+
-			"version within the Terraform driver block", *c.Name)
+		_, err := goVersion.NewSemver(*c.DeprecatedTFVersion)
+		if err != nil {
+			return fmt.Errorf("invalid terraform_version for task %q: %s",
+				*c.Name, err)
+		}
+
+		if len(strings.Split(*c.DeprecatedTFVersion, ".")) < 3 {
+			return fmt.Errorf("provide the exact Terraform version to install "+
+				"for task %q: %s", *c.Name, *c.DeprecatedTFVersion)
+		}
+	}
+
+	if c.FileFormat != nil {
+		if err := validateFileFormat(*c.FileFormat); err != nil {
+			return fmt.Errorf("invalid file_format for task %q: %s", *c.Name, err)
+		}
+	}
+
+	if c.ServicesProtocol != nil {
+		switch *c.ServicesProtocol {
+		case ServicesProtocolV0:
+		case ServicesProtocolV1:
+			if StringVal(c.ServicesTemplate) != "" {
+				return fmt.Errorf("services_protocol %q for task %q cannot be "+
+					"configured with services_template, which determines the "+
+					"value of the services variable", *c.ServicesProtocol, *c.Name)
+			}
+		default:
+			return fmt.Errorf("invalid services_protocol for task %q: "+
+				"unsupported version %q, expected %q or %q", *c.Name,
+				*c.ServicesProtocol, ServicesProtocolV0, ServicesProtocolV1)
+		}
 	}
 
 	if c.TFCWorkspace != nil && !c.TFCWorkspace.IsEmpty() {
@@ -399,6 +536,17 @@ func (c *TaskConfig) Validate() error {
 
 	// TODO validate c.Variables
 
+	if c.OutputsKVPath != nil && strings.HasPrefix(*c.OutputsKVPath, "/") {
+		return fmt.Errorf("outputs_kv_path for task %q cannot begin with a "+
+			"'/': %q", *c.Name, *c.OutputsKVPath)
+	}
+
+	if StringVal(c.OutputsKVPath) != "" && c.ExposeOutputs != nil &&
+		!*c.ExposeOutputs {
+		return fmt.Errorf("outputs_kv_path for task %q requires "+
+			"expose_outputs to be enabled", *c.Name)
+	}
+
 	if err := c.BufferPeriod.Validate(); err != nil {
 		return err
 	}
@@ -430,10 +578,16 @@ func (c *TaskConfig) GoString() string {
 		"Services (deprecated):%s, "+
 		"Module:%s, "+
 		"VarFiles:%s, "+
+		"ServicesTemplate:%s, "+
+		"FileFormat:%s, "+
+		"ServicesProtocol:%s, "+
 		"Version:%s, "+
-		"TFVersion: %s, "+
+		"DeprecatedTFVersion: %s, "+
 		"BufferPeriod:%s, "+
 		"Enabled:%t, "+
+		"DestroyOnDelete:%t, "+
+		"ExposeOutputs:%t, "+
+		"OutputsKVPath:%s, "+
 		"Condition:%s, "+
 		"ModuleInput:%s"+
 		"}",
@@ -443,10 +597,16 @@ func (c *TaskConfig) GoString() string {
 		c.DeprecatedServices,
 		StringVal(c.Module),
 		c.VarFiles,
+		StringVal(c.ServicesTemplate),
+		StringVal(c.FileFormat),
+		StringVal(c.ServicesProtocol),
 		StringVal(c.Version),
 		StringVal(c.DeprecatedTFVersion),
 		c.BufferPeriod.GoString(),
 		BoolVal(c.Enabled),
+		BoolVal(c.DestroyOnDelete),
+		BoolVal(c.ExposeOutputs),
+		StringVal(c.OutputsKVPath),
 		c.Condition.GoString(),
 		c.ModuleInputs.GoString(),
 	)
@@ -40,6 +40,9 @@ func TestTaskConfig_Copy(t *testing.T) {
 				Module:             String("path"),
 				Version:            String("0.0.0"),
 				Enabled:            Bool(true),
+				DestroyOnDelete:    Bool(true),
+				ExposeOutputs:      Bool(true),
+				OutputsKVPath:      String("cts/outputs/name"),
 				Condition: &CatalogServicesConditionConfig{
 					CatalogServicesMonitorConfig{
 						Regexp:           String(".*"),
@@ -67,6 +70,9 @@ func TestTaskConfig_Copy(t *testing.T) {
 				},
 				WorkingDir:          String("cts-dir"),
 				DeprecatedTFVersion: String("1.0.0"),
+				ServicesTemplate:    String("services.tmpl"),
+				FileFormat:          String(FileFormatJSON),
+				ServicesProtocol:    String(ServicesProtocolV1),
 				TFCWorkspace: &TerraformCloudWorkspaceConfig{
 					ExecutionMode: String("agent"),
 					AgentPoolID:   String("apool-1"),
@@ -355,6 +361,36 @@ func TestTaskConfig_Merge(t *testing.T) {
 			&TaskConfig{Enabled: Bool(false)},
 			&TaskConfig{Enabled: Bool(false)},
 		},
+		{
+			"destroy_on_delete_overrides",
+			&TaskConfig{DestroyOnDelete: Bool(false)},
+			&TaskConfig{DestroyOnDelete: Bool(true)},
+			&TaskConfig{DestroyOnDelete: Bool(true)},
+		},
+		{
+			"destroy_on_delete_empty_one",
+			&TaskConfig{DestroyOnDelete: Bool(true)},
+			&TaskConfig{},
+			&TaskConfig{DestroyOnDelete: Bool(true)},
+		},
+		{
+			"expose_outputs_overrides",
+			&TaskConfig{ExposeOutputs: Bool(true)},
+			&TaskConfig{ExposeOutputs: Bool(false)},
+			&TaskConfig{ExposeOutputs: Bool(false)},
+		},
+		{
+			"outputs_kv_path_overrides",
+			&TaskConfig{OutputsKVPath: String("a")},
+			&TaskConfig{OutputsKVPath: String("b")},
+			&TaskConfig{OutputsKVPath: String("b")},
+		},
+		{
+			"outputs_kv_path_empty_one",
+			&TaskConfig{OutputsKVPath: String("a")},
+			&TaskConfig{},
+			&TaskConfig{OutputsKVPath: String("a")},
+		},
 		{
 			"condition_overrides",
 			&TaskConfig{Condition: &CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Regexp: String(".*")}}},
@@ -379,6 +415,30 @@ func TestTaskConfig_Merge(t *testing.T) {
 			&TaskConfig{Condition: &CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Regexp: String(".*")}}},
 			&TaskConfig{Condition: &CatalogServicesConditionConfig{CatalogServicesMonitorConfig{Regexp: String(".*")}}},
 		},
+		{
+			"file_format_overrides",
+			&TaskConfig{FileFormat: String(FileFormatHCL)},
+			&TaskConfig{FileFormat: String(FileFormatJSON)},
+			&TaskConfig{FileFormat: String(FileFormatJSON)},
+		},
+		{
+			"services_protocol_overrides",
+			&TaskConfig{ServicesProtocol: String(ServicesProtocolV0)},
+			&TaskConfig{ServicesProtocol: String(ServicesProtocolV1)},
+			&TaskConfig{ServicesProtocol: String(ServicesProtocolV1)},
+		},
+		{
+			"services_template_overrides",
+			&TaskConfig{ServicesTemplate: String("a.tmpl")},
+			&TaskConfig{ServicesTemplate: String("b.tmpl")},
+			&TaskConfig{ServicesTemplate: String("b.tmpl")},
+		},
+		{
+			"services_template_empty_one",
+			&TaskConfig{ServicesTemplate: String("a.tmpl")},
+			&TaskConfig{},
+			&TaskConfig{ServicesTemplate: String("a.tmpl")},
+		},
 		{
 			"working_dir_overrides",
 			&TaskConfig{WorkingDir: String("cts-dir")},
@@ -405,45 +465,45 @@ func TestTaskConfig_Merge(t *testing.T) {
 		},
 		{
 			"source_input_merges",
-			&TaskConfig{DeprecatedSourceInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String("a")}}}},
-			&TaskConfig{DeprecatedSourceInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String("b")}}}},
+			&TaskConfig{DeprecatedSourceInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String("a")}}}},
+			&TaskConfig{DeprecatedSourceInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String("b")}}}},
 			&TaskConfig{DeprecatedSourceInputs: &ModuleInputConfigs{
-				&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String("a")}},
-				&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String("b")}},
+				&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String("a")}},
+				&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String("b")}},
 			}},
 		},
 		{
 			"source_input_empty_one",
-			&TaskConfig{DeprecatedSourceInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
+			&TaskConfig{DeprecatedSourceInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String(".*")}}}},
 			&TaskConfig{},
-			&TaskConfig{DeprecatedSourceInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
+			&TaskConfig{DeprecatedSourceInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String(".*")}}}},
 		},
 		{
 			"source_input_empty_two",
 			&TaskConfig{},
-			&TaskConfig{DeprecatedSourceInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
-			&TaskConfig{DeprecatedSourceInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
+			&TaskConfig{DeprecatedSourceInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String(".*")}}}},
+			&TaskConfig{DeprecatedSourceInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String(".*")}}}},
 		},
 		{
 			"module_input_merges",
-			&TaskConfig{ModuleInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String("a")}}}},
-			&TaskConfig{ModuleInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String("b")}}}},
+			&TaskConfig{ModuleInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String("a")}}}},
+			&TaskConfig{ModuleInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String("b")}}}},
 			&TaskConfig{ModuleInputs: &ModuleInputConfigs{
-				&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String("a")}},
-				&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String("b")}},
+				&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String("a")}},
+				&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String("b")}},
 			}},
 		},
 		{
 			"module_input_empty_one",
-			&TaskConfig{ModuleInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
+			&TaskConfig{ModuleInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String(".*")}}}},
 			&TaskConfig{},
-			&TaskConfig{ModuleInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
+			&TaskConfig{ModuleInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String(".*")}}}},
 		},
 		{
 			"module_input_empty_two",
 			&TaskConfig{},
-			&TaskConfig{ModuleInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
-			&TaskConfig{ModuleInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig{Regexp: String(".*")}}}},
+			&TaskConfig{ModuleInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String(".*")}}}},
+			&TaskConfig{ModuleInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String(".*")}}}},
 		},
 		{
 			"tfc_workspace_merges",
@@ -501,6 +561,37 @@ func TestTaskConfig_Merge(t *testing.T) {
 	}
 }
 
+func TestTaskConfig_Finalize_ExposeOutputs(t *testing.T) {
+	cases := []struct {
+		name     string
+		i        *TaskConfig
+		expected bool
+	}{
+		{
+			"default",
+			&TaskConfig{},
+			false,
+		},
+		{
+			"outputs_kv_path",
+			&TaskConfig{OutputsKVPath: String("cts/outputs")},
+			true,
+		},
+		{
+			"configured",
+			&TaskConfig{ExposeOutputs: Bool(true)},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize(DefaultBufferPeriodConfig(), DefaultWorkingDir)
+			assert.Equal(t, tc.expected, *tc.i.ExposeOutputs)
+		})
+	}
+}
+
 func TestTaskConfig_Finalize(t *testing.T) {
 	cases := []struct {
 		name string
@@ -517,12 +608,18 @@ func TestTaskConfig_Finalize(t *testing.T) {
 				DeprecatedServices:  []string{},
 				Module:              String(""),
 				VarFiles:            []string{},
+				ServicesTemplate:    String(""),
+				FileFormat:          String(""),
+				ServicesProtocol:    String(ServicesProtocolV0),
 				Variables:           map[string]string{},
 				Version:             String(""),
 				DeprecatedTFVersion: String(""),
 				TFCWorkspace:        DefaultTerraformCloudWorkspaceConfig(),
 				BufferPeriod:        DefaultBufferPeriodConfig(),
 				Enabled:             Bool(true),
+				DestroyOnDelete:     Bool(false),
+				ExposeOutputs:       Bool(false),
+				OutputsKVPath:       String(""),
 				Condition:           EmptyConditionConfig(),
 				WorkingDir:          String("sync-tasks"),
 				ModuleInputs:        DefaultModuleInputConfigs(),
@@ -540,12 +637,18 @@ func TestTaskConfig_Finalize(t *testing.T) {
 				DeprecatedServices:  []string{},
 				Module:              String(""),
 				VarFiles:            []string{},
+				ServicesTemplate:    String(""),
+				FileFormat:          String(""),
+				ServicesProtocol:    String(ServicesProtocolV0),
 				Variables:           map[string]string{},
 				Version:             String(""),
 				DeprecatedTFVersion: String(""),
 				TFCWorkspace:        DefaultTerraformCloudWorkspaceConfig(),
 				BufferPeriod:        DefaultBufferPeriodConfig(),
 				Enabled:             Bool(true),
+				DestroyOnDelete:     Bool(false),
+				ExposeOutputs:       Bool(false),
+				OutputsKVPath:       String(""),
 				Condition:           EmptyConditionConfig(),
 				WorkingDir:          String("sync-tasks/task"),
 				ModuleInputs:        DefaultModuleInputConfigs(),
@@ -564,6 +667,9 @@ func TestTaskConfig_Finalize(t *testing.T) {
 				DeprecatedServices:  []string{},
 				Module:              String(""),
 				VarFiles:            []string{},
+				ServicesTemplate:    String(""),
+				FileFormat:          String(""),
+				ServicesProtocol:    String(ServicesProtocolV0),
 				Variables:           map[string]string{},
 				Version:             String(""),
 				DeprecatedTFVersion: String(""),
@@ -573,10 +679,13 @@ func TestTaskConfig_Finalize(t *testing.T) {
 					Min:     TimeDuration(0 * time.Second),
 					Max:     TimeDuration(0 * time.Second),
 				},
-				Enabled:      Bool(true),
-				Condition:    &ScheduleConditionConfig{String("")},
-				WorkingDir:   String("sync-tasks/task"),
-				ModuleInputs: DefaultModuleInputConfigs(),
+				Enabled:         Bool(true),
+				DestroyOnDelete: Bool(false),
+				ExposeOutputs:   Bool(false),
+				OutputsKVPath:   String(""),
+				Condition:       &ScheduleConditionConfig{String("")},
+				WorkingDir:      String("sync-tasks/task"),
+				ModuleInputs:    DefaultModuleInputConfigs(),
 			},
 		},
 		{
@@ -586,7 +695,7 @@ func TestTaskConfig_Finalize(t *testing.T) {
 				Condition: &ScheduleConditionConfig{},
 				ModuleInputs: &ModuleInputConfigs{
 					&ServicesModuleInputConfig{
-						ServicesMonitorConfig{Regexp: String("^api$")}},
+						ServicesMonitorConfig: ServicesMonitorConfig{Regexp: String("^api$")}},
 				},
 			},
 			&TaskConfig{
@@ -596,6 +705,9 @@ func TestTaskConfig_Finalize(t *testing.T) {
 				DeprecatedServices:  []string{},
 				Module:              String(""),
 				VarFiles:            []string{},
+				ServicesTemplate:    String(""),
+				FileFormat:          String(""),
+				ServicesProtocol:    String(ServicesProtocolV0),
 				Variables:           map[string]string{},
 				Version:             String(""),
 				DeprecatedTFVersion: String(""),
@@ -605,18 +717,26 @@ func TestTaskConfig_Finalize(t *testing.T) {
 					Min:     TimeDuration(0 * time.Second),
 					Max:     TimeDuration(0 * time.Second),
 				},
-				Enabled:    Bool(true),
-				Condition:  &ScheduleConditionConfig{String("")},
-				WorkingDir: String("sync-tasks/task"),
+				Enabled:         Bool(true),
+				DestroyOnDelete: Bool(false),
+				ExposeOutputs:   Bool(false),
+				OutputsKVPath:   String(""),
+				Condition:       &ScheduleConditionConfig{String("")},
+				WorkingDir:      String("sync-tasks/task"),
 				ModuleInputs: &ModuleInputConfigs{&ServicesModuleInputConfig{
-					ServicesMonitorConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
 						Regexp:             String("^api$"),
 						Names:              []string{},
 						Datacenter:         String(""),
 						Namespace:          String(""),
+						Partition:          String(""),
+						Peer:               String(""),
 						Filter:             String(""),
+						Status:             String(HealthPassing),
 						CTSUserDefinedMeta: map[string]string{},
-					}}},
+					},
+					Alias: String(""),
+				}},
 			},
 		},
 	}
@@ -688,6 +808,7 @@ func TestTaskConfig_Finalize_DeprecatedSourceInputs(t *testing.T) {
 						ConsulKVMonitorConfig: ConsulKVMonitorConfig{
 							Path: String("path"),
 						},
+						Alias: String(""),
 					},
 				},
 			},
@@ -698,7 +819,10 @@ func TestTaskConfig_Finalize_DeprecatedSourceInputs(t *testing.T) {
 						Recurse:    Bool(false),
 						Datacenter: String(""),
 						Namespace:  String(""),
+						Partition:  String(""),
+						Decode:     String(""),
 					},
+					Alias: String(""),
 				},
 			},
 		},
@@ -710,6 +834,7 @@ func TestTaskConfig_Finalize_DeprecatedSourceInputs(t *testing.T) {
 						ServicesMonitorConfig: ServicesMonitorConfig{
 							Regexp: String(".*"),
 						},
+						Alias: String(""),
 					},
 				},
 			},
@@ -720,9 +845,13 @@ func TestTaskConfig_Finalize_DeprecatedSourceInputs(t *testing.T) {
 						Names:              []string{},
 						Datacenter:         String(""),
 						Namespace:          String(""),
+						Partition:          String(""),
+						Peer:               String(""),
 						Filter:             String(""),
+						Status:             String(HealthPassing),
 						CTSUserDefinedMeta: map[string]string{},
 					},
+					Alias: String(""),
 				},
 			},
 		},
@@ -734,6 +863,7 @@ func TestTaskConfig_Finalize_DeprecatedSourceInputs(t *testing.T) {
 						ConsulKVMonitorConfig: ConsulKVMonitorConfig{
 							Path: String("path"),
 						},
+						Alias: String(""),
 					},
 				},
 				DeprecatedSourceInputs: &ModuleInputConfigs{
@@ -741,6 +871,7 @@ func TestTaskConfig_Finalize_DeprecatedSourceInputs(t *testing.T) {
 						ServicesMonitorConfig: ServicesMonitorConfig{
 							Regexp: String(".*"),
 						},
+						Alias: String(""),
 					},
 				},
 			},
@@ -751,7 +882,10 @@ func TestTaskConfig_Finalize_DeprecatedSourceInputs(t *testing.T) {
 						Recurse:    Bool(false),
 						Datacenter: String(""),
 						Namespace:  String(""),
+						Partition:  String(""),
+						Decode:     String(""),
 					},
+					Alias: String(""),
 				},
 				&ServicesModuleInputConfig{
 					ServicesMonitorConfig: ServicesMonitorConfig{
@@ -759,9 +893,13 @@ func TestTaskConfig_Finalize_DeprecatedSourceInputs(t *testing.T) {
 						Names:              []string{},
 						Datacenter:         String(""),
 						Namespace:          String(""),
+						Partition:          String(""),
+						Peer:               String(""),
 						Filter:             String(""),
+						Status:             String(HealthPassing),
 						CTSUserDefinedMeta: map[string]string{},
 					},
+					Alias: String(""),
 				},
 			},
 		},
@@ -834,7 +972,7 @@ func TestTaskConfig_Validate(t *testing.T) {
 			false,
 		},
 		{
-			"invalid: TF version: unsupported version",
+			"valid: TF version",
 			&TaskConfig{
 				Name: String("task"),
 				Condition: &ServicesConditionConfig{
@@ -845,6 +983,63 @@ func TestTaskConfig_Validate(t *testing.T) {
 				Module:              String("path"),
 				DeprecatedTFVersion: String("0.15.0"),
 			},
+			true,
+		},
+		{
+			"invalid: TF version: not exact",
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				Module:              String("path"),
+				DeprecatedTFVersion: String("1.1"),
+			},
+			false,
+		},
+		{
+			"invalid: TF version: malformed",
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				Module:              String("path"),
+				DeprecatedTFVersion: String("latest"),
+			},
+			false,
+		},
+		{
+			"invalid: outputs KV path leading slash",
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				Module:        String("path"),
+				OutputsKVPath: String("/cts/outputs"),
+			},
+			false,
+		},
+		{
+			"invalid: outputs KV path without expose outputs",
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				Module:        String("path"),
+				ExposeOutputs: Bool(false),
+				OutputsKVPath: String("cts/outputs"),
+			},
 			false,
 		},
 		{
@@ -905,6 +1100,77 @@ func TestTaskConfig_Validate(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"valid: file_format",
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				Module:     String("path"),
+				FileFormat: String(FileFormatJSON),
+			},
+			true,
+		},
+		{
+			"invalid: file_format",
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				Module:     String("path"),
+				FileFormat: String("yaml"),
+			},
+			false,
+		},
+		{
+			"valid: services_protocol",
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				Module:           String("path"),
+				ServicesProtocol: String(ServicesProtocolV1),
+			},
+			true,
+		},
+		{
+			"invalid: services_protocol",
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				Module:           String("path"),
+				ServicesProtocol: String("v2"),
+			},
+			false,
+		},
+		{
+			"invalid: services_protocol with services_template",
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				Module:           String("path"),
+				ServicesTemplate: String("services.tmpl"),
+				ServicesProtocol: String(ServicesProtocolV1),
+			},
+			false,
+		},
 	}
 
 	for i, tc := range cases {
@@ -1019,21 +1285,6 @@ func TestTasksConfig_Validate(t *testing.T) {
 				},
 			},
 			isValid: false,
-		}, {
-			name: "unsupported TF version per task",
-			i: []*TaskConfig{
-				{
-					Name: String("task"),
-					Condition: &ServicesConditionConfig{
-						ServicesMonitorConfig: ServicesMonitorConfig{
-							Names: []string{"api"},
-						},
-					},
-					Module:              String("path"),
-					DeprecatedTFVersion: String("0.15.0"),
-				},
-			},
-			isValid: false,
 		},
 	}
 
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
@@ -18,6 +20,38 @@ const (
 	logSystemName          = "config"
 )
 
+const (
+	// TerraformFlavorTerraform is the flavor for HashiCorp Terraform
+	TerraformFlavorTerraform = "terraform"
+
+	// TerraformFlavorOpenTofu is the flavor for OpenTofu, a
+	// Terraform-compatible alternative
+	TerraformFlavorOpenTofu = "opentofu"
+
+	// DefaultTerraformBinaryName is the default name of the Terraform binary
+	DefaultTerraformBinaryName = "terraform"
+
+	// DefaultOpenTofuBinaryName is the default name of the OpenTofu binary
+	DefaultOpenTofuBinaryName = "tofu"
+
+	// FileFormatHCL is the file format for generating the root module and
+	// input variables in the HCL native syntax
+	FileFormatHCL = "hcl"
+
+	// FileFormatJSON is the file format for generating the root module and
+	// input variables in the JSON syntax as main.tf.json and
+	// terraform.tfvars.json
+	FileFormatJSON = "json"
+)
+
+// sha256Re matches a SHA256 checksum in hex
+var sha256Re = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+// IsSHA256Checksum returns whether the value is a SHA256 checksum in hex
+func IsSHA256Checksum(s string) bool {
+	return sha256Re.MatchString(s)
+}
+
 // TerraformConfig is the configuration for the Terraform driver.
 type TerraformConfig struct {
 	Version           *string                `mapstructure:"version"`
@@ -26,6 +60,49 @@ type TerraformConfig struct {
 	Path              *string                `mapstructure:"path"`
 	Backend           map[string]interface{} `mapstructure:"backend"`
 	RequiredProviders map[string]interface{} `mapstructure:"required_providers"`
+
+	// Flavor is the distribution of the Terraform-compatible binary that the
+	// driver runs, either "terraform" or "opentofu".
+	Flavor *string `mapstructure:"flavor"`
+
+	// BinaryName is the file name of the binary within Path. Defaults to
+	// "terraform" for the terraform flavor and "tofu" for the opentofu flavor.
+	BinaryName *string `mapstructure:"binary_name"`
+
+	// ArchivePath is the path to a local zip archive containing the binary.
+	// When the binary does not exist within Path, it is extracted from the
+	// archive instead of being downloaded.
+	ArchivePath *string `mapstructure:"archive_path"`
+
+	// PluginCacheDir is the directory for a provider plugin cache shared by
+	// all tasks, so that each provider version is downloaded once instead of
+	// for each task. The cache is disabled when empty.
+	PluginCacheDir *string `mapstructure:"plugin_cache_dir"`
+
+	// Mirror configures local mirrors of modules and providers for running
+	// without internet access.
+	Mirror *TerraformMirrorConfig `mapstructure:"mirror"`
+
+	// Checksums is a list of trusted SHA256 checksums, in hex, of binaries and
+	// archives. When checksums are configured, the binary found in Path, the
+	// archive at ArchivePath, and downloaded binaries must match a trusted
+	// checksum or they are not used. A binary in Path also matches when it is
+	// the binary within a trusted archive at ArchivePath.
+	Checksums []string `mapstructure:"checksums"`
+
+	// ChecksumsFile is the path to a file of trusted checksums in the format
+	// of the sha256sum command, such as the SHA256SUMS file of a release. Its
+	// checksums are trusted in addition to Checksums.
+	ChecksumsFile *string `mapstructure:"checksums_file"`
+
+	// FileFormat is the format of the generated root module and rendered input
+	// variables of tasks, either "hcl" or "json". Tasks can override the
+	// format with the task's file_format option.
+	FileFormat *string `mapstructure:"file_format"`
+
+	// GitOutput configures the git client, which commits the generated root
+	// modules to a git repository instead of running Terraform.
+	GitOutput *TerraformGitOutputConfig `mapstructure:"git_output"`
 }
 
 // DefaultTerraformConfig returns the default configuration struct.
@@ -43,6 +120,15 @@ func DefaultTerraformConfig() *TerraformConfig {
 		Path:              String(wd),
 		Backend:           make(map[string]interface{}),
 		RequiredProviders: make(map[string]interface{}),
+		Flavor:            String(TerraformFlavorTerraform),
+		BinaryName:        String(DefaultTerraformBinaryName),
+		ArchivePath:       String(""),
+		PluginCacheDir:    String(""),
+		Mirror:            DefaultTerraformMirrorConfig(),
+		Checksums:         []string{},
+		ChecksumsFile:     String(""),
+		FileFormat:        String(FileFormatHCL),
+		GitOutput:         DefaultTerraformGitOutputConfig(),
 	}
 }
 
@@ -127,6 +213,21 @@ func (c *TerraformConfig) Copy() *TerraformConfig {
 		}
 	}
 
+	o.Flavor = StringCopy(c.Flavor)
+	o.BinaryName = StringCopy(c.BinaryName)
+	o.ArchivePath = StringCopy(c.ArchivePath)
+	o.PluginCacheDir = StringCopy(c.PluginCacheDir)
+	o.Mirror = c.Mirror.Copy()
+
+	if c.Checksums != nil {
+		o.Checksums = make([]string, 0, len(c.Checksums))
+		o.Checksums = append(o.Checksums, c.Checksums...)
+	}
+
+	o.ChecksumsFile = StringCopy(c.ChecksumsFile)
+	o.FileFormat = StringCopy(c.FileFormat)
+	o.GitOutput = c.GitOutput.Copy()
+
 	return &o
 }
 
@@ -185,6 +286,40 @@ func (c *TerraformConfig) Merge(o *TerraformConfig) *TerraformConfig {
 		}
 	}
 
+	if o.Flavor != nil {
+		r.Flavor = StringCopy(o.Flavor)
+	}
+
+	if o.BinaryName != nil {
+		r.BinaryName = StringCopy(o.BinaryName)
+	}
+
+	if o.ArchivePath != nil {
+		r.ArchivePath = StringCopy(o.ArchivePath)
+	}
+
+	if o.PluginCacheDir != nil {
+		r.PluginCacheDir = StringCopy(o.PluginCacheDir)
+	}
+
+	if o.Mirror != nil {
+		r.Mirror = r.Mirror.Merge(o.Mirror)
+	}
+
+	r.Checksums = mergeSlices(r.Checksums, o.Checksums)
+
+	if o.ChecksumsFile != nil {
+		r.ChecksumsFile = StringCopy(o.ChecksumsFile)
+	}
+
+	if o.FileFormat != nil {
+		r.FileFormat = StringCopy(o.FileFormat)
+	}
+
+	if o.GitOutput != nil {
+		r.GitOutput = r.GitOutput.Merge(o.GitOutput)
+	}
+
 	return r
 }
 
@@ -234,6 +369,48 @@ func (c *TerraformConfig) Finalize(consul *ConsulConfig) {
 	if c.RequiredProviders == nil {
 		c.RequiredProviders = make(map[string]interface{})
 	}
+
+	if c.Flavor == nil || *c.Flavor == "" {
+		c.Flavor = String(TerraformFlavorTerraform)
+	}
+
+	if c.BinaryName == nil || *c.BinaryName == "" {
+		if *c.Flavor == TerraformFlavorOpenTofu {
+			c.BinaryName = String(DefaultOpenTofuBinaryName)
+		} else {
+			c.BinaryName = String(DefaultTerraformBinaryName)
+		}
+	}
+
+	if c.ArchivePath == nil {
+		c.ArchivePath = String("")
+	}
+
+	if c.PluginCacheDir == nil {
+		c.PluginCacheDir = String("")
+	}
+
+	if c.Mirror == nil {
+		c.Mirror = DefaultTerraformMirrorConfig()
+	}
+	c.Mirror.Finalize()
+
+	if c.Checksums == nil {
+		c.Checksums = []string{}
+	}
+
+	if c.ChecksumsFile == nil {
+		c.ChecksumsFile = String("")
+	}
+
+	if c.FileFormat == nil || *c.FileFormat == "" {
+		c.FileFormat = String(FileFormatHCL)
+	}
+
+	if c.GitOutput == nil {
+		c.GitOutput = DefaultTerraformGitOutputConfig()
+	}
+	c.GitOutput.Finalize()
 }
 
 // Validate validates the values and nested values of the configuration struct
@@ -242,6 +419,23 @@ func (c *TerraformConfig) Validate() error {
 		return fmt.Errorf("missing Terraform driver configuration")
 	}
 
+	if c.Flavor != nil {
+		switch *c.Flavor {
+		case TerraformFlavorTerraform, TerraformFlavorOpenTofu:
+		default:
+			return fmt.Errorf("unsupported flavor for the Terraform driver %q, "+
+				"expected %q or %q", *c.Flavor, TerraformFlavorTerraform,
+				TerraformFlavorOpenTofu)
+		}
+	}
+
+	if c.BinaryName != nil && *c.BinaryName != "" {
+		if filepath.Base(*c.BinaryName) != *c.BinaryName {
+			return fmt.Errorf("binary_name must be a file name within the "+
+				"Terraform path, configure the directory with path: %s", *c.BinaryName)
+		}
+	}
+
 	if c.Version != nil && *c.Version != "" {
 		v, err := goVersion.NewSemver(*c.Version)
 		if err != nil {
@@ -249,13 +443,14 @@ func (c *TerraformConfig) Validate() error {
 		}
 
 		if len(strings.Split(*c.Version, ".")) < 3 {
-			return fmt.Errorf("provide the exact Terraform version to install: %s", *c.Version)
+			return fmt.Errorf("provide the exact %s version to install: %s",
+				c.ProductName(), *c.Version)
 		}
 
-		if !ctsVersion.TerraformConstraint.Check(v) {
-			return fmt.Errorf("Terraform version is not supported by Consul-"+
+		if constraint, raw := c.VersionConstraint(); !constraint.Check(v) {
+			return fmt.Errorf("%s version is not supported by Consul-"+
 				"Terraform-Sync, try updating to a different version (%s): %s",
-				ctsVersion.CompatibleTerraformVersionConstraint, *c.Version)
+				c.ProductName(), raw, *c.Version)
 		}
 	}
 
@@ -263,6 +458,27 @@ func (c *TerraformConfig) Validate() error {
 		return fmt.Errorf("missing Terraform backend configuration")
 	}
 
+	if err := c.Mirror.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.GitOutput.Validate(); err != nil {
+		return err
+	}
+
+	if c.FileFormat != nil {
+		if err := validateFileFormat(*c.FileFormat); err != nil {
+			return fmt.Errorf("invalid file_format for the Terraform driver: %s", err)
+		}
+	}
+
+	for _, checksum := range c.Checksums {
+		if !IsSHA256Checksum(checksum) {
+			return fmt.Errorf("invalid checksum for the Terraform driver, "+
+				"expected a SHA256 checksum in hex: %q", checksum)
+		}
+	}
+
 	// Backend is only validated for supported backend label. The backend
 	// configuration options are verified at run time. The allowed backends
 	// for state store have state locking and workspace suppport.
@@ -297,7 +513,16 @@ func (c *TerraformConfig) GoString() string {
 		"PersistLog:%v, "+
 		"Path:%s, "+
 		"Backend:%+v, "+
-		"RequiredProviders:%+v"+
+		"RequiredProviders:%+v, "+
+		"Flavor:%s, "+
+		"BinaryName:%s, "+
+		"ArchivePath:%s, "+
+		"PluginCacheDir:%s, "+
+		"Mirror:%s, "+
+		"Checksums:%v, "+
+		"ChecksumsFile:%s, "+
+		"FileFormat:%s, "+
+		"GitOutput:%s"+
 		"}",
 		StringVal(c.Version),
 		BoolVal(c.Log),
@@ -305,16 +530,66 @@ func (c *TerraformConfig) GoString() string {
 		StringVal(c.Path),
 		c.Backend,
 		c.RequiredProviders,
+		StringVal(c.Flavor),
+		StringVal(c.BinaryName),
+		StringVal(c.ArchivePath),
+		StringVal(c.PluginCacheDir),
+		c.Mirror.GoString(),
+		c.Checksums,
+		StringVal(c.ChecksumsFile),
+		StringVal(c.FileFormat),
+		c.GitOutput.GoString(),
 	)
 }
 
+// ChecksumVerificationEnabled returns whether binaries and archives must
+// match a trusted checksum.
+func (c *TerraformConfig) ChecksumVerificationEnabled() bool {
+	return c != nil && (len(c.Checksums) > 0 || StringVal(c.ChecksumsFile) != "")
+}
+
+// IsOpenTofu returns if the driver is configured to run OpenTofu instead of
+// Terraform.
+func (c *TerraformConfig) IsOpenTofu() bool {
+	return c != nil && StringVal(c.Flavor) == TerraformFlavorOpenTofu
+}
+
+// ProductName returns the display name of the configured flavor.
+func (c *TerraformConfig) ProductName() string {
+	if c.IsOpenTofu() {
+		return "OpenTofu"
+	}
+	return "Terraform"
+}
+
+// VersionConstraint returns the version constraint supported by CTS for the
+// configured flavor along with its string representation.
+func (c *TerraformConfig) VersionConstraint() (goVersion.Constraints, string) {
+	if c.IsOpenTofu() {
+		return ctsVersion.OpenTofuConstraint, ctsVersion.CompatibleOpenTofuVersionConstraint
+	}
+	return ctsVersion.TerraformConstraint, ctsVersion.CompatibleTerraformVersionConstraint
+}
+
 // IsConsulBackend returns if the Terraform backend is using Consul KV for
 // remote state store.
 func (c *TerraformConfig) IsConsulBackend() bool {
-	if c.Backend == nil {
+	if c == nil || c.Backend == nil {
 		return false
 	}
 
 	_, ok := c.Backend["consul"]
 	return ok
 }
+
+// validateFileFormat validates the format of the generated files. An empty
+// format is valid and uses the default format.
+func validateFileFormat(format string) error {
+	switch format {
+	case "", FileFormatHCL, FileFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("unsupported file format %q, expected %q or %q",
+			format, FileFormatHCL, FileFormatJSON)
+	}
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// DefaultGitOutputBranch is the default branch that the git client pushes
+	// commits to
+	DefaultGitOutputBranch = "main"
+
+	// DefaultGitOutputAuthorName and DefaultGitOutputAuthorEmail are the
+	// default author of the commits made by the git client
+	DefaultGitOutputAuthorName  = "Consul-Terraform-Sync"
+	DefaultGitOutputAuthorEmail = "consul-terraform-sync@localhost"
+
+	// GitClientType is the client type for the Terraform driver that commits
+	// the generated root module of each task to a git repository instead of
+	// running Terraform
+	GitClientType = "git"
+)
+
+// TerraformGitOutputConfig configures the git client of the Terraform driver,
+// which commits the generated root module of each task to a local git
+// repository so that changes can be reviewed and applied outside of CTS. The
+// git client is used when the client_type is "git".
+type TerraformGitOutputConfig struct {
+	// Path is the directory of the local git repository. The repository is
+	// initialized if it does not exist. The files of each task are written
+	// to a directory named after the task.
+	Path *string `mapstructure:"path"`
+
+	// Remote is the name or URL of the remote that commits are pushed to.
+	// Commits are not pushed when empty.
+	Remote *string `mapstructure:"remote"`
+
+	// Branch is the branch that commits are pushed to, which is also the
+	// initial branch of a new repository.
+	Branch *string `mapstructure:"branch"`
+
+	// AuthorName and AuthorEmail are the author and committer of the commits.
+	AuthorName  *string `mapstructure:"author_name"`
+	AuthorEmail *string `mapstructure:"author_email"`
+}
+
+// DefaultTerraformGitOutputConfig returns the default configuration struct.
+func DefaultTerraformGitOutputConfig() *TerraformGitOutputConfig {
+	return &TerraformGitOutputConfig{
+		Path:        String(""),
+		Remote:      String(""),
+		Branch:      String(DefaultGitOutputBranch),
+		AuthorName:  String(DefaultGitOutputAuthorName),
+		AuthorEmail: String(DefaultGitOutputAuthorEmail),
+	}
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *TerraformGitOutputConfig) Copy() *TerraformGitOutputConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o TerraformGitOutputConfig
+	o.Path = StringCopy(c.Path)
+	o.Remote = StringCopy(c.Remote)
+	o.Branch = StringCopy(c.Branch)
+	o.AuthorName = StringCopy(c.AuthorName)
+	o.AuthorEmail = StringCopy(c.AuthorEmail)
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *TerraformGitOutputConfig) Merge(o *TerraformGitOutputConfig) *TerraformGitOutputConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Path != nil {
+		r.Path = StringCopy(o.Path)
+	}
+
+	if o.Remote != nil {
+		r.Remote = StringCopy(o.Remote)
+	}
+
+	if o.Branch != nil {
+		r.Branch = StringCopy(o.Branch)
+	}
+
+	if o.AuthorName != nil {
+		r.AuthorName = StringCopy(o.AuthorName)
+	}
+
+	if o.AuthorEmail != nil {
+		r.AuthorEmail = StringCopy(o.AuthorEmail)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *TerraformGitOutputConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.Path == nil {
+		c.Path = String("")
+	}
+
+	if c.Remote == nil {
+		c.Remote = String("")
+	}
+
+	if c.Branch == nil || *c.Branch == "" {
+		c.Branch = String(DefaultGitOutputBranch)
+	}
+
+	if c.AuthorName == nil || *c.AuthorName == "" {
+		c.AuthorName = String(DefaultGitOutputAuthorName)
+	}
+
+	if c.AuthorEmail == nil || *c.AuthorEmail == "" {
+		c.AuthorEmail = String(DefaultGitOutputAuthorEmail)
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct.
+// The path is only required when the git client is used, which is validated
+// with the client type.
+func (c *TerraformGitOutputConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.Branch != nil && *c.Branch != "" {
+		if *c.Branch != strings.TrimSpace(*c.Branch) || strings.HasPrefix(*c.Branch, "-") {
+			return fmt.Errorf("invalid branch for the git_output: %q", *c.Branch)
+		}
+	}
+
+	if c.Remote != nil && strings.HasPrefix(*c.Remote, "-") {
+		return fmt.Errorf("invalid remote for the git_output: %q", *c.Remote)
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *TerraformGitOutputConfig) GoString() string {
+	if c == nil {
+		return "(*TerraformGitOutputConfig)(nil)"
+	}
+
+	// remote URLs may contain credentials
+	remote := StringVal(c.Remote)
+	if u, err := url.Parse(remote); err == nil && u.User != nil {
+		remote = u.Redacted()
+	}
+
+	return fmt.Sprintf("&TerraformGitOutputConfig{"+
+		"Path:%s, "+
+		"Remote:%s, "+
+		"Branch:%s, "+
+		"AuthorName:%s, "+
+		"AuthorEmail:%s"+
+		"}",
+		StringVal(c.Path),
+		remote,
+		StringVal(c.Branch),
+		StringVal(c.AuthorName),
+		StringVal(c.AuthorEmail),
+	)
+}
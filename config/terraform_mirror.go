@@ -0,0 +1,146 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// TerraformMirrorConfig configures local mirrors of the modules and providers
+// used by tasks so that the Terraform driver can run without internet access.
+type TerraformMirrorConfig struct {
+	// ModuleDir is the directory of the local module mirror. Remote module
+	// sources that exist in the mirror are used from the mirror instead of
+	// being downloaded.
+	//
+	// Registry modules are located at <host>/<namespace>/<name>/<system>/<version>
+	// within the directory, and other remote modules are located at a
+	// directory named after the source with special characters replaced.
+	ModuleDir *string `mapstructure:"module_dir"`
+
+	// FilesystemMirror is a directory that Terraform installs providers from,
+	// in the layout created by `terraform providers mirror`.
+	FilesystemMirror *string `mapstructure:"filesystem_mirror"`
+
+	// NetworkMirror is the HTTPS URL of a provider network mirror that
+	// Terraform installs providers from.
+	NetworkMirror *string `mapstructure:"network_mirror"`
+}
+
+// DefaultTerraformMirrorConfig returns the default configuration struct.
+func DefaultTerraformMirrorConfig() *TerraformMirrorConfig {
+	return &TerraformMirrorConfig{
+		ModuleDir:        String(""),
+		FilesystemMirror: String(""),
+		NetworkMirror:    String(""),
+	}
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *TerraformMirrorConfig) Copy() *TerraformMirrorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o TerraformMirrorConfig
+	o.ModuleDir = StringCopy(c.ModuleDir)
+	o.FilesystemMirror = StringCopy(c.FilesystemMirror)
+	o.NetworkMirror = StringCopy(c.NetworkMirror)
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *TerraformMirrorConfig) Merge(o *TerraformMirrorConfig) *TerraformMirrorConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.ModuleDir != nil {
+		r.ModuleDir = StringCopy(o.ModuleDir)
+	}
+
+	if o.FilesystemMirror != nil {
+		r.FilesystemMirror = StringCopy(o.FilesystemMirror)
+	}
+
+	if o.NetworkMirror != nil {
+		r.NetworkMirror = StringCopy(o.NetworkMirror)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *TerraformMirrorConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.ModuleDir == nil {
+		c.ModuleDir = String("")
+	}
+
+	if c.FilesystemMirror == nil {
+		c.FilesystemMirror = String("")
+	}
+
+	if c.NetworkMirror == nil {
+		c.NetworkMirror = String("")
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *TerraformMirrorConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.NetworkMirror != nil && *c.NetworkMirror != "" {
+		u, err := url.Parse(*c.NetworkMirror)
+		if err != nil {
+			return fmt.Errorf("invalid network_mirror for the Terraform driver: %s", err)
+		}
+		if u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("network_mirror for the Terraform driver must be "+
+				"an https URL: %s", *c.NetworkMirror)
+		}
+	}
+
+	return nil
+}
+
+// ProviderMirrorEnabled returns whether providers are installed from a mirror.
+func (c *TerraformMirrorConfig) ProviderMirrorEnabled() bool {
+	if c == nil {
+		return false
+	}
+	return StringVal(c.FilesystemMirror) != "" || StringVal(c.NetworkMirror) != ""
+}
+
+// GoString defines the printable version of this struct.
+func (c *TerraformMirrorConfig) GoString() string {
+	if c == nil {
+		return "(*TerraformMirrorConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&TerraformMirrorConfig{"+
+		"ModuleDir:%s, "+
+		"FilesystemMirror:%s, "+
+		"NetworkMirror:%s"+
+		"}",
+		StringVal(c.ModuleDir),
+		StringVal(c.FilesystemMirror),
+		StringVal(c.NetworkMirror),
+	)
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerraformMirrorConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *TerraformMirrorConfig
+	}{
+		{
+			"nil",
+			nil,
+		}, {
+			"empty",
+			&TerraformMirrorConfig{},
+		}, {
+			"default",
+			DefaultTerraformMirrorConfig(),
+		}, {
+			"fully_configured",
+			&TerraformMirrorConfig{
+				ModuleDir:        String("/opt/cts/modules"),
+				FilesystemMirror: String("/opt/cts/providers"),
+				NetworkMirror:    String("https://mirror.example.com/providers/"),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestTerraformMirrorConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *TerraformMirrorConfig
+		b    *TerraformMirrorConfig
+		r    *TerraformMirrorConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&TerraformMirrorConfig{},
+			&TerraformMirrorConfig{},
+		},
+		{
+			"nil_b",
+			&TerraformMirrorConfig{},
+			nil,
+			&TerraformMirrorConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"module_dir_overrides",
+			&TerraformMirrorConfig{ModuleDir: String("a")},
+			&TerraformMirrorConfig{ModuleDir: String("b")},
+			&TerraformMirrorConfig{ModuleDir: String("b")},
+		},
+		{
+			"filesystem_mirror_empty_one",
+			&TerraformMirrorConfig{FilesystemMirror: String("a")},
+			&TerraformMirrorConfig{},
+			&TerraformMirrorConfig{FilesystemMirror: String("a")},
+		},
+		{
+			"network_mirror_empty_two",
+			&TerraformMirrorConfig{},
+			&TerraformMirrorConfig{NetworkMirror: String("https://a")},
+			&TerraformMirrorConfig{NetworkMirror: String("https://a")},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestTerraformMirrorConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *TerraformMirrorConfig
+		r    *TerraformMirrorConfig
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&TerraformMirrorConfig{},
+			DefaultTerraformMirrorConfig(),
+		},
+		{
+			"module_dir",
+			&TerraformMirrorConfig{ModuleDir: String("modules")},
+			&TerraformMirrorConfig{
+				ModuleDir:        String("modules"),
+				FilesystemMirror: String(""),
+				NetworkMirror:    String(""),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestTerraformMirrorConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *TerraformMirrorConfig
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		}, {
+			"empty",
+			DefaultTerraformMirrorConfig(),
+			true,
+		}, {
+			"valid_network_mirror",
+			&TerraformMirrorConfig{NetworkMirror: String("https://mirror.example.com/")},
+			true,
+		}, {
+			"http_network_mirror",
+			&TerraformMirrorConfig{NetworkMirror: String("http://mirror.example.com/")},
+			false,
+		}, {
+			"network_mirror_missing_host",
+			&TerraformMirrorConfig{NetworkMirror: String("https://")},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestTerraformMirrorConfig_ProviderMirrorEnabled(t *testing.T) {
+	t.Parallel()
+
+	var nilConf *TerraformMirrorConfig
+	assert.False(t, nilConf.ProviderMirrorEnabled())
+	assert.False(t, DefaultTerraformMirrorConfig().ProviderMirrorEnabled())
+	assert.False(t, (&TerraformMirrorConfig{ModuleDir: String("a")}).ProviderMirrorEnabled())
+	assert.True(t, (&TerraformMirrorConfig{FilesystemMirror: String("a")}).ProviderMirrorEnabled())
+	assert.True(t, (&TerraformMirrorConfig{NetworkMirror: String("https://a")}).ProviderMirrorEnabled())
+}
@@ -45,6 +45,8 @@ func TestTerraformConfig_Copy(t *testing.T) {
 						"source":  "namespace/pName2",
 					},
 				},
+				Checksums:     []string{"abc"},
+				ChecksumsFile: String("SHA256SUMS"),
 			},
 		},
 	}
@@ -180,6 +182,42 @@ func TestTerraformConfig_Merge(t *testing.T) {
 			&TerraformConfig{Path: String("path")},
 			&TerraformConfig{Path: String("path")},
 		},
+		{
+			"flavor_overrides",
+			&TerraformConfig{Flavor: String(TerraformFlavorTerraform)},
+			&TerraformConfig{Flavor: String(TerraformFlavorOpenTofu)},
+			&TerraformConfig{Flavor: String(TerraformFlavorOpenTofu)},
+		},
+		{
+			"binary_name_empty_one",
+			&TerraformConfig{BinaryName: String("tofu")},
+			&TerraformConfig{},
+			&TerraformConfig{BinaryName: String("tofu")},
+		},
+		{
+			"archive_path_empty_two",
+			&TerraformConfig{},
+			&TerraformConfig{ArchivePath: String("tofu.zip")},
+			&TerraformConfig{ArchivePath: String("tofu.zip")},
+		},
+		{
+			"plugin_cache_dir_overrides",
+			&TerraformConfig{PluginCacheDir: String("a")},
+			&TerraformConfig{PluginCacheDir: String("b")},
+			&TerraformConfig{PluginCacheDir: String("b")},
+		},
+		{
+			"checksums_merges",
+			&TerraformConfig{Checksums: []string{"a", "b"}},
+			&TerraformConfig{Checksums: []string{"b", "c"}},
+			&TerraformConfig{Checksums: []string{"a", "b", "c"}},
+		},
+		{
+			"checksums_file_overrides",
+			&TerraformConfig{ChecksumsFile: String("a")},
+			&TerraformConfig{ChecksumsFile: String("b")},
+			&TerraformConfig{ChecksumsFile: String("b")},
+		},
 		{
 			"backend_overrides",
 			&TerraformConfig{
@@ -406,6 +444,15 @@ func TestTerraformConfig_Finalize(t *testing.T) {
 				Path:              String(wd),
 				Backend:           map[string]interface{}{},
 				RequiredProviders: map[string]interface{}{},
+				Flavor:            String(TerraformFlavorTerraform),
+				BinaryName:        String(DefaultTerraformBinaryName),
+				ArchivePath:       String(""),
+				PluginCacheDir:    String(""),
+				Mirror:            DefaultTerraformMirrorConfig(),
+				Checksums:         []string{},
+				ChecksumsFile:     String(""),
+				FileFormat:        String(FileFormatHCL),
+				GitOutput:         DefaultTerraformGitOutputConfig(),
 			},
 		},
 		{
@@ -425,6 +472,15 @@ func TestTerraformConfig_Finalize(t *testing.T) {
 					},
 				},
 				RequiredProviders: map[string]interface{}{},
+				Flavor:            String(TerraformFlavorTerraform),
+				BinaryName:        String(DefaultTerraformBinaryName),
+				ArchivePath:       String(""),
+				PluginCacheDir:    String(""),
+				Mirror:            DefaultTerraformMirrorConfig(),
+				Checksums:         []string{},
+				ChecksumsFile:     String(""),
+				FileFormat:        String(FileFormatHCL),
+				GitOutput:         DefaultTerraformGitOutputConfig(),
 			},
 		},
 		{
@@ -454,6 +510,15 @@ func TestTerraformConfig_Finalize(t *testing.T) {
 					},
 				},
 				RequiredProviders: map[string]interface{}{},
+				Flavor:            String(TerraformFlavorTerraform),
+				BinaryName:        String(DefaultTerraformBinaryName),
+				ArchivePath:       String(""),
+				PluginCacheDir:    String(""),
+				Mirror:            DefaultTerraformMirrorConfig(),
+				Checksums:         []string{},
+				ChecksumsFile:     String(""),
+				FileFormat:        String(FileFormatHCL),
+				GitOutput:         DefaultTerraformGitOutputConfig(),
 			},
 		},
 		{
@@ -476,6 +541,15 @@ func TestTerraformConfig_Finalize(t *testing.T) {
 					},
 				},
 				RequiredProviders: map[string]interface{}{},
+				Flavor:            String(TerraformFlavorTerraform),
+				BinaryName:        String(DefaultTerraformBinaryName),
+				ArchivePath:       String(""),
+				PluginCacheDir:    String(""),
+				Mirror:            DefaultTerraformMirrorConfig(),
+				Checksums:         []string{},
+				ChecksumsFile:     String(""),
+				FileFormat:        String(FileFormatHCL),
+				GitOutput:         DefaultTerraformGitOutputConfig(),
 			},
 		},
 		{
@@ -494,6 +568,40 @@ func TestTerraformConfig_Finalize(t *testing.T) {
 				Path:              String(wd),
 				Backend:           map[string]interface{}{},
 				RequiredProviders: map[string]interface{}{},
+				Flavor:            String(TerraformFlavorTerraform),
+				BinaryName:        String(DefaultTerraformBinaryName),
+				ArchivePath:       String(""),
+				PluginCacheDir:    String(""),
+				Mirror:            DefaultTerraformMirrorConfig(),
+				Checksums:         []string{},
+				ChecksumsFile:     String(""),
+				FileFormat:        String(FileFormatHCL),
+				GitOutput:         DefaultTerraformGitOutputConfig(),
+			},
+		},
+		{
+			"opentofu",
+			&TerraformConfig{
+				Flavor:      String(TerraformFlavorOpenTofu),
+				ArchivePath: String("tofu.zip"),
+			},
+			nil,
+			&TerraformConfig{
+				Version:           String(""),
+				Log:               Bool(false),
+				PersistLog:        Bool(false),
+				Path:              String(wd),
+				Backend:           map[string]interface{}{},
+				RequiredProviders: map[string]interface{}{},
+				Flavor:            String(TerraformFlavorOpenTofu),
+				BinaryName:        String(DefaultOpenTofuBinaryName),
+				ArchivePath:       String("tofu.zip"),
+				PluginCacheDir:    String(""),
+				Mirror:            DefaultTerraformMirrorConfig(),
+				Checksums:         []string{},
+				ChecksumsFile:     String(""),
+				FileFormat:        String(FileFormatHCL),
+				GitOutput:         DefaultTerraformGitOutputConfig(),
 			},
 		},
 	}
@@ -542,6 +650,88 @@ func TestTerraformConfig_Validate(t *testing.T) {
 			"backend_invalid",
 			&TerraformConfig{Backend: map[string]interface{}{"unsupported": nil}},
 			false,
+		}, {
+			"valid terraform version",
+			&TerraformConfig{
+				Version: String("1.2.0"),
+				Backend: map[string]interface{}{"local": nil},
+			},
+			true,
+		}, {
+			"unsupported terraform version",
+			&TerraformConfig{
+				Version: String("1.6.0"),
+				Backend: map[string]interface{}{"local": nil},
+			},
+			false,
+		}, {
+			"valid opentofu version",
+			&TerraformConfig{
+				Flavor:  String(TerraformFlavorOpenTofu),
+				Version: String("1.6.0"),
+				Backend: map[string]interface{}{"local": nil},
+			},
+			true,
+		}, {
+			"unsupported opentofu version",
+			&TerraformConfig{
+				Flavor:  String(TerraformFlavorOpenTofu),
+				Version: String("1.2.0"),
+				Backend: map[string]interface{}{"local": nil},
+			},
+			false,
+		}, {
+			"invalid flavor",
+			&TerraformConfig{
+				Flavor:  String("terraform-ce"),
+				Backend: map[string]interface{}{"local": nil},
+			},
+			false,
+		}, {
+			"valid binary name",
+			&TerraformConfig{
+				BinaryName: String("terraform-1.2"),
+				Backend:    map[string]interface{}{"local": nil},
+			},
+			true,
+		}, {
+			"binary name with directory",
+			&TerraformConfig{
+				BinaryName: String("bin/tofu"),
+				Backend:    map[string]interface{}{"local": nil},
+			},
+			false,
+		}, {
+			"valid checksums",
+			&TerraformConfig{
+				Checksums: []string{
+					"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+					"E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855",
+				},
+				Backend: map[string]interface{}{"local": nil},
+			},
+			true,
+		}, {
+			"invalid checksum",
+			&TerraformConfig{
+				Checksums: []string{"sha256:e3b0c44298fc1c149afbf4c8996fb924"},
+				Backend:   map[string]interface{}{"local": nil},
+			},
+			false,
+		}, {
+			"json file format",
+			&TerraformConfig{
+				FileFormat: String(FileFormatJSON),
+				Backend:    map[string]interface{}{"local": nil},
+			},
+			true,
+		}, {
+			"unsupported file format",
+			&TerraformConfig{
+				FileFormat: String("yaml"),
+				Backend:    map[string]interface{}{"local": nil},
+			},
+			false,
 		},
 	}
 
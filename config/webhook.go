@@ -0,0 +1,237 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultWebhookTimeout is the default timeout for each request made by
+	// the webhook driver.
+	DefaultWebhookTimeout = 30 * time.Second
+
+	// DefaultWebhookMaxRetries is the default number of times the webhook
+	// driver retries a failed request for a task run.
+	DefaultWebhookMaxRetries = 2
+)
+
+// WebhookConfig is the configuration for the webhook driver. The webhook
+// driver sends a POST request with the task's rendered Consul data and task
+// information as JSON to the configured URL.
+type WebhookConfig struct {
+	// URL is the endpoint that requests are sent to.
+	URL *string `mapstructure:"url"`
+
+	// Headers are custom headers to include with each request.
+	Headers map[string]string `mapstructure:"headers"`
+
+	// Secret is the key used to sign the request body with HMAC-SHA256. The
+	// signature is sent in the X-CTS-Signature header. Requests are not signed
+	// if the secret is empty.
+	Secret *string `mapstructure:"secret"`
+
+	// TLS is the TLS configuration for requests to the URL.
+	TLS *TLSConfig `mapstructure:"tls"`
+
+	// Timeout is the timeout for each request.
+	Timeout *time.Duration `mapstructure:"timeout"`
+
+	// MaxRetries is the number of times a failed request is retried for a task
+	// run. Requests that fail with a 4xx status code are not retried.
+	MaxRetries *int `mapstructure:"max_retries"`
+}
+
+// DefaultWebhookConfig returns the default configuration struct.
+func DefaultWebhookConfig() *WebhookConfig {
+	tls := DefaultTLSConfig()
+	tls.Finalize()
+
+	return &WebhookConfig{
+		URL:        String(""),
+		Headers:    make(map[string]string),
+		Secret:     String(""),
+		TLS:        tls,
+		Timeout:    TimeDuration(DefaultWebhookTimeout),
+		MaxRetries: Int(DefaultWebhookMaxRetries),
+	}
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *WebhookConfig) Copy() *WebhookConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o WebhookConfig
+
+	o.URL = StringCopy(c.URL)
+
+	if c.Headers != nil {
+		o.Headers = make(map[string]string, len(c.Headers))
+		for k, v := range c.Headers {
+			o.Headers[k] = v
+		}
+	}
+
+	o.Secret = StringCopy(c.Secret)
+
+	if c.TLS != nil {
+		o.TLS = c.TLS.Copy()
+	}
+
+	o.Timeout = TimeDurationCopy(c.Timeout)
+	o.MaxRetries = IntCopy(c.MaxRetries)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *WebhookConfig) Merge(o *WebhookConfig) *WebhookConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.URL != nil {
+		r.URL = StringCopy(o.URL)
+	}
+
+	if o.Headers != nil {
+		if r.Headers == nil {
+			r.Headers = make(map[string]string, len(o.Headers))
+		}
+		for k, v := range o.Headers {
+			r.Headers[k] = v
+		}
+	}
+
+	if o.Secret != nil {
+		r.Secret = StringCopy(o.Secret)
+	}
+
+	if o.TLS != nil {
+		r.TLS = r.TLS.Merge(o.TLS)
+	}
+
+	if o.Timeout != nil {
+		r.Timeout = TimeDurationCopy(o.Timeout)
+	}
+
+	if o.MaxRetries != nil {
+		r.MaxRetries = IntCopy(o.MaxRetries)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *WebhookConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.URL == nil {
+		c.URL = String("")
+	}
+
+	if c.Headers == nil {
+		c.Headers = make(map[string]string)
+	}
+
+	if c.Secret == nil {
+		c.Secret = String("")
+	}
+
+	if c.TLS == nil {
+		c.TLS = DefaultTLSConfig()
+	}
+	c.TLS.Finalize()
+
+	if c.Timeout == nil {
+		c.Timeout = TimeDuration(DefaultWebhookTimeout)
+	}
+
+	if c.MaxRetries == nil {
+		c.MaxRetries = Int(DefaultWebhookMaxRetries)
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct.
+func (c *WebhookConfig) Validate() error {
+	if c == nil {
+		return fmt.Errorf("missing webhook driver configuration")
+	}
+
+	if c.URL == nil || *c.URL == "" {
+		return fmt.Errorf("url for the webhook driver is required")
+	}
+
+	u, err := url.Parse(*c.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url for the webhook driver: %s", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url for the webhook driver must use the http or "+
+			"https scheme: %s", *c.URL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("url for the webhook driver is missing the host: %s",
+			*c.URL)
+	}
+
+	if c.Timeout != nil && *c.Timeout <= 0 {
+		return fmt.Errorf("timeout for the webhook driver must be greater "+
+			"than zero: %s", *c.Timeout)
+	}
+
+	if c.MaxRetries != nil && *c.MaxRetries < 0 {
+		return fmt.Errorf("max_retries for the webhook driver cannot be "+
+			"negative: %d", *c.MaxRetries)
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+// Sensitive information is redacted.
+func (c *WebhookConfig) GoString() string {
+	if c == nil {
+		return "(*WebhookConfig)(nil)"
+	}
+
+	// header values may contain credentials, only print the header names
+	headers := make([]string, 0, len(c.Headers))
+	for k := range c.Headers {
+		headers = append(headers, k)
+	}
+	sort.Strings(headers)
+
+	return fmt.Sprintf("&WebhookConfig{"+
+		"URL:%s, "+
+		"Headers:%v, "+
+		"Secret:%s, "+
+		"TLS:%s, "+
+		"Timeout:%s, "+
+		"MaxRetries:%d"+
+		"}",
+		StringVal(c.URL),
+		headers,
+		sensitiveGoString(c.Secret),
+		c.TLS.GoString(),
+		TimeDurationVal(c.Timeout),
+		IntVal(c.MaxRetries),
+	)
+}
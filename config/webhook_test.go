@@ -0,0 +1,259 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &WebhookConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *WebhookConfig
+	}{
+		{
+			"nil",
+			nil,
+		}, {
+			"empty",
+			&WebhookConfig{},
+		}, {
+			"finalized",
+			finalizedConf,
+		}, {
+			"fully_configured",
+			&WebhookConfig{
+				URL:        String("https://example.com/hook"),
+				Headers:    map[string]string{"Authorization": "Bearer token"},
+				Secret:     String("secret"),
+				TLS:        &TLSConfig{CACert: String("ca.pem")},
+				Timeout:    TimeDuration(time.Second),
+				MaxRetries: Int(5),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestWebhookConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *WebhookConfig
+		b    *WebhookConfig
+		r    *WebhookConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&WebhookConfig{},
+			&WebhookConfig{},
+		},
+		{
+			"nil_b",
+			&WebhookConfig{},
+			nil,
+			&WebhookConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&WebhookConfig{},
+			&WebhookConfig{},
+			&WebhookConfig{},
+		},
+		{
+			"url_overrides",
+			&WebhookConfig{URL: String("http://a")},
+			&WebhookConfig{URL: String("http://b")},
+			&WebhookConfig{URL: String("http://b")},
+		},
+		{
+			"headers_merge",
+			&WebhookConfig{Headers: map[string]string{"a": "1", "b": "2"}},
+			&WebhookConfig{Headers: map[string]string{"b": "3", "c": "4"}},
+			&WebhookConfig{Headers: map[string]string{"a": "1", "b": "3", "c": "4"}},
+		},
+		{
+			"headers_empty_one",
+			&WebhookConfig{},
+			&WebhookConfig{Headers: map[string]string{"a": "1"}},
+			&WebhookConfig{Headers: map[string]string{"a": "1"}},
+		},
+		{
+			"secret_overrides",
+			&WebhookConfig{Secret: String("a")},
+			&WebhookConfig{Secret: String("b")},
+			&WebhookConfig{Secret: String("b")},
+		},
+		{
+			"tls_merge",
+			&WebhookConfig{TLS: &TLSConfig{CACert: String("ca.pem")}},
+			&WebhookConfig{TLS: &TLSConfig{Verify: Bool(false)}},
+			&WebhookConfig{TLS: &TLSConfig{CACert: String("ca.pem"), Verify: Bool(false)}},
+		},
+		{
+			"timeout_overrides",
+			&WebhookConfig{Timeout: TimeDuration(time.Minute)},
+			&WebhookConfig{Timeout: TimeDuration(time.Second)},
+			&WebhookConfig{Timeout: TimeDuration(time.Second)},
+		},
+		{
+			"max_retries_empty_one",
+			&WebhookConfig{MaxRetries: Int(3)},
+			&WebhookConfig{},
+			&WebhookConfig{MaxRetries: Int(3)},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestWebhookConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *WebhookConfig
+		r    *WebhookConfig
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&WebhookConfig{},
+			DefaultWebhookConfig(),
+		},
+		{
+			"with_tls",
+			&WebhookConfig{
+				URL: String("https://example.com"),
+				TLS: &TLSConfig{CACert: String("ca.pem")},
+			},
+			&WebhookConfig{
+				URL:     String("https://example.com"),
+				Headers: map[string]string{},
+				Secret:  String(""),
+				TLS: &TLSConfig{
+					CACert:     String("ca.pem"),
+					CAPath:     String(""),
+					Cert:       String(""),
+					Enabled:    Bool(true),
+					Key:        String(""),
+					ServerName: String(""),
+					Verify:     Bool(true),
+				},
+				Timeout:    TimeDuration(DefaultWebhookTimeout),
+				MaxRetries: Int(DefaultWebhookMaxRetries),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestWebhookConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *WebhookConfig
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			false,
+		}, {
+			"valid",
+			&WebhookConfig{URL: String("https://example.com/hook")},
+			true,
+		}, {
+			"missing_url",
+			&WebhookConfig{},
+			false,
+		}, {
+			"invalid_scheme",
+			&WebhookConfig{URL: String("ftp://example.com")},
+			false,
+		}, {
+			"missing_host",
+			&WebhookConfig{URL: String("http://")},
+			false,
+		}, {
+			"invalid_timeout",
+			&WebhookConfig{
+				URL:     String("https://example.com"),
+				Timeout: TimeDuration(0),
+			},
+			false,
+		}, {
+			"negative_max_retries",
+			&WebhookConfig{
+				URL:        String("https://example.com"),
+				MaxRetries: Int(-1),
+			},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestWebhookConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	conf := &WebhookConfig{
+		URL:     String("https://example.com"),
+		Headers: map[string]string{"Authorization": "Bearer token"},
+		Secret:  String("secret"),
+	}
+	conf.Finalize()
+
+	actual := conf.GoString()
+	assert.Contains(t, actual, "Headers:[Authorization]")
+	assert.Contains(t, actual, "Secret:(redacted)")
+	assert.NotContains(t, actual, "Bearer token")
+	assert.NotContains(t, actual, "Secret:secret")
+}
@@ -101,6 +101,11 @@ func (cm *ConditionMonitor) Run(ctx context.Context) error {
 
 			go cm.runDynamicTask(ctx, taskName) // errors are logged for now
 
+		case taskName := <-cm.tasksManager.WatchDampedTasks():
+			// Run tasks for suppressed changes that are stable or have been
+			// suppressed for the maximum time
+			go cm.runDynamicTask(ctx, taskName) // errors are logged for now
+
 		case taskName := <-cm.tasksManager.WatchCreatedScheduleTasks():
 			// Run newly created scheduled tasks
 			stopCh := make(chan struct{}, 1)
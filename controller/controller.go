@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/driver"
@@ -30,7 +31,45 @@ type Controller interface {
 // InstallDriver installs necessary drivers based on user configuration.
 func InstallDriver(ctx context.Context, conf *config.Config) error {
 	if conf.Driver.Terraform != nil {
-		return driver.InstallTerraform(ctx, conf.Driver.Terraform)
+		if err := driver.InstallTerraform(ctx, conf.Driver.Terraform); err != nil {
+			return err
+		}
+		if conf.Tasks == nil {
+			return nil
+		}
+		for _, t := range *conf.Tasks {
+			if err := installTaskTerraformVersion(ctx, conf, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if conf.Driver.Exec != nil {
+		// the exec driver runs a user-provided command, nothing to install
+		return nil
+	}
+	if conf.Driver.Webhook != nil {
+		// the webhook driver sends requests to a user-provided URL, nothing to
+		// install
+		return nil
 	}
 	return errors.New("unsupported driver")
 }
+
+// installTaskTerraformVersion installs the Terraform version configured for a
+// task with the Terraform driver alongside the driver's version. The versions
+// of tasks in the configuration are installed with the driver. The versions of
+// tasks created by the API are installed before the task's driver is created.
+func installTaskTerraformVersion(ctx context.Context, conf *config.Config,
+	t *config.TaskConfig) error {
+	v := config.StringVal(t.DeprecatedTFVersion)
+	if v == "" || conf.Driver == nil || conf.Driver.Terraform == nil {
+		return nil
+	}
+
+	if _, _, err := driver.InstallTerraformVersion(ctx, conf.Driver.Terraform, v); err != nil {
+		return fmt.Errorf("error installing Terraform version %s for task %q: %s",
+			v, config.StringVal(t.Name), err)
+	}
+	return nil
+}
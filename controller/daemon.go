@@ -99,9 +99,10 @@ func (ctrl *Daemon) Run(ctx context.Context) error {
 		exitBufLen++
 		exitCh = make(chan error, exitBufLen)
 
-		// Configure Consul client if not already
+		// Configure Consul client if not already. The client is shared with
+		// the tasks manager
 		if ctrl.consulClient == nil {
-			c, err := client.NewConsulClient(conf.Consul, client.ConsulDefaultMaxRetry)
+			c, err := ctrl.tasksManager.getConsulClient()
 			if err != nil {
 				ctrl.logger.Error("error setting up Consul client", "error", err)
 				return err
@@ -13,6 +13,8 @@ import (
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	"github.com/hashicorp/consul-terraform-sync/templates"
 	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	goVersion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcat"
 )
 
@@ -27,6 +29,10 @@ type driverFactory struct {
 	logger    logging.Logger
 	providers []driver.TerraformProviderBlock
 
+	// webhookPayloads stores the payloads received by the trigger task API
+	// and is shared with the drivers of tasks with a webhook condition
+	webhookPayloads *tmplfunc.WebhookStore
+
 	// config that CTS is initialized with i.e. only used by driver factory.
 	// subsequent access to the configs should be through the state store.
 	initConf *config.Config
@@ -42,11 +48,12 @@ func NewDriverFactory(conf *config.Config, watcher templates.Watcher) (*driverFa
 	logger := logging.Global().Named(ctrlSystemName)
 
 	return &driverFactory{
-		newDriver: nd,
-		watcher:   watcher,
-		resolver:  hcat.NewResolver(),
-		logger:    logger,
-		initConf:  conf,
+		newDriver:       nd,
+		watcher:         watcher,
+		resolver:        hcat.NewResolver(),
+		logger:          logger,
+		initConf:        conf,
+		webhookPayloads: tmplfunc.NewWebhookStore(),
 	}, nil
 }
 
@@ -95,7 +102,7 @@ func (f *driverFactory) Make(ctx context.Context, conf *config.Config,
 func (f *driverFactory) createNewTaskDriver(ctx context.Context, conf *config.Config, taskConfig config.TaskConfig) (driver.Driver, error) {
 	logger := f.logger.With("task_name", *taskConfig.Name)
 	logger.Trace("creating new task driver")
-	task, err := newDriverTask(conf, &taskConfig, f.providers)
+	task, err := newDriverTask(conf, &taskConfig, f.providers, f.webhookPayloads)
 	if err != nil {
 		return nil, err
 	}
@@ -147,6 +154,12 @@ func newDriverFunc(conf *config.Config) (driverFactoryFunc, error) {
 	if conf.Driver.Terraform != nil {
 		return newTerraformDriver, nil
 	}
+	if conf.Driver.Exec != nil {
+		return newExecDriver, nil
+	}
+	if conf.Driver.Webhook != nil {
+		return newWebhookDriver, nil
+	}
 	return nil, errors.New("unsupported driver")
 }
 
@@ -154,20 +167,86 @@ func newDriverFunc(conf *config.Config) (driverFactoryFunc, error) {
 // for a task
 func newTerraformDriver(_ context.Context, conf *config.Config, task *driver.Task, w templates.Watcher) (driver.Driver, error) {
 	tfConf := *conf.Driver.Terraform
+	_, requiredVersion := tfConf.VersionConstraint()
+
+	// tasks configured with their own Terraform version use a separate binary
+	// installed alongside the driver's binary
+	path := *tfConf.Path
+	binaryName := config.StringVal(tfConf.BinaryName)
+	var tfVersion *goVersion.Version
+	if v := task.TerraformVersion(); v != "" {
+		// the version is installed when the driver is installed or the task
+		// is created, see installTaskTerraformVersion
+		var err error
+		path, tfVersion, err = driver.InstalledTerraformVersion(&tfConf, v)
+		if err != nil {
+			return nil, fmt.Errorf("error using Terraform version %s for "+
+				"task %s: %s", v, task.Name(), err)
+		}
+		if path != *tfConf.Path {
+			binaryName = config.DefaultTerraformBinaryName
+		}
+	}
+
+	fileFormat := task.FileFormat()
+	if fileFormat == "" {
+		fileFormat = config.StringVal(tfConf.FileFormat)
+	}
+
 	return driver.NewTerraform(&driver.TerraformConfig{
 		Task:              task,
 		Watcher:           w,
 		Log:               *tfConf.Log,
 		PersistLog:        *tfConf.PersistLog,
-		Path:              *tfConf.Path,
+		Path:              path,
 		Backend:           tfConf.Backend,
 		RequiredProviders: tfConf.RequiredProviders,
 		ClientType:        *conf.ClientType,
+		BinaryName:        binaryName,
+		Version:           tfVersion,
+		RequiredVersion:   requiredVersion,
+		PluginCacheDir:    config.StringVal(tfConf.PluginCacheDir),
+		GitOutput:         tfConf.GitOutput,
+		FileFormat:        fileFormat,
+
+		ModuleMirrorDir:          config.StringVal(tfConf.Mirror.ModuleDir),
+		ProviderFilesystemMirror: config.StringVal(tfConf.Mirror.FilesystemMirror),
+		ProviderNetworkMirror:    config.StringVal(tfConf.Mirror.NetworkMirror),
+	})
+}
+
+// newExecDriver maps user configuration to initialize an exec driver for a
+// task
+func newExecDriver(_ context.Context, conf *config.Config, task *driver.Task, w templates.Watcher) (driver.Driver, error) {
+	execConf := *conf.Driver.Exec
+	return driver.NewExec(&driver.ExecConfig{
+		Task:    task,
+		Watcher: w,
+		Command: *execConf.Command,
+		Args:    execConf.Args,
+		Timeout: *execConf.Timeout,
+	})
+}
+
+// newWebhookDriver maps user configuration to initialize a webhook driver for
+// a task
+func newWebhookDriver(_ context.Context, conf *config.Config, task *driver.Task, w templates.Watcher) (driver.Driver, error) {
+	webhookConf := *conf.Driver.Webhook
+	return driver.NewWebhook(&driver.WebhookConfig{
+		Task:       task,
+		Watcher:    w,
+		URL:        *webhookConf.URL,
+		Headers:    webhookConf.Headers,
+		Secret:     *webhookConf.Secret,
+		TLS:        webhookConf.TLS,
+		Timeout:    *webhookConf.Timeout,
+		MaxRetries: *webhookConf.MaxRetries,
 	})
 }
 
 func newDriverTask(conf *config.Config, taskConfig *config.TaskConfig,
-	providerConfigs driver.TerraformProviderBlocks) (*driver.Task, error) {
+	providerConfigs driver.TerraformProviderBlocks,
+	webhooks *tmplfunc.WebhookStore) (*driver.Task, error) {
 	if conf == nil || conf.Driver == nil {
 		// only expected for testing
 		return nil, nil
@@ -205,21 +284,28 @@ func newDriverTask(conf *config.Config, taskConfig *config.TaskConfig,
 	}
 
 	task, err := driver.NewTask(driver.TaskConfig{
-		Description:  *taskConfig.Description,
-		Name:         *taskConfig.Name,
-		Enabled:      *taskConfig.Enabled,
-		Env:          buildTaskEnv(conf, providers.Env()),
-		Providers:    providers,
-		ProviderInfo: providerInfo,
-		Services:     services,
-		Module:       *taskConfig.Module,
-		VarFiles:     taskConfig.VarFiles,
-		Version:      *taskConfig.Version,
-		Variables:    taskConfig.Variables,
-		BufferPeriod: bp,
-		Condition:    taskConfig.Condition,
-		ModuleInputs: *taskConfig.ModuleInputs,
-		WorkingDir:   *taskConfig.WorkingDir,
+		Description:      *taskConfig.Description,
+		Name:             *taskConfig.Name,
+		Enabled:          *taskConfig.Enabled,
+		DestroyOnDelete:  config.BoolVal(taskConfig.DestroyOnDelete),
+		ExposeOutputs:    config.BoolVal(taskConfig.ExposeOutputs),
+		OutputsKVPath:    config.StringVal(taskConfig.OutputsKVPath),
+		Env:              buildTaskEnv(conf, providers.Env()),
+		Providers:        providers,
+		ProviderInfo:     providerInfo,
+		Services:         services,
+		Module:           *taskConfig.Module,
+		VarFiles:         taskConfig.VarFiles,
+		ServicesTemplate: config.StringVal(taskConfig.ServicesTemplate),
+		Version:          *taskConfig.Version,
+		Variables:        taskConfig.Variables,
+		BufferPeriod:     bp,
+		Condition:        taskConfig.Condition,
+		ModuleInputs:     *taskConfig.ModuleInputs,
+		WorkingDir:       *taskConfig.WorkingDir,
+		FileFormat:       config.StringVal(taskConfig.FileFormat),
+		ServicesProtocol: config.StringVal(taskConfig.ServicesProtocol),
+		WebhookPayloads:  webhooks,
 
 		// Enterprise
 		DeprecatedTFVersion: *taskConfig.DeprecatedTFVersion,
@@ -154,9 +154,10 @@ func TestNewDriverTask(t *testing.T) {
 					Min: 5 * time.Second,
 					Max: 20 * time.Second,
 				},
-				Condition:    config.EmptyConditionConfig(),
-				ModuleInputs: *config.DefaultModuleInputConfigs(),
-				WorkingDir:   "working-dir/name",
+				Condition:        config.EmptyConditionConfig(),
+				ModuleInputs:     *config.DefaultModuleInputConfigs(),
+				WorkingDir:       "working-dir/name",
+				ServicesProtocol: config.ServicesProtocolV0,
 
 				// Enterprise
 				DeprecatedTFVersion: "1.0.0",
@@ -222,7 +223,8 @@ func TestNewDriverTask(t *testing.T) {
 					Min: 5 * time.Second,
 					Max: 20 * time.Second,
 				},
-				WorkingDir: "sync-tasks/name",
+				WorkingDir:       "sync-tasks/name",
+				ServicesProtocol: config.ServicesProtocolV0,
 
 				// Enterprise
 				TFCWorkspace: *config.DefaultTerraformCloudWorkspaceConfig(),
@@ -292,7 +294,8 @@ func TestNewDriverTask(t *testing.T) {
 					Min: 5 * time.Second,
 					Max: 20 * time.Second,
 				},
-				WorkingDir: "sync-tasks/name",
+				WorkingDir:       "sync-tasks/name",
+				ServicesProtocol: config.ServicesProtocolV0,
 
 				// Enterprise
 				TFCWorkspace: *config.DefaultTerraformCloudWorkspaceConfig(),
@@ -347,7 +350,8 @@ func TestNewDriverTask(t *testing.T) {
 					Min: 5 * time.Second,
 					Max: 20 * time.Second,
 				},
-				WorkingDir: "sync-tasks/name",
+				WorkingDir:       "sync-tasks/name",
+				ServicesProtocol: config.ServicesProtocolV0,
 				// Enterprise
 				TFCWorkspace: *config.DefaultTerraformCloudWorkspaceConfig(),
 			})},
@@ -381,7 +385,7 @@ func newTestDriverTasks(conf *config.Config, providerConfigs driver.TerraformPro
 	tasks := make([]*driver.Task, len(*conf.Tasks))
 	for i, t := range *conf.Tasks {
 		var err error
-		tasks[i], err = newDriverTask(conf, t, providerConfigs)
+		tasks[i], err = newDriverTask(conf, t, providerConfigs, nil)
 		if err != nil {
 			return nil, err
 		}
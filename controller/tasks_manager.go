@@ -2,9 +2,15 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/hashicorp/consul-terraform-sync/api"
+	"github.com/hashicorp/consul-terraform-sync/client"
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/driver"
 	"github.com/hashicorp/consul-terraform-sync/logging"
@@ -12,12 +18,16 @@ import (
 	"github.com/hashicorp/consul-terraform-sync/state"
 	"github.com/hashicorp/consul-terraform-sync/state/event"
 	"github.com/hashicorp/consul-terraform-sync/templates"
+	consulapi "github.com/hashicorp/consul/api"
 	"github.com/pkg/errors"
 	ctyjson "github.com/zclconf/go-cty/cty/json"
 )
 
 var tasksManagerSystemName = "tasksmanager"
 
+// maxKVTxnOps is the maximum number of operations in a Consul KV transaction
+const maxKVTxnOps = 64
+
 // TasksManager manages the CRUD operations and execution of tasks
 type TasksManager struct {
 	logger logging.Logger
@@ -28,6 +38,11 @@ type TasksManager struct {
 
 	retry retry.Retry
 
+	// consulClient is used to publish task outputs to Consul KV. It is created
+	// when it is first needed. Use getConsulClient()
+	consulClient   client.ConsulClientInterface
+	consulClientMu *sync.Mutex
+
 	// createdScheduleCh sends the task name of newly created scheduled tasks
 	// that will need to be monitored
 	createdScheduleCh chan string
@@ -36,6 +51,10 @@ type TasksManager struct {
 	// should stop being monitored
 	deletedScheduleCh chan string
 
+	// dampedTaskCh sends the task name of tasks with damping configured whose
+	// suppressed changes are ready to trigger the task
+	dampedTaskCh chan string
+
 	// ranTaskNotify is only initialized if EnableTaskRanNotify() is used. It
 	// provides tests insight into which tasks were triggered and had completed
 	ranTaskNotify chan string
@@ -60,8 +79,10 @@ func NewTasksManager(conf *config.Config, state state.Store, watcher templates.W
 		state:             state,
 		drivers:           driver.NewDrivers(),
 		retry:             retry.NewRetry(defaultRetry, time.Now().UnixNano()),
+		consulClientMu:    &sync.Mutex{},
 		createdScheduleCh: make(chan string, 10), // arbitrarily chosen size
 		deletedScheduleCh: make(chan string, 10), // arbitrarily chosen size
+		dampedTaskCh:      make(chan string, 10), // arbitrarily chosen size
 	}, nil
 }
 
@@ -95,6 +116,59 @@ func (tm *TasksManager) Tasks(_ context.Context) config.TaskConfigs {
 	return tm.state.GetAllTasks()
 }
 
+// TaskOutputs returns the most recent event of a task that has outputs stored.
+// Returns nil if the task has not yet stored outputs.
+func (tm *TasksManager) TaskOutputs(_ context.Context, taskName string) (*event.Event, error) {
+	if _, ok := tm.state.GetTask(taskName); !ok {
+		return nil, fmt.Errorf("a task with name '%s' does not exist or has not been initialized yet", taskName)
+	}
+
+	// Events are sorted in reverse chronological order
+	events := tm.state.GetTaskEvents(taskName)[taskName]
+	for _, e := range events {
+		if e.Outputs != nil {
+			return &e, nil
+		}
+	}
+	return nil, nil
+}
+
+// TaskTrigger triggers a task with a webhook condition. The payload is made
+// available to the task's module and the task runs once the watcher
+// notifies of the new payload.
+func (tm *TasksManager) TaskTrigger(_ context.Context, taskName string, payload interface{}) error {
+	d, ok := tm.drivers.Get(taskName)
+	if !ok || tm.drivers.IsMarkedForDeletion(taskName) {
+		return fmt.Errorf("a task with name '%s' does not exist or has not been initialized yet", taskName)
+	}
+
+	if _, ok := d.Task().Condition().(*config.WebhookConditionConfig); !ok {
+		return fmt.Errorf("task '%s' does not have a webhook condition", taskName)
+	}
+
+	tm.logger.Trace("publishing webhook payload", taskNameLogKey, taskName)
+	tm.factory.webhookPayloads.Publish(taskName, payload)
+	return nil
+}
+
+// TaskDamping returns the status of the changes suppressed by the damping of a
+// task's services condition. Returns nil if the task does not have damping
+// configured.
+func (tm *TasksManager) TaskDamping(_ context.Context, taskName string) (*event.DampingStatus, error) {
+	d, ok := tm.drivers.Get(taskName)
+	if !ok {
+		return nil, fmt.Errorf("a task with name '%s' does not exist or has not been initialized yet", taskName)
+	}
+
+	damper := d.Task().Damper()
+	if damper == nil {
+		return nil, nil
+	}
+
+	status := damper.Status()
+	return &status, nil
+}
+
 func (tm *TasksManager) TaskCreate(ctx context.Context, taskConfig config.TaskConfig) (config.TaskConfig, error) {
 	d, err := tm.createTask(ctx, taskConfig)
 	if err != nil {
@@ -118,20 +192,54 @@ func (tm *TasksManager) TaskCreateAndRun(ctx context.Context, taskConfig config.
 }
 
 // TaskDelete marks an existing task that has been added to CTS for deletion
-// then asynchronously deletes the task.
+// then asynchronously deletes the task. If the task is configured to destroy
+// on delete, the infrastructure managed by the task is destroyed first.
 func (tm *TasksManager) TaskDelete(_ context.Context, name string) error {
+	tm.markAndDeleteTask(name, false)
+	return nil
+}
+
+// TaskDeleteAndDestroy marks an existing task that has been added to CTS for
+// deletion then asynchronously destroys the infrastructure managed by the task
+// before deleting the task.
+func (tm *TasksManager) TaskDeleteAndDestroy(_ context.Context, name string) error {
+	tm.markAndDeleteTask(name, true)
+	return nil
+}
+
+// TaskInspectDestroy inspects the changes to destroy the infrastructure
+// managed by an existing task. The task is not deleted.
+func (tm *TasksManager) TaskInspectDestroy(ctx context.Context, name string) (bool, string, string, error) {
+	d, ok := tm.drivers.Get(name)
+	if !ok || tm.drivers.IsMarkedForDeletion(name) {
+		return false, "", "", api.TaskNotFoundError{Name: name}
+	}
+
+	if tm.drivers.IsActive(name) {
+		return false, "", "", fmt.Errorf("task '%s' is active and cannot be "+
+			"inspected at this time", name)
+	}
+	tm.drivers.SetActive(name)
+	defer tm.drivers.SetInactive(name)
+
+	plan, err := d.InspectDestroyResources(ctx)
+	return plan.ChangesPresent, plan.Plan, plan.URL, err
+}
+
+// markAndDeleteTask marks a task for deletion and then asynchronously deletes
+// the task.
+func (tm *TasksManager) markAndDeleteTask(name string, destroy bool) {
 	logger := tm.logger.With(taskNameLogKey, name)
 	if tm.drivers.IsMarkedForDeletion(name) {
 		logger.Debug("task is already marked for deletion")
-		return nil
+		return
 	}
 	tm.drivers.MarkForDeletion(name)
-	logger.Debug("task marked for deletion")
+	logger.Debug("task marked for deletion", "destroy", destroy)
 
 	// Use new context. For runtime task deletions, deleteTask() would get
 	// canceled when the API request completes if shared context.
-	go tm.deleteTask(context.Background(), name)
-	return nil
+	go tm.deleteTask(context.Background(), name, destroy)
 }
 
 // TaskInspect creates and inspects a temporary task that is not added to the drivers list.
@@ -169,6 +277,7 @@ func (tm *TasksManager) TaskUpdate(ctx context.Context, updateConf config.TaskCo
 	}
 
 	var storedErr error
+	evCtx := ctx
 	if runOp == driver.RunOptionNow {
 		task := d.Task()
 		ev, err := event.NewEvent(taskName, &event.Config{
@@ -182,6 +291,8 @@ func (tm *TasksManager) TaskUpdate(ctx context.Context, updateConf config.TaskCo
 			logger.Error("error creating new event", "error", err)
 			return false, "", "", err
 		}
+		ev.Trigger = "task updated and run through the API"
+		evCtx = event.NewContext(ctx, ev)
 		defer func() {
 			ev.End(storedErr)
 			logger.Trace("adding event", "event", ev.GoString())
@@ -206,7 +317,7 @@ func (tm *TasksManager) TaskUpdate(ctx context.Context, updateConf config.TaskCo
 		Enabled:   *updateConf.Enabled,
 	}
 	var plan driver.InspectPlan
-	plan, storedErr = d.UpdateTask(ctx, patch)
+	plan, storedErr = d.UpdateTask(evCtx, patch)
 	if storedErr != nil {
 		logger.Trace("error while updating task", "error", storedErr)
 		return false, "", "", storedErr
@@ -288,6 +399,9 @@ func configFromDriverTask(t *driver.Task) (config.TaskConfig, error) {
 		Description:        config.String(t.Description()),
 		Name:               config.String(t.Name()),
 		Enabled:            config.Bool(t.IsEnabled()),
+		DestroyOnDelete:    config.Bool(t.DestroyOnDelete()),
+		ExposeOutputs:      config.Bool(t.ExposeOutputs()),
+		OutputsKVPath:      config.String(t.OutputsKVPath()),
 		Providers:          t.ProviderIDs(),
 		DeprecatedServices: t.ServiceNames(),
 		Module:             config.String(t.Module()),
@@ -297,9 +411,11 @@ func configFromDriverTask(t *driver.Task) (config.TaskConfig, error) {
 		Condition:          t.Condition(),
 		ModuleInputs:       &inputs,
 		WorkingDir:         config.String(t.WorkingDir()),
+		FileFormat:         config.String(t.FileFormat()),
+		ServicesProtocol:   config.String(t.ServicesProtocol()),
 
 		// Enterprise
-		DeprecatedTFVersion: config.String(t.DeprecatedTFVersion()),
+		DeprecatedTFVersion: config.String(t.TerraformVersion()),
 		TFCWorkspace:        &tfcWs,
 	}, nil
 }
@@ -330,10 +446,27 @@ func (tm TasksManager) addTask(ctx context.Context, d driver.Driver) (config.Tas
 		return config.TaskConfig{}, err
 	}
 
-	if d.Task().IsScheduled() {
+	task := d.Task()
+	if task.IsScheduled() {
 		tm.createdScheduleCh <- name
 	}
 
+	if damper := task.Damper(); damper != nil {
+		damper.SetTrigger(func() bool {
+			// the damper's timer must not block on a full channel, e.g. when
+			// the tasks are no longer being watched. the damper retries
+			// triggers that are not accepted
+			select {
+			case tm.dampedTaskCh <- name:
+				tm.logger.Trace("suppressed changes ready to trigger task",
+					taskNameLogKey, name)
+				return true
+			default:
+				return false
+			}
+		})
+	}
+
 	return conf, nil
 }
 
@@ -416,6 +549,8 @@ func (tm *TasksManager) TaskRunNow(ctx context.Context, taskName string) error {
 		return fmt.Errorf("error creating event for task %s: %s",
 			taskName, err)
 	}
+	ev.Trigger = conditionTrigger(task)
+	evCtx := event.NewContext(ctx, ev)
 	var storedErr error
 	storeEvent := func() {
 		ev.End(storedErr)
@@ -452,12 +587,14 @@ func (tm *TasksManager) TaskRunNow(ctx context.Context, taskName string) error {
 		defer storeEvent()
 
 		desc := fmt.Sprintf("ApplyTask %s", taskName)
-		storedErr = tm.retry.Do(ctx, d.ApplyTask, desc)
+		storedErr = tm.retry.Do(evCtx, d.ApplyTask, desc)
 		if storedErr != nil {
 			return fmt.Errorf("could not apply changes for task %s: %s",
 				taskName, storedErr)
 		}
 
+		tm.storeOutputs(ctx, d, task, ev)
+
 		logger.Info("task completed")
 
 		if tm.ranTaskNotify != nil {
@@ -510,6 +647,12 @@ func (tm TasksManager) WatchDeletedScheduleTask() <-chan string {
 	return tm.deletedScheduleCh
 }
 
+// WatchDampedTasks returns a channel to inform any watcher that the suppressed
+// changes of a task with damping configured are ready to trigger the task.
+func (tm TasksManager) WatchDampedTasks() <-chan string {
+	return tm.dampedTaskCh
+}
+
 // createTask creates and initializes a singular task from configuration
 func (tm *TasksManager) createTask(ctx context.Context, taskConfig config.TaskConfig) (driver.Driver, error) {
 	conf := tm.state.GetConfig()
@@ -518,6 +661,18 @@ func (tm *TasksManager) createTask(ctx context.Context, taskConfig config.TaskCo
 		tm.logger.Trace("invalid config to create task", "error", err)
 		return nil, err
 	}
+	if err := conf.ValidateTaskDriver(&taskConfig); err != nil {
+		tm.logger.Trace("invalid config to create task", "error", err)
+		return nil, err
+	}
+	if err := conf.ValidateTaskVault(&taskConfig); err != nil {
+		tm.logger.Trace("invalid config to create task", "error", err)
+		return nil, err
+	}
+	if err := conf.ValidateTaskHTTP(&taskConfig); err != nil {
+		tm.logger.Trace("invalid config to create task", "error", err)
+		return nil, err
+	}
 
 	// task config needs to be validated/finalized before retrieving task name
 	taskName := *taskConfig.Name
@@ -529,6 +684,11 @@ func (tm *TasksManager) createTask(ctx context.Context, taskConfig config.TaskCo
 		return nil, fmt.Errorf("task with name %s already exists", taskName)
 	}
 
+	if err := installTaskTerraformVersion(ctx, &conf, &taskConfig); err != nil {
+		logger.Error("unable to install Terraform version for task", "error", err)
+		return nil, err
+	}
+
 	d, err := tm.factory.Make(ctx, &conf, taskConfig)
 	if err != nil {
 		return nil, err
@@ -605,15 +765,19 @@ func (tm *TasksManager) runNewTask(ctx context.Context, d driver.Driver,
 		logger.Error("error initializing run task event", "error", err)
 		return err
 	}
+	ev.Trigger = "task created"
+	evCtx := event.NewContext(ctx, ev)
 	ev.Start()
 
 	// Apply task
-	err = d.ApplyTask(ctx)
+	err = d.ApplyTask(evCtx)
 	if err != nil {
 		logger.Error("error applying task", "error", err)
 		if !allowApplyErr {
 			return err
 		}
+	} else {
+		tm.storeOutputs(ctx, d, task, ev)
 	}
 
 	// Store event if apply was successful and task will be created
@@ -631,13 +795,150 @@ func (tm *TasksManager) runNewTask(ctx context.Context, d driver.Driver,
 	return err
 }
 
+// storeOutputs collects the output values of the task's module after the task
+// is successfully applied, if the task exposes its outputs. The outputs are
+// stored with the event for the task run and are published to Consul KV if
+// configured for the task. Errors are logged and do not fail the task run.
+func (tm *TasksManager) storeOutputs(ctx context.Context, d driver.Driver,
+	task *driver.Task, ev *event.Event) {
+
+	if !task.ExposeOutputs() {
+		return
+	}
+
+	logger := tm.logger.With(taskNameLogKey, task.Name())
+
+	outputs, err := d.Outputs(ctx)
+	if err != nil {
+		logger.Error("error collecting outputs for task", "error", err)
+		return
+	}
+
+	path := task.OutputsKVPath()
+	if path != "" {
+		// The outputs of the task's previous run determine which keys this
+		// task has written that are removed
+		var prevOutputs map[string]json.RawMessage
+		if prev, err := tm.TaskOutputs(ctx, task.Name()); err == nil && prev != nil {
+			prevOutputs = prev.Outputs
+		}
+
+		logger.Debug("publishing outputs to Consul KV", "outputs_kv_path", path)
+		err = tm.publishOutputs(ctx, path, outputs, prevOutputs)
+		if err != nil {
+			logger.Error("error publishing outputs to Consul KV",
+				"outputs_kv_path", path, "error", err)
+		}
+	}
+
+	ev.Outputs = outputs
+}
+
+// publishOutputs writes each output value as JSON to Consul KV under the path
+// prefix. The keys of the previous outputs that are no longer outputs, such as
+// for outputs that were removed from the module, are deleted. Other keys under
+// the prefix are left in place.
+//
+// Consul limits the number of operations in a transaction, so the operations
+// are split into transactions of at most maxKVTxnOps. The values are set
+// before the keys are deleted so that a failed transaction does not remove
+// outputs before the new outputs are written.
+func (tm *TasksManager) publishOutputs(ctx context.Context, path string,
+	outputs, prevOutputs map[string]json.RawMessage) error {
+
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	var ops consulapi.KVTxnOps
+
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ops = append(ops, &consulapi.KVTxnOp{
+			Verb:  consulapi.KVSet,
+			Key:   prefix + name,
+			Value: outputs[name],
+		})
+	}
+
+	removed := make([]string, 0, len(prevOutputs))
+	for name := range prevOutputs {
+		if _, ok := outputs[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		ops = append(ops, &consulapi.KVTxnOp{
+			Verb: consulapi.KVDelete,
+			Key:  prefix + name,
+		})
+	}
+
+	if len(ops) == 0 {
+		return nil
+	}
+
+	consulClient, err := tm.getConsulClient()
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(ops); start += maxKVTxnOps {
+		end := start + maxKVTxnOps
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		ok, resp, _, err := consulClient.KVTxn(ctx, ops[start:end], nil)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			var msgs []string
+			if resp != nil {
+				for _, txnErr := range resp.Errors {
+					msgs = append(msgs, txnErr.What)
+				}
+			}
+			return fmt.Errorf("KV transaction was rolled back: %s", strings.Join(msgs, ", "))
+		}
+	}
+	return nil
+}
+
+// getConsulClient returns the Consul client of the tasks manager. The client
+// is created when it is first needed, so that a client is not created unless
+// a feature that requires it is used.
+func (tm *TasksManager) getConsulClient() (client.ConsulClientInterface, error) {
+	tm.consulClientMu.Lock()
+	defer tm.consulClientMu.Unlock()
+
+	if tm.consulClient != nil {
+		return tm.consulClient, nil
+	}
+
+	conf := tm.state.GetConfig()
+	c, err := client.NewConsulClient(conf.Consul, client.ConsulDefaultMaxRetry)
+	if err != nil {
+		return nil, err
+	}
+	tm.consulClient = c
+	return c, nil
+}
+
 // deleteTask deletes an existing task that has been added to CTS. If a task is
 // active and running, it will wait until the task has completed before
 // proceeding with the deletion. Deletion:
+// - destroy the task's infrastructure if requested or configured for the task
 // - delete task from drivers map (and destroys driver dependencies)
 // - delete task config from state
 // - delete task events from state
-func (tm *TasksManager) deleteTask(ctx context.Context, name string) error {
+//
+// If destroying the task's infrastructure fails, the task is not deleted and
+// is no longer marked for deletion.
+func (tm *TasksManager) deleteTask(ctx context.Context, name string, destroy bool) error {
 	logger := tm.logger.With(taskNameLogKey, name)
 
 	// Check if task exists
@@ -655,6 +956,15 @@ func (tm *TasksManager) deleteTask(ctx context.Context, name string) error {
 		return err
 	}
 
+	if destroy || d.Task().DestroyOnDelete() {
+		if err = tm.destroyTaskResources(ctx, d); err != nil {
+			logger.Error("error deleting task: unable to destroy resources "+
+				"managed by task", "error", err)
+			tm.drivers.UnmarkForDeletion(name)
+			return err
+		}
+	}
+
 	logger.Trace("task is inactive, deleting")
 	if d.Task().IsScheduled() {
 		// Notify the scheduled task to stop
@@ -668,6 +978,10 @@ func (tm *TasksManager) deleteTask(ctx context.Context, name string) error {
 		return err
 	}
 
+	if _, ok := d.Task().Condition().(*config.WebhookConditionConfig); ok {
+		tm.factory.webhookPayloads.Delete(name)
+	}
+
 	// Delete task from state only after driver successfully deleted
 	if err = tm.state.DeleteTask(name); err != nil {
 		logger.Error("error while deleting task state", "error", err)
@@ -686,6 +1000,52 @@ func (tm *TasksManager) deleteTask(ctx context.Context, name string) error {
 	return nil
 }
 
+// destroyTaskResources destroys the infrastructure managed by a task. If the
+// destroy fails, an event is stored so that the failure is visible in the
+// task's status.
+func (tm *TasksManager) destroyTaskResources(ctx context.Context, d driver.Driver) error {
+	task := d.Task()
+	taskName := task.Name()
+	logger := tm.logger.With(taskNameLogKey, taskName)
+
+	tm.drivers.SetActive(taskName)
+	defer tm.drivers.SetInactive(taskName)
+
+	ev, err := event.NewEvent(taskName, &event.Config{
+		Providers: task.ProviderIDs(),
+		Services:  task.ServiceNames(),
+		Source:    task.Module(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating event for task %s: %s", taskName, err)
+	}
+	ev.Trigger = "task deleted"
+	evCtx := event.NewContext(ctx, ev)
+	ev.Start()
+
+	logger.Info("destroying resources managed by task")
+	err = d.DestroyResources(evCtx)
+	if err == nil {
+		logger.Info("resources managed by task destroyed")
+		return nil
+	}
+
+	ev.End(err)
+	logger.Trace("adding event", "event", ev.GoString())
+	if err := tm.state.AddTaskEvent(*ev); err != nil {
+		logger.Error("error storing event", "event", ev.GoString(), "error", err)
+	}
+	return err
+}
+
+// conditionTrigger describes the trigger of a task run by the task's condition
+func conditionTrigger(task *driver.Task) string {
+	if c := task.Condition(); c != nil && c.VariableType() != "" {
+		return fmt.Sprintf("%s condition", c.VariableType())
+	}
+	return "change in monitored dependencies"
+}
+
 func (tm *TasksManager) waitForTaskInactive(ctx context.Context, name string) error {
 	// Check first if inactive, return early and don't log
 	if !tm.drivers.IsActive(name) {
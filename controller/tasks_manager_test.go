@@ -2,20 +2,27 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/consul-terraform-sync/api"
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/driver"
 	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocksC "github.com/hashicorp/consul-terraform-sync/mocks/client"
 	mocksD "github.com/hashicorp/consul-terraform-sync/mocks/driver"
 	mocksS "github.com/hashicorp/consul-terraform-sync/mocks/state"
 	mocksTmpl "github.com/hashicorp/consul-terraform-sync/mocks/templates"
 	"github.com/hashicorp/consul-terraform-sync/state"
 	"github.com/hashicorp/consul-terraform-sync/state/event"
 	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/hcat/dep"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -159,6 +166,23 @@ func Test_TasksManager_TaskCreate(t *testing.T) {
 		assert.Contains(t, err.Error(), "required")
 	})
 
+	t.Run("unsupported by driver", func(t *testing.T) {
+		execConf := &config.Config{
+			BufferPeriod: config.DefaultBufferPeriodConfig(),
+			WorkingDir:   config.String(config.DefaultWorkingDir),
+			Driver:       &config.DriverConfig{Exec: &config.ExecConfig{}},
+		}
+		execConf.Finalize()
+		tm := newTestTasksManager()
+		tm.state = state.NewInMemoryStore(execConf)
+
+		taskConf := validTaskConf
+		taskConf.DestroyOnDelete = config.Bool(true)
+		_, err := tm.TaskCreate(ctx, taskConf)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "destroy_on_delete")
+	})
+
 	t.Run("create error", func(t *testing.T) {
 		mockD := new(mocksD.Driver)
 		mockD.On("InitTask", mock.Anything).Return(fmt.Errorf("init err"))
@@ -263,7 +287,7 @@ func Test_TasksManager_TaskCreateAndRun(t *testing.T) {
 			On("InitTask", ctx).Return(nil).
 			On("OverrideNotifier").Return().
 			On("RenderTemplate", mock.Anything).Return(true, nil).
-			On("ApplyTask", ctx).Return(fmt.Errorf("apply err"))
+			On("ApplyTask", mock.Anything).Return(fmt.Errorf("apply err"))
 		tm.state = state.NewInMemoryStore(conf)
 		tm.drivers = driver.NewDrivers()
 		tm.factory.newDriver = func(context.Context, *config.Config, *driver.Task, templates.Watcher) (driver.Driver, error) {
@@ -320,6 +344,89 @@ func Test_TasksManager_TaskDelete(t *testing.T) {
 	})
 }
 
+func Test_TasksManager_TaskDeleteAndDestroy(t *testing.T) {
+	ctx := context.Background()
+	tm := newTestTasksManager()
+	deletedCh := tm.EnableTaskDeletedNotify()
+
+	drivers := driver.NewDrivers()
+	taskName := "delete_task"
+
+	mockD := new(mocksD.Driver)
+	mockD.On("TemplateIDs").Return(nil)
+	mockD.On("Task").Return(enabledTestTask(t, taskName))
+	mockD.On("DestroyResources", mock.Anything).Return(nil).Once()
+	mockD.On("DestroyTask", mock.Anything).Return()
+	drivers.Add(taskName, mockD)
+
+	tm.drivers = drivers
+
+	err := tm.TaskDeleteAndDestroy(ctx, taskName)
+	assert.NoError(t, err)
+	select {
+	case n := <-deletedCh:
+		assert.Equal(t, taskName, n)
+	case <-time.After(1 * time.Second):
+		t.Fatal("delete channel did not receive message")
+	}
+	assert.Equal(t, 0, drivers.Len())
+	mockD.AssertExpectations(t)
+}
+
+func Test_TasksManager_TaskInspectDestroy(t *testing.T) {
+	ctx := context.Background()
+	taskName := "task"
+
+	t.Run("happy_path", func(t *testing.T) {
+		tm := newTestTasksManager()
+		mockD := new(mocksD.Driver)
+		mockD.On("TemplateIDs").Return(nil)
+		mockD.On("InspectDestroyResources", ctx).Return(
+			driver.InspectPlan{ChangesPresent: true, Plan: "plan"}, nil).Once()
+		tm.drivers.Add(taskName, mockD)
+
+		changes, plan, url, err := tm.TaskInspectDestroy(ctx, taskName)
+		assert.NoError(t, err)
+		assert.True(t, changes)
+		assert.Equal(t, "plan", plan)
+		assert.Empty(t, url)
+		assert.False(t, tm.drivers.IsActive(taskName))
+
+		_, exists := tm.drivers.Get(taskName)
+		assert.True(t, exists, "task should not be deleted on inspect")
+	})
+
+	t.Run("does_not_exist", func(t *testing.T) {
+		tm := newTestTasksManager()
+		_, _, _, err := tm.TaskInspectDestroy(ctx, taskName)
+		assert.ErrorAs(t, err, &api.TaskNotFoundError{})
+	})
+
+	t.Run("marked_for_deletion", func(t *testing.T) {
+		tm := newTestTasksManager()
+		mockD := new(mocksD.Driver)
+		mockD.On("TemplateIDs").Return(nil)
+		tm.drivers.Add(taskName, mockD)
+		tm.drivers.MarkForDeletion(taskName)
+
+		_, _, _, err := tm.TaskInspectDestroy(ctx, taskName)
+		assert.ErrorAs(t, err, &api.TaskNotFoundError{})
+		mockD.AssertNotCalled(t, "InspectDestroyResources", ctx)
+	})
+
+	t.Run("active_task", func(t *testing.T) {
+		tm := newTestTasksManager()
+		mockD := new(mocksD.Driver)
+		mockD.On("TemplateIDs").Return(nil)
+		tm.drivers.Add(taskName, mockD)
+		tm.drivers.SetActive(taskName)
+
+		_, _, _, err := tm.TaskInspectDestroy(ctx, taskName)
+		assert.Error(t, err)
+		mockD.AssertNotCalled(t, "InspectDestroyResources", ctx)
+	})
+}
+
 func Test_TasksManager_TaskUpdate(t *testing.T) {
 	t.Parallel()
 
@@ -341,7 +448,7 @@ func Test_TasksManager_TaskUpdate(t *testing.T) {
 			},
 		}
 		taskConf.Finalize(conf.BufferPeriod, *conf.WorkingDir)
-		task, err := newDriverTask(conf, &taskConf, nil)
+		task, err := newDriverTask(conf, &taskConf, nil, nil)
 		require.NoError(t, err)
 
 		d := new(mocksD.Driver)
@@ -579,6 +686,50 @@ func Test_TasksManager_addTask(t *testing.T) {
 		// Confirm that this second driver was not added to drivers list
 		assert.Equal(t, 1, tm.drivers.Len())
 	})
+
+	t.Run("damping trigger", func(t *testing.T) {
+		tm := newTestTasksManager()
+		tm.dampedTaskCh = make(chan string, 1)
+
+		// Set up driver's task object
+		driverTask, err := driver.NewTask(driver.TaskConfig{
+			Name: "task_damped",
+			Condition: &config.ServicesConditionConfig{
+				Damping: &config.DampingConfig{
+					MinStableTime:      config.TimeDuration(10 * time.Millisecond),
+					MaxSuppressionTime: config.TimeDuration(time.Second),
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		// Mock driver
+		d := new(mocksD.Driver)
+		d.On("SetBufferPeriod").Return().Once()
+		d.On("Task").Return(driverTask)
+		d.On("TemplateIDs").Return(nil).Once()
+
+		// Mock state
+		s := new(mocksS.Store)
+		s.On("SetTask", mock.Anything).Return(nil).Once()
+		tm.state = s
+
+		_, err = tm.addTask(context.Background(), d)
+		require.NoError(t, err)
+
+		// Suppress a change and confirm the task is triggered once stable
+		damper := driverTask.Damper()
+		damper.Record([]*dep.HealthService{{ID: "api-1", Name: "api"}})
+		assert.False(t, damper.Observe([]*dep.HealthService{
+			{ID: "api-1", Name: "api"}, {ID: "api-2", Name: "api"}}))
+
+		select {
+		case taskName := <-tm.WatchDampedTasks():
+			assert.Equal(t, "task_damped", taskName)
+		case <-time.After(time.Second * 5):
+			t.Fatal("did not receive from dampedTaskCh as expected")
+		}
+	})
 }
 
 func Test_TasksManager_TaskRunNow(t *testing.T) {
@@ -766,7 +917,7 @@ func Test_TasksManager_TaskRunNow(t *testing.T) {
 		d.On("Task").Return(enabledTestTask(t, validTaskName)).
 			On("TemplateIDs").Return(nil).
 			On("RenderTemplate", mock.Anything).Return(true, nil).
-			On("ApplyTask", ctx).Return(nil)
+			On("ApplyTask", mock.Anything).Return(nil)
 		drivers := tm.drivers
 		drivers.Add(validTaskName, d)
 		drivers.SetActive(validTaskName)
@@ -829,6 +980,351 @@ func Test_TasksManager_TaskRunNow_Store(t *testing.T) {
 	})
 }
 
+func Test_TasksManager_TaskOutputs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		tm := newTestTasksManager()
+		require.NoError(t, tm.state.SetTask(validTaskConf))
+
+		outputs := map[string]json.RawMessage{"id": json.RawMessage(`"abc"`)}
+		withOutputs := event.Event{ID: "1", TaskName: validTaskName, Outputs: outputs}
+		withoutOutputs := event.Event{ID: "2", TaskName: validTaskName}
+		require.NoError(t, tm.state.AddTaskEvent(withOutputs))
+		require.NoError(t, tm.state.AddTaskEvent(withoutOutputs))
+
+		actual, err := tm.TaskOutputs(ctx, validTaskName)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, "1", actual.ID)
+		assert.Equal(t, outputs, actual.Outputs)
+	})
+
+	t.Run("no_outputs", func(t *testing.T) {
+		tm := newTestTasksManager()
+		require.NoError(t, tm.state.SetTask(validTaskConf))
+
+		actual, err := tm.TaskOutputs(ctx, validTaskName)
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("task_not_found", func(t *testing.T) {
+		tm := newTestTasksManager()
+
+		_, err := tm.TaskOutputs(ctx, "non-existent-task")
+		assert.Error(t, err)
+	})
+}
+
+func Test_TasksManager_TaskTrigger(t *testing.T) {
+	ctx := context.Background()
+	taskName := "task"
+
+	t.Run("happy_path", func(t *testing.T) {
+		task, err := driver.NewTask(driver.TaskConfig{
+			Name:    taskName,
+			Enabled: true,
+			Condition: &config.WebhookConditionConfig{
+				Secret: config.String("s3cr3t"),
+			},
+		})
+		require.NoError(t, err)
+
+		tm := newTestTasksManager()
+		mockD := new(mocksD.Driver)
+		mockD.On("TemplateIDs").Return(nil)
+		mockD.On("Task").Return(task)
+		tm.drivers.Add(taskName, mockD)
+
+		err = tm.TaskTrigger(ctx, taskName, map[string]interface{}{"image": "web"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("does_not_exist", func(t *testing.T) {
+		tm := newTestTasksManager()
+		err := tm.TaskTrigger(ctx, taskName, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("not_webhook_condition", func(t *testing.T) {
+		tm := newTestTasksManager()
+		mockD := new(mocksD.Driver)
+		mockD.On("TemplateIDs").Return(nil)
+		mockD.On("Task").Return(scheduledTestTask(t, taskName))
+		tm.drivers.Add(taskName, mockD)
+
+		err := tm.TaskTrigger(ctx, taskName, nil)
+		assert.Error(t, err)
+	})
+}
+
+func Test_TasksManager_TaskDamping(t *testing.T) {
+	ctx := context.Background()
+	taskName := "task"
+
+	t.Run("damping", func(t *testing.T) {
+		task, err := driver.NewTask(driver.TaskConfig{
+			Name:    taskName,
+			Enabled: true,
+			Condition: &config.ServicesConditionConfig{
+				Damping: &config.DampingConfig{
+					Threshold:          config.Int(5),
+					MinStableTime:      config.TimeDuration(time.Minute),
+					MaxSuppressionTime: config.TimeDuration(time.Hour),
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		tm := newTestTasksManager()
+		mockD := new(mocksD.Driver)
+		mockD.On("TemplateIDs").Return(nil)
+		mockD.On("Task").Return(task)
+		tm.drivers.Add(taskName, mockD)
+
+		status, err := tm.TaskDamping(ctx, taskName)
+		assert.NoError(t, err)
+		assert.Equal(t, &event.DampingStatus{}, status)
+	})
+
+	t.Run("no_damping", func(t *testing.T) {
+		tm := newTestTasksManager()
+		mockD := new(mocksD.Driver)
+		mockD.On("TemplateIDs").Return(nil)
+		mockD.On("Task").Return(scheduledTestTask(t, taskName))
+		tm.drivers.Add(taskName, mockD)
+
+		status, err := tm.TaskDamping(ctx, taskName)
+		assert.NoError(t, err)
+		assert.Nil(t, status)
+	})
+
+	t.Run("does_not_exist", func(t *testing.T) {
+		tm := newTestTasksManager()
+		_, err := tm.TaskDamping(ctx, taskName)
+		assert.Error(t, err)
+	})
+}
+
+func Test_TasksManager_storeOutputs(t *testing.T) {
+	ctx := context.Background()
+	outputs := map[string]json.RawMessage{
+		"name": json.RawMessage(`"test"`),
+		"ids":  json.RawMessage(`["a","b"]`),
+	}
+
+	newOutputsTask := func(t *testing.T, expose bool, path string) *driver.Task {
+		task, err := driver.NewTask(driver.TaskConfig{
+			Name:          validTaskName,
+			Enabled:       true,
+			ExposeOutputs: expose,
+			OutputsKVPath: path,
+		})
+		require.NoError(t, err)
+		return task
+	}
+
+	t.Run("not_exposed", func(t *testing.T) {
+		tm := newTestTasksManager()
+		consul := new(mocksC.ConsulClientInterface)
+		tm.consulClient = consul
+
+		d := new(mocksD.Driver)
+
+		ev := &event.Event{}
+		tm.storeOutputs(ctx, d, newOutputsTask(t, false, ""), ev)
+		assert.Nil(t, ev.Outputs)
+		d.AssertNotCalled(t, "Outputs", mock.Anything)
+		consul.AssertNotCalled(t, "KVTxn", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("store_only", func(t *testing.T) {
+		tm := newTestTasksManager()
+		consul := new(mocksC.ConsulClientInterface)
+		tm.consulClient = consul
+
+		d := new(mocksD.Driver)
+		d.On("Outputs", ctx).Return(outputs, nil).Once()
+
+		ev := &event.Event{}
+		tm.storeOutputs(ctx, d, newOutputsTask(t, true, ""), ev)
+		assert.Equal(t, outputs, ev.Outputs)
+		d.AssertExpectations(t)
+		consul.AssertNotCalled(t, "KVTxn", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("publish", func(t *testing.T) {
+		tm := newTestTasksManager()
+		consul := new(mocksC.ConsulClientInterface)
+		expectedOps := consulapi.KVTxnOps{
+			{Verb: consulapi.KVSet, Key: "cts/outputs/ids", Value: []byte(`["a","b"]`)},
+			{Verb: consulapi.KVSet, Key: "cts/outputs/name", Value: []byte(`"test"`)},
+		}
+		consul.On("KVTxn", ctx, expectedOps, (*consulapi.QueryOptions)(nil)).
+			Return(true, &consulapi.KVTxnResponse{}, &consulapi.QueryMeta{}, nil).Once()
+		tm.consulClient = consul
+
+		d := new(mocksD.Driver)
+		d.On("Outputs", ctx).Return(outputs, nil).Once()
+
+		ev := &event.Event{}
+		tm.storeOutputs(ctx, d, newOutputsTask(t, true, "cts/outputs/"), ev)
+		assert.Equal(t, outputs, ev.Outputs)
+		d.AssertExpectations(t)
+		consul.AssertExpectations(t)
+	})
+
+	t.Run("publish_removed_outputs", func(t *testing.T) {
+		tm := newTestTasksManager()
+		task := newOutputsTask(t, true, "cts/outputs")
+		require.NoError(t, tm.state.SetTask(config.TaskConfig{
+			Name: config.String(validTaskName),
+		}))
+		prev, err := event.NewEvent(validTaskName, nil)
+		require.NoError(t, err)
+		prev.Outputs = map[string]json.RawMessage{
+			"name": json.RawMessage(`"old"`),
+			"vip":  json.RawMessage(`"10.0.0.1"`),
+		}
+		require.NoError(t, tm.state.AddTaskEvent(*prev))
+
+		consul := new(mocksC.ConsulClientInterface)
+		expectedOps := consulapi.KVTxnOps{
+			{Verb: consulapi.KVSet, Key: "cts/outputs/ids", Value: []byte(`["a","b"]`)},
+			{Verb: consulapi.KVSet, Key: "cts/outputs/name", Value: []byte(`"test"`)},
+			{Verb: consulapi.KVDelete, Key: "cts/outputs/vip"},
+		}
+		consul.On("KVTxn", ctx, expectedOps, (*consulapi.QueryOptions)(nil)).
+			Return(true, &consulapi.KVTxnResponse{}, &consulapi.QueryMeta{}, nil).Once()
+		tm.consulClient = consul
+
+		d := new(mocksD.Driver)
+		d.On("Outputs", ctx).Return(outputs, nil).Once()
+
+		ev := &event.Event{}
+		tm.storeOutputs(ctx, d, task, ev)
+		assert.Equal(t, outputs, ev.Outputs)
+		consul.AssertExpectations(t)
+	})
+
+	t.Run("outputs_error", func(t *testing.T) {
+		tm := newTestTasksManager()
+		consul := new(mocksC.ConsulClientInterface)
+		tm.consulClient = consul
+
+		d := new(mocksD.Driver)
+		d.On("Outputs", ctx).Return(nil, errors.New("output error")).Once()
+
+		ev := &event.Event{}
+		tm.storeOutputs(ctx, d, newOutputsTask(t, true, "cts/outputs"), ev)
+		assert.Nil(t, ev.Outputs)
+		consul.AssertNotCalled(t, "KVTxn", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func Test_TasksManager_publishOutputs(t *testing.T) {
+	ctx := context.Background()
+	outputs := map[string]json.RawMessage{"id": json.RawMessage(`"abc"`)}
+
+	t.Run("rolled_back", func(t *testing.T) {
+		tm := newTestTasksManager()
+		consul := new(mocksC.ConsulClientInterface)
+		resp := &consulapi.KVTxnResponse{
+			Errors: consulapi.TxnErrors{{OpIndex: 1, What: "invalid key"}},
+		}
+		consul.On("KVTxn", ctx, mock.Anything, mock.Anything).
+			Return(false, resp, &consulapi.QueryMeta{}, nil).Once()
+		tm.consulClient = consul
+
+		err := tm.publishOutputs(ctx, "cts/outputs", outputs, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid key")
+	})
+
+	t.Run("txn_error", func(t *testing.T) {
+		tm := newTestTasksManager()
+		consul := new(mocksC.ConsulClientInterface)
+		consul.On("KVTxn", ctx, mock.Anything, mock.Anything).
+			Return(false, nil, nil, errors.New("txn error")).Once()
+		tm.consulClient = consul
+
+		err := tm.publishOutputs(ctx, "cts/outputs", outputs, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "txn error")
+	})
+
+	t.Run("batches", func(t *testing.T) {
+		tm := newTestTasksManager()
+		consul := new(mocksC.ConsulClientInterface)
+		tm.consulClient = consul
+
+		many := make(map[string]json.RawMessage)
+		prev := make(map[string]json.RawMessage)
+		for i := 0; i < 100; i++ {
+			many[fmt.Sprintf("out_%03d", i)] = json.RawMessage(`"value"`)
+			prev[fmt.Sprintf("stale_%03d", i)] = json.RawMessage(`"value"`)
+		}
+
+		var batches []consulapi.KVTxnOps
+		consul.On("KVTxn", ctx, mock.Anything, (*consulapi.QueryOptions)(nil)).
+			Run(func(args mock.Arguments) {
+				batches = append(batches, args.Get(1).(consulapi.KVTxnOps))
+			}).
+			Return(true, &consulapi.KVTxnResponse{}, &consulapi.QueryMeta{}, nil)
+
+		err := tm.publishOutputs(ctx, "cts/outputs", many, prev)
+		require.NoError(t, err)
+
+		require.Len(t, batches, 4)
+		var sets, deletes int
+		for _, batch := range batches {
+			assert.LessOrEqual(t, len(batch), maxKVTxnOps)
+			for _, op := range batch {
+				switch op.Verb {
+				case consulapi.KVSet:
+					assert.Zero(t, deletes, "values are set before keys are deleted")
+					sets++
+				case consulapi.KVDelete:
+					deletes++
+				}
+			}
+		}
+		assert.Equal(t, 100, sets)
+		assert.Equal(t, 100, deletes)
+	})
+
+	t.Run("batch_rolled_back", func(t *testing.T) {
+		tm := newTestTasksManager()
+		consul := new(mocksC.ConsulClientInterface)
+		tm.consulClient = consul
+
+		many := make(map[string]json.RawMessage)
+		for i := 0; i < maxKVTxnOps+1; i++ {
+			many[fmt.Sprintf("out_%03d", i)] = json.RawMessage(`"value"`)
+		}
+		resp := &consulapi.KVTxnResponse{
+			Errors: consulapi.TxnErrors{{OpIndex: 0, What: "invalid key"}},
+		}
+		consul.On("KVTxn", ctx, mock.Anything, mock.Anything).
+			Return(false, resp, &consulapi.QueryMeta{}, nil).Once()
+
+		err := tm.publishOutputs(ctx, "cts/outputs", many, nil)
+		require.Error(t, err)
+		consul.AssertNumberOfCalls(t, "KVTxn", 1)
+	})
+
+	t.Run("no_outputs", func(t *testing.T) {
+		tm := newTestTasksManager()
+		consul := new(mocksC.ConsulClientInterface)
+		tm.consulClient = consul
+
+		err := tm.publishOutputs(ctx, "cts/outputs", nil, nil)
+		assert.NoError(t, err)
+		consul.AssertNotCalled(t, "KVTxn", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 func Test_ConditionMonitor_EnableTaskRanNotify(t *testing.T) {
 	t.Parallel()
 
@@ -876,7 +1372,7 @@ func Test_TasksManager_TaskCreateAndRunAllowFail(t *testing.T) {
 			On("InitTask", ctx).Return(nil).
 			On("OverrideNotifier").Return().
 			On("RenderTemplate", mock.Anything).Return(true, nil).
-			On("ApplyTask", ctx).Return(fmt.Errorf("apply err")).
+			On("ApplyTask", mock.Anything).Return(fmt.Errorf("apply err")).
 			On("SetBufferPeriod").Return().Once().
 			On("TemplateIDs").Return(nil).Once()
 
@@ -931,7 +1427,7 @@ func Test_TasksManager_deleteTask(t *testing.T) {
 
 			tm.state.AddTaskEvent(event.Event{TaskName: "success"})
 
-			err := tm.deleteTask(ctx, tc.name)
+			err := tm.deleteTask(ctx, tc.name, false)
 
 			assert.NoError(t, err)
 			_, exists := tm.drivers.Get(tc.name)
@@ -958,7 +1454,7 @@ func Test_TasksManager_deleteTask(t *testing.T) {
 		tm.deletedScheduleCh = make(chan string, 1)
 
 		// Delete task
-		err := tm.deleteTask(ctx, schedTaskName)
+		err := tm.deleteTask(ctx, schedTaskName, false)
 		assert.NoError(t, err)
 
 		// Verify the deleted schedule channel received message
@@ -989,7 +1485,7 @@ func Test_TasksManager_deleteTask(t *testing.T) {
 		// Attempt to delete the active task
 		ch := make(chan error)
 		go func() {
-			err := tm.deleteTask(ctx, taskName)
+			err := tm.deleteTask(ctx, taskName, false)
 			ch <- err
 		}()
 
@@ -1020,7 +1516,58 @@ func Test_TasksManager_deleteTask(t *testing.T) {
 		assert.False(t, exists, "task should no longer exist in state")
 	})
 
+	t.Run("destroy_on_delete", func(t *testing.T) {
+		taskName := "destroy_task"
+		task, err := driver.NewTask(driver.TaskConfig{
+			Name:            taskName,
+			Enabled:         true,
+			DestroyOnDelete: true,
+		})
+		require.NoError(t, err)
+
+		d := new(mocksD.Driver)
+		d.On("Task").Return(task)
+		d.On("DestroyResources", mock.Anything).Return(nil).Once()
+		d.On("DestroyTask", ctx).Return()
+		d.On("TemplateIDs").Return(nil)
+
+		tm := newTestTasksManager()
+		tm.drivers.Add(taskName, d)
+
+		err = tm.deleteTask(ctx, taskName, false)
+		assert.NoError(t, err)
+		d.AssertExpectations(t)
+
+		_, exists := tm.drivers.Get(taskName)
+		assert.False(t, exists, "driver should no longer exist")
+	})
+
+	t.Run("destroy_error", func(t *testing.T) {
+		taskName := "destroy_error_task"
+		d := new(mocksD.Driver)
+		d.On("Task").Return(enabledTestTask(t, taskName))
+		d.On("DestroyResources", mock.Anything).Return(errors.New("destroy error")).Once()
+		d.On("TemplateIDs").Return(nil)
+
+		tm := newTestTasksManager()
+		tm.drivers.Add(taskName, d)
+		tm.drivers.MarkForDeletion(taskName)
+
+		err := tm.deleteTask(ctx, taskName, true)
+		assert.Error(t, err)
+		d.AssertNotCalled(t, "DestroyTask", ctx)
+
+		_, exists := tm.drivers.Get(taskName)
+		assert.True(t, exists, "task should not be deleted when destroy fails")
+		assert.False(t, tm.drivers.IsMarkedForDeletion(taskName))
+		assert.False(t, tm.drivers.IsActive(taskName))
+
+		events := tm.state.GetTaskEvents(taskName)
+		require.Len(t, events[taskName], 1, "event should be stored for failed destroy")
+		assert.False(t, events[taskName][0].Success)
+	})
 }
+
 func Test_TasksManager_waitForTaskInactive(t *testing.T) {
 	ctx := context.Background()
 	t.Run("active_task", func(t *testing.T) {
@@ -1079,16 +1626,18 @@ func mockDriver(ctx context.Context, d *mocksD.Driver, task *driver.Task) {
 		On("InitTask", ctx).Return(nil).
 		On("TemplateIDs").Return(nil).
 		On("RenderTemplate", mock.Anything).Return(true, nil).
-		On("ApplyTask", ctx).Return(nil)
+		On("ApplyTask", mock.Anything).Return(nil)
 }
 
 func newTestTasksManager() *TasksManager {
 	return &TasksManager{
 		logger: logging.NewNullLogger(),
 		factory: &driverFactory{
-			logger: logging.NewNullLogger(),
+			logger:          logging.NewNullLogger(),
+			webhookPayloads: tmplfunc.NewWebhookStore(),
 		},
-		drivers: driver.NewDrivers(),
-		state:   state.NewInMemoryStore(nil),
+		drivers:        driver.NewDrivers(),
+		state:          state.NewInMemoryStore(nil),
+		consulClientMu: &sync.Mutex{},
 	}
 }
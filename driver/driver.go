@@ -2,6 +2,7 @@ package driver
 
 import (
 	"context"
+	"encoding/json"
 )
 
 //go:generate mockery --name=Driver --filename=driver.go  --output=../mocks/driver
@@ -29,12 +30,24 @@ type Driver interface {
 	// ApplyTask applies change for the task managed by the driver
 	ApplyTask(ctx context.Context) error
 
+	// Outputs returns the output values of the task's module from the latest
+	// apply
+	Outputs(ctx context.Context) (map[string]json.RawMessage, error)
+
 	// UpdateTask supports updating certain fields of a task
 	UpdateTask(ctx context.Context, task PatchTask) (InspectPlan, error)
 
 	// DestroyTask destroys task dependencies so that it can be safely deleted
 	DestroyTask(ctx context.Context)
 
+	// InspectDestroyResources inspects the changes to destroy the
+	// infrastructure managed by the task
+	InspectDestroyResources(ctx context.Context) (InspectPlan, error)
+
+	// DestroyResources destroys the infrastructure managed by the task and
+	// removes any generated artifacts for the task
+	DestroyResources(ctx context.Context) error
+
 	// Task returns the task information of the driver
 	Task() *Task
 
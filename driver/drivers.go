@@ -178,6 +178,14 @@ func (d *Drivers) MarkForDeletion(name string) {
 	d.deletion[name] = true
 }
 
+// UnmarkForDeletion removes the deletion mark for a task, e.g. when the task
+// was unable to be deleted.
+func (d *Drivers) UnmarkForDeletion(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.deletion, name)
+}
+
 func (d *Drivers) IsMarkedForDeletion(name string) bool {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
@@ -0,0 +1,156 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/hcat"
+)
+
+const (
+	execSubsystemName = "exec"
+
+	// Environment variables set for the command run by the exec driver
+	execTaskNameEnv   = "CTS_TASK_NAME"
+	execTaskModuleEnv = "CTS_TASK_MODULE"
+
+	// execStdoutOutput is the name of the output that contains the standard out
+	// of the command from the latest successful run of the task
+	execStdoutOutput = "stdout"
+)
+
+var _ Driver = (*Exec)(nil)
+
+// Exec is a CTS driver that runs a user-configured command for a task. The
+// task's monitored Consul data is rendered and passed to the command as JSON
+// on stdin. A non-zero exit code of the command fails the task run.
+type Exec struct {
+	renderedDriver
+
+	command string
+	args    []string
+	timeout time.Duration
+}
+
+// ExecConfig configures the exec driver
+type ExecConfig struct {
+	Task    *Task
+	Command string
+	Args    []string
+	Timeout time.Duration
+	Watcher templates.Watcher
+}
+
+// NewExec configures and initializes a new exec driver for a task.
+func NewExec(config *ExecConfig) (*Exec, error) {
+	task := config.Task
+	wd := task.WorkingDir()
+	logger := logging.Global().Named(logSystemName).Named(execSubsystemName)
+	if _, err := os.Stat(wd); os.IsNotExist(err) {
+		if err := os.MkdirAll(wd, workingDirPerms); err != nil {
+			logger.Error("error creating task work directory", "error", err)
+			return nil, err
+		}
+	}
+
+	args := make([]string, len(config.Args))
+	copy(args, config.Args)
+
+	e := &Exec{
+		renderedDriver: renderedDriver{
+			task:       task,
+			outputName: execStdoutOutput,
+			resolver:   hcat.NewResolver(),
+			watcher:    config.Watcher,
+			fileReader: ioutil.ReadFile,
+			logger:     logger,
+		},
+		command: config.Command,
+		args:    args,
+		timeout: config.Timeout,
+	}
+	e.runner = e
+	return e, nil
+}
+
+// describe returns the command that is run for the task
+func (e *Exec) describe() string {
+	cmd := strings.Join(append([]string{e.command}, e.args...), " ")
+	return fmt.Sprintf("The command '%s' would be run", cmd)
+}
+
+// run runs the command with the rendered Consul data on stdin. The command's
+// standard out is returned on success and is included in the error when the
+// command exits with a non-zero exit code.
+func (e *Exec) run(ctx context.Context, input []byte) (string, error) {
+	taskName := e.task.Name()
+
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.command, e.args...)
+	cmd.Dir = e.task.WorkingDir()
+	cmd.Env = e.env()
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	e.logger.Trace("exec", taskNameLogKey, taskName, "command", e.command)
+	err := cmd.Run()
+	if stderr.Len() > 0 {
+		e.logger.Debug("command wrote to stderr", taskNameLogKey, taskName,
+			"stderr", stderr.String())
+	}
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("error exec for '%s': command did not complete "+
+				"within the timeout %s", taskName, e.timeout)
+		}
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("error exec for '%s': command exited with code %d: %s",
+				taskName, exitErr.ExitCode(), strings.TrimSpace(stdout.String()))
+		}
+		return "", fmt.Errorf("error exec for '%s': %s", taskName, err)
+	}
+
+	return stdout.String(), nil
+}
+
+// env returns the environment for the command, which inherits the os
+// environment and includes the task's environment and information
+func (e *Exec) env() []string {
+	env := envMap(os.Environ())
+	for k, v := range e.task.Env() {
+		env[k] = v
+	}
+	env[execTaskNameEnv] = e.task.Name()
+	env[execTaskModuleEnv] = e.task.Module()
+
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	environ := make([]string, len(keys))
+	for i, k := range keys {
+		environ[i] = fmt.Sprintf("%s=%s", k, env[k])
+	}
+	return environ
+}
@@ -0,0 +1,115 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mocksTmpl "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExec_ApplyTask(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("happy_path", func(t *testing.T) {
+		e := newTestExec(t, "sh", "-c", `cat; echo "$CTS_TASK_NAME"`)
+
+		ev, err := event.NewEvent("test", nil)
+		require.NoError(t, err)
+		err = e.ApplyTask(event.NewContext(ctx, ev))
+		require.NoError(t, err)
+		assert.Contains(t, ev.Detail, "}test\n")
+
+		outputs, err := e.Outputs(ctx)
+		require.NoError(t, err)
+		var stdout string
+		require.NoError(t, json.Unmarshal(outputs[execStdoutOutput], &stdout))
+		assert.Contains(t, stdout, `"services":`)
+		assert.Contains(t, stdout, "}test\n")
+	})
+
+	t.Run("exit_code", func(t *testing.T) {
+		e := newTestExec(t, "sh", "-c", "echo failed to deploy; exit 3")
+
+		ev, err := event.NewEvent("test", nil)
+		require.NoError(t, err)
+		err = e.ApplyTask(event.NewContext(ctx, ev))
+		require.Error(t, err)
+		assert.Empty(t, ev.Detail)
+		assert.Contains(t, err.Error(), "exited with code 3")
+		assert.Contains(t, err.Error(), "failed to deploy")
+
+		outputs, err := e.Outputs(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, outputs)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		e := newTestExec(t, "sleep", "5")
+		e.timeout = 50 * time.Millisecond
+
+		err := e.ApplyTask(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "timeout")
+	})
+
+	t.Run("missing_command", func(t *testing.T) {
+		e := newTestExec(t, filepath.Join(t.TempDir(), "does-not-exist"))
+
+		err := e.ApplyTask(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		e := newTestExec(t, "sh", "-c", "exit 1")
+		e.task.enabled = false
+
+		err := e.ApplyTask(ctx)
+		assert.NoError(t, err)
+	})
+}
+
+func TestExec_InspectTask(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	e := newTestExec(t, "sh", "-c", "exit 1")
+	w := new(mocksTmpl.Watcher)
+	w.On("Deregister", e.template).Return().Once()
+	e.watcher = w
+
+	plan, err := e.InspectTask(ctx)
+	require.NoError(t, err)
+	assert.True(t, plan.ChangesPresent)
+	assert.Contains(t, plan.Plan, "sh -c exit 1")
+	assert.Contains(t, plan.Plan, `"services": {`)
+	w.AssertExpectations(t)
+}
+
+func TestExec_DestroyResources(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	e := newTestExec(t, "cat")
+	_, err := e.InspectDestroyResources(ctx)
+	assert.Error(t, err)
+	assert.Error(t, e.DestroyResources(ctx))
+}
+
+// newTestExec returns an exec driver for an enabled task with rendered data
+// written to the task's working directory
+func newTestExec(t *testing.T, command string, args ...string) *Exec {
+	e := &Exec{
+		command: command,
+		args:    args,
+	}
+	setupTestRenderedDriver(t, &e.renderedDriver, execStdoutOutput)
+	e.runner = e
+	return e
+}
@@ -0,0 +1,166 @@
+package driver
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/notifier"
+)
+
+// notifierTemplate is a template wrapped by a notifier that determines which
+// dependency changes trigger the task
+type notifierTemplate interface {
+	templates.Template
+	notifier.Overrider
+}
+
+// newNotifier wraps the template with the notifier for the task's condition to
+// ensure only the condition's monitored changes (and not the module input's
+// changes) trigger the task. The exception is the vault module input, where
+// changes to the secret data also trigger the task unless it is scheduled.
+func newNotifier(task *Task, tmpl templates.Template) (notifierTemplate, error) {
+	n, err := newConditionNotifier(task, tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := task.Condition().(*config.ScheduleConditionConfig); ok {
+		return n, nil
+	}
+
+	for _, moduleInput := range task.ModuleInputs() {
+		if _, ok := moduleInput.(*config.VaultModuleInputConfig); ok {
+			return notifier.NewVaultSecret(n, tmpl), nil
+		}
+	}
+
+	return n, nil
+}
+
+// newConditionNotifier wraps the template with the notifier for the task's
+// condition.
+func newConditionNotifier(task *Task, tmpl templates.Template) (notifierTemplate, error) {
+	tmplFuncTotal, err := countTmplFunc(task)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cond := task.Condition().(type) {
+	case *config.ServicesConditionConfig:
+		return notifier.NewServices(tmpl, tmplFuncTotal,
+			config.BoolVal(cond.IgnoreStatusChanges), task.Damper()), nil
+	case *config.CatalogServicesConditionConfig:
+		return notifier.NewCatalogServicesRegistration(tmpl, tmplFuncTotal), nil
+	case *config.ConsulKVConditionConfig:
+		return notifier.NewConsulKV(tmpl, tmplFuncTotal), nil
+	case *config.NodesConditionConfig:
+		return notifier.NewNodes(tmpl, tmplFuncTotal), nil
+	case *config.IntentionsConditionConfig:
+		return notifier.NewIntentions(tmpl, tmplFuncTotal), nil
+	case *config.ConfigEntriesConditionConfig:
+		return notifier.NewConfigEntries(tmpl, tmplFuncTotal), nil
+	case *config.HTTPConditionConfig:
+		return notifier.NewHTTP(tmpl, tmplFuncTotal), nil
+	case *config.WebhookConditionConfig:
+		return notifier.NewWebhook(tmpl, tmplFuncTotal), nil
+	case *config.ScheduleConditionConfig:
+		return notifier.NewSuppressNotification(tmpl, tmplFuncTotal), nil
+	default:
+		// services list
+		return notifier.NewServices(tmpl, tmplFuncTotal, false, nil), nil
+	}
+}
+
+// countTmplFunc counts the number of template functions (tmplfunc) that are
+// added to the template file. Counts the tmplfunc needed by the task's
+// services field, condition block, and module_input blocks.
+func countTmplFunc(task *Task) (int, error) {
+	// Count tmplfuncs for the service variable separately. Currently services
+	// can only be configured in one of services field, condition "services",
+	// and module_input "services". Enforced by config validation
+	serviceCount := len(task.Services())
+	nonServiceCount := 0
+
+	switch cond := task.Condition().(type) {
+	case *config.CatalogServicesConditionConfig:
+		perDC, dcCount := countDatacenters(cond.Datacenters)
+		nonServiceCount += perDC + dcCount
+	case *config.ServicesConditionConfig:
+		perDC, dcCount := countDatacenters(cond.Datacenters)
+		if cond.Regexp != nil {
+			serviceCount = perDC
+		} else {
+			serviceCount = len(cond.Names) * perDC
+		}
+		nonServiceCount += dcCount
+	case *config.ConsulKVConditionConfig:
+		nonServiceCount++
+	case *config.NodesConditionConfig:
+		nonServiceCount++
+	case *config.IntentionsConditionConfig:
+		nonServiceCount++
+	case *config.ConfigEntriesConditionConfig:
+		nonServiceCount++
+	case *config.HTTPConditionConfig:
+		nonServiceCount++
+	case *config.WebhookConditionConfig:
+		nonServiceCount++
+	default:
+		// no-op: condition block currently not required since services list
+		// can be used alternatively. enforced by config validation
+	}
+
+	for _, moduleInput := range task.ModuleInputs() {
+		switch input := moduleInput.(type) {
+		case *config.ServicesModuleInputConfig:
+			perDC, dcCount := countDatacenters(input.Datacenters)
+			count := perDC
+			if input.Regexp == nil {
+				count = len(input.Names) * perDC
+			}
+			nonServiceCount += dcCount
+
+			// labelled module inputs render their own variable. relies on
+			// config validation to restrict to one ServicesModuleInput for
+			// the services variable
+			if input.VariableName() != input.VariableType() {
+				nonServiceCount += count
+			} else {
+				serviceCount = count
+			}
+		case *config.ConsulKVModuleInputConfig:
+			nonServiceCount++
+		case *config.NodesModuleInputConfig:
+			nonServiceCount++
+		case *config.IntentionsModuleInputConfig:
+			nonServiceCount++
+		case *config.ConfigEntriesModuleInputConfig:
+			nonServiceCount++
+		case *config.HTTPModuleInputConfig:
+			nonServiceCount++
+		case *config.VaultModuleInputConfig:
+			nonServiceCount++
+		default:
+			return 0, fmt.Errorf("task %q has unsupported type of module_input "+
+				"block configuration %T", task.Name(), input)
+		}
+	}
+
+	return serviceCount + nonServiceCount, nil
+}
+
+// countDatacenters returns the number of datacenters that each monitored
+// query is repeated for, and the number of tmplfuncs needed to query the
+// datacenters. When monitoring all datacenters, the datacenters are unknown
+// until queried and only the local datacenter is counted.
+func countDatacenters(dcs []string) (int, int) {
+	switch {
+	case len(dcs) == 0:
+		return 1, 0
+	case len(dcs) == 1 && dcs[0] == config.AllDatacenters:
+		return 1, 1
+	default:
+		return len(dcs), 0
+	}
+}
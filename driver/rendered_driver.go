@@ -0,0 +1,537 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/notifier"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// runner hands the task's rendered Consul data to the system that the driver
+// integrates with.
+type runner interface {
+	// run runs the task with the rendered data as JSON. Returns the result of
+	// the run, which is recorded as the detail of the task run's event and is
+	// stored as the task's output value.
+	run(ctx context.Context, input []byte) (string, error)
+
+	// describe returns a description of how the task is run, used when
+	// inspecting the task.
+	describe() string
+}
+
+// renderedDriver implements the driver functionality shared by drivers that
+// render the task's monitored Consul data as JSON and hand it to a runner
+// instead of Terraform. The data is rendered using the same templates, watcher,
+// and notifiers as the Terraform driver, so task conditions, buffer periods,
+// and enabling and disabling tasks behave the same.
+type renderedDriver struct {
+	mu sync.RWMutex
+
+	task   *Task
+	runner runner
+
+	// outputName is the name of the task's output value that stores the result
+	// of the latest successful run
+	outputName string
+
+	resolver   templates.Resolver
+	template   templates.Template
+	watcher    templates.Watcher
+	fileReader func(string) ([]byte, error)
+
+	renderedOnce bool
+
+	// output is the result of the latest successful run of the task. nil if
+	// the task has not successfully run.
+	output json.RawMessage
+
+	logger logging.Logger
+
+	overrider notifier.Overrider
+}
+
+// Version returns the version of the driver. The driver hands data to a
+// user-configured system, so there is no version to report.
+func (d *renderedDriver) Version() string {
+	return ""
+}
+
+// Task returns the task config info
+func (d *renderedDriver) Task() *Task {
+	return d.task
+}
+
+// InitTask initializes the task by creating the template used to render the
+// task's Consul data.
+func (d *renderedDriver) InitTask(_ context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.initTask()
+}
+
+// DestroyTask destroys task dependencies so that it is safe for deletion
+func (d *renderedDriver) DestroyTask(_ context.Context) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.deregisterTemplate()
+	if d.task != nil {
+		if damper := d.task.Damper(); damper != nil {
+			damper.Stop()
+		}
+	}
+}
+
+// SetBufferPeriod sets the buffer period for the task. Do not set this when
+// task needs to immediately render a template and run.
+func (d *renderedDriver) SetBufferPeriod() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	taskName := d.task.Name()
+	if !d.task.IsEnabled() {
+		d.logger.Trace("task disabled. skip setting buffer period", taskNameLogKey, taskName)
+		return
+	}
+
+	if d.template == nil {
+		d.logger.Warn("attempted to set buffer for task which does not have a template", taskNameLogKey, taskName)
+		return
+	}
+
+	bp, ok := d.task.BufferPeriod()
+	if !ok {
+		d.logger.Trace("no buffer period for task", taskNameLogKey, taskName)
+		return
+	}
+
+	d.logger.Trace("set buffer period for task", taskNameLogKey, taskName, "buffer_period", bp)
+	d.watcher.SetBufferPeriod(bp.Min, bp.Max, d.template.ID())
+}
+
+func (d *renderedDriver) TemplateIDs() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.template == nil {
+		return nil
+	}
+	return []string{d.template.ID()}
+}
+
+func (d *renderedDriver) OverrideNotifier() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.overrider == nil {
+		return
+	}
+
+	d.overrider.Override()
+}
+
+// RenderTemplate fetches data for the template. If the data is complete fetched,
+// renders the template. Rendering a template for the first time may take several
+// cycles to load all the dependencies asynchronously. Returns a boolean whether
+// the template was rendered
+func (d *renderedDriver) RenderTemplate(_ context.Context) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	taskName := d.task.Name()
+
+	if !d.task.IsEnabled() {
+		d.logger.Trace("task disabled. skip rendering template", taskNameLogKey, taskName)
+		return true, nil
+	}
+
+	d.logger.Trace("checking dependency changes for task", taskNameLogKey, taskName)
+	re, err := d.renderTemplate()
+	return re.Complete && !re.NoChange, err
+}
+
+// InspectTask returns the data that the task would be run with. The driver
+// cannot determine the changes a run would make, so the task is not run.
+func (d *renderedDriver) InspectTask(_ context.Context) (InspectPlan, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.task.IsEnabled() {
+		d.logger.Trace(
+			"task disabled. skip inspecting", taskNameLogKey, d.task.Name())
+		return InspectPlan{
+			Plan: "Task is disabled, inspection was skipped.",
+		}, nil
+	}
+
+	plan, err := d.inspectTask()
+	d.deregisterTemplate()
+	return plan, err
+}
+
+// ApplyTask runs the task with the rendered Consul data.
+func (d *renderedDriver) ApplyTask(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.task.IsEnabled() {
+		d.logger.Trace(
+			"task disabled. skip applying", taskNameLogKey, d.task.Name())
+		return nil
+	}
+
+	return d.applyTask(ctx)
+}
+
+// Outputs returns the result of the latest successful run of the task.
+func (d *renderedDriver) Outputs(_ context.Context) (map[string]json.RawMessage, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	values := make(map[string]json.RawMessage)
+	if d.output != nil {
+		values[d.outputName] = d.output
+	}
+	return values, nil
+}
+
+// InspectDestroyResources is not supported. The driver does not track the
+// resources that are managed by the task.
+func (d *renderedDriver) InspectDestroyResources(_ context.Context) (InspectPlan, error) {
+	return InspectPlan{}, fmt.Errorf("destroying resources is not supported "+
+		"by the driver for task '%s'", d.task.Name())
+}
+
+// DestroyResources is not supported. The driver does not track the resources
+// that are managed by the task.
+func (d *renderedDriver) DestroyResources(_ context.Context) error {
+	return fmt.Errorf("destroying resources is not supported by the driver "+
+		"for task '%s'", d.task.Name())
+}
+
+// UpdateTask updates the task on the driver. Makes any calls to re-init
+// depending on the fields updated. If update task is requested with the inspect
+// run option, then return the data the task would be run with but do not
+// update the task
+func (d *renderedDriver) UpdateTask(ctx context.Context, patch PatchTask) (InspectPlan, error) {
+	taskName := d.task.Name()
+	switch patch.RunOption {
+	case "", RunOptionInspect, RunOptionNow:
+		// valid options
+	default:
+		return InspectPlan{}, fmt.Errorf("Invalid run option '%s'. Please select a valid "+
+			"option", patch.RunOption)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	originalEnabled := d.task.IsEnabled()
+
+	// for inspect, dry-run the task with the planned change and then make sure
+	// to reset the task back to the way it was
+	if patch.RunOption == RunOptionInspect && originalEnabled != patch.Enabled {
+		defer func() {
+			if originalEnabled {
+				d.task.Enable()
+			} else {
+				d.task.Disable()
+			}
+		}()
+	}
+
+	reinit := false
+
+	if originalEnabled != patch.Enabled {
+		if patch.Enabled {
+			d.task.Enable()
+			reinit = true
+		} else {
+			d.task.Disable()
+		}
+	}
+
+	if !patch.Enabled {
+		return InspectPlan{}, nil
+	}
+
+	if reinit {
+		if err := d.initTask(); err != nil {
+			return InspectPlan{}, fmt.Errorf("Error updating task '%s'. Unable to init "+
+				"task: %s", taskName, err)
+		}
+
+		for {
+			result, err := d.renderTemplate()
+			if err != nil {
+				return InspectPlan{}, fmt.Errorf("Error updating task '%s'. Unable to "+
+					"render template for task: %s", taskName, err)
+			}
+			if (result.Complete && !result.NoChange) || (result.Complete && result.NoChange && d.renderedOnce) {
+				// Continue if the template has completed or the template had already
+				// completed prior to enabling the task and there is no change.
+				break
+			}
+		}
+	}
+
+	if patch.RunOption == RunOptionInspect {
+		d.logger.Trace("update task. inspect run option", taskNameLogKey, taskName)
+		plan, err := d.inspectTask()
+		if err != nil {
+			return InspectPlan{}, fmt.Errorf("Error updating task '%s'. Unable to inspect "+
+				"task: %s", taskName, err)
+		}
+		return plan, nil
+	}
+
+	if patch.RunOption == RunOptionNow {
+		d.logger.Trace("update task. run now option", taskNameLogKey, taskName)
+		return InspectPlan{}, d.applyTask(ctx)
+	}
+
+	// allow the task to update naturally!
+	return InspectPlan{}, nil
+}
+
+// initTask initializes the task
+func (d *renderedDriver) initTask() error {
+	input := tftmpl.RootModuleInputData{
+		Path:      d.task.WorkingDir(),
+		FilePerms: filePerms,
+	}
+
+	if err := d.task.configureRootModuleInput(&input); err != nil {
+		return err
+	}
+
+	if err := tftmpl.InitTFVarsTemplate(&input); err != nil {
+		return err
+	}
+
+	return d.initTaskTemplate()
+}
+
+// initTaskTemplate creates templates to be monitored and rendered.
+func (d *renderedDriver) initTaskTemplate() error {
+	wd := d.task.WorkingDir()
+	tmplFullpath := filepath.Join(wd, tftmpl.TFVarsTmplFilename)
+	renderedFilepath := filepath.Join(wd, tftmpl.TFVarsFilename)
+	logger := d.logger.With(taskNameLogKey, d.task.Name())
+
+	content, err := d.fileReader(tmplFullpath)
+	if err != nil {
+		logger.Error("unable to read for task", "error", err)
+		return err
+	}
+
+	renderer := hcat.NewFileRenderer(hcat.FileRendererInput{
+		Path:  renderedFilepath,
+		Perms: filePerms,
+	})
+
+	servicesMeta, err := getServicesMetaData(d.logger, d.task)
+	if err != nil {
+		return err
+	}
+
+	tmpl := hcat.NewTemplate(hcat.TemplateInput{
+		Contents:     string(content),
+		Renderer:     renderer,
+		FuncMapMerge: tmplfunc.HCLMap(servicesMeta, d.task.WebhookPayloads()),
+	})
+
+	if d.template != nil {
+		if d.template.ID() == tmpl.ID() {
+			// Template content is unique across tasks and the same for an
+			// existing template. Reset the buffer period if applicable to
+			// avoid possible waiting after re-init to render latest content
+			d.watcher.BufferReset(d.template)
+			return nil
+		}
+
+		// cleanup old template from watcher
+		d.watcher.MarkForSweep(d.template)
+		d.watcher.Sweep(d.template)
+	}
+
+	n, err := newNotifier(d.task, tmpl)
+	if err != nil {
+		return err
+	}
+	d.template = n
+	d.overrider = n
+
+	logger.Debug("validating template")
+	err = validateTemplate(tmpl, d.watcher.Clients())
+	if err != nil {
+		logger.Error("error validating template", "error", err)
+		return fmt.Errorf("unable to retrieve data from Consul: %s", err)
+	}
+	logger.Debug("template validation complete")
+
+	err = d.watcher.Register(d.template)
+	if err != nil && err != hcat.ErrRegistry {
+		logger.Error("unable to register template", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// deregisterTemplate attempts to deregister the hashicat template
+func (d *renderedDriver) deregisterTemplate() {
+	d.watcher.Deregister(d.template)
+}
+
+// renderTemplate attempts to render the hashicat template
+func (d *renderedDriver) renderTemplate() (hcat.ResolveEvent, error) {
+	taskName := d.task.Name()
+
+	// log the task name with each log
+	tnlog := d.logger.With(taskNameLogKey, taskName)
+	result, err := d.resolver.Run(d.template, d.watcher)
+	if err != nil {
+		tnlog.Error("error checking dependency changes for task", "error", err)
+
+		return hcat.ResolveEvent{}, fmt.Errorf("error fetching template dependencies for task %s: %s",
+			taskName, err)
+	}
+
+	// result.NoChange can occur when template rendering is forced even though
+	// there may be no dependency changes rather than naturally triggered
+	// e.g. when a task is re-enabled
+	if result.Complete && result.NoChange && d.renderedOnce {
+		tnlog.Trace("no changes detected for task")
+		return result, nil
+	}
+
+	if result.Complete && !result.NoChange {
+		tnlog.Debug("change detected for task")
+
+		rendered, err := d.template.Render(result.Contents)
+		if err != nil {
+			tnlog.Error("rendering template for task", "error", err)
+
+			return hcat.ResolveEvent{}, err
+		}
+		tnlog.Trace("template for task rendered", "rendered_template", rendered)
+		d.renderedOnce = true
+	}
+
+	return result, nil
+}
+
+// inspectTask returns the data that the task would be run with
+func (d *renderedDriver) inspectTask() (InspectPlan, error) {
+	input, err := d.input()
+	if err != nil {
+		return InspectPlan{}, err
+	}
+
+	var buf bytes.Buffer
+	if err = json.Indent(&buf, input, "", "  "); err != nil {
+		return InspectPlan{}, err
+	}
+
+	return InspectPlan{
+		ChangesPresent: true,
+		Plan: fmt.Sprintf("%s with the following data:\n%s\n",
+			d.runner.describe(), buf.String()),
+	}, nil
+}
+
+// applyTask runs the task with the rendered data and stores the result. The
+// result is recorded as the detail of the event of the task run, if any,
+// regardless of whether the task exposes its outputs.
+func (d *renderedDriver) applyTask(ctx context.Context) error {
+	input, err := d.input()
+	if err != nil {
+		return err
+	}
+
+	result, err := d.runner.run(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	output, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	d.output = output
+
+	if ev, ok := event.FromContext(ctx); ok {
+		ev.Detail = result
+	}
+	return nil
+}
+
+// input returns the rendered data as JSON. The data is an object of the
+// task's variables and the rendered Consul data, with the rendered data taking
+// precedence.
+func (d *renderedDriver) input() ([]byte, error) {
+	taskName := d.task.Name()
+	path := filepath.Join(d.task.WorkingDir(), tftmpl.TFVarsFilename)
+
+	content, err := d.fileReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read rendered data for task %s: %s",
+			taskName, err)
+	}
+
+	values := make(map[string]cty.Value)
+	for k, v := range d.task.Variables() {
+		values[k] = v
+	}
+
+	rendered, err := parseTFVars(content, path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse rendered data for task %s: %s",
+			taskName, err)
+	}
+	for k, v := range rendered {
+		values[k] = v
+	}
+
+	return ctyjson.SimpleJSONValue{Value: cty.ObjectVal(values)}.MarshalJSON()
+}
+
+// parseTFVars parses the attributes of a tfvars file into values. The rendered
+// tfvars only contain literal values, so no evaluation context is needed.
+func parseTFVars(content []byte, filename string) (map[string]cty.Value, error) {
+	file, diags := hclsyntax.ParseConfig(content, filename, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	attrs, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	values := make(map[string]cty.Value, len(attrs))
+	for name, attr := range attrs {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		values[name] = value
+	}
+	return values, nil
+}
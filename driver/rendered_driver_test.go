@@ -0,0 +1,128 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const testRenderedTFVars = `# Task: test
+# Description: test task
+
+services = {
+  "api.worker-01.dc1" = {
+    id      = "api"
+    name    = "api"
+    address = "1.2.3.4"
+    port    = 8080
+    meta    = {}
+    tags    = ["tag"]
+  },
+}
+
+consul_kv = {
+  "path/key" = "value"
+}
+`
+
+func TestRenderedDriver_input(t *testing.T) {
+	t.Parallel()
+
+	d := newTestRenderedDriver(t, "output")
+	d.task.variables = hcltmpl.Variables{
+		"count":     cty.NumberIntVal(2),
+		"consul_kv": cty.StringVal("overridden by rendered data"),
+	}
+
+	input, err := d.input()
+	require.NoError(t, err)
+
+	expected := `{
+		"count": 2,
+		"consul_kv": {"path/key": "value"},
+		"services": {
+			"api.worker-01.dc1": {
+				"id": "api",
+				"name": "api",
+				"address": "1.2.3.4",
+				"port": 8080,
+				"meta": {},
+				"tags": ["tag"]
+			}
+		}
+	}`
+	assert.JSONEq(t, expected, string(input))
+}
+
+func TestRenderedDriver_Outputs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	d := newTestRenderedDriver(t, "result")
+
+	outputs, err := d.Outputs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, outputs, "expected no outputs before a successful run")
+
+	d.output = json.RawMessage(`"ok"`)
+	outputs, err = d.Outputs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]json.RawMessage{"result": json.RawMessage(`"ok"`)}, outputs)
+}
+
+func TestParseTFVars(t *testing.T) {
+	t.Parallel()
+
+	t.Run("golden_tfvars", func(t *testing.T) {
+		path := filepath.Join("..", "templates", "tftmpl", "testdata",
+			tftmpl.TFVarsFilename)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		values, err := parseTFVars(content, path)
+		require.NoError(t, err)
+		require.Contains(t, values, "services")
+		assert.True(t, values["services"].Type().IsObjectType())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := parseTFVars([]byte(`services = {`), "invalid.tfvars")
+		assert.Error(t, err)
+	})
+}
+
+// newTestRenderedDriver returns a driver for an enabled task with rendered
+// data written to the task's working directory
+func newTestRenderedDriver(t *testing.T, outputName string) *renderedDriver {
+	d := &renderedDriver{}
+	setupTestRenderedDriver(t, d, outputName)
+	return d
+}
+
+// setupTestRenderedDriver sets up the driver for an enabled task with rendered
+// data written to the task's working directory
+func setupTestRenderedDriver(t *testing.T, d *renderedDriver, outputName string) {
+	wd := t.TempDir()
+	err := os.WriteFile(filepath.Join(wd, tftmpl.TFVarsFilename),
+		[]byte(testRenderedTFVars), filePerms)
+	require.NoError(t, err)
+
+	d.task = &Task{
+		name:       "test",
+		enabled:    true,
+		workingDir: wd,
+		logger:     logging.NewNullLogger(),
+	}
+	d.outputName = outputName
+	d.fileReader = os.ReadFile
+	d.logger = logging.NewNullLogger()
+}
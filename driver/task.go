@@ -2,9 +2,11 @@ package driver
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/client"
@@ -13,6 +15,8 @@ import (
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/client"
 	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
 	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/notifier"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
 )
 
 const (
@@ -53,21 +57,29 @@ type BufferPeriod struct {
 type Task struct {
 	mu sync.RWMutex
 
-	description  string
-	name         string
-	enabled      bool
-	env          map[string]string
-	providers    TerraformProviderBlocks // task.providers config info
-	providerInfo map[string]interface{}  // driver.required_provider config info
-	services     []Service
-	module       string
-	variables    hcltmpl.Variables // loaded variables from varFiles
-	version      string
-	bufferPeriod *BufferPeriod // nil when disabled
-	condition    config.ConditionConfig
-	moduleInputs config.ModuleInputConfigs
-	workingDir   string
-	logger       logging.Logger
+	description     string
+	name            string
+	enabled         bool
+	destroyOnDelete bool
+	exposeOutputs   bool
+	outputsKVPath   string
+	env             map[string]string
+	providers       TerraformProviderBlocks // task.providers config info
+	providerInfo    map[string]interface{}  // driver.required_provider config info
+	services        []Service
+	module          string
+	variables       hcltmpl.Variables // loaded variables from varFiles
+	servicesTmpl    string            // loaded template from servicesTemplate
+	version         string
+	bufferPeriod    *BufferPeriod // nil when disabled
+	condition       config.ConditionConfig
+	moduleInputs    config.ModuleInputConfigs
+	workingDir      string
+	fileFormat      string
+	servicesProto   string
+	damper          *notifier.Damper // nil when damping is not configured
+	webhookPayloads *tmplfunc.WebhookStore
+	logger          logging.Logger
 
 	// Enterprise
 	deprecatedTFVersion string
@@ -75,21 +87,28 @@ type Task struct {
 }
 
 type TaskConfig struct {
-	Description  string
-	Name         string
-	Enabled      bool
-	Env          map[string]string
-	Providers    TerraformProviderBlocks
-	ProviderInfo map[string]interface{}
-	Services     []Service
-	Module       string
-	VarFiles     []string
-	Variables    map[string]string
-	Version      string
-	BufferPeriod *BufferPeriod
-	Condition    config.ConditionConfig
-	ModuleInputs config.ModuleInputConfigs
-	WorkingDir   string
+	Description      string
+	Name             string
+	Enabled          bool
+	DestroyOnDelete  bool
+	ExposeOutputs    bool
+	OutputsKVPath    string
+	Env              map[string]string
+	Providers        TerraformProviderBlocks
+	ProviderInfo     map[string]interface{}
+	Services         []Service
+	Module           string
+	VarFiles         []string
+	Variables        map[string]string
+	ServicesTemplate string
+	Version          string
+	BufferPeriod     *BufferPeriod
+	Condition        config.ConditionConfig
+	ModuleInputs     config.ModuleInputConfigs
+	WorkingDir       string
+	FileFormat       string
+	ServicesProtocol string
+	WebhookPayloads  *tmplfunc.WebhookStore
 
 	// Enterprise
 	DeprecatedTFVersion string
@@ -123,22 +142,58 @@ func NewTask(conf TaskConfig) (*Task, error) {
 		loadedVars[k] = v
 	}
 
+	// Load the template for the services variable
+	var servicesTmpl string
+	if conf.ServicesTemplate != "" {
+		content, err := ioutil.ReadFile(conf.ServicesTemplate)
+		if err != nil {
+			return nil, err
+		}
+
+		// check the template early rather than when the task's template is
+		// rendered
+		_, err = template.New(conf.ServicesTemplate).
+			Funcs(tmplfunc.HCLMap(nil, nil)).Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("invalid services_template for task %q: %s",
+				conf.Name, err)
+		}
+		servicesTmpl = string(content)
+	}
+
+	var damper *notifier.Damper
+	if cond, ok := conf.Condition.(*config.ServicesConditionConfig); ok && cond.Damping != nil {
+		damper = notifier.NewDamper(
+			config.IntVal(cond.Damping.Threshold),
+			config.IntVal(cond.Damping.ThresholdPercent),
+			config.TimeDurationVal(cond.Damping.MinStableTime),
+			config.TimeDurationVal(cond.Damping.MaxSuppressionTime))
+	}
+
 	return &Task{
-		description:  conf.Description,
-		name:         conf.Name,
-		enabled:      conf.Enabled,
-		env:          conf.Env,
-		providers:    conf.Providers,
-		providerInfo: conf.ProviderInfo,
-		services:     conf.Services,
-		module:       conf.Module,
-		variables:    loadedVars,
-		version:      conf.Version,
-		bufferPeriod: conf.BufferPeriod,
-		condition:    conf.Condition,
-		moduleInputs: conf.ModuleInputs,
-		workingDir:   conf.WorkingDir,
-		logger:       logging.Global().Named(logSystemName),
+		description:     conf.Description,
+		name:            conf.Name,
+		enabled:         conf.Enabled,
+		destroyOnDelete: conf.DestroyOnDelete,
+		exposeOutputs:   conf.ExposeOutputs,
+		outputsKVPath:   conf.OutputsKVPath,
+		env:             conf.Env,
+		providers:       conf.Providers,
+		providerInfo:    conf.ProviderInfo,
+		services:        conf.Services,
+		module:          conf.Module,
+		variables:       loadedVars,
+		servicesTmpl:    servicesTmpl,
+		version:         conf.Version,
+		bufferPeriod:    conf.BufferPeriod,
+		condition:       conf.Condition,
+		moduleInputs:    conf.ModuleInputs,
+		workingDir:      conf.WorkingDir,
+		fileFormat:      conf.FileFormat,
+		servicesProto:   conf.ServicesProtocol,
+		damper:          damper,
+		webhookPayloads: conf.WebhookPayloads,
+		logger:          logging.Global().Named(logSystemName),
 
 		// Enterprise
 		deprecatedTFVersion: conf.DeprecatedTFVersion,
@@ -214,6 +269,30 @@ func (t *Task) Disable() {
 	t.enabled = false
 }
 
+// DestroyOnDelete returns whether the infrastructure managed by the task is
+// destroyed when the task is deleted
+func (t *Task) DestroyOnDelete() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.destroyOnDelete
+}
+
+// ExposeOutputs returns whether the output values of the task's module are
+// collected after the task is applied
+func (t *Task) ExposeOutputs() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.exposeOutputs
+}
+
+// OutputsKVPath returns the Consul KV path prefix to publish the task's
+// outputs to. Returns an empty string if outputs are not published.
+func (t *Task) OutputsKVPath() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.outputsKVPath
+}
+
 // Env returns a copy of task environment variables
 func (t *Task) Env() map[string]string {
 	t.mu.RLock()
@@ -306,15 +385,57 @@ func (t *Task) WorkingDir() string {
 	return t.workingDir
 }
 
-// DeprecatedTFVersion returns the Terraform version to use when using the Terraform Cloud
-// driver. Enterprise.
-// Deprecated, use the Terraform Version from TFCWorkspace() instead.
+// TerraformVersion returns the exact Terraform version to use for the task
+// with the Terraform driver, which is installed alongside the driver's
+// version. Empty if the task uses the driver's version.
+func (t *Task) TerraformVersion() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.deprecatedTFVersion
+}
+
+// DeprecatedTFVersion returns the Terraform version of the task's workspace
+// when using the Terraform Cloud driver.
+// Enterprise: Deprecated, use the Terraform Version from TFCWorkspace()
+// instead. Use TerraformVersion() for the Terraform driver.
 func (t *Task) DeprecatedTFVersion() string {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 	return t.deprecatedTFVersion
 }
 
+// FileFormat returns the format of the task's generated files. Empty if the
+// task uses the driver's format.
+func (t *Task) FileFormat() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.fileFormat
+}
+
+// ServicesProtocol returns the version of the service definition protocol for
+// the task's services variable. Empty if the task uses the default version.
+func (t *Task) ServicesProtocol() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.servicesProto
+}
+
+// WebhookPayloads returns the store of payloads received by the trigger task
+// API which is used to render the task's webhook condition.
+func (t *Task) WebhookPayloads() *tmplfunc.WebhookStore {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.webhookPayloads
+}
+
+// Damper returns the damper for changes to the task's services condition. Nil
+// if the task does not have damping configured.
+func (t *Task) Damper() *notifier.Damper {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.damper
+}
+
 // TFCWorkspace returns the Terraform Cloud Workspace configuration to use for the task
 // when using the Terraform Cloud driver. Enterprise only.
 func (t *Task) TFCWorkspace() config.TerraformCloudWorkspaceConfig {
@@ -341,14 +462,25 @@ func (t *Task) configureRootModuleInput(input *tftmpl.RootModuleInputData) error
 	defer t.mu.RUnlock()
 
 	input.Task = tftmpl.Task{
-		Description: t.description,
-		Name:        t.name,
-		Module:      t.module,
-		Version:     t.version,
+		Description:   t.description,
+		Name:          t.name,
+		Module:        t.module,
+		Version:       t.version,
+		ExposeOutputs: t.exposeOutputs,
 	}
 
 	var templates []tftmpl.Template
 
+	// The services template replaces the generated value of the services
+	// variable. Services templates are still included to detect changes.
+	input.ServicesTemplate = t.servicesTmpl
+	renderServices := t.servicesTmpl == ""
+
+	// Services templates render the services variable with the task's version
+	// of the service definition protocol
+	protocolV1 := t.servicesProto == config.ServicesProtocolV1
+	input.ServicesProtocolV1 = protocolV1
+
 	// Create a ServicesTemplate for task.services list. task.services is
 	// deprecated in 0.5 and is replaced by condition / module_input "services"
 	// which is handled further below.
@@ -367,8 +499,10 @@ func (t *Task) configureRootModuleInput(input *tftmpl.RootModuleInputData) error
 		template := &tftmpl.ServicesTemplate{
 			Names:    t.ServiceNames(),
 			Services: services,
-			// services list must always render the variable
-			RenderVar: true,
+			// services list must always render the variable unless it is
+			// rendered by the services template
+			RenderVar:  renderServices,
+			ProtocolV1: protocolV1,
 		}
 		templates = append(templates, template)
 
@@ -380,28 +514,41 @@ func (t *Task) configureRootModuleInput(input *tftmpl.RootModuleInputData) error
 	switch v := t.condition.(type) {
 	case *config.CatalogServicesConditionConfig:
 		condition = &tftmpl.CatalogServicesTemplate{
-			Regexp:     *v.Regexp,
-			Datacenter: *v.Datacenter,
-			Namespace:  *v.Namespace,
-			NodeMeta:   v.NodeMeta,
-			RenderVar:  *v.UseAsModuleInput,
+			Regexp:      *v.Regexp,
+			Datacenter:  *v.Datacenter,
+			Datacenters: v.Datacenters,
+			Namespace:   *v.Namespace,
+			Partition:   config.StringVal(v.Partition),
+			Peer:        config.StringVal(v.Peer),
+			NodeMeta:    v.NodeMeta,
+			RenderVar:   *v.UseAsModuleInput,
 		}
 	case *config.ServicesConditionConfig:
 		if v.Regexp != nil {
 			condition = &tftmpl.ServicesRegexTemplate{
-				Regexp:     *v.Regexp,
-				Datacenter: *v.Datacenter,
-				Namespace:  *v.Namespace,
-				Filter:     *v.Filter,
-				RenderVar:  *v.UseAsModuleInput,
+				Regexp:      *v.Regexp,
+				Datacenter:  *v.Datacenter,
+				Datacenters: v.Datacenters,
+				Namespace:   *v.Namespace,
+				Partition:   config.StringVal(v.Partition),
+				Peer:        config.StringVal(v.Peer),
+				Filter:      *v.Filter,
+				Status:      config.StringVal(v.Status),
+				RenderVar:   *v.UseAsModuleInput && renderServices,
+				ProtocolV1:  protocolV1,
 			}
 		} else {
 			condition = &tftmpl.ServicesTemplate{
-				Names:      v.Names,
-				Datacenter: *v.Datacenter,
-				Namespace:  *v.Namespace,
-				Filter:     *v.Filter,
-				RenderVar:  *v.UseAsModuleInput,
+				Names:       v.Names,
+				Datacenter:  *v.Datacenter,
+				Datacenters: v.Datacenters,
+				Namespace:   *v.Namespace,
+				Partition:   config.StringVal(v.Partition),
+				Peer:        config.StringVal(v.Peer),
+				Filter:      *v.Filter,
+				Status:      config.StringVal(v.Status),
+				RenderVar:   *v.UseAsModuleInput && renderServices,
+				ProtocolV1:  protocolV1,
 			}
 		}
 	case *config.ConsulKVConditionConfig:
@@ -410,8 +557,40 @@ func (t *Task) configureRootModuleInput(input *tftmpl.RootModuleInputData) error
 			Datacenter: *v.Datacenter,
 			Recurse:    *v.Recurse,
 			Namespace:  *v.Namespace,
+			Partition:  config.StringVal(v.Partition),
+			Decode:     config.StringVal(v.Decode),
+			RenderVar:  *v.UseAsModuleInput,
+		}
+	case *config.NodesConditionConfig:
+		condition = &tftmpl.NodesTemplate{
+			Datacenter: *v.Datacenter,
+			NodeMeta:   v.NodeMeta,
+			Filter:     *v.Filter,
+			RenderVar:  *v.UseAsModuleInput,
+		}
+	case *config.IntentionsConditionConfig:
+		condition = &tftmpl.IntentionsTemplate{
+			Datacenter: *v.Datacenter,
+			Namespace:  *v.Namespace,
+			Filter:     *v.Filter,
 			RenderVar:  *v.UseAsModuleInput,
 		}
+	case *config.ConfigEntriesConditionConfig:
+		condition = &tftmpl.ConfigEntriesTemplate{
+			Kind:       *v.Kind,
+			Name:       *v.Name,
+			Regexp:     config.StringVal(v.Regexp),
+			Datacenter: *v.Datacenter,
+			Namespace:  *v.Namespace,
+			RenderVar:  *v.UseAsModuleInput,
+		}
+	case *config.HTTPConditionConfig:
+		condition = newHTTPTemplate(v.HTTPMonitorConfig, *v.UseAsModuleInput)
+	case *config.WebhookConditionConfig:
+		condition = &tftmpl.WebhookTemplate{
+			TaskName:  t.name,
+			RenderVar: *v.UseAsModuleInput,
+		}
 	default:
 		// no-op: condition block currently not required since services.list
 		// can be used alternatively
@@ -426,25 +605,41 @@ func (t *Task) configureRootModuleInput(input *tftmpl.RootModuleInputData) error
 	tmplTypes := make([]string, len(t.moduleInputs))
 	moduleInputs := make([]tftmpl.Template, len(t.moduleInputs))
 	for ix, moduleInput := range t.moduleInputs {
+		// a labelled module_input renders its own variable named after the
+		// alias, including for module inputs of the services variable type
+		aliased := moduleInput.VariableName() != moduleInput.VariableType()
+
 		switch v := moduleInput.(type) {
 		case *config.ServicesModuleInputConfig:
 			if v.Regexp != nil {
 				moduleInputs[ix] = &tftmpl.ServicesRegexTemplate{
-					Regexp:     *v.Regexp,
-					Datacenter: *v.Datacenter,
-					Namespace:  *v.Namespace,
-					Filter:     *v.Filter,
-					// always render var for module_input config
-					RenderVar: true,
+					Regexp:      *v.Regexp,
+					Datacenter:  *v.Datacenter,
+					Datacenters: v.Datacenters,
+					Namespace:   *v.Namespace,
+					Partition:   config.StringVal(v.Partition),
+					Peer:        config.StringVal(v.Peer),
+					Filter:      *v.Filter,
+					Status:      config.StringVal(v.Status),
+					// render var for module_input config unless it is
+					// rendered by the services template
+					RenderVar:  renderServices || aliased,
+					ProtocolV1: protocolV1,
 				}
 			} else {
 				moduleInputs[ix] = &tftmpl.ServicesTemplate{
-					Names:      v.Names,
-					Datacenter: *v.Datacenter,
-					Namespace:  *v.Namespace,
-					Filter:     *v.Filter,
-					// always render var for module_input config
-					RenderVar: true,
+					Names:       v.Names,
+					Datacenter:  *v.Datacenter,
+					Datacenters: v.Datacenters,
+					Namespace:   *v.Namespace,
+					Partition:   config.StringVal(v.Partition),
+					Peer:        config.StringVal(v.Peer),
+					Filter:      *v.Filter,
+					Status:      config.StringVal(v.Status),
+					// render var for module_input config unless it is
+					// rendered by the services template
+					RenderVar:  renderServices || aliased,
+					ProtocolV1: protocolV1,
 				}
 			}
 		case *config.ConsulKVModuleInputConfig:
@@ -453,14 +648,56 @@ func (t *Task) configureRootModuleInput(input *tftmpl.RootModuleInputData) error
 				Datacenter: *v.Datacenter,
 				Recurse:    *v.Recurse,
 				Namespace:  *v.Namespace,
+				Partition:  config.StringVal(v.Partition),
+				Decode:     config.StringVal(v.Decode),
 				// always render var for module_input config
 				RenderVar: true,
 			}
+		case *config.NodesModuleInputConfig:
+			moduleInputs[ix] = &tftmpl.NodesTemplate{
+				Datacenter: *v.Datacenter,
+				NodeMeta:   v.NodeMeta,
+				Filter:     *v.Filter,
+				// always render var for module_input config
+				RenderVar: true,
+			}
+		case *config.IntentionsModuleInputConfig:
+			moduleInputs[ix] = &tftmpl.IntentionsTemplate{
+				Datacenter: *v.Datacenter,
+				Namespace:  *v.Namespace,
+				Filter:     *v.Filter,
+				// always render var for module_input config
+				RenderVar: true,
+			}
+		case *config.ConfigEntriesModuleInputConfig:
+			moduleInputs[ix] = &tftmpl.ConfigEntriesTemplate{
+				Kind:       *v.Kind,
+				Name:       *v.Name,
+				Regexp:     config.StringVal(v.Regexp),
+				Datacenter: *v.Datacenter,
+				Namespace:  *v.Namespace,
+				// always render var for module_input config
+				RenderVar: true,
+			}
+		case *config.HTTPModuleInputConfig:
+			// always render var for module_input config
+			moduleInputs[ix] = newHTTPTemplate(v.HTTPMonitorConfig, true)
+		case *config.VaultModuleInputConfig:
+			moduleInputs[ix] = &tftmpl.VaultTemplate{
+				Path: *v.Path,
+			}
 		default:
 			return fmt.Errorf("task %q has unsupported type of module_input "+
 				" block configuration %T", t.name, v)
 		}
 
+		if aliased {
+			moduleInputs[ix] = &tftmpl.AliasTemplate{
+				Name:     moduleInput.VariableName(),
+				Template: moduleInputs[ix],
+			}
+		}
+
 		// store the newly created template's type for logging
 		tmplTypes[ix] = fmt.Sprintf("%T", moduleInputs[ix])
 	}
@@ -486,6 +723,35 @@ func (t *Task) configureRootModuleInput(input *tftmpl.RootModuleInputData) error
 	return nil
 }
 
+// newHTTPTemplate creates the template for an http condition or module_input
+func newHTTPTemplate(c config.HTTPMonitorConfig, renderVar bool) *tftmpl.HTTPTemplate {
+	headers := make(map[string]string, len(c.Headers))
+	for k, v := range c.Headers {
+		headers[k] = v
+	}
+
+	tmpl := &tftmpl.HTTPTemplate{
+		URL:       config.StringVal(c.URL),
+		Headers:   headers,
+		Interval:  config.TimeDurationVal(c.Interval),
+		Timeout:   config.TimeDurationVal(c.Timeout),
+		Selector:  config.StringVal(c.Selector),
+		RenderVar: renderVar,
+	}
+
+	if c.TLS != nil && config.BoolVal(c.TLS.Enabled) {
+		tmpl.TLSEnabled = true
+		tmpl.TLSVerify = config.BoolVal(c.TLS.Verify)
+		tmpl.CACert = config.StringVal(c.TLS.CACert)
+		tmpl.CAPath = config.StringVal(c.TLS.CAPath)
+		tmpl.Cert = config.StringVal(c.TLS.Cert)
+		tmpl.Key = config.StringVal(c.TLS.Key)
+		tmpl.ServerName = config.StringVal(c.TLS.ServerName)
+	}
+
+	return tmpl
+}
+
 // clientConfig configures a driver client for a task
 type clientConfig struct {
 	clientType string
@@ -493,7 +759,9 @@ type clientConfig struct {
 	taskName   string
 	persistLog bool
 	path       string
+	binaryName string
 	workingDir string
+	gitOutput  *config.TerraformGitOutputConfig
 }
 
 // newClient initializes a specific type of client given a task
@@ -515,12 +783,27 @@ func newClient(conf *clientConfig) (client.Client, error) {
 	case testClient:
 		tnlog.Trace("creating mock client for task")
 		c = new(mocks.Client)
+	case gitClient:
+		tnlog.Trace("creating git client for task")
+		if conf.gitOutput == nil {
+			return nil, fmt.Errorf("git_output is required for the git client")
+		}
+		c, err = client.NewGit(&client.GitConfig{
+			RepoPath:    config.StringVal(conf.gitOutput.Path),
+			Remote:      config.StringVal(conf.gitOutput.Remote),
+			Branch:      config.StringVal(conf.gitOutput.Branch),
+			AuthorName:  config.StringVal(conf.gitOutput.AuthorName),
+			AuthorEmail: config.StringVal(conf.gitOutput.AuthorEmail),
+			WorkingDir:  conf.workingDir,
+			Workspace:   taskName,
+		})
 	default:
 		tnlog.Trace("creating terraform cli client for task")
 		c, err = client.NewTerraformCLI(&client.TerraformCLIConfig{
 			Log:        conf.log,
 			PersistLog: conf.persistLog,
 			ExecPath:   conf.path,
+			BinaryName: conf.binaryName,
 			WorkingDir: conf.workingDir,
 			Workspace:  taskName,
 		})
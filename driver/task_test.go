@@ -1,8 +1,11 @@
 package driver
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/client"
 	"github.com/hashicorp/consul-terraform-sync/config"
@@ -18,27 +21,47 @@ import (
 func TestNewClient(t *testing.T) {
 	t.Parallel()
 
+	gitOutput := &config.TerraformGitOutputConfig{Path: config.String("repo")}
+
 	cases := []struct {
 		name        string
 		clientType  string
+		gitOutput   *config.TerraformGitOutputConfig
 		expectError bool
 		expect      client.Client
 	}{
 		{
 			"happy path with development client",
 			developmentClient,
+			nil,
 			false,
 			&client.Printer{},
 		},
 		{
 			"happy path with mock client",
 			testClient,
+			nil,
 			false,
 			&mocks.Client{},
 		},
+		{
+			"happy path with git client",
+			gitClient,
+			gitOutput,
+			false,
+			&client.Git{},
+		},
+		{
+			"error when git output is not configured",
+			gitClient,
+			nil,
+			true,
+			&client.Git{},
+		},
 		{
 			"error when creating Terraform CLI client",
 			"",
+			nil,
 			true,
 			&client.TerraformCLI{},
 		},
@@ -48,6 +71,7 @@ func TestNewClient(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			actual, err := newClient(&clientConfig{
 				clientType: tc.clientType,
+				gitOutput:  tc.gitOutput,
 			})
 			if tc.expectError {
 				assert.Error(t, err)
@@ -357,6 +381,12 @@ func TestTask_WorkingDir(t *testing.T) {
 	assert.Equal(t, task.workingDir, workingDir)
 }
 
+func TestTask_TerraformVersion(t *testing.T) {
+	var task Task
+	task.deprecatedTFVersion = "1.0.0"
+	assert.Equal(t, "1.0.0", task.TerraformVersion())
+}
+
 func TestTask_DeprecatedTFVersion(t *testing.T) {
 	var task Task
 	task.deprecatedTFVersion = "1.0.0"
@@ -364,6 +394,12 @@ func TestTask_DeprecatedTFVersion(t *testing.T) {
 	assert.Equal(t, task.deprecatedTFVersion, tfVersion)
 }
 
+func TestTask_ServicesProtocol(t *testing.T) {
+	var task Task
+	task.servicesProto = config.ServicesProtocolV1
+	assert.Equal(t, config.ServicesProtocolV1, task.ServicesProtocol())
+}
+
 func TestTask_TFCWorkspace(t *testing.T) {
 	var task Task
 	task.tfcWorkspace = config.TerraformCloudWorkspaceConfig{
@@ -459,6 +495,28 @@ func TestTask_configureRootModuleInput(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "templates: services cond names datacenters",
+			task: &Task{
+				condition: &config.ServicesConditionConfig{
+					ServicesMonitorConfig: config.ServicesMonitorConfig{
+						Names:       []string{"api"},
+						Datacenter:  config.String(""),
+						Datacenters: []string{"dc1", "dc2"},
+						Namespace:   config.String(""),
+						Filter:      config.String(""),
+					},
+					UseAsModuleInput: config.Bool(true),
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.ServicesTemplate{
+					Names:       []string{"api"},
+					Datacenters: []string{"dc1", "dc2"},
+					RenderVar:   true,
+				},
+			},
+		},
 		{
 			name: "templates: catalog services condition",
 			task: &Task{
@@ -505,6 +563,251 @@ func TestTask_configureRootModuleInput(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "templates: consul kv condition with decode",
+			task: &Task{
+				condition: &config.ConsulKVConditionConfig{
+					ConsulKVMonitorConfig: config.ConsulKVMonitorConfig{
+						Path:       config.String("app/config"),
+						Datacenter: config.String(""),
+						Namespace:  config.String(""),
+						Recurse:    config.Bool(false),
+						Decode:     config.String("json"),
+					},
+					UseAsModuleInput: config.Bool(true),
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.ConsulKVTemplate{
+					Path:      "app/config",
+					Decode:    "json",
+					RenderVar: true,
+				},
+			},
+		},
+		{
+			name: "templates: nodes condition",
+			task: &Task{
+				condition: &config.NodesConditionConfig{
+					NodesMonitorConfig: config.NodesMonitorConfig{
+						Datacenter: config.String("dc1"),
+						NodeMeta:   map[string]string{"key": "value"},
+						Filter:     config.String("filter"),
+					},
+					UseAsModuleInput: config.Bool(false),
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.NodesTemplate{
+					Datacenter: "dc1",
+					NodeMeta:   map[string]string{"key": "value"},
+					Filter:     "filter",
+					RenderVar:  false,
+				},
+			},
+		},
+		{
+			name: "templates: nodes module_input",
+			task: &Task{
+				moduleInputs: config.ModuleInputConfigs{
+					&config.NodesModuleInputConfig{
+						NodesMonitorConfig: config.NodesMonitorConfig{
+							Datacenter: config.String("dc1"),
+							NodeMeta:   map[string]string{},
+							Filter:     config.String(""),
+						},
+					},
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.NodesTemplate{
+					Datacenter: "dc1",
+					NodeMeta:   map[string]string{},
+					RenderVar:  true,
+				},
+			},
+		},
+		{
+			name: "templates: intentions condition",
+			task: &Task{
+				condition: &config.IntentionsConditionConfig{
+					IntentionsMonitorConfig: config.IntentionsMonitorConfig{
+						Datacenter: config.String("dc1"),
+						Namespace:  config.String("ns1"),
+						Filter:     config.String("filter"),
+					},
+					UseAsModuleInput: config.Bool(true),
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.IntentionsTemplate{
+					Datacenter: "dc1",
+					Namespace:  "ns1",
+					Filter:     "filter",
+					RenderVar:  true,
+				},
+			},
+		},
+		{
+			name: "templates: intentions module_input",
+			task: &Task{
+				moduleInputs: config.ModuleInputConfigs{
+					&config.IntentionsModuleInputConfig{
+						IntentionsMonitorConfig: config.IntentionsMonitorConfig{
+							Datacenter: config.String(""),
+							Namespace:  config.String(""),
+							Filter:     config.String("filter"),
+						},
+					},
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.IntentionsTemplate{
+					Filter:    "filter",
+					RenderVar: true,
+				},
+			},
+		},
+		{
+			name: "templates: config-entries condition",
+			task: &Task{
+				condition: &config.ConfigEntriesConditionConfig{
+					ConfigEntriesMonitorConfig: config.ConfigEntriesMonitorConfig{
+						Kind:       config.String("ingress-gateway"),
+						Name:       config.String(""),
+						Regexp:     config.String("^ingress-"),
+						Datacenter: config.String("dc1"),
+						Namespace:  config.String("ns1"),
+					},
+					UseAsModuleInput: config.Bool(true),
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.ConfigEntriesTemplate{
+					Kind:       "ingress-gateway",
+					Regexp:     "^ingress-",
+					Datacenter: "dc1",
+					Namespace:  "ns1",
+					RenderVar:  true,
+				},
+			},
+		},
+		{
+			name: "templates: config-entries module_input",
+			task: &Task{
+				moduleInputs: config.ModuleInputConfigs{
+					&config.ConfigEntriesModuleInputConfig{
+						ConfigEntriesMonitorConfig: config.ConfigEntriesMonitorConfig{
+							Kind:       config.String("service-defaults"),
+							Name:       config.String("api"),
+							Datacenter: config.String(""),
+							Namespace:  config.String(""),
+						},
+					},
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.ConfigEntriesTemplate{
+					Kind:      "service-defaults",
+					Name:      "api",
+					RenderVar: true,
+				},
+			},
+		},
+		{
+			name: "templates: vault module_input",
+			task: &Task{
+				moduleInputs: config.ModuleInputConfigs{
+					&config.VaultModuleInputConfig{
+						Path: config.String("secret/my-app"),
+					},
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.VaultTemplate{
+					Path: "secret/my-app",
+				},
+			},
+		},
+		{
+			name: "templates: http condition",
+			task: &Task{
+				condition: &config.HTTPConditionConfig{
+					HTTPMonitorConfig: config.HTTPMonitorConfig{
+						URL:      config.String("https://ipam.example.com"),
+						Headers:  map[string]string{"Authorization": "Bearer token"},
+						Interval: config.TimeDuration(30 * time.Second),
+						Timeout:  config.TimeDuration(5 * time.Second),
+						Selector: config.String("$.data"),
+						TLS: &config.TLSConfig{
+							CACert:     config.String("ca.pem"),
+							CAPath:     config.String(""),
+							Cert:       config.String(""),
+							Enabled:    config.Bool(true),
+							Key:        config.String(""),
+							ServerName: config.String(""),
+							Verify:     config.Bool(false),
+						},
+					},
+					UseAsModuleInput: config.Bool(false),
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.HTTPTemplate{
+					URL:        "https://ipam.example.com",
+					Headers:    map[string]string{"Authorization": "Bearer token"},
+					Interval:   30 * time.Second,
+					Timeout:    5 * time.Second,
+					Selector:   "$.data",
+					TLSEnabled: true,
+					TLSVerify:  false,
+					CACert:     "ca.pem",
+					RenderVar:  false,
+				},
+			},
+		},
+		{
+			name: "templates: webhook condition",
+			task: &Task{
+				name: "deploy",
+				condition: &config.WebhookConditionConfig{
+					Secret:           config.String("s3cr3t"),
+					UseAsModuleInput: config.Bool(true),
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.WebhookTemplate{
+					TaskName:  "deploy",
+					RenderVar: true,
+				},
+			},
+		},
+		{
+			name: "templates: http module_input",
+			task: &Task{
+				moduleInputs: config.ModuleInputConfigs{
+					&config.HTTPModuleInputConfig{
+						HTTPMonitorConfig: config.HTTPMonitorConfig{
+							URL:      config.String("http://ipam.example.com"),
+							Headers:  map[string]string{},
+							Interval: config.TimeDuration(time.Minute),
+							Timeout:  config.TimeDuration(10 * time.Second),
+							Selector: config.String(""),
+							TLS:      &config.TLSConfig{Enabled: config.Bool(false)},
+						},
+					},
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.HTTPTemplate{
+					URL:       "http://ipam.example.com",
+					Headers:   map[string]string{},
+					Interval:  time.Minute,
+					Timeout:   10 * time.Second,
+					RenderVar: true,
+				},
+			},
+		},
 		{
 			name: "templates: services module_input regex",
 			task: &Task{
@@ -570,6 +873,7 @@ func TestTask_configureRootModuleInput(t *testing.T) {
 							Recurse:    config.Bool(true),
 							Datacenter: config.String("dc1"),
 							Namespace:  config.String("ns1"),
+							Decode:     config.String("yaml"),
 						},
 					},
 				},
@@ -587,10 +891,150 @@ func TestTask_configureRootModuleInput(t *testing.T) {
 					Recurse:    true,
 					Datacenter: "dc1",
 					Namespace:  "ns1",
+					Decode:     "yaml",
 					RenderVar:  true,
 				},
 			},
 		},
+		{
+			name: "templates: services template",
+			task: &Task{
+				servicesTmpl: `{ api = "{{ len (service "api") }}" }`,
+				moduleInputs: config.ModuleInputConfigs{
+					&config.ServicesModuleInputConfig{
+						ServicesMonitorConfig: config.ServicesMonitorConfig{
+							Names:      []string{"api"},
+							Datacenter: config.String("dc1"),
+							Namespace:  config.String("ns1"),
+							Filter:     config.String("filter"),
+						},
+					},
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.ServicesTemplate{
+					Names:      []string{"api"},
+					Datacenter: "dc1",
+					Namespace:  "ns1",
+					Filter:     "filter",
+					RenderVar:  false,
+				},
+			},
+		},
+		{
+			name: "templates: labelled module_inputs",
+			task: &Task{
+				servicesTmpl: `{ api = "{{ len (service "api") }}" }`,
+				moduleInputs: config.ModuleInputConfigs{
+					&config.ServicesModuleInputConfig{
+						ServicesMonitorConfig: config.ServicesMonitorConfig{
+							Names:      []string{"web"},
+							Datacenter: config.String(""),
+							Namespace:  config.String(""),
+							Filter:     config.String(""),
+						},
+						Alias: config.String("backends"),
+					},
+					&config.ServicesModuleInputConfig{
+						ServicesMonitorConfig: config.ServicesMonitorConfig{
+							Regexp:     config.String("^db"),
+							Datacenter: config.String(""),
+							Namespace:  config.String(""),
+							Filter:     config.String(""),
+						},
+						Alias: config.String("databases"),
+					},
+					&config.ConsulKVModuleInputConfig{
+						ConsulKVMonitorConfig: config.ConsulKVMonitorConfig{
+							Path:       config.String("path"),
+							Recurse:    config.Bool(false),
+							Datacenter: config.String(""),
+							Namespace:  config.String(""),
+						},
+						Alias: config.String(""),
+					},
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.AliasTemplate{
+					Name: "backends",
+					Template: &tftmpl.ServicesTemplate{
+						Names:     []string{"web"},
+						RenderVar: true,
+					},
+				},
+				&tftmpl.AliasTemplate{
+					Name: "databases",
+					Template: &tftmpl.ServicesRegexTemplate{
+						Regexp:    "^db",
+						RenderVar: true,
+					},
+				},
+				&tftmpl.ConsulKVTemplate{
+					Path:      "path",
+					RenderVar: true,
+				},
+			},
+		},
+		{
+			name: "templates: services status",
+			task: &Task{
+				condition: &config.ServicesConditionConfig{
+					ServicesMonitorConfig: config.ServicesMonitorConfig{
+						Names:      []string{"api"},
+						Datacenter: config.String(""),
+						Namespace:  config.String(""),
+						Filter:     config.String(""),
+						Status:     config.String(config.HealthWarning),
+					},
+					UseAsModuleInput: config.Bool(true),
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.ServicesTemplate{
+					Names:     []string{"api"},
+					Status:    config.HealthWarning,
+					RenderVar: true,
+				},
+			},
+		},
+		{
+			name: "templates: services protocol v1",
+			task: &Task{
+				servicesProto: config.ServicesProtocolV1,
+				condition: &config.ServicesConditionConfig{
+					ServicesMonitorConfig: config.ServicesMonitorConfig{
+						Regexp:     config.String("^web.*"),
+						Datacenter: config.String(""),
+						Namespace:  config.String(""),
+						Filter:     config.String(""),
+					},
+					UseAsModuleInput: config.Bool(true),
+				},
+				moduleInputs: config.ModuleInputConfigs{
+					&config.ServicesModuleInputConfig{
+						ServicesMonitorConfig: config.ServicesMonitorConfig{
+							Names:      []string{"api"},
+							Datacenter: config.String(""),
+							Namespace:  config.String(""),
+							Filter:     config.String(""),
+						},
+					},
+				},
+			},
+			expectedTemplates: []tftmpl.Template{
+				&tftmpl.ServicesRegexTemplate{
+					Regexp:     "^web.*",
+					RenderVar:  true,
+					ProtocolV1: true,
+				},
+				&tftmpl.ServicesTemplate{
+					Names:      []string{"api"},
+					RenderVar:  true,
+					ProtocolV1: true,
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -604,6 +1048,61 @@ func TestTask_configureRootModuleInput(t *testing.T) {
 			if len(tc.expectedTemplates) > 0 {
 				assert.Equal(t, tc.expectedTemplates, input.Templates)
 			}
+			assert.Equal(t, tc.task.servicesTmpl, input.ServicesTemplate)
+			assert.Equal(t, tc.task.servicesProto == config.ServicesProtocolV1,
+				input.ServicesProtocolV1)
+		})
+	}
+}
+
+func TestNewTask_ServicesTemplate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		content     string
+		expectError bool
+	}{
+		{
+			"valid",
+			`{ api = "{{ len (service "api") }}" }`,
+			false,
+		},
+		{
+			"unknown function",
+			`{ api = "{{ unknown "api" }}" }`,
+			true,
+		},
+		{
+			"malformed",
+			`{ api = "{{ range service "api" }}" }`,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "services.tmpl")
+			require.NoError(t, ioutil.WriteFile(path, []byte(tc.content), 0644))
+
+			task, err := NewTask(TaskConfig{
+				Name:             "task",
+				ServicesTemplate: path,
+			})
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.content, task.servicesTmpl)
 		})
 	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewTask(TaskConfig{
+			Name:             "task",
+			ServicesTemplate: filepath.Join(t.TempDir(), "missing.tmpl"),
+		})
+		assert.Error(t, err)
+	})
 }
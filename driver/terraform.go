@@ -3,6 +3,7 @@ package driver
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -10,6 +11,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/client"
 	"github.com/hashicorp/consul-terraform-sync/config"
@@ -19,6 +21,7 @@ import (
 	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
 	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/notifier"
 	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	goVersion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcat"
 	"github.com/hashicorp/hcat/dep"
 	"github.com/pkg/errors"
@@ -28,6 +31,10 @@ const (
 	// Types of clients that are alternatives to the default Terraform CLI client
 	developmentClient = "development"
 	testClient        = "test"
+	gitClient         = config.GitClientType
+
+	// Format of generated files in the JSON syntax
+	jsonFileFormat = config.FileFormatJSON
 
 	// Permissions for created directories and files
 	workingDirPerms = os.FileMode(0750) // drwxr-x---
@@ -53,6 +60,11 @@ type Terraform struct {
 	task              *Task
 	backend           map[string]interface{}
 	requiredProviders map[string]interface{}
+	requiredVersion   string
+
+	// version is the Terraform version of the task's binary. Nil if the task
+	// uses the binary installed for the driver.
+	version *goVersion.Version
 
 	resolver   templates.Resolver
 	template   templates.Template
@@ -63,6 +75,22 @@ type Terraform struct {
 	logClient bool
 	postApply handler.Handler
 
+	// pluginCacheDir is the provider plugin cache shared by all tasks. Empty if
+	// the cache is disabled.
+	pluginCacheDir string
+
+	// initEnv is the environment of the client that changes the result of
+	// initializing the workspace, see initFingerprint
+	initEnv map[string]string
+
+	// moduleMirrorDir is the local module mirror. Empty if modules are not
+	// mirrored.
+	moduleMirrorDir string
+
+	// jsonFormat is whether the root module and input variables are
+	// generated in the JSON syntax
+	jsonFormat bool
+
 	inited       bool
 	renderedOnce bool
 
@@ -82,6 +110,40 @@ type TerraformConfig struct {
 	Watcher           templates.Watcher
 	// empty/unknown string will default to TerraformCLI client
 	ClientType string
+
+	// BinaryName is the file name of the Terraform-compatible binary within
+	// Path. Empty defaults to "terraform".
+	BinaryName string
+
+	// Version is the version of the binary when the task uses a Terraform
+	// version other than the one installed for the driver. Nil defaults to
+	// the driver's version.
+	Version *goVersion.Version
+
+	// RequiredVersion is the version constraint pinned to the task's root
+	// module. Empty defaults to the constraint for Terraform.
+	RequiredVersion string
+
+	// PluginCacheDir is the provider plugin cache directory shared by all
+	// tasks. Empty disables the cache.
+	PluginCacheDir string
+
+	// GitOutput configures the git client. Only used when ClientType is "git".
+	GitOutput *config.TerraformGitOutputConfig
+
+	// FileFormat is the format of the generated root module and input
+	// variables. Empty defaults to "hcl".
+	FileFormat string
+
+	// ModuleMirrorDir is the local module mirror directory. Empty disables
+	// using modules from the mirror.
+	ModuleMirrorDir string
+
+	// ProviderFilesystemMirror and ProviderNetworkMirror are the mirrors to
+	// install providers from. Providers are installed from their origin
+	// registries when both are empty.
+	ProviderFilesystemMirror string
+	ProviderNetworkMirror    string
 }
 
 // NewTerraform configures and initializes a new Terraform driver for a task.
@@ -104,14 +166,48 @@ func NewTerraform(config *TerraformConfig) (*Terraform, error) {
 		taskName:   taskName,
 		persistLog: config.PersistLog,
 		path:       config.Path,
+		binaryName: config.BinaryName,
 		workingDir: wd,
+		gitOutput:  config.GitOutput,
 	})
 	if err != nil {
 		logger.Error("init client type error", "client_type", config.ClientType, "error", err)
 		return nil, err
 	}
 
-	if taskEnv := task.Env(); len(taskEnv) > 0 {
+	pluginCacheDir, err := setupPluginCacheDir(config.PluginCacheDir)
+	if err != nil {
+		logger.Error("error setting up the provider plugin cache directory",
+			"plugin_cache_dir", config.PluginCacheDir, "error", err)
+		return nil, err
+	}
+
+	moduleMirrorDir := config.ModuleMirrorDir
+	if moduleMirrorDir != "" {
+		// local module sources in the root module must be absolute
+		if moduleMirrorDir, err = filepath.Abs(moduleMirrorDir); err != nil {
+			return nil, err
+		}
+	}
+
+	taskEnv := task.Env()
+	if pluginCacheDir != "" {
+		taskEnv[pluginCacheDirEnv] = pluginCacheDir
+	}
+	if config.ProviderFilesystemMirror != "" || config.ProviderNetworkMirror != "" {
+		cliConfig, err := filepath.Abs(filepath.Join(wd, cliConfigFilename))
+		if err == nil {
+			err = writeCLIConfig(cliConfig, config.ProviderFilesystemMirror,
+				config.ProviderNetworkMirror)
+		}
+		if err != nil {
+			logger.Error("error writing the Terraform CLI configuration for "+
+				"provider mirrors", taskNameLogKey, taskName, "error", err)
+			return nil, err
+		}
+		taskEnv[cliConfigEnv] = cliConfig
+	}
+	if len(taskEnv) > 0 {
 		// Terraform init requires discovering git in the PATH env.
 		//
 		// The terraform-exec package disables inheriting from the os environment
@@ -138,9 +234,15 @@ func NewTerraform(config *TerraformConfig) (*Terraform, error) {
 		task:              config.Task,
 		backend:           config.Backend,
 		requiredProviders: config.RequiredProviders,
+		requiredVersion:   config.RequiredVersion,
+		version:           config.Version,
 		client:            tfClient,
 		logClient:         config.Log,
 		postApply:         h,
+		pluginCacheDir:    pluginCacheDir,
+		initEnv:           initEnv(taskEnv),
+		moduleMirrorDir:   moduleMirrorDir,
+		jsonFormat:        config.FileFormat == jsonFileFormat,
 		resolver:          hcat.NewResolver(),
 		watcher:           config.Watcher,
 		fileReader:        ioutil.ReadFile,
@@ -150,7 +252,15 @@ func NewTerraform(config *TerraformConfig) (*Terraform, error) {
 
 // Version returns the Terraform CLI version for the Terraform driver.
 func (tf *Terraform) Version() string {
-	return TerraformVersion.String()
+	return tf.terraformVersion().String()
+}
+
+// terraformVersion returns the Terraform version of the task's binary
+func (tf *Terraform) terraformVersion() *goVersion.Version {
+	if tf.version != nil {
+		return tf.version
+	}
+	return TerraformVersion
 }
 
 // Task returns the task config info
@@ -173,6 +283,11 @@ func (tf *Terraform) DestroyTask(_ context.Context) {
 	defer tf.mu.Unlock()
 
 	tf.deregisterTemplate()
+	if tf.task != nil {
+		if damper := tf.task.Damper(); damper != nil {
+			damper.Stop()
+		}
+	}
 }
 
 // SetBufferPeriod sets the buffer period for the task. Do not set this when
@@ -275,6 +390,100 @@ func (tf *Terraform) ApplyTask(ctx context.Context) error {
 	return tf.applyTask(ctx)
 }
 
+// Outputs returns the output values of the task's module from the Terraform
+// state. The values are read from the root module output that is generated to
+// contain the module's outputs. Outputs that are marked sensitive by the
+// module are not returned.
+func (tf *Terraform) Outputs(ctx context.Context) (map[string]json.RawMessage, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	taskName := tf.task.Name()
+
+	tf.logger.Trace("output", taskNameLogKey, taskName)
+	outputs, err := tf.client.Output(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error tf-output for '%s'", taskName))
+	}
+
+	values := make(map[string]json.RawMessage)
+	moduleOutputs, ok := outputs[tftmpl.ModuleOutputsName]
+	if !ok || len(moduleOutputs.Value) == 0 {
+		return values, nil
+	}
+
+	if err = json.Unmarshal(moduleOutputs.Value, &values); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error decoding outputs for '%s'", taskName))
+	}
+	if values == nil {
+		// output value was null
+		values = make(map[string]json.RawMessage)
+	}
+	if len(values) == 0 {
+		return values, nil
+	}
+
+	sensitive, err := sensitiveModuleOutputs(tf.task.WorkingDir(), taskName)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error reading sensitive "+
+			"outputs for '%s'", taskName))
+	}
+	for name := range sensitive {
+		delete(values, name)
+	}
+	return values, nil
+}
+
+// InspectDestroyResources inspects the infrastructure that would be destroyed
+// for the task using the Terraform plan command with the destroy option
+func (tf *Terraform) InspectDestroyResources(ctx context.Context) (InspectPlan, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	taskName := tf.task.Name()
+
+	var buf bytes.Buffer
+	defer tf.captureStdout(&buf)()
+
+	tf.logger.Trace("plan destroy", taskNameLogKey, taskName)
+	c, err := tf.client.PlanDestroy(ctx)
+	if err != nil {
+		return InspectPlan{}, errors.Wrap(err,
+			fmt.Sprintf("error tf-plan-destroy for '%s'", taskName))
+	}
+
+	return InspectPlan{
+		ChangesPresent: c,
+		Plan:           buf.String(),
+	}, nil
+}
+
+// DestroyResources destroys the infrastructure managed by the task using the
+// Terraform destroy command. Once destroyed, the task's working directory is
+// removed.
+func (tf *Terraform) DestroyResources(ctx context.Context) error {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	taskName := tf.task.Name()
+
+	tf.logger.Trace("destroy", taskNameLogKey, taskName)
+	if err := tf.client.Destroy(ctx); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("error tf-destroy for '%s'", taskName))
+	}
+
+	wd := tf.task.WorkingDir()
+	tf.logger.Debug("removing working directory for task", taskNameLogKey,
+		taskName, "working_dir", wd)
+	if err := os.RemoveAll(wd); err != nil {
+		tf.logger.Error("unable to remove working directory for task",
+			taskNameLogKey, taskName, "working_dir", wd, "error", err)
+		return err
+	}
+
+	return nil
+}
+
 // InspectPlan stores return the information about what
 type InspectPlan struct {
 	ChangesPresent bool   `json:"changes_present"`
@@ -380,19 +589,72 @@ func (tf *Terraform) init(ctx context.Context) error {
 		return nil
 	}
 
+	fingerprint, err := tf.initFingerprint()
+	if err != nil {
+		// Not being able to fingerprint the workspace is not fatal, the
+		// workspace is always initialized instead.
+		tf.logger.Warn("unable to fingerprint workspace, initializing workspace",
+			taskNameLogKey, taskName, "error", err)
+	}
+	selector, ok := tf.client.(client.WorkspaceSelector)
+	if ok && fingerprint != "" && fingerprint == tf.lastInitFingerprint() {
+		// The workspace still needs to be selected for the working directory.
+		// Errors fall back to initializing the workspace, which recovers
+		// workspaces without state.
+		err := selector.SelectWorkspace(ctx)
+		if err == nil {
+			tf.logger.Debug("workspace unchanged since it was last initialized, "+
+				"skipping init for task", taskNameLogKey, taskName)
+			tf.inited = true
+			return nil
+		}
+		tf.logger.Debug("unable to select unchanged workspace, initializing "+
+			"workspace", taskNameLogKey, taskName, "error", err)
+	}
+
+	if tf.pluginCacheDir != "" {
+		// The plugin cache is not safe for concurrent use by Terraform
+		// processes that install providers into it. Workspaces that may
+		// install providers, such as when they are first initialized, are
+		// initialized one at a time. Workspaces with all of their locked
+		// providers cached, such as when CTS restarts, only read from the
+		// cache and are initialized concurrently. The cache is checked while
+		// holding the lock so that providers are not partially installed.
+		start := time.Now()
+		pluginCacheMu.Lock()
+		tf.logger.Debug("acquired plugin cache lock", taskNameLogKey, taskName,
+			"wait_time", time.Since(start))
+		if providersCached(tf.task.WorkingDir(), tf.pluginCacheDir) {
+			pluginCacheMu.Unlock()
+		} else {
+			tf.logger.Debug("workspace may install providers into the plugin "+
+				"cache, initializing while holding the lock", taskNameLogKey, taskName)
+			defer pluginCacheMu.Unlock()
+		}
+	}
+
 	tf.logger.Trace("initializing workspace", taskNameLogKey, taskName)
 	if err := tf.client.Init(ctx); err != nil {
 		return errors.Wrap(err, fmt.Sprintf("error tf-init for '%s'", taskName))
 	}
 	tf.inited = true
+
+	if fingerprint != "" {
+		if err := tf.saveInitFingerprint(fingerprint); err != nil {
+			tf.logger.Warn("unable to save workspace fingerprint, workspace will "+
+				"be initialized on the next run", taskNameLogKey, taskName, "error", err)
+		}
+	}
 	return nil
 }
 
 // initTask initializes the task
 func (tf *Terraform) initTask(ctx context.Context) error {
 	input := tftmpl.RootModuleInputData{
-		TerraformVersion: TerraformVersion,
+		TerraformVersion: tf.terraformVersion(),
+		RequiredVersion:  tf.requiredVersion,
 		Backend:          tf.backend,
+		JSON:             tf.jsonFormat,
 		Path:             tf.task.WorkingDir(),
 		FilePerms:        filePerms,
 	}
@@ -414,6 +676,23 @@ func (tf *Terraform) initTask(ctx context.Context) error {
 		return err
 	}
 
+	// use the module from the mirror instead of its remote source
+	if tf.moduleMirrorDir != "" {
+		if m, ok := newMirroredModule(tf.moduleMirrorDir, module); ok {
+			path, err := m.resolve(input.Task.Version)
+			if err != nil {
+				tf.logger.Warn("unable to use module from the mirror, using the "+
+					"module source", taskNameLogKey, tf.task.Name(), "module", module,
+					"error", err)
+			} else {
+				tf.logger.Debug("using module from the mirror", taskNameLogKey,
+					tf.task.Name(), "module", module, "mirror_path", path)
+				input.Task.Module = path
+				input.Task.Version = ""
+			}
+		}
+	}
+
 	if err := tftmpl.InitRootModule(&input); err != nil {
 		return err
 	}
@@ -492,15 +771,7 @@ func (tf *Terraform) inspectTask(ctx context.Context, returnPlan bool) (InspectP
 
 	var buf bytes.Buffer
 	if returnPlan {
-		tf.client.SetStdout(&buf)
-
-		var tfLogger *log.Logger
-		if tf.logClient {
-			tfLogger = log.New(log.Writer(), "", log.Flags())
-		} else {
-			tfLogger = log.New(ioutil.Discard, "", 0)
-		}
-		defer tf.client.SetStdout(tfLogger.Writer())
+		defer tf.captureStdout(&buf)()
 	}
 
 	tf.logger.Trace("plan", taskNameLogKey, taskName)
@@ -516,6 +787,22 @@ func (tf *Terraform) inspectTask(ctx context.Context, returnPlan bool) (InspectP
 	}, nil
 }
 
+// captureStdout sets the client's standard out to the buffer. Returns a
+// function to reset the standard out to the client's logger.
+func (tf *Terraform) captureStdout(buf *bytes.Buffer) func() {
+	tf.client.SetStdout(buf)
+
+	var tfLogger *log.Logger
+	if tf.logClient {
+		tfLogger = log.New(log.Writer(), "", log.Flags())
+	} else {
+		tfLogger = log.New(ioutil.Discard, "", 0)
+	}
+	return func() {
+		tf.client.SetStdout(tfLogger.Writer())
+	}
+}
+
 // applyTask applies the task changes.
 func (tf *Terraform) applyTask(ctx context.Context) error {
 	taskName := tf.task.Name()
@@ -540,6 +827,9 @@ func (tf *Terraform) initTaskTemplate() error {
 	wd := tf.task.WorkingDir()
 	tmplFullpath := filepath.Join(wd, tftmpl.TFVarsTmplFilename)
 	tfvarsFilepath := filepath.Join(wd, tftmpl.TFVarsFilename)
+	if tf.jsonFormat {
+		tfvarsFilepath = filepath.Join(wd, tftmpl.TFVarsJSONFilename)
+	}
 	logger := tf.logger.With(taskNameLogKey, tf.task.Name())
 
 	content, err := tf.fileReader(tmplFullpath)
@@ -548,10 +838,13 @@ func (tf *Terraform) initTaskTemplate() error {
 		return err
 	}
 
-	renderer := hcat.NewFileRenderer(hcat.FileRendererInput{
+	var renderer hcat.Renderer = hcat.NewFileRenderer(hcat.FileRendererInput{
 		Path:  tfvarsFilepath,
 		Perms: filePerms,
 	})
+	if tf.jsonFormat {
+		renderer = tfvarsJSONRenderer{renderer}
+	}
 
 	servicesMeta, err := getServicesMetaData(tf.logger, tf.task)
 	if err != nil {
@@ -561,7 +854,7 @@ func (tf *Terraform) initTaskTemplate() error {
 	tmpl := hcat.NewTemplate(hcat.TemplateInput{
 		Contents:     string(content),
 		Renderer:     renderer,
-		FuncMapMerge: tmplfunc.HCLMap(servicesMeta),
+		FuncMapMerge: tmplfunc.HCLMap(servicesMeta, tf.task.WebhookPayloads()),
 	})
 
 	if tf.template != nil {
@@ -622,81 +915,20 @@ func validateTemplate(t *hcat.Template, clients hcat.Looker) error {
 // setNotifier sets a notifier on the template to ensure only the condition's
 // monitored changes (and not the module input's changes) trigger the task.
 func (tf *Terraform) setNotifier(tmpl templates.Template) error {
-	tmplFuncTotal, err := tf.countTmplFunc()
+	n, err := newNotifier(tf.task, tmpl)
 	if err != nil {
 		return err
 	}
 
-	switch tf.task.Condition().(type) {
-	case *config.ServicesConditionConfig:
-		tmpl := notifier.NewServices(tmpl, tmplFuncTotal)
-		tf.template = tmpl
-		tf.overrider = tmpl
-	case *config.CatalogServicesConditionConfig:
-		tmpl := notifier.NewCatalogServicesRegistration(tmpl, tmplFuncTotal)
-		tf.template = tmpl
-		tf.overrider = tmpl
-	case *config.ConsulKVConditionConfig:
-		tmpl := notifier.NewConsulKV(tmpl, tmplFuncTotal)
-		tf.template = tmpl
-		tf.overrider = tmpl
-	case *config.ScheduleConditionConfig:
-		tmpl := notifier.NewSuppressNotification(tmpl, tmplFuncTotal)
-		tf.template = tmpl
-		tf.overrider = tmpl
-	default:
-		// services list
-		tmpl := notifier.NewServices(tmpl, tmplFuncTotal)
-		tf.template = tmpl
-		tf.overrider = tmpl
-	}
+	tf.template = n
+	tf.overrider = n
 	return nil
 }
 
 // countTmplFunc counts the number of template functions (tmplfunc) that are
-// added to the template file. Counts the tmplfunc needed by the task's
-// services field, condition block, and module_input blocks.
+// added to the template file for the task.
 func (tf *Terraform) countTmplFunc() (int, error) {
-	// Count tmplfuncs for the service variable separately. Currently services
-	// can only be configured in one of services field, condition "services",
-	// and module_input "services". Enforced by config validation
-	serviceCount := len(tf.task.Services())
-	nonServiceCount := 0
-
-	switch cond := tf.task.Condition().(type) {
-	case *config.CatalogServicesConditionConfig:
-		nonServiceCount++
-	case *config.ServicesConditionConfig:
-		if cond.Regexp != nil {
-			serviceCount = 1
-		} else {
-			serviceCount = len(cond.Names)
-		}
-	case *config.ConsulKVConditionConfig:
-		nonServiceCount++
-	default:
-		// no-op: condition block currently not required since services list
-		// can be used alternatively. enforced by config validation
-	}
-
-	for _, moduleInput := range tf.task.ModuleInputs() {
-		switch input := moduleInput.(type) {
-		case *config.ServicesModuleInputConfig:
-			// relies on config validation to restrict to one ServicesModuleInput
-			if input.Regexp != nil {
-				serviceCount = 1
-			} else {
-				serviceCount = len(input.Names)
-			}
-		case *config.ConsulKVModuleInputConfig:
-			nonServiceCount++
-		default:
-			return 0, fmt.Errorf("task %q has unsupported type of module_input "+
-				"block configuration %T", tf.task.name, input)
-		}
-	}
-
-	return serviceCount + nonServiceCount, nil
+	return countTmplFunc(tf.task)
 }
 
 func (tf *Terraform) validateTask(ctx context.Context) error {
@@ -759,8 +991,9 @@ func getServicesMetaData(logger logging.Logger, task *Task) (*tmplfunc.ServicesM
 
 	// Introduced in 0.5. Metadata comes from module_input "services"
 	for _, moduleInput := range task.ModuleInputs() {
+		// labelled module inputs render their own variable without metadata
 		servicesInput, ok := moduleInput.(*config.ServicesModuleInputConfig)
-		if ok {
+		if ok && servicesInput.VariableName() == servicesInput.VariableType() {
 			err := servicesMeta.SetMeta(servicesInput.CTSUserDefinedMeta)
 			if err != nil {
 				logger.Error("unable to to set metadata from services module_input",
@@ -810,3 +1043,20 @@ func envMap(environ []string) map[string]string {
 	}
 	return env
 }
+
+// tfvarsJSONRenderer renders the task's template in the JSON syntax. The
+// template is rendered in the HCL syntax, which is converted to JSON before it
+// is written.
+type tfvarsJSONRenderer struct {
+	hcat.Renderer
+}
+
+// Render converts the rendered content to JSON and writes it
+func (r tfvarsJSONRenderer) Render(contents []byte) (hcat.RenderResult, error) {
+	content, err := tftmpl.TFVarsJSON(contents)
+	if err != nil {
+		return hcat.RenderResult{}, fmt.Errorf("error converting the rendered "+
+			"input variables to JSON: %s", err)
+	}
+	return r.Renderer.Render(content)
+}
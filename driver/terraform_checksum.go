@@ -0,0 +1,157 @@
+package driver
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+)
+
+// trustedChecksums is the set of trusted SHA256 checksums in lowercase hex. A
+// nil set is used when checksum verification is disabled.
+type trustedChecksums map[string]bool
+
+// loadTrustedChecksums returns the set of trusted SHA256 checksums configured
+// for the Terraform driver, including the checksums of the checksums file.
+// It is loaded once per install and reused to verify each file. Returns nil
+// if checksum verification is disabled.
+func loadTrustedChecksums(conf *config.TerraformConfig) (trustedChecksums, error) {
+	if !conf.ChecksumVerificationEnabled() {
+		return nil, nil
+	}
+
+	trusted := make(trustedChecksums, len(conf.Checksums))
+	for _, sum := range conf.Checksums {
+		trusted[strings.ToLower(sum)] = true
+	}
+
+	path := config.StringVal(conf.ChecksumsFile)
+	if path == "" {
+		return trusted, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read checksums file: %s", err)
+	}
+	defer f.Close()
+
+	// sha256sum format: "<checksum>  <file name>", one per line
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if !config.IsSHA256Checksum(fields[0]) {
+			return nil, fmt.Errorf("invalid SHA256 checksum on line %d of the "+
+				"checksums file %s", line, path)
+		}
+		trusted[strings.ToLower(fields[0])] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read checksums file: %s", err)
+	}
+	return trusted, nil
+}
+
+// verify returns an error if checksum verification is enabled and the checksum
+// of the file is not trusted
+func (trusted trustedChecksums) verify(path string) error {
+	if trusted == nil {
+		return nil
+	}
+
+	sum, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	if !trusted[sum] {
+		return fmt.Errorf("SHA256 checksum %s of %s does not match a trusted "+
+			"checksum", sum, path)
+	}
+	return nil
+}
+
+// verifyInstalledChecksum returns an error if checksum verification is enabled
+// and the binary is not trusted. The binary is trusted if its checksum is
+// trusted or if it is identical to the binary within the trusted archive
+// configured for the driver. This is verified before the binary is run.
+func verifyInstalledChecksum(conf *config.TerraformConfig, trusted trustedChecksums,
+	binPath string) error {
+
+	err := trusted.verify(binPath)
+	if err == nil {
+		return nil
+	}
+
+	archivePath := config.StringVal(conf.ArchivePath)
+	if archivePath == "" {
+		return err
+	}
+	if archiveErr := trusted.verify(archivePath); archiveErr != nil {
+		return err
+	}
+
+	sum, binErr := fileSHA256(binPath)
+	if binErr != nil {
+		return binErr
+	}
+	archiveSum, archiveErr := archiveBinarySHA256(archivePath, config.StringVal(conf.BinaryName))
+	if archiveErr != nil {
+		return archiveErr
+	}
+	if sum != archiveSum {
+		return fmt.Errorf("SHA256 checksum %s of %s does not match a trusted "+
+			"checksum or the binary in the archive %s", sum, binPath, archivePath)
+	}
+	return nil
+}
+
+// fileSHA256 returns the SHA256 checksum of the file in hex
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// archiveBinarySHA256 returns the SHA256 checksum in hex of the binary within
+// the zip archive
+func archiveBinarySHA256(archivePath, binaryName string) (string, error) {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return "", fmt.Errorf("unable to open archive %s: %s", archivePath, err)
+	}
+	defer r.Close()
+
+	binary := findArchiveFile(&r.Reader, binaryName)
+	if binary == nil {
+		return "", fmt.Errorf("binary %q not found in archive %s", binaryName, archivePath)
+	}
+
+	f, err := binary.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,182 @@
+package driver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTrustedChecksums(t *testing.T) {
+	t.Parallel()
+
+	sumA := testSHA256("a")
+	sumB := testSHA256("b")
+
+	t.Run("checksums_and_file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "SHA256SUMS")
+		content := fmt.Sprintf("# release checksums\n%s  terraform_1.2.3_linux_amd64.zip\n\n", sumB)
+		require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+		conf := &config.TerraformConfig{
+			Checksums:     []string{strings.ToUpper(sumA)},
+			ChecksumsFile: config.String(file),
+		}
+		trusted, err := loadTrustedChecksums(conf)
+		require.NoError(t, err)
+		assert.Equal(t, trustedChecksums{sumA: true, sumB: true}, trusted)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		conf := &config.TerraformConfig{Checksums: []string{}}
+		trusted, err := loadTrustedChecksums(conf)
+		require.NoError(t, err)
+		assert.Nil(t, trusted)
+	})
+
+	t.Run("invalid_file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "SHA256SUMS")
+		content := fmt.Sprintf("%s  terraform\nabc123  tofu\n", sumA)
+		require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+		conf := &config.TerraformConfig{ChecksumsFile: config.String(file)}
+		_, err := loadTrustedChecksums(conf)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 2")
+	})
+
+	t.Run("missing_file", func(t *testing.T) {
+		conf := &config.TerraformConfig{
+			ChecksumsFile: config.String(filepath.Join(t.TempDir(), "SHA256SUMS")),
+		}
+		_, err := loadTrustedChecksums(conf)
+		assert.Error(t, err)
+	})
+}
+
+func TestTrustedChecksums_verify(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "terraform")
+	require.NoError(t, os.WriteFile(path, []byte("binary"), 0755))
+
+	cases := []struct {
+		name      string
+		checksums []string
+		valid     bool
+	}{
+		{
+			"disabled",
+			[]string{},
+			true,
+		},
+		{
+			"trusted",
+			[]string{testSHA256("other"), testSHA256("binary")},
+			true,
+		},
+		{
+			"untrusted",
+			[]string{testSHA256("other")},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			conf := &config.TerraformConfig{Checksums: tc.checksums}
+			trusted, err := loadTrustedChecksums(conf)
+			require.NoError(t, err)
+			err = trusted.verify(path)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyInstalledChecksum(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	archive := writeTestArchive(t, dir, "terraform", "binary")
+	archiveSum, err := fileSHA256(archive)
+	require.NoError(t, err)
+
+	binPath := filepath.Join(dir, "terraform")
+	require.NoError(t, os.WriteFile(binPath, []byte("binary"), 0755))
+	modifiedPath := filepath.Join(dir, "terraform-modified")
+	require.NoError(t, os.WriteFile(modifiedPath, []byte("modified"), 0755))
+
+	cases := []struct {
+		name    string
+		binPath string
+		conf    *config.TerraformConfig
+		valid   bool
+	}{
+		{
+			"trusted_binary",
+			binPath,
+			&config.TerraformConfig{Checksums: []string{testSHA256("binary")}},
+			true,
+		},
+		{
+			"binary_from_trusted_archive",
+			binPath,
+			&config.TerraformConfig{
+				Checksums:   []string{archiveSum},
+				ArchivePath: config.String(archive),
+				BinaryName:  config.String("terraform"),
+			},
+			true,
+		},
+		{
+			"modified_binary_from_trusted_archive",
+			modifiedPath,
+			&config.TerraformConfig{
+				Checksums:   []string{archiveSum},
+				ArchivePath: config.String(archive),
+				BinaryName:  config.String("terraform"),
+			},
+			false,
+		},
+		{
+			"untrusted_archive",
+			binPath,
+			&config.TerraformConfig{
+				Checksums:   []string{testSHA256("other")},
+				ArchivePath: config.String(archive),
+				BinaryName:  config.String("terraform"),
+			},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			trusted, err := loadTrustedChecksums(tc.conf)
+			require.NoError(t, err)
+			err = verifyInstalledChecksum(tc.conf, trusted, tc.binPath)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+// testSHA256 returns the SHA256 checksum in hex of the content
+func testSHA256(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,12 +1,15 @@
 package driver
 
 import (
+	"archive/zip"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/logging"
@@ -27,37 +30,86 @@ const (
 
 const fallbackTFVersion = "1.1.8"
 
+// versionsDirName is the directory within the Terraform driver's path where
+// the Terraform versions configured for individual tasks are installed
+const versionsDirName = "versions"
+
 // TerraformVersion is the version of Terraform CLI for the Terraform driver.
 var TerraformVersion *goVersion.Version
 
+// installVersionMu serializes installing Terraform versions for tasks, since
+// tasks that are created concurrently may require the same version
+var installVersionMu sync.Mutex
+
+// installedVersions are the Terraform versions installed for tasks keyed by
+// the directory of the binary. Guarded by installVersionMu.
+var installedVersions = make(map[string]*goVersion.Version)
+
 // InstallTerraform installs the Terraform binary to the configured path.
 // If an existing Terraform exists in the path, it is checked for compatibility.
+// If the binary does not exist and a local archive is configured, the binary
+// is extracted from the archive instead of being downloaded. OpenTofu is not
+// downloaded and must either exist in the path or be installed from an
+// archive.
 func InstallTerraform(ctx context.Context, conf *config.TerraformConfig) error {
 	path := *conf.Path
+	binaryName := config.StringVal(conf.BinaryName)
+	if binaryName == "" {
+		binaryName = config.DefaultTerraformBinaryName
+	}
 
 	logger := logging.Global().Named(logSystemName).Named(terraformSubsystemName)
-	if isTFInstalled(path) {
-		tfVersion, compatible, err := verifyInstalledTF(ctx, conf)
-		if err != nil {
-			if strings.Contains(err.Error(), "exec format error") {
-				return errIncompatibleTerraformBinary
-			}
+	trusted, err := loadTrustedChecksums(conf)
+	if err != nil {
+		logger.Error("unable to load trusted checksums", "error", err)
+		return err
+	}
+
+	if isTFInstalled(path, binaryName) {
+		if err := verifyInstalledChecksum(conf, trusted, filepath.Join(path, binaryName)); err != nil {
+			logger.Error("refusing to use terraform that failed checksum "+
+				"verification", "install_path", path, "error", err)
 			return err
 		}
-
-		// Set the global variable to the installed version
-		TerraformVersion = tfVersion
-		if !compatible {
-			return errUnsupportedTerraformVersion
+		if err := useInstalledTF(ctx, conf); err != nil {
+			return err
 		}
 		logger.Info("skipping install, terraform already exists",
-			"tf_version", tfVersion.String(), "install_path", path)
+			"tf_version", TerraformVersion.String(), "install_path", path,
+			"binary_name", binaryName)
+
+		return nil
+	}
+
+	if archivePath := config.StringVal(conf.ArchivePath); archivePath != "" {
+		logger.Info("install terraform from archive", "install_path", path,
+			"archive_path", archivePath, "binary_name", binaryName)
+		if err := trusted.verify(archivePath); err != nil {
+			logger.Error("refusing to install terraform from archive that failed "+
+				"checksum verification", "error", err)
+			return err
+		}
+		if err := installFromArchive(archivePath, path, binaryName); err != nil {
+			logger.Error("error installing terraform from archive", "error", err)
+			return err
+		}
+		if err := useInstalledTF(ctx, conf); err != nil {
+			return err
+		}
+		logger.Info("successfully installed terraform from archive",
+			"tf_version", TerraformVersion.String())
 
 		return nil
 	}
 
+	if conf.IsOpenTofu() {
+		return fmt.Errorf("OpenTofu binary %q was not found in the path %s, "+
+			"install OpenTofu to the path or configure archive_path to install "+
+			"from a local archive", binaryName, path)
+	}
+
 	logger.Info("install terraform", "install_path", path)
-	tfVersion, err := installTerraform(ctx, conf)
+	tfVersion, err := installTerraform(ctx, conf, trusted)
 	if err != nil {
 		logger.Error("error installing terraform", "error", err)
 		return err
@@ -69,9 +121,28 @@ func InstallTerraform(ctx context.Context, conf *config.TerraformConfig) error {
 	return nil
 }
 
-// isTFInstalled checks to see if terraform already exists at path.
-func isTFInstalled(tfPath string) bool {
-	tfPath = filepath.Join(tfPath, "terraform")
+// useInstalledTF verifies the installed Terraform and sets it as the version
+// for the Terraform driver.
+func useInstalledTF(ctx context.Context, conf *config.TerraformConfig) error {
+	tfVersion, compatible, err := verifyInstalledTF(ctx, conf)
+	if err != nil {
+		if strings.Contains(err.Error(), "exec format error") {
+			return errIncompatibleTerraformBinary
+		}
+		return err
+	}
+
+	// Set the global variable to the installed version
+	TerraformVersion = tfVersion
+	if !compatible {
+		return errUnsupportedTerraformVersion
+	}
+	return nil
+}
+
+// isTFInstalled checks to see if the terraform binary already exists at path.
+func isTFInstalled(tfPath, binaryName string) bool {
+	tfPath = filepath.Join(tfPath, binaryName)
 
 	// Check if terraform exists in target path
 	if _, err := os.Stat(tfPath); err == nil {
@@ -80,7 +151,7 @@ func isTFInstalled(tfPath string) bool {
 
 	// Check if terraform exists in $PATH to notify users about the new
 	// installation for CTS
-	path, err := exec.LookPath("terraform")
+	path, err := exec.LookPath(binaryName)
 	if err != nil {
 		return false
 	}
@@ -106,7 +177,11 @@ func verifyInstalledTF(ctx context.Context, conf *config.TerraformConfig) (*goVe
 
 	// Verify version for existing terraform
 	logger := logging.Global().Named(logSystemName).Named(terraformSubsystemName)
-	tf, err := tfexec.NewTerraform(wd, filepath.Join(tfPath, "terraform"))
+	binaryName := config.StringVal(conf.BinaryName)
+	if binaryName == "" {
+		binaryName = config.DefaultTerraformBinaryName
+	}
+	tf, err := tfexec.NewTerraform(wd, filepath.Join(tfPath, binaryName))
 	if err != nil {
 		logger.Error("unable to setup Terraform client", "terraform_path", tfPath, "error", err)
 		return nil, false, err
@@ -118,10 +193,10 @@ func verifyInstalledTF(ctx context.Context, conf *config.TerraformConfig) (*goVe
 		return nil, false, err
 	}
 
-	if !ctsVersion.TerraformConstraint.Check(tfVersion) {
+	if constraint, raw := conf.VersionConstraint(); !constraint.Check(tfVersion) {
 		logger.Error("found Terraform version does not satisfy the version constraint",
-			"terraform_path", tfPath, "version", tfVersion.String(),
-			"compatible_version_constraint", ctsVersion.CompatibleTerraformVersionConstraint)
+			"terraform_path", tfPath, "flavor", config.StringVal(conf.Flavor),
+			"version", tfVersion.String(), "compatible_version_constraint", raw)
 		return tfVersion, false, nil
 	}
 
@@ -157,10 +232,118 @@ func isTFCompatible(conf *config.TerraformConfig, version *goVersion.Version) er
 	return nil
 }
 
+// InstallTerraformVersion installs a Terraform version configured for a task.
+// Each version is installed into its own directory within the driver's path so
+// that multiple versions exist side by side, and an installed version is
+// reused by all tasks configured with the version. Returns the directory of
+// the binary and its version. The driver's binary is used if it is the same
+// version. A version is only verified and installed once per process.
+func InstallTerraformVersion(ctx context.Context, conf *config.TerraformConfig,
+	version string) (string, *goVersion.Version, error) {
+
+	tfVersion, err := goVersion.NewVersion(version)
+	if err != nil {
+		return "", nil, err
+	}
+	if TerraformVersion != nil && TerraformVersion.Equal(tfVersion) {
+		return *conf.Path, TerraformVersion, nil
+	}
+
+	if conf.IsOpenTofu() {
+		return "", nil, fmt.Errorf("installing a version of OpenTofu per task " +
+			"is not supported")
+	}
+
+	installVersionMu.Lock()
+	defer installVersionMu.Unlock()
+
+	logger := logging.Global().Named(logSystemName).Named(terraformSubsystemName)
+	installPath := filepath.Join(*conf.Path, versionsDirName, tfVersion.String())
+	if v, ok := installedVersions[installPath]; ok {
+		return installPath, v, nil
+	}
+
+	trusted, err := loadTrustedChecksums(conf)
+	if err != nil {
+		logger.Error("unable to load trusted checksums", "error", err)
+		return "", nil, err
+	}
+
+	if _, err := os.Stat(filepath.Join(installPath, config.DefaultTerraformBinaryName)); err == nil {
+		err := trusted.verify(filepath.Join(installPath, config.DefaultTerraformBinaryName))
+		if err != nil {
+			logger.Error("refusing to use terraform version that failed checksum "+
+				"verification", "install_path", installPath, "error", err)
+			return "", nil, err
+		}
+
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", nil, err
+		}
+		tf, err := tfexec.NewTerraform(wd,
+			filepath.Join(installPath, config.DefaultTerraformBinaryName))
+		if err != nil {
+			return "", nil, err
+		}
+		installed, _, err := tf.Version(ctx, true)
+		if err != nil {
+			return "", nil, err
+		}
+		if !installed.Equal(tfVersion) {
+			return "", nil, fmt.Errorf("found Terraform version %s in %s which "+
+				"does not match the version %s", installed, installPath, tfVersion)
+		}
+
+		logger.Debug("skipping install, terraform version already exists",
+			"tf_version", tfVersion.String(), "install_path", installPath)
+		installedVersions[installPath] = tfVersion
+		return installPath, tfVersion, nil
+	}
+
+	if err := isTFCompatible(conf, tfVersion); err != nil {
+		return "", nil, err
+	}
+
+	logger.Info("install terraform version", "tf_version", tfVersion.String(),
+		"install_path", installPath)
+	if err := downloadTerraform(ctx, trusted, tfVersion, installPath); err != nil {
+		logger.Error("error installing terraform version",
+			"tf_version", tfVersion.String(), "error", err)
+		return "", nil, err
+	}
+	installedVersions[installPath] = tfVersion
+	return installPath, tfVersion, nil
+}
+
+// InstalledTerraformVersion returns the directory of the binary and the
+// version of a Terraform version configured for a task. The version must have
+// been installed with InstallTerraformVersion or be the driver's version.
+func InstalledTerraformVersion(conf *config.TerraformConfig, version string) (string, *goVersion.Version, error) {
+	tfVersion, err := goVersion.NewVersion(version)
+	if err != nil {
+		return "", nil, err
+	}
+	if TerraformVersion != nil && TerraformVersion.Equal(tfVersion) {
+		return *conf.Path, TerraformVersion, nil
+	}
+
+	installVersionMu.Lock()
+	defer installVersionMu.Unlock()
+
+	installPath := filepath.Join(*conf.Path, versionsDirName, tfVersion.String())
+	if v, ok := installedVersions[installPath]; ok {
+		return installPath, v, nil
+	}
+	return "", nil, fmt.Errorf("Terraform version %s is not installed", version)
+}
+
 // installTerraform attempts to install the latest version of Terraform into
 // the path. If the latest version is outside of the known supported range for
 // CTS, the fall back version 0.13.5 is downloaded.
-func installTerraform(ctx context.Context, conf *config.TerraformConfig) (*goVersion.Version, error) {
+func installTerraform(ctx context.Context, conf *config.TerraformConfig,
+	trusted trustedChecksums) (*goVersion.Version, error) {
+
 	var tfVersion *goVersion.Version
 	logger := logging.Global().Named(logSystemName).Named(terraformSubsystemName)
 	if conf.Version != nil && *conf.Version != "" {
@@ -190,22 +373,96 @@ func installTerraform(ctx context.Context, conf *config.TerraformConfig) (*goVer
 		return nil, err
 	}
 
+	if err := downloadTerraform(ctx, trusted, tfVersion, *conf.Path); err != nil {
+		return nil, err
+	}
+	return tfVersion, nil
+}
+
+// downloadTerraform downloads the Terraform release into the path. The
+// downloaded binary is removed if it fails checksum verification.
+func downloadTerraform(ctx context.Context, trusted trustedChecksums,
+	tfVersion *goVersion.Version, path string) error {
+
 	// Create path if one doesn't already exist
-	_ = os.MkdirAll(*conf.Path, os.ModePerm)
+	_ = os.MkdirAll(path, os.ModePerm)
 
 	installer := hcinstall.NewInstaller()
 	installedPath, err := installer.Ensure(ctx, []src.Source{
 		&releases.ExactVersion{
 			Product:    product.Terraform,
 			Version:    tfVersion,
-			InstallDir: *conf.Path,
+			InstallDir: path,
 		},
 	})
 
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if err := trusted.verify(installedPath); err != nil {
+		_ = os.Remove(installedPath)
+		return fmt.Errorf("downloaded terraform failed checksum verification: %s", err)
 	}
 
+	logger := logging.Global().Named(logSystemName).Named(terraformSubsystemName)
 	logger.Debug("successfully installed terraform", "version", tfVersion.String(), "install_path", installedPath)
-	return tfVersion, nil
+	return nil
+}
+
+// installFromArchive extracts the binary from a local zip archive, such as a
+// release archive of Terraform or OpenTofu, into the path.
+func installFromArchive(archivePath, installPath, binaryName string) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("unable to open archive %s: %s", archivePath, err)
+	}
+	defer r.Close()
+
+	binary := findArchiveFile(&r.Reader, binaryName)
+	if binary == nil {
+		return fmt.Errorf("binary %q not found in archive %s", binaryName, archivePath)
+	}
+
+	if err := os.MkdirAll(installPath, os.ModePerm); err != nil {
+		return err
+	}
+
+	src, err := binary.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	// Write to a temporary file and rename so that a partially extracted
+	// binary is never found in the path
+	dst, err := os.CreateTemp(installPath, binaryName+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(dst.Name())
+
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(dst.Name(), 0755); err != nil {
+		return err
+	}
+
+	return os.Rename(dst.Name(), filepath.Join(installPath, binaryName))
+}
+
+// findArchiveFile returns the file with the name within the zip archive, or
+// nil if the archive does not contain the file
+func findArchiveFile(r *zip.Reader, name string) *zip.File {
+	for _, f := range r.File {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
 }
@@ -1,11 +1,17 @@
 package driver
 
 import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/go-version"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsTFCompatible(t *testing.T) {
@@ -50,3 +56,340 @@ func TestIsTFCompatible(t *testing.T) {
 		})
 	}
 }
+
+func TestInstallTerraform_OpenTofu(t *testing.T) {
+	// modifies the global TerraformVersion, do not run in parallel
+	original := TerraformVersion
+	t.Cleanup(func() { TerraformVersion = original })
+
+	ctx := context.Background()
+
+	t.Run("from_archive", func(t *testing.T) {
+		dir := t.TempDir()
+		archive := writeTestArchive(t, dir, "tofu", fakeTFScript("1.6.2"))
+		conf := &config.TerraformConfig{
+			Flavor:      config.String(config.TerraformFlavorOpenTofu),
+			Path:        config.String(filepath.Join(dir, "bin")),
+			ArchivePath: config.String(archive),
+		}
+		conf.Finalize(nil)
+
+		err := InstallTerraform(ctx, conf)
+		require.NoError(t, err)
+		assert.Equal(t, "1.6.2", TerraformVersion.String())
+		assert.FileExists(t, filepath.Join(dir, "bin", "tofu"))
+	})
+
+	t.Run("already_installed", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "tofu"), []byte(fakeTFScript("1.7.0")), 0755)
+		require.NoError(t, err)
+		conf := &config.TerraformConfig{
+			Flavor: config.String(config.TerraformFlavorOpenTofu),
+			Path:   config.String(dir),
+		}
+		conf.Finalize(nil)
+
+		err = InstallTerraform(ctx, conf)
+		require.NoError(t, err)
+		assert.Equal(t, "1.7.0", TerraformVersion.String())
+	})
+
+	t.Run("unsupported_version", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "tofu"), []byte(fakeTFScript("1.5.0")), 0755)
+		require.NoError(t, err)
+		conf := &config.TerraformConfig{
+			Flavor: config.String(config.TerraformFlavorOpenTofu),
+			Path:   config.String(dir),
+		}
+		conf.Finalize(nil)
+
+		err = InstallTerraform(ctx, conf)
+		assert.Equal(t, errUnsupportedTerraformVersion, err)
+	})
+
+	t.Run("not_installed", func(t *testing.T) {
+		conf := &config.TerraformConfig{
+			Flavor: config.String(config.TerraformFlavorOpenTofu),
+			Path:   config.String(t.TempDir()),
+		}
+		conf.Finalize(nil)
+
+		err := InstallTerraform(ctx, conf)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "archive_path")
+	})
+}
+
+func TestInstallTerraform_Checksums(t *testing.T) {
+	// modifies the global TerraformVersion, do not run in parallel
+	original := TerraformVersion
+	t.Cleanup(func() { TerraformVersion = original })
+
+	ctx := context.Background()
+	script := fakeTFScript("1.2.3")
+
+	t.Run("trusted_binary", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "terraform"), []byte(script), 0755)
+		require.NoError(t, err)
+		conf := &config.TerraformConfig{
+			Path:      config.String(dir),
+			Checksums: []string{testSHA256(script)},
+		}
+		conf.Finalize(nil)
+
+		err = InstallTerraform(ctx, conf)
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.3", TerraformVersion.String())
+	})
+
+	t.Run("untrusted_binary", func(t *testing.T) {
+		TerraformVersion = nil
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "terraform"), []byte(script), 0755)
+		require.NoError(t, err)
+		conf := &config.TerraformConfig{
+			Path:      config.String(dir),
+			Checksums: []string{testSHA256("other")},
+		}
+		conf.Finalize(nil)
+
+		err = InstallTerraform(ctx, conf)
+		assert.Error(t, err)
+		assert.Nil(t, TerraformVersion, "untrusted binary should not be run")
+	})
+
+	t.Run("trusted_archive", func(t *testing.T) {
+		dir := t.TempDir()
+		archive := writeTestArchive(t, dir, "terraform", script)
+		archiveSum, err := fileSHA256(archive)
+		require.NoError(t, err)
+		conf := &config.TerraformConfig{
+			Path:        config.String(filepath.Join(dir, "bin")),
+			ArchivePath: config.String(archive),
+			Checksums:   []string{archiveSum},
+		}
+		conf.Finalize(nil)
+
+		err = InstallTerraform(ctx, conf)
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.3", TerraformVersion.String())
+
+		// the extracted binary is trusted on restart
+		err = InstallTerraform(ctx, conf)
+		assert.NoError(t, err)
+	})
+
+	t.Run("untrusted_archive", func(t *testing.T) {
+		dir := t.TempDir()
+		archive := writeTestArchive(t, dir, "terraform", script)
+		conf := &config.TerraformConfig{
+			Path:        config.String(filepath.Join(dir, "bin")),
+			ArchivePath: config.String(archive),
+			Checksums:   []string{testSHA256(script)},
+		}
+		conf.Finalize(nil)
+
+		err := InstallTerraform(ctx, conf)
+		assert.Error(t, err)
+		assert.NoFileExists(t, filepath.Join(dir, "bin", "terraform"))
+	})
+}
+
+func TestInstallTerraformVersion(t *testing.T) {
+	// modifies the global TerraformVersion, do not run in parallel
+	original := TerraformVersion
+	t.Cleanup(func() { TerraformVersion = original })
+	TerraformVersion = version.Must(version.NewVersion("1.1.8"))
+
+	ctx := context.Background()
+
+	t.Run("driver_version", func(t *testing.T) {
+		dir := t.TempDir()
+		conf := &config.TerraformConfig{Path: config.String(dir)}
+		conf.Finalize(nil)
+
+		path, v, err := InstallTerraformVersion(ctx, conf, "1.1.8")
+		require.NoError(t, err)
+		assert.Equal(t, dir, path)
+		assert.Equal(t, TerraformVersion, v)
+	})
+
+	t.Run("already_installed", func(t *testing.T) {
+		dir := t.TempDir()
+		installPath := filepath.Join(dir, versionsDirName, "1.2.3")
+		require.NoError(t, os.MkdirAll(installPath, 0755))
+		err := os.WriteFile(filepath.Join(installPath, "terraform"),
+			[]byte(fakeTFScript("1.2.3")), 0755)
+		require.NoError(t, err)
+		conf := &config.TerraformConfig{Path: config.String(dir)}
+		conf.Finalize(nil)
+
+		path, v, err := InstallTerraformVersion(ctx, conf, "1.2.3")
+		require.NoError(t, err)
+		assert.Equal(t, installPath, path)
+		assert.Equal(t, "1.2.3", v.String())
+		assert.Equal(t, "1.1.8", TerraformVersion.String(),
+			"driver version should not change")
+	})
+
+	t.Run("untrusted_installed", func(t *testing.T) {
+		dir := t.TempDir()
+		installPath := filepath.Join(dir, versionsDirName, "1.2.3")
+		require.NoError(t, os.MkdirAll(installPath, 0755))
+		err := os.WriteFile(filepath.Join(installPath, "terraform"),
+			[]byte(fakeTFScript("1.2.3")), 0755)
+		require.NoError(t, err)
+		conf := &config.TerraformConfig{
+			Path:      config.String(dir),
+			Checksums: []string{testSHA256("other")},
+		}
+		conf.Finalize(nil)
+
+		_, _, err = InstallTerraformVersion(ctx, conf, "1.2.3")
+		assert.Error(t, err)
+	})
+
+	t.Run("installed_version_mismatch", func(t *testing.T) {
+		dir := t.TempDir()
+		installPath := filepath.Join(dir, versionsDirName, "1.2.3")
+		require.NoError(t, os.MkdirAll(installPath, 0755))
+		err := os.WriteFile(filepath.Join(installPath, "terraform"),
+			[]byte(fakeTFScript("1.2.2")), 0755)
+		require.NoError(t, err)
+		conf := &config.TerraformConfig{Path: config.String(dir)}
+		conf.Finalize(nil)
+
+		_, _, err = InstallTerraformVersion(ctx, conf, "1.2.3")
+		assert.Error(t, err)
+	})
+
+	t.Run("opentofu", func(t *testing.T) {
+		conf := &config.TerraformConfig{
+			Flavor: config.String(config.TerraformFlavorOpenTofu),
+			Path:   config.String(t.TempDir()),
+		}
+		conf.Finalize(nil)
+
+		_, _, err := InstallTerraformVersion(ctx, conf, "1.2.3")
+		assert.Error(t, err)
+	})
+}
+
+func TestInstalledTerraformVersion(t *testing.T) {
+	// modifies the global TerraformVersion, do not run in parallel
+	original := TerraformVersion
+	t.Cleanup(func() { TerraformVersion = original })
+	TerraformVersion = version.Must(version.NewVersion("1.1.8"))
+
+	t.Run("driver_version", func(t *testing.T) {
+		dir := t.TempDir()
+		conf := &config.TerraformConfig{Path: config.String(dir)}
+		conf.Finalize(nil)
+
+		path, v, err := InstalledTerraformVersion(conf, "1.1.8")
+		require.NoError(t, err)
+		assert.Equal(t, dir, path)
+		assert.Equal(t, TerraformVersion, v)
+	})
+
+	t.Run("installed", func(t *testing.T) {
+		dir := t.TempDir()
+		installPath := filepath.Join(dir, versionsDirName, "1.2.3")
+		require.NoError(t, os.MkdirAll(installPath, 0755))
+		err := os.WriteFile(filepath.Join(installPath, "terraform"),
+			[]byte(fakeTFScript("1.2.3")), 0755)
+		require.NoError(t, err)
+		conf := &config.TerraformConfig{Path: config.String(dir)}
+		conf.Finalize(nil)
+
+		_, _, err = InstallTerraformVersion(context.Background(), conf, "1.2.3")
+		require.NoError(t, err)
+
+		// the binary is not verified again after it is installed
+		require.NoError(t, os.Remove(filepath.Join(installPath, "terraform")))
+		path, v, err := InstalledTerraformVersion(conf, "1.2.3")
+		require.NoError(t, err)
+		assert.Equal(t, installPath, path)
+		assert.Equal(t, "1.2.3", v.String())
+	})
+
+	t.Run("not_installed", func(t *testing.T) {
+		conf := &config.TerraformConfig{Path: config.String(t.TempDir())}
+		conf.Finalize(nil)
+
+		_, _, err := InstalledTerraformVersion(conf, "1.2.3")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid_version", func(t *testing.T) {
+		conf := &config.TerraformConfig{Path: config.String(t.TempDir())}
+		conf.Finalize(nil)
+
+		_, _, err := InstalledTerraformVersion(conf, "invalid")
+		assert.Error(t, err)
+	})
+}
+
+func TestInstallFromArchive(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy_path", func(t *testing.T) {
+		dir := t.TempDir()
+		archive := writeTestArchive(t, dir, "terraform", "binary")
+		installPath := filepath.Join(dir, "install")
+
+		err := installFromArchive(archive, installPath, "terraform")
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(installPath, "terraform"))
+		require.NoError(t, err)
+		assert.Equal(t, "binary", string(content))
+
+		info, err := os.Stat(filepath.Join(installPath, "terraform"))
+		require.NoError(t, err)
+		assert.NotZero(t, info.Mode()&0100, "binary should be executable")
+	})
+
+	t.Run("binary_not_in_archive", func(t *testing.T) {
+		dir := t.TempDir()
+		archive := writeTestArchive(t, dir, "terraform", "binary")
+
+		err := installFromArchive(archive, dir, "tofu")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found in archive")
+	})
+
+	t.Run("missing_archive", func(t *testing.T) {
+		dir := t.TempDir()
+		err := installFromArchive(filepath.Join(dir, "missing.zip"), dir, "terraform")
+		assert.Error(t, err)
+	})
+}
+
+// writeTestArchive writes a zip archive to the directory containing a single
+// file and returns the path to the archive
+func writeTestArchive(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, "archive.zip")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	fw, err := w.Create(name)
+	require.NoError(t, err)
+	_, err = fw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return path
+}
+
+// fakeTFScript returns a shell script that mocks the version command of a
+// Terraform-compatible binary
+func fakeTFScript(v string) string {
+	return fmt.Sprintf(`#!/bin/sh
+echo '{"terraform_version":"%s","platform":"linux_amd64","provider_selections":{},"terraform_outdated":false}'
+`, v)
+}
@@ -0,0 +1,254 @@
+package driver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// pluginCacheDirEnv is the Terraform environment variable to configure the
+	// provider plugin cache
+	pluginCacheDirEnv = "TF_PLUGIN_CACHE_DIR"
+
+	// initFingerprintFilename is the file within the Terraform data directory
+	// of the task's working directory that stores the fingerprint of the
+	// workspace when it was last initialized. Removing the data directory
+	// removes the fingerprint, which triggers re-initializing the workspace.
+	initFingerprintFilename = "cts-init-fingerprint"
+
+	// defaultCLIConfigFilename is the Terraform CLI configuration file in the
+	// home directory that is used when TF_CLI_CONFIG_FILE is not set
+	defaultCLIConfigFilename = ".terraformrc"
+
+	// dependencyLockFilename is the Terraform dependency lock file of the
+	// task's working directory, which records the selected provider versions
+	dependencyLockFilename = ".terraform.lock.hcl"
+)
+
+// pluginCacheMu serializes initializing workspaces that may install providers
+// into the shared plugin cache. See providersCached.
+var pluginCacheMu sync.Mutex
+
+// setupPluginCacheDir creates the provider plugin cache directory if it does
+// not exist and returns its absolute path. Returns an empty string if the
+// cache is disabled.
+func setupPluginCacheDir(dir string) (string, error) {
+	if dir == "" {
+		return "", nil
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, workingDirPerms); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// providersCached returns whether the provider versions in the dependency lock
+// file of the working directory are all installed in the plugin cache for the
+// current platform. Initializing the workspace then only reads from the cache
+// since Terraform installs the locked versions, so it does not need to be
+// serialized with other workspaces. Returns false if the workspace does not
+// have a lock file, such as the first time it is initialized.
+func providersCached(workingDir, cacheDir string) bool {
+	content, err := ioutil.ReadFile(filepath.Join(workingDir, dependencyLockFilename))
+	if err != nil {
+		return false
+	}
+	file, diags := hclsyntax.ParseConfig(content, dependencyLockFilename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return false
+	}
+
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if block.Type != "provider" || len(block.Labels) != 1 {
+			continue
+		}
+		attr, ok := block.Body.Attributes["version"]
+		if !ok {
+			return false
+		}
+		version, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() || version.Type() != cty.String || version.IsNull() {
+			return false
+		}
+
+		// <cache>/<hostname>/<namespace>/<type>/<version>/<os>_<arch>
+		dir := filepath.Join(cacheDir, filepath.FromSlash(block.Labels[0]),
+			version.AsString(), platform)
+		if _, err := os.Stat(dir); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// initEnv returns the environment variables of the task's client that change
+// the result of initializing the workspace. Values set for the task take
+// precedence over the CTS process environment.
+func initEnv(taskEnv map[string]string) map[string]string {
+	env := make(map[string]string)
+	for _, k := range []string{cliConfigEnv, pluginCacheDirEnv} {
+		if v, ok := taskEnv[k]; ok {
+			env[k] = v
+		} else if v, ok := os.LookupEnv(k); ok {
+			env[k] = v
+		}
+	}
+	return env
+}
+
+// initFingerprint returns a fingerprint of what determines the result of
+// initializing the workspace: the generated root module including its backend
+// and required providers, the module source and version, the Terraform
+// version, the plugin cache, and the Terraform CLI configuration including
+// provider mirrors. Local module sources also include the configuration files
+// of their nested modules. Returns an empty string if the root module has not
+// been generated.
+func (tf *Terraform) initFingerprint() (string, error) {
+	rootFilename := tftmpl.RootFilename
+	if tf.jsonFormat {
+		rootFilename = tftmpl.RootJSONFilename
+	}
+
+	main, err := ioutil.ReadFile(filepath.Join(tf.task.WorkingDir(), rootFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	var tfVersion string
+	if v := tf.terraformVersion(); v != nil {
+		tfVersion = v.String()
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "terraform_version=%s\n", tfVersion)
+	fmt.Fprintf(h, "module=%s\n", tf.task.Module())
+	fmt.Fprintf(h, "module_version=%s\n", tf.task.Version())
+	fmt.Fprintf(h, "%s=%s\n", pluginCacheDirEnv, tf.initEnv[pluginCacheDirEnv])
+	if err := hashCLIConfig(h, tf.initEnv[cliConfigEnv]); err != nil {
+		return "", err
+	}
+	fmt.Fprintf(h, "%s=", rootFilename)
+	h.Write(main)
+
+	if err := hashLocalModule(h, tf.task.Module()); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashCLIConfig writes the path and content of the Terraform CLI configuration
+// file to the hash. The file in the home directory is used when the path is
+// not set. A file that does not exist is hashed as empty.
+func hashCLIConfig(h io.Writer, path string) error {
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(home, defaultCLIConfigFilename)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	fmt.Fprintf(h, "%s=%s\n", cliConfigEnv, path)
+	h.Write(content)
+	return nil
+}
+
+// hashLocalModule writes the Terraform configuration files of a local module
+// and its nested directories to the hash. Hidden directories, such as the
+// .terraform data directory, are skipped. Module sources that are not local
+// directories are skipped.
+func hashLocalModule(h io.Writer, module string) error {
+	info, err := os.Stat(module)
+	if err != nil || !info.IsDir() {
+		return nil
+	}
+
+	var names []string
+	err = filepath.Walk(module, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != module && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(info.Name(), ".tf") ||
+			strings.HasSuffix(info.Name(), ".tf.json") {
+			names = append(names, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		content, err := ioutil.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(module, name)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "\n%s=", filepath.ToSlash(rel))
+		h.Write(content)
+	}
+	return nil
+}
+
+// lastInitFingerprint returns the fingerprint saved when the workspace was last
+// initialized. Returns an empty string if there is none.
+func (tf *Terraform) lastInitFingerprint() string {
+	content, err := ioutil.ReadFile(tf.initFingerprintPath())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// saveInitFingerprint saves the fingerprint of the initialized workspace
+func (tf *Terraform) saveInitFingerprint(fingerprint string) error {
+	path := tf.initFingerprintPath()
+	if err := os.MkdirAll(filepath.Dir(path), workingDirPerms); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(fingerprint), filePerms)
+}
+
+// initFingerprintPath returns the path to the file storing the fingerprint
+func (tf *Terraform) initFingerprintPath() string {
+	return filepath.Join(tf.task.WorkingDir(), ".terraform", initFingerprintFilename)
+}
@@ -0,0 +1,295 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/client"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerraform_init_fingerprint(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	newTF := func(wd, module string) (*Terraform, *mocks.Client) {
+		c := new(mocks.Client)
+		return &Terraform{
+			task: &Task{
+				name:       "test",
+				enabled:    true,
+				workingDir: wd,
+				module:     module,
+				logger:     logging.NewNullLogger(),
+			},
+			client: selectorClient{c},
+			logger: logging.NewNullLogger(),
+		}, c
+	}
+
+	writeMain := func(t *testing.T, wd, content string) {
+		err := os.WriteFile(filepath.Join(wd, tftmpl.RootFilename), []byte(content), filePerms)
+		require.NoError(t, err)
+	}
+
+	t.Run("skip_unchanged", func(t *testing.T) {
+		wd := t.TempDir()
+		writeMain(t, wd, `module "test" {}`)
+
+		tf, c := newTF(wd, "org/module/provider")
+		c.On("Init", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+		assert.FileExists(t, tf.initFingerprintPath())
+
+		// restarting with the same workspace skips init and only selects the
+		// workspace
+		tf, c = newTF(wd, "org/module/provider")
+		c.On("SelectWorkspace", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+		assert.True(t, tf.inited)
+		c.AssertNotCalled(t, "Init", ctx)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("select_workspace_error", func(t *testing.T) {
+		wd := t.TempDir()
+		writeMain(t, wd, `module "test" {}`)
+
+		tf, c := newTF(wd, "org/module/provider")
+		c.On("Init", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+
+		// the workspace is initialized when it cannot be selected
+		tf, c = newTF(wd, "org/module/provider")
+		c.On("SelectWorkspace", ctx).Return(errors.New("no workspace")).Once()
+		c.On("Init", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+		c.AssertExpectations(t)
+	})
+
+	t.Run("client_without_selector", func(t *testing.T) {
+		wd := t.TempDir()
+		writeMain(t, wd, `module "test" {}`)
+
+		tf, c := newTF(wd, "org/module/provider")
+		tf.client = c
+		c.On("Init", ctx).Return(nil).Twice()
+		require.NoError(t, tf.init(ctx))
+		tf.inited = false
+		require.NoError(t, tf.init(ctx))
+		c.AssertExpectations(t)
+	})
+
+	t.Run("root_module_changed", func(t *testing.T) {
+		wd := t.TempDir()
+		writeMain(t, wd, `module "test" {}`)
+
+		tf, c := newTF(wd, "org/module/provider")
+		c.On("Init", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+
+		writeMain(t, wd, `module "test" { version = "2.0.0" }`)
+		tf, c = newTF(wd, "org/module/provider")
+		c.On("Init", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+		c.AssertExpectations(t)
+	})
+
+	t.Run("module_changed", func(t *testing.T) {
+		wd := t.TempDir()
+		writeMain(t, wd, `module "test" {}`)
+
+		tf, c := newTF(wd, "org/module/provider")
+		c.On("Init", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+
+		tf, c = newTF(wd, "org/other-module/provider")
+		c.On("Init", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+		c.AssertExpectations(t)
+	})
+
+	t.Run("local_module_changed", func(t *testing.T) {
+		wd := t.TempDir()
+		writeMain(t, wd, `module "test" {}`)
+		module := t.TempDir()
+		modFile := filepath.Join(module, "main.tf")
+		require.NoError(t, os.WriteFile(modFile, []byte(`# v1`), filePerms))
+
+		tf, c := newTF(wd, module)
+		c.On("Init", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+
+		require.NoError(t, os.WriteFile(modFile, []byte(`# v2`), filePerms))
+		tf, c = newTF(wd, module)
+		c.On("Init", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+		c.AssertExpectations(t)
+	})
+
+	t.Run("nested_local_module_changed", func(t *testing.T) {
+		wd := t.TempDir()
+		writeMain(t, wd, `module "test" {}`)
+		module := t.TempDir()
+		nested := filepath.Join(module, "modules", "nested")
+		require.NoError(t, os.MkdirAll(nested, workingDirPerms))
+		modFile := filepath.Join(nested, "main.tf")
+		require.NoError(t, os.WriteFile(modFile, []byte(`# v1`), filePerms))
+
+		tf, c := newTF(wd, module)
+		c.On("Init", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+
+		require.NoError(t, os.WriteFile(modFile, []byte(`# v2`), filePerms))
+		tf, c = newTF(wd, module)
+		c.On("Init", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+		c.AssertExpectations(t)
+	})
+
+	t.Run("init_env_changed", func(t *testing.T) {
+		wd := t.TempDir()
+		writeMain(t, wd, `module "test" {}`)
+		cliConfig := filepath.Join(t.TempDir(), "cts.tfrc")
+		require.NoError(t, os.WriteFile(cliConfig, []byte(`# v1`), filePerms))
+		env := map[string]string{cliConfigEnv: cliConfig}
+
+		tf, c := newTF(wd, "org/module/provider")
+		tf.initEnv = env
+		c.On("Init", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+
+		// CLI configuration changed
+		require.NoError(t, os.WriteFile(cliConfig, []byte(`# v2`), filePerms))
+		tf, c = newTF(wd, "org/module/provider")
+		tf.initEnv = env
+		c.On("Init", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+		c.AssertExpectations(t)
+
+		// plugin cache changed
+		env[pluginCacheDirEnv] = t.TempDir()
+		tf, c = newTF(wd, "org/module/provider")
+		tf.initEnv = env
+		c.On("Init", ctx).Return(nil).Once()
+		require.NoError(t, tf.init(ctx))
+		c.AssertExpectations(t)
+	})
+
+	t.Run("init_error", func(t *testing.T) {
+		wd := t.TempDir()
+		writeMain(t, wd, `module "test" {}`)
+
+		tf, c := newTF(wd, "org/module/provider")
+		c.On("Init", ctx).Return(errors.New("error on init()")).Once()
+		assert.Error(t, tf.init(ctx))
+		assert.NoFileExists(t, tf.initFingerprintPath())
+	})
+
+	t.Run("no_root_module", func(t *testing.T) {
+		wd := t.TempDir()
+
+		tf, c := newTF(wd, "org/module/provider")
+		c.On("Init", ctx).Return(nil).Twice()
+		require.NoError(t, tf.init(ctx))
+		tf.inited = false
+		require.NoError(t, tf.init(ctx))
+		c.AssertExpectations(t)
+	})
+}
+
+func TestInitEnv(t *testing.T) {
+	t.Setenv(cliConfigEnv, "/process.tfrc")
+	t.Setenv(pluginCacheDirEnv, "/process-cache")
+
+	env := initEnv(map[string]string{
+		pluginCacheDirEnv: "/task-cache",
+		"TF_LOG":          "DEBUG",
+	})
+	assert.Equal(t, map[string]string{
+		cliConfigEnv:      "/process.tfrc",
+		pluginCacheDirEnv: "/task-cache",
+	}, env)
+}
+
+func TestSetupPluginCacheDir(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled", func(t *testing.T) {
+		dir, err := setupPluginCacheDir("")
+		require.NoError(t, err)
+		assert.Empty(t, dir)
+	})
+
+	t.Run("creates_dir", func(t *testing.T) {
+		expected := filepath.Join(t.TempDir(), "plugin-cache")
+		dir, err := setupPluginCacheDir(expected)
+		require.NoError(t, err)
+		assert.Equal(t, expected, dir)
+		assert.DirExists(t, dir)
+	})
+}
+
+func TestProvidersCached(t *testing.T) {
+	t.Parallel()
+
+	lockFile := `
+provider "registry.terraform.io/hashicorp/local" {
+  version     = "2.2.3"
+  constraints = "~> 2.2"
+  hashes = [
+    "h1:abc=",
+  ]
+}
+`
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+
+	t.Run("cached", func(t *testing.T) {
+		wd, cache := t.TempDir(), t.TempDir()
+		require.NoError(t, os.WriteFile(
+			filepath.Join(wd, dependencyLockFilename), []byte(lockFile), 0644))
+		require.NoError(t, os.MkdirAll(filepath.Join(cache, "registry.terraform.io",
+			"hashicorp", "local", "2.2.3", platform), 0755))
+
+		assert.True(t, providersCached(wd, cache))
+	})
+
+	t.Run("not_cached", func(t *testing.T) {
+		wd, cache := t.TempDir(), t.TempDir()
+		require.NoError(t, os.WriteFile(
+			filepath.Join(wd, dependencyLockFilename), []byte(lockFile), 0644))
+		require.NoError(t, os.MkdirAll(filepath.Join(cache, "registry.terraform.io",
+			"hashicorp", "local", "2.2.2", platform), 0755))
+
+		assert.False(t, providersCached(wd, cache))
+	})
+
+	t.Run("no_lock_file", func(t *testing.T) {
+		assert.False(t, providersCached(t.TempDir(), t.TempDir()))
+	})
+
+	t.Run("no_providers", func(t *testing.T) {
+		wd := t.TempDir()
+		require.NoError(t, os.WriteFile(
+			filepath.Join(wd, dependencyLockFilename), []byte("# empty\n"), 0644))
+
+		assert.True(t, providersCached(wd, t.TempDir()))
+	})
+}
+
+// selectorClient is a mock client that can select the workspace without
+// initializing it
+type selectorClient struct {
+	*mocks.Client
+}
+
+func (c selectorClient) SelectWorkspace(ctx context.Context) error {
+	return c.Called(ctx).Error(0)
+}
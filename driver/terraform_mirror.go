@@ -0,0 +1,406 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	goVersion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	mirrorSubsystemName = "mirror"
+
+	// cliConfigEnv is the Terraform environment variable to configure the CLI
+	// configuration file
+	cliConfigEnv = "TF_CLI_CONFIG_FILE"
+
+	// cliConfigFilename is the Terraform CLI configuration file generated in
+	// the task's working directory when providers are installed from a mirror
+	cliConfigFilename = "cts.tfrc"
+
+	// defaultRegistryHost is the host of registry module sources that do not
+	// specify a host
+	defaultRegistryHost = "registry.terraform.io"
+
+	// cliConfigHeader is the header of the generated CLI configuration file
+	cliConfigHeader = "# This file is generated by Consul-Terraform-Sync.\n\n"
+
+	// mirrorModuleName is the name of the module block used to download a
+	// task's module when populating the mirror
+	mirrorModuleName = "mirror"
+)
+
+var (
+	// registrySourceRe matches registry module sources in the format
+	// [<host>/]<namespace>/<name>/<system>[//<subdir>]
+	registrySourceRe = regexp.MustCompile(`^(?:([0-9A-Za-z.-]+\.[A-Za-z]+(?::[0-9]+)?)/)?` +
+		`([0-9A-Za-z][0-9A-Za-z_-]*)/([0-9A-Za-z][0-9A-Za-z_-]*)/([0-9a-z]+)(?://(.+))?$`)
+
+	// unsafePathRe matches characters replaced in the mirror directory name of
+	// module sources that are not from a registry
+	unsafePathRe = regexp.MustCompile(`[^0-9A-Za-z._-]+`)
+)
+
+// mirroredModule is the location of a remote module source in the module
+// mirror
+type mirroredModule struct {
+	// path is the directory of the module package within the mirror. For
+	// registry modules, it contains a directory for each mirrored version.
+	path string
+
+	// subdir is the module's subdirectory within the package
+	subdir string
+
+	registry bool
+}
+
+// newMirroredModule returns the location of the module source in the mirror
+// directory. Returns false for local module sources, which are not mirrored.
+func newMirroredModule(mirrorDir, source string) (mirroredModule, bool) {
+	if isLocalModule(source) {
+		return mirroredModule{}, false
+	}
+
+	if m := registrySourceRe.FindStringSubmatch(source); m != nil {
+		host := m[1]
+		if host == "" {
+			host = defaultRegistryHost
+		}
+		return mirroredModule{
+			path:     filepath.Join(mirrorDir, host, m[2], m[3], m[4]),
+			subdir:   m[5],
+			registry: true,
+		}, true
+	}
+
+	name := strings.Trim(unsafePathRe.ReplaceAllString(source, "_"), "_")
+	return mirroredModule{path: filepath.Join(mirrorDir, name)}, true
+}
+
+// resolve returns the local path of the mirrored module. For registry
+// modules, the latest mirrored version that satisfies the version constraint
+// is used. An empty constraint allows any version.
+func (m mirroredModule) resolve(constraint string) (string, error) {
+	if !m.registry {
+		if _, err := os.Stat(m.path); err != nil {
+			return "", fmt.Errorf("module not found in mirror at %s", m.path)
+		}
+		return m.path, nil
+	}
+
+	var constraints goVersion.Constraints
+	if constraint != "" {
+		var err error
+		constraints, err = goVersion.NewConstraint(constraint)
+		if err != nil {
+			return "", fmt.Errorf("invalid module version %q: %s", constraint, err)
+		}
+	}
+
+	entries, err := ioutil.ReadDir(m.path)
+	if err != nil {
+		return "", fmt.Errorf("module not found in mirror at %s", m.path)
+	}
+
+	var latest *goVersion.Version
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		v, err := goVersion.NewVersion(e.Name())
+		if err != nil {
+			continue
+		}
+		if constraints != nil && !constraints.Check(v) {
+			continue
+		}
+		if latest == nil || v.GreaterThan(latest) {
+			latest = v
+		}
+	}
+	if latest == nil {
+		return "", fmt.Errorf("no version of the module in the mirror at %s "+
+			"satisfies the version %q", m.path, constraint)
+	}
+
+	return filepath.Join(m.path, latest.Original(), m.subdir), nil
+}
+
+// isLocalModule returns whether the module source is a path on the local
+// filesystem
+func isLocalModule(source string) bool {
+	return strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") ||
+		filepath.IsAbs(source)
+}
+
+// writeCLIConfig writes a Terraform CLI configuration file that installs
+// providers only from the provider mirrors. This replaces any CLI
+// configuration file of the environment for the task's Terraform commands.
+func writeCLIConfig(path, filesystemMirror, networkMirror string) error {
+	hclFile := hclwrite.NewEmptyFile()
+	installation := hclFile.Body().AppendNewBlock("provider_installation", nil).Body()
+	if filesystemMirror != "" {
+		dir, err := filepath.Abs(filesystemMirror)
+		if err != nil {
+			return err
+		}
+		installation.AppendNewBlock("filesystem_mirror", nil).Body().
+			SetAttributeValue("path", cty.StringVal(dir))
+	}
+	if networkMirror != "" {
+		installation.AppendNewBlock("network_mirror", nil).Body().
+			SetAttributeValue("url", cty.StringVal(networkMirror))
+	}
+
+	content := append([]byte(cliConfigHeader), hclwrite.Format(hclFile.Bytes())...)
+	return ioutil.WriteFile(path, content, filePerms)
+}
+
+// moduleManifest is the manifest of modules installed by Terraform in
+// .terraform/modules/modules.json
+type moduleManifest struct {
+	Modules []struct {
+		Key     string `json:"Key"`
+		Source  string `json:"Source"`
+		Version string `json:"Version"`
+		Dir     string `json:"Dir"`
+	} `json:"Modules"`
+}
+
+// PopulateMirror downloads the remote modules of the configured tasks and the
+// providers they require into the module and provider filesystem mirrors of
+// the Terraform driver configuration. The mirrors can then be copied to hosts
+// without internet access. The Terraform binary must already be installed.
+// Providers are mirrored for the platforms, or for the current platform if
+// none are provided.
+func PopulateMirror(ctx context.Context, conf *config.Config, platforms []string) error {
+	tfConf := conf.Driver.Terraform
+	if tfConf == nil {
+		return fmt.Errorf("populating the mirror requires the Terraform driver")
+	}
+
+	moduleDir := config.StringVal(tfConf.Mirror.ModuleDir)
+	providerDir := config.StringVal(tfConf.Mirror.FilesystemMirror)
+	if moduleDir == "" && providerDir == "" {
+		return fmt.Errorf("no module_dir or filesystem_mirror is configured " +
+			"for the Terraform driver mirror")
+	}
+
+	logger := logging.Global().Named(logSystemName).Named(mirrorSubsystemName)
+	binPath, err := filepath.Abs(filepath.Join(*tfConf.Path, *tfConf.BinaryName))
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for _, task := range *conf.Tasks {
+		source, version := config.StringVal(task.Module), config.StringVal(task.Version)
+		if seen[source+"@"+version] {
+			continue
+		}
+		seen[source+"@"+version] = true
+
+		logger.Info("mirroring module", "task_name", config.StringVal(task.Name),
+			"module", source, "version", version)
+		err := mirrorModule(ctx, binPath, tfConf, source, version, platforms)
+		if err != nil {
+			return fmt.Errorf("error mirroring module %q for task %q: %s",
+				source, config.StringVal(task.Name), err)
+		}
+	}
+	return nil
+}
+
+// mirrorModule downloads the module into the module mirror and mirrors the
+// providers required by the module
+func mirrorModule(ctx context.Context, binPath string, tfConf *config.TerraformConfig,
+	source, version string, platforms []string) error {
+
+	logger := logging.Global().Named(logSystemName).Named(mirrorSubsystemName)
+	moduleDir := config.StringVal(tfConf.Mirror.ModuleDir)
+	providerDir := config.StringVal(tfConf.Mirror.FilesystemMirror)
+
+	wd, err := os.MkdirTemp("", "cts-mirror-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(wd)
+
+	if isLocalModule(source) {
+		if source, err = filepath.Abs(source); err != nil {
+			return err
+		}
+	}
+	if err := writeMirrorRootModule(wd, tfConf.RequiredProviders, source, version); err != nil {
+		return err
+	}
+
+	tf, err := tfexec.NewTerraform(wd, binPath)
+	if err != nil {
+		return err
+	}
+	if err := tf.Get(ctx); err != nil {
+		return fmt.Errorf("unable to download module: %s", err)
+	}
+
+	if m, ok := newMirroredModule(moduleDir, source); ok && moduleDir != "" {
+		manifest, err := readModuleManifest(wd)
+		if err != nil {
+			return err
+		}
+
+		dst := m.path
+		for _, mod := range manifest.Modules {
+			switch {
+			case mod.Key == mirrorModuleName:
+				if m.registry {
+					dst = filepath.Join(m.path, mod.Version)
+				}
+			case strings.HasPrefix(mod.Key, mirrorModuleName+".") && !isLocalModule(mod.Source):
+				logger.Warn("module calls a remote module that is not mirrored, "+
+					"the module cannot be installed without internet access",
+					"module", source, "remote_module", mod.Source)
+			}
+		}
+
+		src := filepath.Join(wd, ".terraform", "modules", mirrorModuleName)
+		if err := os.RemoveAll(dst); err != nil {
+			return err
+		}
+		if err := copyDir(src, dst); err != nil {
+			return fmt.Errorf("unable to copy module to the mirror: %s", err)
+		}
+		logger.Info("mirrored module", "module", source, "mirror_path", dst)
+	}
+
+	if providerDir != "" {
+		dir, err := filepath.Abs(providerDir)
+		if err != nil {
+			return err
+		}
+		args := []string{"providers", "mirror"}
+		for _, p := range platforms {
+			args = append(args, "-platform="+p)
+		}
+		args = append(args, dir)
+
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, binPath, args...)
+		cmd.Dir = wd
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("unable to mirror providers: %s: %s", err,
+				strings.TrimSpace(stderr.String()))
+		}
+		logger.Info("mirrored providers", "module", source, "mirror_path", dir)
+	}
+
+	return nil
+}
+
+// writeMirrorRootModule writes a root module that calls the module with the
+// required providers to the directory
+func writeMirrorRootModule(dir string, requiredProviders map[string]interface{},
+	source, version string) error {
+
+	module := map[string]interface{}{"source": source}
+	if version != "" && !isLocalModule(source) {
+		module["version"] = version
+	}
+
+	root := map[string]interface{}{
+		"module": map[string]interface{}{mirrorModuleName: module},
+	}
+	if len(requiredProviders) > 0 {
+		root["terraform"] = map[string]interface{}{
+			"required_providers": requiredProviders,
+		}
+	}
+
+	content, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "main.tf.json"), content, filePerms)
+}
+
+// readModuleManifest reads the manifest of modules installed in the directory
+func readModuleManifest(dir string) (*moduleManifest, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, ".terraform", "modules", "modules.json"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read installed modules: %s", err)
+	}
+
+	var manifest moduleManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("unable to read installed modules: %s", err)
+	}
+	sort.Slice(manifest.Modules, func(i, j int) bool {
+		return manifest.Modules[i].Key < manifest.Modules[j].Key
+	})
+	return &manifest, nil
+}
+
+// copyDir recursively copies the directory, excluding version control
+// metadata
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, workingDirPerms)
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			// skip symlinks and other special files
+			return nil
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
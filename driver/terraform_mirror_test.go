@@ -0,0 +1,169 @@
+package driver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMirroredModule(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		source   string
+		expected mirroredModule
+		ok       bool
+	}{
+		{
+			"registry",
+			"findkim/print/cts",
+			mirroredModule{
+				path:     filepath.Join("mirror", "registry.terraform.io", "findkim", "print", "cts"),
+				registry: true,
+			},
+			true,
+		},
+		{
+			"registry_host_subdir",
+			"app.terraform.io/org/module/aws//modules/sub",
+			mirroredModule{
+				path:     filepath.Join("mirror", "app.terraform.io", "org", "module", "aws"),
+				subdir:   "modules/sub",
+				registry: true,
+			},
+			true,
+		},
+		{
+			"git",
+			"git::https://example.com/module.git?ref=v1.0.0",
+			mirroredModule{
+				path: filepath.Join("mirror", "git_https_example.com_module.git_ref_v1.0.0"),
+			},
+			true,
+		},
+		{
+			"local",
+			"./modules/print",
+			mirroredModule{},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			m, ok := newMirroredModule("mirror", tc.source)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, m)
+		})
+	}
+}
+
+func TestMirroredModule_Resolve(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m, ok := newMirroredModule(dir, "findkim/print/cts//sub")
+	require.True(t, ok)
+	for _, v := range []string{"0.1.0", "0.2.0", "1.0.0", "not-a-version"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(m.path, v), workingDirPerms))
+	}
+
+	cases := []struct {
+		name       string
+		constraint string
+		expected   string
+	}{
+		{
+			"latest",
+			"",
+			filepath.Join(m.path, "1.0.0", "sub"),
+		},
+		{
+			"exact",
+			"0.1.0",
+			filepath.Join(m.path, "0.1.0", "sub"),
+		},
+		{
+			"constraint",
+			"~> 0.1",
+			filepath.Join(m.path, "0.2.0", "sub"),
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			path, err := m.resolve(tc.constraint)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, path)
+		})
+	}
+
+	t.Run("no_matching_version", func(t *testing.T) {
+		_, err := m.resolve(">= 2.0.0")
+		assert.Error(t, err)
+	})
+
+	t.Run("not_mirrored", func(t *testing.T) {
+		m, _ := newMirroredModule(dir, "findkim/other/cts")
+		_, err := m.resolve("")
+		assert.Error(t, err)
+
+		m, _ = newMirroredModule(dir, "git::https://example.com/module.git")
+		_, err = m.resolve("")
+		assert.Error(t, err)
+	})
+}
+
+func TestWriteCLIConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	providerDir := filepath.Join(dir, "providers")
+	path := filepath.Join(dir, cliConfigFilename)
+
+	err := writeCLIConfig(path, providerDir, "https://mirror.example.com/providers/")
+	require.NoError(t, err)
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	expected := cliConfigHeader + fmt.Sprintf(`provider_installation {
+  filesystem_mirror {
+    path = %q
+  }
+  network_mirror {
+    url = "https://mirror.example.com/providers/"
+  }
+}
+`, providerDir)
+	assert.Equal(t, expected, string(content))
+}
+
+func TestCopyDir(t *testing.T) {
+	t.Parallel()
+
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), workingDirPerms))
+	require.NoError(t, os.MkdirAll(filepath.Join(src, ".git"), workingDirPerms))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "main.tf"), []byte("main"), filePerms))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "sub", "sub.tf"), []byte("sub"), filePerms))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, ".git", "HEAD"), []byte("ref"), filePerms))
+
+	dst := filepath.Join(t.TempDir(), "module")
+	require.NoError(t, copyDir(src, dst))
+
+	content, err := ioutil.ReadFile(filepath.Join(dst, "main.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, "main", string(content))
+
+	content, err = ioutil.ReadFile(filepath.Join(dst, "sub", "sub.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, "sub", string(content))
+
+	assert.NoDirExists(t, filepath.Join(dst, ".git"))
+}
@@ -0,0 +1,110 @@
+package driver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// outputSchema is the part of the Terraform configuration schema to find the
+// sensitive argument of the output blocks of a module
+var outputSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "output", LabelNames: []string{"name"}},
+	},
+}
+
+var outputSensitiveSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{{Name: "sensitive"}},
+}
+
+// sensitiveModuleOutputs returns the names of the outputs that are marked
+// sensitive by the task's module. The module is found in the manifest of the
+// modules installed in the working directory. The values of the module's
+// outputs are read from a single root module output, which loses the
+// sensitive marking of the individual outputs, so the module configuration is
+// the source of which outputs are sensitive.
+//
+// An output is treated as sensitive if its sensitive argument cannot be
+// evaluated, so that outputs are only exposed if they are known to not be
+// sensitive.
+func sensitiveModuleOutputs(workingDir, moduleKey string) (map[string]bool, error) {
+	manifest, err := readModuleManifest(workingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var moduleDir string
+	for _, m := range manifest.Modules {
+		if m.Key == moduleKey {
+			moduleDir = m.Dir
+			break
+		}
+	}
+	if moduleDir == "" {
+		return nil, fmt.Errorf("module %q is not installed", moduleKey)
+	}
+	if !filepath.IsAbs(moduleDir) {
+		moduleDir = filepath.Join(workingDir, moduleDir)
+	}
+
+	files, err := ioutil.ReadDir(moduleDir)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := hclparse.NewParser()
+	sensitive := make(map[string]bool)
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(moduleDir, f.Name())
+		var file *hcl.File
+		var diags hcl.Diagnostics
+		switch {
+		case strings.HasSuffix(f.Name(), ".tf"):
+			file, diags = parser.ParseHCLFile(path)
+		case strings.HasSuffix(f.Name(), ".tf.json"):
+			file, diags = parser.ParseJSONFile(path)
+		default:
+			continue
+		}
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		content, _, diags := file.Body.PartialContent(outputSchema)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		for _, block := range content.Blocks {
+			name := block.Labels[0]
+			attrs, _, diags := block.Body.PartialContent(outputSensitiveSchema)
+			if diags.HasErrors() {
+				sensitive[name] = true
+				continue
+			}
+
+			attr, ok := attrs.Attributes["sensitive"]
+			if !ok {
+				continue
+			}
+
+			val, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() || val.Type() != cty.Bool || !val.IsKnown() ||
+				val.IsNull() || val.True() {
+				sensitive[name] = true
+			}
+		}
+	}
+
+	return sensitive, nil
+}
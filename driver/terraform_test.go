@@ -2,7 +2,12 @@ package driver
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -15,12 +20,14 @@ import (
 	mocksTmpl "github.com/hashicorp/consul-terraform-sync/mocks/templates"
 	"github.com/hashicorp/consul-terraform-sync/templates"
 	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
 	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/notifier"
 	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
 	"github.com/hashicorp/consul-terraform-sync/testutils"
 	"github.com/hashicorp/go-uuid"
 	goVersion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/terraform-exec/tfexec"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -117,6 +124,12 @@ func TestTerraform_Version(t *testing.T) {
 	var tf Terraform
 	s := tf.Version()
 	assert.Equal(t, "1.2.0", s)
+
+	// task configured with its own Terraform version
+	tf.version, err = goVersion.NewVersion("1.1.9")
+	require.NoError(t, err)
+	s = tf.Version()
+	assert.Equal(t, "1.1.9", s)
 }
 
 func TestInspectTask(t *testing.T) {
@@ -761,6 +774,32 @@ func TestDisabledTask(t *testing.T) {
 	})
 }
 
+func TestTFVarsJSONRenderer(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), tftmpl.TFVarsJSONFilename)
+	r := tfvarsJSONRenderer{hcat.NewFileRenderer(hcat.FileRendererInput{
+		Path:  path,
+		Perms: filePerms,
+	})}
+
+	result, err := r.Render([]byte(`services = {
+  "api" = {
+    port = 8080
+  }
+}
+`))
+	require.NoError(t, err)
+	assert.True(t, result.DidRender)
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"services": {"api": {"port": 8080}}}`, string(content))
+
+	_, err = r.Render([]byte(`services = {`))
+	assert.Error(t, err)
+}
+
 func TestInitTask(t *testing.T) {
 	t.Parallel()
 
@@ -852,6 +891,226 @@ func TestTerraform_DestroyTask(t *testing.T) {
 	tf.DestroyTask(ctx)
 }
 
+func TestTerraform_InspectDestroyResources(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy_path", func(t *testing.T) {
+		c := new(mocks.Client)
+		c.On("PlanDestroy", ctx).Return(true, nil).Once()
+		c.On("SetStdout", mock.Anything).Twice()
+
+		tf := &Terraform{
+			task:   &Task{name: "task", logger: logging.NewNullLogger()},
+			client: c,
+			logger: logging.NewNullLogger(),
+		}
+
+		plan, err := tf.InspectDestroyResources(ctx)
+		assert.NoError(t, err)
+		assert.True(t, plan.ChangesPresent)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := new(mocks.Client)
+		c.On("PlanDestroy", ctx).Return(false, errors.New("plan error")).Once()
+		c.On("SetStdout", mock.Anything).Twice()
+
+		tf := &Terraform{
+			task:   &Task{name: "task", logger: logging.NewNullLogger()},
+			client: c,
+			logger: logging.NewNullLogger(),
+		}
+
+		_, err := tf.InspectDestroyResources(ctx)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "plan error")
+	})
+}
+
+func TestTerraform_DestroyResources(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy_path", func(t *testing.T) {
+		workingDir := filepath.Join(t.TempDir(), "task")
+		require.NoError(t, os.MkdirAll(workingDir, 0755))
+
+		c := new(mocks.Client)
+		c.On("Destroy", ctx).Return(nil).Once()
+
+		tf := &Terraform{
+			task: &Task{name: "task", workingDir: workingDir,
+				logger: logging.NewNullLogger()},
+			client: c,
+			logger: logging.NewNullLogger(),
+		}
+
+		err := tf.DestroyResources(ctx)
+		assert.NoError(t, err)
+		c.AssertExpectations(t)
+
+		_, err = os.Stat(workingDir)
+		assert.True(t, os.IsNotExist(err), "working directory should be removed")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		workingDir := filepath.Join(t.TempDir(), "task")
+		require.NoError(t, os.MkdirAll(workingDir, 0755))
+
+		c := new(mocks.Client)
+		c.On("Destroy", ctx).Return(errors.New("destroy error")).Once()
+
+		tf := &Terraform{
+			task: &Task{name: "task", workingDir: workingDir,
+				logger: logging.NewNullLogger()},
+			client: c,
+			logger: logging.NewNullLogger(),
+		}
+
+		err := tf.DestroyResources(ctx)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "destroy error")
+
+		_, err = os.Stat(workingDir)
+		assert.NoError(t, err, "working directory should not be removed on error")
+	})
+}
+
+func TestTerraform_Outputs(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name      string
+		outputs   map[string]tfexec.OutputMeta
+		outputErr error
+		moduleKey string
+		expected  map[string]json.RawMessage
+		expectErr bool
+	}{
+		{
+			name: "happy_path",
+			outputs: map[string]tfexec.OutputMeta{
+				tftmpl.ModuleOutputsName: {
+					Sensitive: true,
+					Value:     json.RawMessage(`{"id":"abc","ports":[80,443]}`),
+				},
+			},
+			expected: map[string]json.RawMessage{
+				"id":    json.RawMessage(`"abc"`),
+				"ports": json.RawMessage(`[80,443]`),
+			},
+		},
+		{
+			name: "sensitive_outputs",
+			outputs: map[string]tfexec.OutputMeta{
+				tftmpl.ModuleOutputsName: {
+					Sensitive: true,
+					Value: json.RawMessage(
+						`{"id":"abc","password":"secret","token":"secret"}`),
+				},
+			},
+			expected: map[string]json.RawMessage{
+				"id": json.RawMessage(`"abc"`),
+			},
+		},
+		{
+			name: "module_not_installed",
+			outputs: map[string]tfexec.OutputMeta{
+				tftmpl.ModuleOutputsName: {
+					Sensitive: true,
+					Value:     json.RawMessage(`{"id":"abc"}`),
+				},
+			},
+			moduleKey: "other",
+			expectErr: true,
+		},
+		{
+			name:     "no_outputs",
+			outputs:  map[string]tfexec.OutputMeta{},
+			expected: map[string]json.RawMessage{},
+		},
+		{
+			name: "null_outputs",
+			outputs: map[string]tfexec.OutputMeta{
+				tftmpl.ModuleOutputsName: {Value: json.RawMessage(`null`)},
+			},
+			expected: map[string]json.RawMessage{},
+		},
+		{
+			name:      "output_error",
+			outputErr: errors.New("output error"),
+			expectErr: true,
+		},
+		{
+			name: "decode_error",
+			outputs: map[string]tfexec.OutputMeta{
+				tftmpl.ModuleOutputsName: {Value: json.RawMessage(`"string"`)},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := new(mocks.Client)
+			c.On("Output", ctx).Return(tc.outputs, tc.outputErr).Once()
+
+			moduleKey := tc.moduleKey
+			if moduleKey == "" {
+				moduleKey = "task"
+			}
+			workingDir := t.TempDir()
+			writeTestModule(t, workingDir, moduleKey)
+
+			tf := &Terraform{
+				task: &Task{
+					name:       "task",
+					workingDir: workingDir,
+					logger:     logging.NewNullLogger(),
+				},
+				client: c,
+				logger: logging.NewNullLogger(),
+			}
+
+			actual, err := tf.Outputs(ctx)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+			c.AssertExpectations(t)
+		})
+	}
+}
+
+// writeTestModule writes a module with outputs and the manifest of installed
+// modules with the module installed for the module key
+func writeTestModule(t *testing.T, workingDir, moduleKey string) {
+	moduleDir := filepath.Join(workingDir, "module")
+	require.NoError(t, os.MkdirAll(moduleDir, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(moduleDir, "outputs.tf"),
+		[]byte(`
+output "id" {
+  value = "abc"
+}
+
+output "password" {
+  value     = var.password
+  sensitive = true
+}
+`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(moduleDir, "outputs.tf.json"),
+		[]byte(`{"output": {"token": {"value": "secret", "sensitive": true}}}`), 0644))
+
+	manifestDir := filepath.Join(workingDir, ".terraform", "modules")
+	require.NoError(t, os.MkdirAll(manifestDir, 0755))
+	manifest := fmt.Sprintf(`{"Modules": [{"Key": "", "Source": "", "Dir": "."}, `+
+		`{"Key": %q, "Source": "./module", "Dir": "module"}]}`, moduleKey)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(manifestDir, "modules.json"),
+		[]byte(manifest), 0644))
+}
+
 func TestTerraform_TemplateIDs(t *testing.T) {
 	var tmpl mocksTmpl.Template
 	tf := Terraform{
@@ -930,6 +1189,20 @@ func TestTerraform_countTmplFunc(t *testing.T) {
 				condition: &config.ConsulKVConditionConfig{},
 			},
 		},
+		{
+			"condition: http",
+			1,
+			&Task{
+				condition: &config.HTTPConditionConfig{},
+			},
+		},
+		{
+			"condition: webhook",
+			1,
+			&Task{
+				condition: &config.WebhookConditionConfig{},
+			},
+		},
 		{
 			"condition: services-regex",
 			1,
@@ -952,6 +1225,41 @@ func TestTerraform_countTmplFunc(t *testing.T) {
 				},
 			},
 		},
+		{
+			"condition: services-names datacenters",
+			6,
+			&Task{
+				condition: &config.ServicesConditionConfig{
+					ServicesMonitorConfig: config.ServicesMonitorConfig{
+						Names:       []string{"api", "db", "web"},
+						Datacenters: []string{"dc1", "dc2"},
+					},
+				},
+			},
+		},
+		{
+			"condition: services-regex all datacenters",
+			2,
+			&Task{
+				condition: &config.ServicesConditionConfig{
+					ServicesMonitorConfig: config.ServicesMonitorConfig{
+						Regexp:      config.String(".*"),
+						Datacenters: []string{config.AllDatacenters},
+					},
+				},
+			},
+		},
+		{
+			"condition: catalog-services datacenters",
+			2,
+			&Task{
+				condition: &config.CatalogServicesConditionConfig{
+					CatalogServicesMonitorConfig: config.CatalogServicesMonitorConfig{
+						Datacenters: []string{"dc1", "dc2"},
+					},
+				},
+			},
+		},
 		{
 			"module_input: consul-kv",
 			1,
@@ -961,6 +1269,24 @@ func TestTerraform_countTmplFunc(t *testing.T) {
 				},
 			},
 		},
+		{
+			"module_input: http",
+			1,
+			&Task{
+				moduleInputs: config.ModuleInputConfigs{
+					&config.HTTPModuleInputConfig{},
+				},
+			},
+		},
+		{
+			"module_input: vault",
+			1,
+			&Task{
+				moduleInputs: config.ModuleInputConfigs{
+					&config.VaultModuleInputConfig{},
+				},
+			},
+		},
 		{
 			"module_input: services-regex",
 			1,
@@ -1002,6 +1328,31 @@ func TestTerraform_countTmplFunc(t *testing.T) {
 				},
 			},
 		},
+		{
+			"labelled module_inputs",
+			4,
+			&Task{
+				condition: &config.ServicesConditionConfig{
+					ServicesMonitorConfig: config.ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				moduleInputs: config.ModuleInputConfigs{
+					&config.ServicesModuleInputConfig{
+						ServicesMonitorConfig: config.ServicesMonitorConfig{
+							Names: []string{"db", "web"},
+						},
+						Alias: config.String("backends"),
+					},
+					&config.ServicesModuleInputConfig{
+						ServicesMonitorConfig: config.ServicesMonitorConfig{
+							Regexp: config.String(".*"),
+						},
+						Alias: config.String("all"),
+					},
+				},
+			},
+		},
 		{
 			"combination w services",
 			4,
@@ -1068,6 +1419,40 @@ func TestTerraform_setNotifier(t *testing.T) {
 			},
 			&notifier.Services{},
 		},
+		{
+			"condition: http",
+			&Task{
+				condition: &config.HTTPConditionConfig{},
+			},
+			&notifier.DependencyType{},
+		},
+		{
+			"condition: webhook",
+			&Task{
+				condition: &config.WebhookConditionConfig{},
+			},
+			&notifier.DependencyType{},
+		},
+		{
+			"module_input: vault",
+			&Task{
+				condition: &config.ConsulKVConditionConfig{},
+				moduleInputs: config.ModuleInputConfigs{
+					&config.VaultModuleInputConfig{},
+				},
+			},
+			&notifier.VaultSecret{},
+		},
+		{
+			"module_input: vault with schedule",
+			&Task{
+				condition: &config.ScheduleConditionConfig{},
+				moduleInputs: config.ModuleInputConfigs{
+					&config.VaultModuleInputConfig{},
+				},
+			},
+			&notifier.SuppressNotification{},
+		},
 	}
 
 	for _, tc := range cases {
@@ -1124,6 +1509,26 @@ func TestGetServicesMetaData(t *testing.T) {
 				return sm
 			},
 		},
+		{
+			"meta-data configured in module_input with labelled module_input",
+			&Task{
+				moduleInputs: config.ModuleInputConfigs{
+					&config.ServicesModuleInputConfig{
+						Alias: config.String("backends"),
+					},
+					&config.ServicesModuleInputConfig{
+						ServicesMonitorConfig: config.ServicesMonitorConfig{
+							CTSUserDefinedMeta: meta,
+						},
+					},
+				},
+			},
+			func() *tmplfunc.ServicesMeta {
+				sm := &tmplfunc.ServicesMeta{}
+				_ = sm.SetMeta(meta)
+				return sm
+			},
+		},
 		{
 			"meta-data configured in service block",
 			&Task{
@@ -0,0 +1,270 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/go-rootcerts"
+	"github.com/hashicorp/hcat"
+)
+
+const (
+	webhookSubsystemName = "webhook"
+
+	// WebhookSignatureHeader is the header of the webhook request that contains
+	// the HMAC-SHA256 signature of the request body, formatted as
+	// "sha256=<hex digest>"
+	WebhookSignatureHeader = "X-CTS-Signature"
+
+	// webhookResponseOutput is the name of the output that contains the
+	// response body from the latest successful run of the task
+	webhookResponseOutput = "response"
+
+	// webhookMaxResponseSize limits how much of the response body is read.
+	// Larger response bodies are truncated.
+	webhookMaxResponseSize = 1 << 20
+)
+
+var _ Driver = (*Webhook)(nil)
+
+// Webhook is a CTS driver that sends a POST request to a user-configured URL
+// for a task. The request body is JSON containing the task's information and
+// its rendered Consul data. A request that does not receive a 2xx response
+// fails the task run.
+type Webhook struct {
+	renderedDriver
+
+	url     string
+	headers map[string]string
+	secret  string
+	timeout time.Duration
+	client  *http.Client
+	retry   retry.Retry
+}
+
+// WebhookConfig configures the webhook driver
+type WebhookConfig struct {
+	Task       *Task
+	URL        string
+	Headers    map[string]string
+	Secret     string
+	TLS        *config.TLSConfig
+	Timeout    time.Duration
+	MaxRetries int
+	Watcher    templates.Watcher
+}
+
+// webhookPayload is the body of the request sent by the webhook driver
+type webhookPayload struct {
+	Task      webhookTask     `json:"task"`
+	Data      json.RawMessage `json:"data"`
+	Timestamp string          `json:"timestamp"`
+}
+
+// webhookTask is the task information included in the webhook request
+type webhookTask struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Module      string `json:"module"`
+}
+
+// NewWebhook configures and initializes a new webhook driver for a task.
+func NewWebhook(config *WebhookConfig) (*Webhook, error) {
+	task := config.Task
+	wd := task.WorkingDir()
+	logger := logging.Global().Named(logSystemName).Named(webhookSubsystemName)
+	if _, err := os.Stat(wd); os.IsNotExist(err) {
+		if err := os.MkdirAll(wd, workingDirPerms); err != nil {
+			logger.Error("error creating task work directory", "error", err)
+			return nil, err
+		}
+	}
+
+	client, err := newWebhookHTTPClient(config.TLS)
+	if err != nil {
+		logger.Error("error configuring TLS for webhook", taskNameLogKey,
+			task.Name(), "error", err)
+		return nil, err
+	}
+
+	headers := make(map[string]string, len(config.Headers))
+	for k, v := range config.Headers {
+		headers[k] = v
+	}
+
+	w := &Webhook{
+		renderedDriver: renderedDriver{
+			task:       task,
+			outputName: webhookResponseOutput,
+			resolver:   hcat.NewResolver(),
+			watcher:    config.Watcher,
+			fileReader: ioutil.ReadFile,
+			logger:     logger,
+		},
+		url:     config.URL,
+		headers: headers,
+		secret:  config.Secret,
+		timeout: config.Timeout,
+		client:  client,
+		retry:   retry.NewRetry(config.MaxRetries, time.Now().UnixNano()),
+	}
+	w.runner = w
+	return w, nil
+}
+
+// describe returns the URL that the request is sent to for the task
+func (w *Webhook) describe() string {
+	return fmt.Sprintf("A POST request would be sent to '%s'", w.url)
+}
+
+// run sends the request with the rendered Consul data, retrying on failure.
+// The response body is returned on success and is included in the error for
+// responses with a non-2xx status code.
+//
+// The driver retries the request up to the configured max_retries, so the
+// error after the final attempt is not retryable. This keeps the task run
+// from retrying the request again and multiplying the number of requests.
+func (w *Webhook) run(ctx context.Context, input []byte) (string, error) {
+	taskName := w.task.Name()
+
+	body, err := json.Marshal(webhookPayload{
+		Task: webhookTask{
+			Name:        taskName,
+			Description: w.task.Description(),
+			Module:      w.task.Module(),
+		},
+		Data:      input,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error webhook for '%s': unable to create "+
+			"request body: %s", taskName, err)
+	}
+
+	var resp []byte
+	desc := fmt.Sprintf("webhook for task '%s'", taskName)
+	err = w.retry.Do(ctx, func(ctx context.Context) error {
+		resp, err = w.send(ctx, body)
+		return err
+	}, desc)
+	if err != nil {
+		return "", &retry.NonRetryableError{
+			Err: fmt.Errorf("error webhook for '%s': %s", taskName, err),
+		}
+	}
+
+	return string(resp), nil
+}
+
+// send makes a single request to the URL. Errors from responses with a 4xx
+// status code are not retryable.
+func (w *Webhook) send(ctx context.Context, body []byte) ([]byte, error) {
+	if w.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url,
+		bytes.NewReader(body))
+	if err != nil {
+		return nil, &retry.NonRetryableError{Err: err}
+	}
+	for k, v := range w.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.secret != "" {
+		req.Header.Set(WebhookSignatureHeader, WebhookSignature(w.secret, body))
+	}
+
+	w.logger.Trace("webhook", taskNameLogKey, w.task.Name(), "url", w.url)
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(
+		io.LimitReader(resp.Body, webhookMaxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response: %s", err)
+	}
+	if len(respBody) > webhookMaxResponseSize {
+		w.logger.Debug("truncating webhook response", taskNameLogKey,
+			w.task.Name(), "max_size", webhookMaxResponseSize)
+		respBody = respBody[:webhookMaxResponseSize]
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err = fmt.Errorf("request failed with status %d: %s",
+			resp.StatusCode, bytes.TrimSpace(respBody))
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+			return nil, &retry.NonRetryableError{Err: err}
+		}
+		return nil, err
+	}
+
+	return respBody, nil
+}
+
+// WebhookSignature returns the value of the signature header for a webhook
+// request body signed with the secret
+func WebhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// newWebhookHTTPClient returns an HTTP client configured with the TLS
+// configuration for the webhook URL
+func newWebhookHTTPClient(tc *config.TLSConfig) (*http.Client, error) {
+	tlsClientConfig := &tls.Config{}
+
+	if tc != nil && config.BoolVal(tc.Enabled) {
+		// InsecureSkipVerify will always be the opposite of Verify
+		tlsClientConfig.InsecureSkipVerify = !config.BoolVal(tc.Verify)
+		tlsClientConfig.ServerName = config.StringVal(tc.ServerName)
+
+		cert, key := config.StringVal(tc.Cert), config.StringVal(tc.Key)
+		if cert != "" && key != "" {
+			tlsCert, err := tls.LoadX509KeyPair(cert, key)
+			if err != nil {
+				return nil, err
+			}
+			tlsClientConfig.Certificates = []tls.Certificate{tlsCert}
+		} else if cert != "" || key != "" {
+			return nil, fmt.Errorf("both client cert and client key must be provided")
+		}
+
+		caCert, caPath := config.StringVal(tc.CACert), config.StringVal(tc.CAPath)
+		if caCert != "" || caPath != "" {
+			rootConfig := &rootcerts.Config{
+				CAFile: caCert,
+				CAPath: caPath,
+			}
+			if err := rootcerts.ConfigureTLS(tlsClientConfig, rootConfig); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsClientConfig
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,253 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	mocksTmpl "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_ApplyTask(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("happy_path", func(t *testing.T) {
+		var payload webhookPayload
+		var header http.Header
+		var signatureValid bool
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(body, &payload))
+			header = r.Header
+			signatureValid = r.Header.Get(WebhookSignatureHeader) ==
+				WebhookSignature("secret", body)
+			w.Write([]byte("deployed"))
+		}))
+		defer ts.Close()
+
+		wh := newTestWebhook(t, ts.URL)
+		wh.secret = "secret"
+		wh.headers = map[string]string{"Authorization": "Bearer token"}
+
+		err := wh.ApplyTask(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, "test", payload.Task.Name)
+		assert.Contains(t, string(payload.Data), `"services":`)
+		assert.NotEmpty(t, payload.Timestamp)
+		assert.Equal(t, "application/json", header.Get("Content-Type"))
+		assert.Equal(t, "Bearer token", header.Get("Authorization"))
+		assert.True(t, signatureValid)
+
+		outputs, err := wh.Outputs(ctx)
+		require.NoError(t, err)
+		assert.JSONEq(t, `"deployed"`, string(outputs[webhookResponseOutput]))
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		var signature string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			signature = r.Header.Get(WebhookSignatureHeader)
+		}))
+		defer ts.Close()
+
+		wh := newTestWebhook(t, ts.URL)
+		err := wh.ApplyTask(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, signature)
+	})
+
+	t.Run("retry_server_error", func(t *testing.T) {
+		var count int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&count, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer ts.Close()
+
+		wh := newTestWebhook(t, ts.URL)
+		wh.retry = retry.NewTestRetry(1)
+
+		err := wh.ApplyTask(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&count))
+	})
+
+	t.Run("no_retry_client_error", func(t *testing.T) {
+		var count int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&count, 1)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("invalid payload"))
+		}))
+		defer ts.Close()
+
+		wh := newTestWebhook(t, ts.URL)
+		wh.retry = retry.NewTestRetry(2)
+
+		err := wh.ApplyTask(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 400")
+		assert.Contains(t, err.Error(), "invalid payload")
+		assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+
+		outputs, err := wh.Outputs(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, outputs)
+	})
+
+	t.Run("not_retried_by_task_run", func(t *testing.T) {
+		var count int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&count, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer ts.Close()
+
+		wh := newTestWebhook(t, ts.URL)
+		wh.retry = retry.NewTestRetry(2)
+
+		// the task run retries applying the task, which should not retry the
+		// requests that the driver already retried
+		err := retry.NewTestRetry(2).Do(ctx, wh.ApplyTask, "test")
+		require.Error(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&count))
+	})
+
+	t.Run("truncate_response", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write(bytes.Repeat([]byte("a"), webhookMaxResponseSize+10))
+		}))
+		defer ts.Close()
+
+		wh := newTestWebhook(t, ts.URL)
+		err := wh.ApplyTask(ctx)
+		require.NoError(t, err)
+
+		outputs, err := wh.Outputs(ctx)
+		require.NoError(t, err)
+		var resp string
+		require.NoError(t, json.Unmarshal(outputs[webhookResponseOutput], &resp))
+		assert.Len(t, resp, webhookMaxResponseSize)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		done := make(chan struct{})
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-done
+		}))
+		defer ts.Close()
+		defer close(done)
+
+		wh := newTestWebhook(t, ts.URL)
+		wh.timeout = 50 * time.Millisecond
+
+		err := wh.ApplyTask(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer ts.Close()
+
+		// self-signed certificate is rejected by default
+		wh := newTestWebhook(t, ts.URL)
+		err := wh.ApplyTask(ctx)
+		assert.Error(t, err)
+
+		tlsConf := &config.TLSConfig{Verify: config.Bool(false)}
+		tlsConf.Finalize()
+		wh.client, err = newWebhookHTTPClient(tlsConf)
+		require.NoError(t, err)
+		err = wh.ApplyTask(ctx)
+		assert.NoError(t, err)
+	})
+}
+
+func TestWebhook_InspectTask(t *testing.T) {
+	t.Parallel()
+
+	wh := newTestWebhook(t, "https://example.com/hook")
+	w := new(mocksTmpl.Watcher)
+	w.On("Deregister", wh.template).Return().Once()
+	wh.watcher = w
+
+	plan, err := wh.InspectTask(context.Background())
+	require.NoError(t, err)
+	assert.True(t, plan.ChangesPresent)
+	assert.Contains(t, plan.Plan, "https://example.com/hook")
+	assert.Contains(t, plan.Plan, `"services": {`)
+	w.AssertExpectations(t)
+}
+
+func TestWebhookSignature(t *testing.T) {
+	t.Parallel()
+
+	// expected value generated with:
+	// echo -n '{"task":{}}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"sha256=d68c5827c5950f6e72f51f5c8e9112ac48f957672e62fa448eb8d44e2e1bbfdf",
+		WebhookSignature("secret", []byte(`{"task":{}}`)))
+}
+
+func TestNewWebhookHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		c, err := newWebhookHTTPClient(nil)
+		require.NoError(t, err)
+		tr := c.Transport.(*http.Transport)
+		assert.False(t, tr.TLSClientConfig.InsecureSkipVerify)
+	})
+
+	t.Run("verify_false", func(t *testing.T) {
+		tlsConf := &config.TLSConfig{
+			Verify:     config.Bool(false),
+			ServerName: config.String("example.com"),
+		}
+		tlsConf.Finalize()
+		c, err := newWebhookHTTPClient(tlsConf)
+		require.NoError(t, err)
+		tr := c.Transport.(*http.Transport)
+		assert.True(t, tr.TLSClientConfig.InsecureSkipVerify)
+		assert.Equal(t, "example.com", tr.TLSClientConfig.ServerName)
+	})
+
+	t.Run("cert_without_key", func(t *testing.T) {
+		tlsConf := &config.TLSConfig{Cert: config.String("cert.pem")}
+		tlsConf.Finalize()
+		_, err := newWebhookHTTPClient(tlsConf)
+		assert.Error(t, err)
+	})
+}
+
+// newTestWebhook returns a webhook driver for an enabled task with rendered
+// data written to the task's working directory
+func newTestWebhook(t *testing.T, url string) *Webhook {
+	client, err := newWebhookHTTPClient(nil)
+	require.NoError(t, err)
+
+	wh := &Webhook{
+		url:     url,
+		headers: map[string]string{},
+		timeout: time.Second,
+		client:  client,
+		retry:   retry.NewTestRetry(0),
+	}
+	setupTestRenderedDriver(t, &wh.renderedDriver, webhookResponseOutput)
+	wh.runner = wh
+	return wh
+}
@@ -0,0 +1,138 @@
+/*
+Package selector provides a minimal JSONPath-like selector to extract a value
+from decoded JSON.
+
+A selector is an optional root "$" followed by any number of steps:
+  - ".key" selects the value of an object by key
+  - "[n]" selects the nth element of an array
+  - ["key"] or ['key'] selects the value of an object by a key that contains
+    characters that are not allowed in the dot notation
+
+Examples: "$.data.prefixes", "items[0].name", `$["ip-ranges"][1]`
+*/
+package selector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// step is a single step of a selector that either selects an object key or
+// an array index
+type step struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// Selector is a parsed selector that can be applied to decoded JSON.
+type Selector struct {
+	raw   string
+	steps []step
+}
+
+// Parse parses the selector expression. An empty expression or "$" selects
+// the entire JSON value.
+func Parse(expr string) (*Selector, error) {
+	s := &Selector{raw: expr}
+
+	rest := strings.TrimSpace(expr)
+	rest = strings.TrimPrefix(rest, "$")
+
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			key := rest[:end]
+			if key == "" {
+				return nil, fmt.Errorf("invalid selector %q: empty key", expr)
+			}
+			s.steps = append(s.steps, step{key: key})
+			rest = rest[end:]
+
+		case '[':
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("invalid selector %q: missing ']'", expr)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+
+			if len(inner) >= 2 && (inner[0] == '"' || inner[0] == '\'') &&
+				inner[len(inner)-1] == inner[0] {
+				s.steps = append(s.steps, step{key: inner[1 : len(inner)-1]})
+				continue
+			}
+
+			ix, err := strconv.Atoi(inner)
+			if err != nil || ix < 0 {
+				return nil, fmt.Errorf("invalid selector %q: index must be a "+
+					"non-negative integer or a quoted key: %q", expr, inner)
+			}
+			s.steps = append(s.steps, step{index: ix, isIndex: true})
+
+		default:
+			// allow the first key to be specified without a leading dot
+			if len(s.steps) > 0 {
+				return nil, fmt.Errorf("invalid selector %q: unexpected "+
+					"character %q", expr, rest[0])
+			}
+			rest = "." + rest
+		}
+	}
+
+	return s, nil
+}
+
+// Select returns the value of the decoded JSON that the selector refers to.
+// The JSON is expected to be decoded into the generic types used by
+// encoding/json i.e. map[string]interface{} and []interface{}.
+func (s *Selector) Select(v interface{}) (interface{}, error) {
+	if s == nil {
+		return v, nil
+	}
+
+	cur := v
+	for i, st := range s.steps {
+		if st.isIndex {
+			arr, ok := cur.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("selector %q: value at step %d is not "+
+					"an array", s.raw, i+1)
+			}
+			if st.index >= len(arr) {
+				return nil, fmt.Errorf("selector %q: index %d out of range "+
+					"for array of length %d", s.raw, st.index, len(arr))
+			}
+			cur = arr[st.index]
+			continue
+		}
+
+		obj, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("selector %q: value at step %d is not "+
+				"an object", s.raw, i+1)
+		}
+		val, ok := obj[st.key]
+		if !ok {
+			return nil, fmt.Errorf("selector %q: key %q not found",
+				s.raw, st.key)
+		}
+		cur = val
+	}
+
+	return cur, nil
+}
+
+// String returns the original selector expression
+func (s *Selector) String() string {
+	if s == nil {
+		return ""
+	}
+	return s.raw
+}
@@ -0,0 +1,130 @@
+package selector
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelector(t *testing.T) {
+	t.Parallel()
+
+	data := `{
+		"data": {
+			"prefixes": [
+				{"cidr": "10.0.0.0/24", "site": "dc1"},
+				{"cidr": "10.0.1.0/24", "site": "dc2"}
+			]
+		},
+		"ip-ranges": [1, 2, 3],
+		"empty": null
+	}`
+	var v interface{}
+	require.NoError(t, json.Unmarshal([]byte(data), &v))
+
+	cases := []struct {
+		name     string
+		expr     string
+		expected interface{}
+	}{
+		{
+			"empty",
+			"",
+			v,
+		},
+		{
+			"root",
+			"$",
+			v,
+		},
+		{
+			"dot notation",
+			"$.data.prefixes[1].site",
+			"dc2",
+		},
+		{
+			"without root",
+			"data.prefixes[0].cidr",
+			"10.0.0.0/24",
+		},
+		{
+			"bracket key double quotes",
+			`$["ip-ranges"][2]`,
+			float64(3),
+		},
+		{
+			"bracket key single quotes",
+			`$['ip-ranges'][0]`,
+			float64(1),
+		},
+		{
+			"null value",
+			"$.empty",
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := Parse(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expr, s.String())
+
+			actual, err := s.Select(v)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		expr string
+	}{
+		{"empty key", "$.data..prefixes"},
+		{"trailing dot", "$.data."},
+		{"missing bracket", "$.data[0"},
+		{"negative index", "$.data[-1]"},
+		{"invalid index", "$.data[abc]"},
+		{"unexpected character", "$.data[0]prefixes"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSelector_Select_Error(t *testing.T) {
+	t.Parallel()
+
+	var v interface{}
+	require.NoError(t, json.Unmarshal(
+		[]byte(`{"data": {"prefixes": ["a", "b"]}}`), &v))
+
+	cases := []struct {
+		name string
+		expr string
+	}{
+		{"key not found", "$.missing"},
+		{"not an object", "$.data.prefixes.cidr"},
+		{"not an array", "$.data[0]"},
+		{"index out of range", "$.data.prefixes[2]"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := Parse(tc.expr)
+			require.NoError(t, err)
+			_, err = s.Select(v)
+			assert.Error(t, err)
+		})
+	}
+}
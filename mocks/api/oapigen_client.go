@@ -76,20 +76,20 @@ func (_m *ClientWithResponsesInterface) CreateTaskWithResponse(ctx context.Conte
 	return r0, r1
 }
 
-// DeleteTaskByNameWithResponse provides a mock function with given fields: ctx, name, reqEditors
-func (_m *ClientWithResponsesInterface) DeleteTaskByNameWithResponse(ctx context.Context, name string, reqEditors ...oapigen.RequestEditorFn) (*oapigen.DeleteTaskByNameResponse, error) {
+// DeleteTaskByNameWithResponse provides a mock function with given fields: ctx, name, params, reqEditors
+func (_m *ClientWithResponsesInterface) DeleteTaskByNameWithResponse(ctx context.Context, name string, params *oapigen.DeleteTaskByNameParams, reqEditors ...oapigen.RequestEditorFn) (*oapigen.DeleteTaskByNameResponse, error) {
 	_va := make([]interface{}, len(reqEditors))
 	for _i := range reqEditors {
 		_va[_i] = reqEditors[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, name)
+	_ca = append(_ca, ctx, name, params)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 *oapigen.DeleteTaskByNameResponse
-	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapigen.RequestEditorFn) *oapigen.DeleteTaskByNameResponse); ok {
-		r0 = rf(ctx, name, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *oapigen.DeleteTaskByNameParams, ...oapigen.RequestEditorFn) *oapigen.DeleteTaskByNameResponse); ok {
+		r0 = rf(ctx, name, params, reqEditors...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*oapigen.DeleteTaskByNameResponse)
@@ -97,8 +97,8 @@ func (_m *ClientWithResponsesInterface) DeleteTaskByNameWithResponse(ctx context
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapigen.RequestEditorFn) error); ok {
-		r1 = rf(ctx, name, reqEditors...)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *oapigen.DeleteTaskByNameParams, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, params, reqEditors...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -196,6 +196,96 @@ func (_m *ClientWithResponsesInterface) GetTaskByNameWithResponse(ctx context.Co
 	return r0, r1
 }
 
+// GetTaskOutputsByNameWithResponse provides a mock function with given fields: ctx, name, reqEditors
+func (_m *ClientWithResponsesInterface) GetTaskOutputsByNameWithResponse(ctx context.Context, name string, reqEditors ...oapigen.RequestEditorFn) (*oapigen.GetTaskOutputsByNameResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.GetTaskOutputsByNameResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapigen.RequestEditorFn) *oapigen.GetTaskOutputsByNameResponse); ok {
+		r0 = rf(ctx, name, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.GetTaskOutputsByNameResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TriggerTaskByNameWithBodyWithResponse provides a mock function with given fields: ctx, name, params, contentType, body, reqEditors
+func (_m *ClientWithResponsesInterface) TriggerTaskByNameWithBodyWithResponse(ctx context.Context, name string, params *oapigen.TriggerTaskByNameParams, contentType string, body io.Reader, reqEditors ...oapigen.RequestEditorFn) (*oapigen.TriggerTaskByNameResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name, params, contentType, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.TriggerTaskByNameResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, *oapigen.TriggerTaskByNameParams, string, io.Reader, ...oapigen.RequestEditorFn) *oapigen.TriggerTaskByNameResponse); ok {
+		r0 = rf(ctx, name, params, contentType, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.TriggerTaskByNameResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *oapigen.TriggerTaskByNameParams, string, io.Reader, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, params, contentType, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TriggerTaskByNameWithResponse provides a mock function with given fields: ctx, name, params, body, reqEditors
+func (_m *ClientWithResponsesInterface) TriggerTaskByNameWithResponse(ctx context.Context, name string, params *oapigen.TriggerTaskByNameParams, body interface{}, reqEditors ...oapigen.RequestEditorFn) (*oapigen.TriggerTaskByNameResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name, params, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.TriggerTaskByNameResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, *oapigen.TriggerTaskByNameParams, interface{}, ...oapigen.RequestEditorFn) *oapigen.TriggerTaskByNameResponse); ok {
+		r0 = rf(ctx, name, params, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.TriggerTaskByNameResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *oapigen.TriggerTaskByNameParams, interface{}, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, params, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewClientWithResponsesInterface interface {
 	mock.TestingT
 	Cleanup(func())
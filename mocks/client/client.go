@@ -7,6 +7,8 @@ import (
 	io "io"
 
 	mock "github.com/stretchr/testify/mock"
+
+	tfexec "github.com/hashicorp/terraform-exec/tfexec"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -28,6 +30,20 @@ func (_m *Client) Apply(ctx context.Context) error {
 	return r0
 }
 
+// Destroy provides a mock function with given fields: ctx
+func (_m *Client) Destroy(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GoString provides a mock function with given fields:
 func (_m *Client) GoString() string {
 	ret := _m.Called()
@@ -56,6 +72,29 @@ func (_m *Client) Init(ctx context.Context) error {
 	return r0
 }
 
+// Output provides a mock function with given fields: ctx
+func (_m *Client) Output(ctx context.Context) (map[string]tfexec.OutputMeta, error) {
+	ret := _m.Called(ctx)
+
+	var r0 map[string]tfexec.OutputMeta
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]tfexec.OutputMeta); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]tfexec.OutputMeta)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Plan provides a mock function with given fields: ctx
 func (_m *Client) Plan(ctx context.Context) (bool, error) {
 	ret := _m.Called(ctx)
@@ -77,6 +116,27 @@ func (_m *Client) Plan(ctx context.Context) (bool, error) {
 	return r0, r1
 }
 
+// PlanDestroy provides a mock function with given fields: ctx
+func (_m *Client) PlanDestroy(ctx context.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetEnv provides a mock function with given fields: _a0
 func (_m *Client) SetEnv(_a0 map[string]string) error {
 	ret := _m.Called(_a0)
@@ -82,6 +82,45 @@ func (_m *ConsulClientInterface) KVGet(ctx context.Context, key string, q *api.Q
 	return r0, r1, r2
 }
 
+// KVTxn provides a mock function with given fields: ctx, ops, q
+func (_m *ConsulClientInterface) KVTxn(ctx context.Context, ops api.KVTxnOps, q *api.QueryOptions) (bool, *api.KVTxnResponse, *api.QueryMeta, error) {
+	ret := _m.Called(ctx, ops, q)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, api.KVTxnOps, *api.QueryOptions) bool); ok {
+		r0 = rf(ctx, ops, q)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 *api.KVTxnResponse
+	if rf, ok := ret.Get(1).(func(context.Context, api.KVTxnOps, *api.QueryOptions) *api.KVTxnResponse); ok {
+		r1 = rf(ctx, ops, q)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*api.KVTxnResponse)
+		}
+	}
+
+	var r2 *api.QueryMeta
+	if rf, ok := ret.Get(2).(func(context.Context, api.KVTxnOps, *api.QueryOptions) *api.QueryMeta); ok {
+		r2 = rf(ctx, ops, q)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*api.QueryMeta)
+		}
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(context.Context, api.KVTxnOps, *api.QueryOptions) error); ok {
+		r3 = rf(ctx, ops, q)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
 // Lock provides a mock function with given fields: l, stopCh
 func (_m *ConsulClientInterface) Lock(l *api.Lock, stopCh <-chan struct{}) (<-chan struct{}, error) {
 	ret := _m.Called(l, stopCh)
@@ -39,6 +39,27 @@ func (_m *TerraformExec) Apply(ctx context.Context, opts ...tfexec.ApplyOption)
 	return r0
 }
 
+// Destroy provides a mock function with given fields: ctx, opts
+func (_m *TerraformExec) Destroy(ctx context.Context, opts ...tfexec.DestroyOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...tfexec.DestroyOption) error); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Init provides a mock function with given fields: ctx, opts
 func (_m *TerraformExec) Init(ctx context.Context, opts ...tfexec.InitOption) error {
 	_va := make([]interface{}, len(opts))
@@ -60,6 +81,36 @@ func (_m *TerraformExec) Init(ctx context.Context, opts ...tfexec.InitOption) er
 	return r0
 }
 
+// Output provides a mock function with given fields: ctx, opts
+func (_m *TerraformExec) Output(ctx context.Context, opts ...tfexec.OutputOption) (map[string]tfexec.OutputMeta, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 map[string]tfexec.OutputMeta
+	if rf, ok := ret.Get(0).(func(context.Context, ...tfexec.OutputOption) map[string]tfexec.OutputMeta); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]tfexec.OutputMeta)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...tfexec.OutputOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Plan provides a mock function with given fields: ctx, opts
 func (_m *TerraformExec) Plan(ctx context.Context, opts ...tfexec.PlanOption) (bool, error) {
 	_va := make([]interface{}, len(opts))
@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	json "encoding/json"
+
 	driver "github.com/hashicorp/consul-terraform-sync/driver"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -28,6 +30,20 @@ func (_m *Driver) ApplyTask(ctx context.Context) error {
 	return r0
 }
 
+// DestroyResources provides a mock function with given fields: ctx
+func (_m *Driver) DestroyResources(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DestroyTask provides a mock function with given fields: ctx
 func (_m *Driver) DestroyTask(ctx context.Context) {
 	_m.Called(ctx)
@@ -47,6 +63,27 @@ func (_m *Driver) InitTask(ctx context.Context) error {
 	return r0
 }
 
+// InspectDestroyResources provides a mock function with given fields: ctx
+func (_m *Driver) InspectDestroyResources(ctx context.Context) (driver.InspectPlan, error) {
+	ret := _m.Called(ctx)
+
+	var r0 driver.InspectPlan
+	if rf, ok := ret.Get(0).(func(context.Context) driver.InspectPlan); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(driver.InspectPlan)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InspectTask provides a mock function with given fields: ctx
 func (_m *Driver) InspectTask(ctx context.Context) (driver.InspectPlan, error) {
 	ret := _m.Called(ctx)
@@ -73,6 +110,29 @@ func (_m *Driver) OverrideNotifier() {
 	_m.Called()
 }
 
+// Outputs provides a mock function with given fields: ctx
+func (_m *Driver) Outputs(ctx context.Context) (map[string]json.RawMessage, error) {
+	ret := _m.Called(ctx)
+
+	var r0 map[string]json.RawMessage
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]json.RawMessage); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]json.RawMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RenderTemplate provides a mock function with given fields: ctx
 func (_m *Driver) RenderTemplate(ctx context.Context) (bool, error) {
 	ret := _m.Called(ctx)
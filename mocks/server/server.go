@@ -117,6 +117,29 @@ func (_m *Server) TaskCreateAndRun(_a0 context.Context, _a1 config.TaskConfig) (
 	return r0, r1
 }
 
+// TaskDamping provides a mock function with given fields: ctx, taskName
+func (_m *Server) TaskDamping(ctx context.Context, taskName string) (*event.DampingStatus, error) {
+	ret := _m.Called(ctx, taskName)
+
+	var r0 *event.DampingStatus
+	if rf, ok := ret.Get(0).(func(context.Context, string) *event.DampingStatus); ok {
+		r0 = rf(ctx, taskName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*event.DampingStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TaskDelete provides a mock function with given fields: ctx, taskName
 func (_m *Server) TaskDelete(ctx context.Context, taskName string) error {
 	ret := _m.Called(ctx, taskName)
@@ -131,6 +154,20 @@ func (_m *Server) TaskDelete(ctx context.Context, taskName string) error {
 	return r0
 }
 
+// TaskDeleteAndDestroy provides a mock function with given fields: ctx, taskName
+func (_m *Server) TaskDeleteAndDestroy(ctx context.Context, taskName string) error {
+	ret := _m.Called(ctx, taskName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, taskName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // TaskInspect provides a mock function with given fields: _a0, _a1
 func (_m *Server) TaskInspect(_a0 context.Context, _a1 config.TaskConfig) (bool, string, string, error) {
 	ret := _m.Called(_a0, _a1)
@@ -166,6 +203,78 @@ func (_m *Server) TaskInspect(_a0 context.Context, _a1 config.TaskConfig) (bool,
 	return r0, r1, r2, r3
 }
 
+// TaskInspectDestroy provides a mock function with given fields: ctx, taskName
+func (_m *Server) TaskInspectDestroy(ctx context.Context, taskName string) (bool, string, string, error) {
+	ret := _m.Called(ctx, taskName)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, taskName)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, taskName)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 string
+	if rf, ok := ret.Get(2).(func(context.Context, string) string); ok {
+		r2 = rf(ctx, taskName)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(context.Context, string) error); ok {
+		r3 = rf(ctx, taskName)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// TaskOutputs provides a mock function with given fields: ctx, taskName
+func (_m *Server) TaskOutputs(ctx context.Context, taskName string) (*event.Event, error) {
+	ret := _m.Called(ctx, taskName)
+
+	var r0 *event.Event
+	if rf, ok := ret.Get(0).(func(context.Context, string) *event.Event); ok {
+		r0 = rf(ctx, taskName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*event.Event)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskTrigger provides a mock function with given fields: ctx, taskName, payload
+func (_m *Server) TaskTrigger(ctx context.Context, taskName string, payload interface{}) error {
+	ret := _m.Called(ctx, taskName, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) error); ok {
+		r0 = rf(ctx, taskName, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // TaskUpdate provides a mock function with given fields: ctx, updateConf, runOp
 func (_m *Server) TaskUpdate(ctx context.Context, updateConf config.TaskConfig, runOp string) (bool, string, string, error) {
 	ret := _m.Called(ctx, updateConf, runOp)
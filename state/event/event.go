@@ -1,6 +1,8 @@
 package event
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -25,6 +27,20 @@ type Event struct {
 	TaskName   string    `json:"task_name"`
 	EventError *Error    `json:"error"`
 
+	// Detail is the result of a successful task run reported by the driver,
+	// such as the standard out of the command run by the exec driver.
+	Detail string `json:"detail,omitempty"`
+
+	// Outputs are the output values of the task's module, collected after the
+	// task was successfully applied. Outputs are served by the task outputs
+	// API and are excluded from the event's JSON representation.
+	Outputs map[string]json.RawMessage `json:"-"`
+
+	// Trigger describes what triggered the task run, such as a change
+	// detected by the task's condition or a request to create the task. It is
+	// excluded from the event's JSON representation.
+	Trigger string `json:"-"`
+
 	// Config is deprecated in v0.5. This is configuration details about the
 	// task rather than status information. Users should switch to using the
 	// Get Task API to request the task's config information.
@@ -38,6 +54,26 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// DampingStatus is the status of the changes suppressed by the damping of a
+// task's services condition, which are pending to trigger the task.
+type DampingStatus struct {
+	// PendingChanges is the number of changed service instances that have not
+	// yet triggered the task.
+	PendingChanges int
+
+	// SuppressedNotifications is the number of notifications suppressed since
+	// the task was last triggered.
+	SuppressedNotifications int
+
+	// PendingSince is the time of the first suppressed change. It is zero when
+	// no changes are pending.
+	PendingSince time.Time
+
+	// NextTrigger is the time that the pending changes will trigger the task
+	// if no other changes are received. It is zero when no changes are pending.
+	NextTrigger time.Time
+}
+
 // Config provides details on an event's task configuration. It is deprecated
 // in v0.5 and should be removed in 0.8
 type Config struct {
@@ -64,6 +100,21 @@ func NewEvent(taskName string, config *Config) (*Event, error) {
 	}, nil
 }
 
+// contextKey is the key of the event stored in a context
+type contextKey struct{}
+
+// NewContext returns a copy of the context that carries the event, so that
+// the event of a task run is available to the driver's clients.
+func NewContext(ctx context.Context, e *Event) context.Context {
+	return context.WithValue(ctx, contextKey{}, e)
+}
+
+// FromContext returns the event stored in the context, if any.
+func FromContext(ctx context.Context) (*Event, bool) {
+	e, ok := ctx.Value(contextKey{}).(*Event)
+	return e, ok && e != nil
+}
+
 // Start sets the start time on an event. Can only be called once.
 func (e *Event) Start() {
 	if !e.StartTime.IsZero() {
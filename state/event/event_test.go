@@ -1,6 +1,7 @@
 package event
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -147,6 +148,20 @@ func TestEvent_Start(t *testing.T) {
 	}
 }
 
+func TestEventContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, ok := FromContext(ctx)
+	assert.False(t, ok)
+
+	e := &Event{ID: "123", TaskName: "task", Trigger: "task created"}
+	ctx = NewContext(ctx, e)
+	actual, ok := FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, e, actual)
+}
+
 func TestEvent_End(t *testing.T) {
 	t.Parallel()
 
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/consul-terraform-sync/config"
@@ -443,7 +444,7 @@ func Test_InMemoryStore_AddTaskEvent(t *testing.T) {
 			actualEvents := actual[taskName]
 			exists := false
 			for _, actualEvent := range actualEvents {
-				if actualEvent == tc.event {
+				if reflect.DeepEqual(actualEvent, tc.event) {
 					exists = true
 				}
 			}
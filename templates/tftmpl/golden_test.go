@@ -6,6 +6,7 @@ import (
 	"io"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
 	"github.com/hashicorp/consul-terraform-sync/testutils"
@@ -17,6 +18,17 @@ import (
 
 var update = flag.Bool("update", false, "update golden files")
 
+// servicesCustomTemplate is a user-provided template for the services variable
+// that renders the addresses of the api service instances
+const servicesCustomTemplate = `{
+  api = [
+  {{- range service "api" }}
+    "{{ .Address }}:{{ .Port }}",
+  {{- end }}
+  ]
+}
+`
+
 func TestNewFiles(t *testing.T) {
 	task := Task{
 		Description: "user description for task named 'test'",
@@ -66,6 +78,41 @@ func TestNewFiles(t *testing.T) {
 					"bool_true": cty.BoolVal(true),
 				},
 			},
+		}, {
+			Name:   "main.tf.json",
+			Func:   newMainTFJSON,
+			Golden: "testdata/main.tf.json",
+			Input: RootModuleInputData{
+				Backend: map[string]interface{}{
+					"consul": map[string]interface{}{
+						"scheme": "https",
+						"path":   "consul-terraform-sync/terraform",
+					},
+				},
+				Providers: []hcltmpl.NamedBlock{hcltmpl.NewNamedBlock(
+					map[string]interface{}{
+						"testProvider": map[string]interface{}{
+							"alias": "tp",
+							"obj": map[string]interface{}{
+								"username": "name",
+								"id":       "123",
+							},
+							"attr":  "value",
+							"count": 10,
+						},
+					})},
+				ProviderInfo: map[string]interface{}{
+					"testProvider": map[string]interface{}{
+						"version": "1.2.0",
+						"source":  "namespace/testProvider",
+					},
+				},
+				Task: task,
+				Variables: hcltmpl.Variables{
+					"one":       cty.NumberIntVal(1),
+					"bool_true": cty.BoolVal(true),
+				},
+			},
 		}, {
 			Name:   "main.tf (catalog-services - render var)",
 			Func:   newMainTF,
@@ -162,6 +209,68 @@ func TestNewFiles(t *testing.T) {
 				Task: task,
 			},
 		},
+		{
+			Name:   "terraform.tfvars.tmpl (services - protocol v1)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/services/terraform_v1.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&ServicesTemplate{
+						Names:      []string{"web", "api"},
+						RenderVar:  true,
+						ProtocolV1: true,
+					},
+				},
+				ServicesProtocolV1: true,
+				Task:               task,
+			},
+		},
+		{
+			Name:   "variables.tf (services - protocol v1)",
+			Func:   newVariablesTF,
+			Golden: "testdata/services/variables_v1.tf",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&ServicesTemplate{
+						Names:      []string{"web", "api"},
+						RenderVar:  true,
+						ProtocolV1: true,
+					},
+				},
+				ServicesProtocolV1: true,
+				Task:               task,
+			},
+		},
+		{
+			Name:   "terraform.tfvars.tmpl (services - custom template)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/services/terraform_custom.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&ServicesTemplate{
+						Names:     []string{"web", "api"},
+						RenderVar: false,
+					},
+				},
+				ServicesTemplate: servicesCustomTemplate,
+				Task:             task,
+			},
+		},
+		{
+			Name:   "variables.tf (services - custom template)",
+			Func:   newVariablesTF,
+			Golden: "testdata/services/variables_custom.tf",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&ServicesTemplate{
+						Names:     []string{"web", "api"},
+						RenderVar: false,
+					},
+				},
+				ServicesTemplate: servicesCustomTemplate,
+				Task:             task,
+			},
+		},
 		{
 			Name:   "terraform.tfvars.tmpl (services deprecated)",
 			Func:   newTFVarsTmpl,
@@ -415,6 +524,198 @@ func TestNewFiles(t *testing.T) {
 				},
 				Task: task,
 			},
+		}, {
+			Name:   "terraform.tfvars.tmpl (nodes - render var)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/nodes/terraform.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&NodesTemplate{
+						Datacenter: "dc1",
+						NodeMeta:   map[string]string{"k": "v"},
+						Filter:     "Meta.env == \"prod\"",
+						RenderVar:  true,
+					},
+				},
+				Task: task,
+			},
+		}, {
+			Name:   "terraform.tfvars.tmpl (nodes - no var)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/nodes/terraform_no_var.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&NodesTemplate{
+						RenderVar: false,
+					},
+				},
+				Task: task,
+			},
+		}, {
+			Name:   "variables.tf (nodes - render var)",
+			Func:   newVariablesTF,
+			Golden: "testdata/nodes/variables.tf",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&NodesTemplate{
+						RenderVar: true,
+					},
+				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "terraform.tfvars.tmpl (intentions - render var)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/intentions/terraform.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&IntentionsTemplate{
+						Datacenter: "dc1",
+						Namespace:  "ns1",
+						Filter:     "DestinationName == \"api\"",
+						RenderVar:  true,
+					},
+				},
+				Task: task,
+			},
+		}, {
+			Name:   "variables.tf (intentions - render var)",
+			Func:   newVariablesTF,
+			Golden: "testdata/intentions/variables.tf",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&IntentionsTemplate{
+						RenderVar: true,
+					},
+				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "terraform.tfvars.tmpl (config-entries - render var)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/config-entries/terraform.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&ConfigEntriesTemplate{
+						Kind:       "ingress-gateway",
+						Regexp:     "^ingress-",
+						Datacenter: "dc1",
+						RenderVar:  true,
+					},
+				},
+				Task: task,
+			},
+		}, {
+			Name:   "variables.tf (config-entries - render var)",
+			Func:   newVariablesTF,
+			Golden: "testdata/config-entries/variables.tf",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&ConfigEntriesTemplate{
+						Kind:      "service-defaults",
+						RenderVar: true,
+					},
+				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "terraform.tfvars.tmpl (vault)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/vault/terraform.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&VaultTemplate{
+						Path: "secret/my-app",
+					},
+				},
+				Task: task,
+			},
+		}, {
+			Name:   "variables.tf (vault)",
+			Func:   newVariablesTF,
+			Golden: "testdata/vault/variables.tf",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&VaultTemplate{
+						Path: "secret/my-app",
+					},
+				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "variables.tf (vault - tf 0.13)",
+			Func:   newVariablesTF,
+			Golden: "testdata/vault/variables_tf013.tf",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&VaultTemplate{
+						Path: "secret/my-app",
+					},
+				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.13.7")),
+				Task:             task,
+			},
+		}, {
+			Name:   "terraform.tfvars.tmpl (http - render var)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/http/terraform.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&HTTPTemplate{
+						URL:       "https://ipam.example.com/api/prefixes",
+						Headers:   map[string]string{"Authorization": "Bearer token"},
+						Interval:  30 * time.Second,
+						Selector:  "$.data",
+						RenderVar: true,
+					},
+				},
+				Task: task,
+			},
+		}, {
+			Name:   "variables.tf (http - render var)",
+			Func:   newVariablesTF,
+			Golden: "testdata/http/variables.tf",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&HTTPTemplate{
+						URL:       "https://ipam.example.com/api/prefixes",
+						RenderVar: true,
+					},
+				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
+		}, {
+			Name:   "terraform.tfvars.tmpl (webhook - render var)",
+			Func:   newTFVarsTmpl,
+			Golden: "testdata/webhook/terraform.tfvars.tmpl",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&WebhookTemplate{
+						TaskName:  "test",
+						RenderVar: true,
+					},
+				},
+				Task: task,
+			},
+		}, {
+			Name:   "variables.tf (webhook - render var)",
+			Func:   newVariablesTF,
+			Golden: "testdata/webhook/variables.tf",
+			Input: RootModuleInputData{
+				Templates: []Template{
+					&WebhookTemplate{
+						TaskName:  "test",
+						RenderVar: true,
+					},
+				},
+				TerraformVersion: goVersion.Must(goVersion.NewSemver("0.99.9")),
+				Task:             task,
+			},
 		}, {
 			Name:   "providers.tfvars",
 			Func:   newProvidersTFVars,
@@ -508,6 +809,39 @@ func TestNewFiles(t *testing.T) {
 	}
 }
 
+func TestTFVarsJSON(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		TFVars string
+		Golden string
+	}{
+		{
+			Name:   "services",
+			TFVars: "testdata/terraform.tfvars",
+			Golden: "testdata/terraform.tfvars.json",
+		}, {
+			Name:   "consul-kv",
+			TFVars: "testdata/consul-kv/terraform.tfvars",
+			Golden: "testdata/consul-kv/terraform.tfvars.json",
+		}, {
+			Name:   "catalog-services",
+			TFVars: "testdata/catalog-services/terraform_with_var.tfvars",
+			Golden: "testdata/catalog-services/terraform_with_var.tfvars.json",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			content, err := ioutil.ReadFile(tc.TFVars)
+			require.NoError(t, err)
+
+			actual, err := TFVarsJSON(content)
+			require.NoError(t, err)
+			checkGoldenFile(t, tc.Golden, string(actual))
+		})
+	}
+}
+
 func checkGoldenFile(t *testing.T, goldenFile string, actual string) {
 	// update golden files if necessary
 	if *update {
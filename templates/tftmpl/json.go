@@ -0,0 +1,275 @@
+package tftmpl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/internal/hcl2shim"
+	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// jsonCommentKey is the property name Terraform ignores in the JSON syntax,
+// which is used for comments.
+const jsonCommentKey = "//"
+
+// jsonBody is an object of the Terraform JSON configuration syntax that
+// represents the body of a block.
+type jsonBody map[string]interface{}
+
+// appendBlock appends a block to the body and returns the body of the new
+// block. Labeled blocks are nested objects keyed by the block type followed by
+// each label. Repeated blocks of the same type and labels are written as an
+// array of objects.
+func (b jsonBody) appendBlock(typeName string, labels ...string) jsonBody {
+	parent := b
+	key := typeName
+	for _, label := range labels {
+		next, ok := parent[key].(jsonBody)
+		if !ok {
+			next = make(jsonBody)
+			parent[key] = next
+		}
+		parent = next
+		key = label
+	}
+
+	block := make(jsonBody)
+	switch existing := parent[key].(type) {
+	case jsonBody:
+		parent[key] = []jsonBody{existing, block}
+	case []jsonBody:
+		parent[key] = append(existing, block)
+	default:
+		parent[key] = block
+	}
+	return block
+}
+
+// setAttributeValue sets an attribute to a literal value. Strings are escaped
+// since they are interpreted as templates.
+func (b jsonBody) setAttributeValue(name string, val cty.Value) error {
+	val, err := cty.Transform(val, func(_ cty.Path, v cty.Value) (cty.Value, error) {
+		if v.IsKnown() && !v.IsNull() && v.Type() == cty.String {
+			s := strings.ReplaceAll(v.AsString(), "${", "$${")
+			s = strings.ReplaceAll(s, "%{", "%%{")
+			return cty.StringVal(s), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		return err
+	}
+	b[name] = ctyJSONValue(val)
+	return nil
+}
+
+// setAttributeTraversal sets an attribute to an expression that references
+// another object, which is written as a string template.
+func (b jsonBody) setAttributeTraversal(name string, traversal ...string) {
+	b[name] = fmt.Sprintf("${%s}", strings.Join(traversal, "."))
+}
+
+// newMainTFJSON writes content used for main.tf.json of a Terraform root
+// module. The content is the same as main.tf written in the Terraform JSON
+// configuration syntax.
+func newMainTFJSON(w io.Writer, filename string, input *RootModuleInputData) error {
+	root := make(jsonBody)
+	root[jsonCommentKey] = fmt.Sprintf(
+		"This file is generated by Consul-Terraform-Sync. Task: %s", input.Task.Name)
+
+	requiredVersion := input.RequiredVersion
+	if requiredVersion == "" {
+		requiredVersion = TerraformRequiredVersion
+	}
+	err := appendRootTerraformJSON(root, requiredVersion, input.backend, input.ProviderInfo)
+	if err != nil {
+		return err
+	}
+	appendRootProviderJSON(root, input.Providers)
+	if err := appendRootModuleJSON(root, input.Task, input.Variables.Keys(), input.Templates...); err != nil {
+		return err
+	}
+	if input.Task.ExposeOutputs {
+		outputBody := root.appendBlock("output", ModuleOutputsName)
+		outputBody.setAttributeTraversal("value", "module", input.Task.Name)
+		outputBody["sensitive"] = true
+	}
+
+	return writeJSON(w, root)
+}
+
+// appendRootTerraformJSON appends the Terraform block with version constraint
+// and backend. See appendRootTerraformBlock.
+func appendRootTerraformJSON(body jsonBody, requiredVersion string,
+	backend *hcltmpl.NamedBlock, providerInfo map[string]interface{}) error {
+
+	tfBody := body.appendBlock("terraform")
+	if err := tfBody.setAttributeValue("required_version", cty.StringVal(requiredVersion)); err != nil {
+		return err
+	}
+
+	if len(providerInfo) != 0 {
+		requiredProvidersBody := tfBody.appendBlock("required_providers")
+		for pName, info := range providerInfo {
+			val := hcl2shim.HCL2ValueFromConfigValue(info)
+			if err := requiredProvidersBody.setAttributeValue(pName, val); err != nil {
+				return err
+			}
+		}
+	}
+
+	if backend == nil || backend.Name == "" {
+		return nil
+	}
+	backendBody := tfBody.appendBlock("backend", backend.Name)
+	for attr, val := range backend.Variables {
+		if err := backendBody.setAttributeValue(attr, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendRootProviderJSON appends Terraform provider blocks for the providers
+// the task requires. See appendRootProviderBlocks.
+func appendRootProviderJSON(body jsonBody, providers []hcltmpl.NamedBlock) {
+	for _, p := range providers {
+		providerBody := body.appendBlock("provider", p.Name)
+		for attr, val := range p.Variables {
+			if attr == "alias" || attr == "auto_commit" {
+				continue
+			}
+
+			if val.Type().IsObjectType() {
+				objProviderBody := providerBody.appendBlock(attr)
+				for subAttr := range val.AsValueMap() {
+					objProviderBody.setAttributeTraversal(subAttr, "var", p.Name, attr, subAttr)
+				}
+				continue
+			}
+			providerBody.setAttributeTraversal(attr, "var", p.Name, attr)
+		}
+	}
+}
+
+// appendRootModuleJSON appends a Terraform module block for the task. See
+// appendRootModuleBlock.
+func appendRootModuleJSON(body jsonBody, task Task, varNames []string, templates ...Template) error {
+	moduleBody := body.appendBlock("module", task.Name)
+	if task.Description != "" {
+		moduleBody[jsonCommentKey] = task.Description
+	}
+
+	if err := moduleBody.setAttributeValue("source", cty.StringVal(task.Module)); err != nil {
+		return err
+	}
+	if len(task.Version) > 0 {
+		if err := moduleBody.setAttributeValue("version", cty.StringVal(task.Version)); err != nil {
+			return err
+		}
+	}
+	moduleBody.setAttributeTraversal("services", "var", "services")
+
+	for _, t := range templates {
+		if t != nil && t.RendersVar() {
+			appendModuleAttributeJSON(moduleBody, t)
+		}
+	}
+
+	for _, name := range varNames {
+		moduleBody.setAttributeTraversal(name, "var", name)
+	}
+	return nil
+}
+
+// appendModuleAttributeJSON appends the module arguments of a template. The
+// templates only write the arguments for the HCL syntax, so the expressions
+// of the arguments are written as string templates.
+func appendModuleAttributeJSON(body jsonBody, t Template) {
+	tmp := hclwrite.NewEmptyFile().Body()
+	t.appendModuleAttribute(tmp)
+	for name, attr := range tmp.Attributes() {
+		expr := strings.TrimSpace(string(attr.Expr().BuildTokens(nil).Bytes()))
+		body[name] = fmt.Sprintf("${%s}", expr)
+	}
+}
+
+// TFVarsJSON converts the content of a rendered terraform.tfvars file into
+// the JSON syntax for terraform.tfvars.json. Values in a .tfvars.json file are
+// literal, so string values are not escaped for the template syntax.
+func TFVarsJSON(content []byte) ([]byte, error) {
+	file, diags := hclsyntax.ParseConfig(content, TFVarsFilename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	attrs, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	obj := make(map[string]interface{}, len(attrs))
+	for name, attr := range attrs {
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		obj[name] = ctyJSONValue(val)
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, obj); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ctyJSONValue converts a cty value into a value that is encoded to JSON the
+// same as the cty JSON encoding. It is used instead of the cty encoding to
+// avoid escaping HTML characters.
+func ctyJSONValue(val cty.Value) interface{} {
+	if val.IsNull() || !val.IsKnown() {
+		return nil
+	}
+
+	ty := val.Type()
+	switch {
+	case ty == cty.String:
+		return val.AsString()
+	case ty == cty.Number:
+		return json.Number(val.AsBigFloat().Text('f', -1))
+	case ty == cty.Bool:
+		return val.True()
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		l := make([]interface{}, 0, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			l = append(l, ctyJSONValue(v))
+		}
+		return l
+	case ty.IsMapType() || ty.IsObjectType():
+		m := make(map[string]interface{}, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			m[k.AsString()] = ctyJSONValue(v)
+		}
+		return m
+	}
+	return nil
+}
+
+// writeJSON writes the indented JSON encoding of the object. HTML characters
+// are not escaped, which are common in version constraints.
+func writeJSON(w io.Writer, obj interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(obj)
+}
@@ -0,0 +1,127 @@
+package tftmpl
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTFVarsJSON_literalStrings(t *testing.T) {
+	t.Parallel()
+
+	// escaped template sequences in HCL strings are literal values in JSON
+	content := []byte(`services = {
+  "api" = {
+    meta = {
+      template = "$${var.example}"
+      quote    = "\"quoted\""
+    }
+  }
+}
+`)
+	actual, err := TFVarsJSON(content)
+	require.NoError(t, err)
+	assert.Contains(t, string(actual), `"template": "${var.example}"`)
+	assert.Contains(t, string(actual), `"quote": "\"quoted\""`)
+
+	_, err = TFVarsJSON([]byte(`services = var.services`))
+	assert.Error(t, err)
+}
+
+func TestNewMainTFJSON_escapeStrings(t *testing.T) {
+	t.Parallel()
+
+	input := &RootModuleInputData{
+		Backend: map[string]interface{}{
+			"local": map[string]interface{}{
+				"path": "${path.module}/%{literal}.tfstate",
+			},
+		},
+		Task: Task{Name: "test", Module: "path/to/module"},
+	}
+	input.init()
+
+	var buf bytes.Buffer
+	require.NoError(t, newMainTFJSON(&buf, RootJSONFilename, input))
+	assert.Contains(t, buf.String(), `"path": "$${path.module}/%%{literal}.tfstate"`)
+	assert.Contains(t, buf.String(), `"services": "${var.services}"`)
+}
+
+func TestNewMainTFJSON_repeatedBlocks(t *testing.T) {
+	t.Parallel()
+
+	input := &RootModuleInputData{
+		Providers: []hcltmpl.NamedBlock{
+			hcltmpl.NewNamedBlock(map[string]interface{}{
+				"aws": map[string]interface{}{"alias": "east", "region": "us-east-1"},
+			}),
+			hcltmpl.NewNamedBlock(map[string]interface{}{
+				"aws": map[string]interface{}{"alias": "west", "region": "us-west-1"},
+			}),
+		},
+		Task: Task{Name: "test", Module: "path/to/module"},
+	}
+	input.init()
+
+	var buf bytes.Buffer
+	require.NoError(t, newMainTFJSON(&buf, RootJSONFilename, input))
+
+	var actual map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+	providers, ok := actual["provider"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"region": "${var.aws.region}"},
+		map[string]interface{}{"region": "${var.aws.region}"},
+	}, providers["aws"])
+}
+
+func TestJSONBody_appendBlock(t *testing.T) {
+	t.Parallel()
+
+	body := make(jsonBody)
+	body.appendBlock("terraform")["a"] = 1
+	body.appendBlock("module", "one")["a"] = 1
+	body.appendBlock("module", "two")["a"] = 2
+	body.appendBlock("module", "two")["a"] = 3
+	body.appendBlock("module", "two")["a"] = 4
+
+	assert.Equal(t, jsonBody{
+		"terraform": jsonBody{"a": 1},
+		"module": jsonBody{
+			"one": jsonBody{"a": 1},
+			"two": []jsonBody{{"a": 2}, {"a": 3}, {"a": 4}},
+		},
+	}, body)
+}
+
+func TestInitRootModule_JSON(t *testing.T) {
+	dir := t.TempDir()
+	input := &RootModuleInputData{
+		Task:      Task{Name: "test", Module: "path/to/module"},
+		Path:      dir,
+		FilePerms: 0644,
+	}
+
+	// files of the other format are removed when the format changes
+	require.NoError(t, InitRootModule(input))
+	assert.FileExists(t, filepath.Join(dir, RootFilename))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, TFVarsFilename), nil, 0644))
+
+	input.JSON = true
+	require.NoError(t, InitRootModule(input))
+	assert.FileExists(t, filepath.Join(dir, RootJSONFilename))
+	assert.NoFileExists(t, filepath.Join(dir, RootFilename))
+	assert.NoFileExists(t, filepath.Join(dir, TFVarsFilename))
+
+	input.JSON = false
+	require.NoError(t, InitRootModule(input))
+	assert.FileExists(t, filepath.Join(dir, RootFilename))
+	assert.NoFileExists(t, filepath.Join(dir, RootJSONFilename))
+}
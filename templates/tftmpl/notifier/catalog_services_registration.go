@@ -5,7 +5,7 @@ import (
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	"github.com/hashicorp/consul-terraform-sync/templates"
-	"github.com/hashicorp/hcat/dep"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
 )
 
 const (
@@ -23,8 +23,11 @@ const (
 // tmplfuncs and changes to Catalog Services tag data.
 type CatalogServicesRegistration struct {
 	templates.Template
-	services []string
-	logger   logging.Logger
+	logger logging.Logger
+
+	// services is the list of registered service names, keyed by the
+	// datacenter of the Catalog Services dependency
+	services map[string][]string
 
 	// count all tmplfuncs needed to complete once-mode
 	once    bool
@@ -72,7 +75,7 @@ func NewCatalogServicesRegistration(tmpl templates.Template, tmplFuncTotal int)
 // Notify notifies when Catalog Services registration changes.
 //
 // Notifications are sent when:
-// A. There is a change in the Catalog Service's dependency ([]*dep.CatalogSnippet)
+// A. There is a change in the Catalog Service's dependency (*tmplfunc.CatalogServices)
 //    that is specifically a service _registration_ change.
 // B. All the dependencies have been received for the first time. This is
 //    regardless of the dependency type that "completes" having received all the
@@ -80,7 +83,7 @@ func NewCatalogServicesRegistration(tmpl templates.Template, tmplFuncTotal int)
 //    condition that causes hanging during once-mode (details below)
 //
 // Notification are suppressed when:
-//  - There is a change in the Catalog Service's dependency ([]*dep.CatalogSnippet)
+//  - There is a change in the Catalog Service's dependency (*tmplfunc.CatalogServices)
 //    that is specifically a service _tag_ change.
 //  - Other types of dependencies that are not Catalog Service. For example,
 //    Services ([]*dep.HealthService).
@@ -112,7 +115,7 @@ func (n *CatalogServicesRegistration) Notify(d interface{}) (notify bool) {
 	}
 
 	// dependency for {{ catalogServicesRegistration}}
-	if v, ok := d.([]*dep.CatalogSnippet); ok {
+	if v, ok := d.(*tmplfunc.CatalogServices); ok {
 		if n.registrationChange(v) {
 			n.logger.Debug("notify registration change")
 			notify = true
@@ -128,18 +131,24 @@ func (n *CatalogServicesRegistration) Notify(d interface{}) (notify bool) {
 
 // registrationChange determines whether or not the latest Catalog Service
 // changes are registration changes i.e. we want to ignore tag changes.
-func (n *CatalogServicesRegistration) registrationChange(new []*dep.CatalogSnippet) bool {
-	newServices := make([]string, len(new))
-	for ix, s := range new {
+// Changes are compared per datacenter for templates that monitor Catalog
+// Services in multiple datacenters.
+func (n *CatalogServicesRegistration) registrationChange(new *tmplfunc.CatalogServices) bool {
+	newServices := make([]string, len(new.Services))
+	for ix, s := range new.Services {
 		newServices[ix] = s.Name
 	}
-	defer func() { n.services = newServices }()
+	if n.services == nil {
+		n.services = make(map[string][]string)
+	}
+	services := n.services[new.Datacenter]
+	defer func() { n.services[new.Datacenter] = newServices }()
 
 	// change in list of service names should notify
-	if len(n.services) != len(newServices) {
+	if len(services) != len(newServices) {
 		return true
 	}
-	for i, v := range n.services {
+	for i, v := range services {
 		if v != newServices[i] {
 			return true
 		}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
 	"github.com/hashicorp/hcat/dep"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -25,12 +26,14 @@ func Test_CatalogServicesRegistration_Notify(t *testing.T) {
 		},
 		{
 			"don't notify: no change in services",
-			[]*dep.CatalogSnippet{},
+			&tmplfunc.CatalogServices{},
 			false,
 		},
 		{
 			"notify: new service registration",
-			[]*dep.CatalogSnippet{{Name: "api"}},
+			&tmplfunc.CatalogServices{
+				Services: []*dep.CatalogSnippet{{Name: "api"}},
+			},
 			true,
 		},
 	}
@@ -67,11 +70,14 @@ func Test_CatalogServicesRegistration_Notify(t *testing.T) {
 		assert.Equal(t, 1, n.counter, "first services dep should be 1st dep")
 
 		// 2. catalog-service notifies
-		notify = n.Notify([]*dep.CatalogSnippet{{Name: "api"}})
+		notify = n.Notify(&tmplfunc.CatalogServices{
+			Services: []*dep.CatalogSnippet{{Name: "api"}},
+		})
 		assert.True(t, notify, "catalog-service dep should have notified")
 		assert.False(t, n.once, "got 2/3 deps. once-mode should not be completed")
 		assert.Equal(t, 2, n.counter, "catalog-service dep should be 2nd dep")
-		assert.Equal(t, []string{"api"}, n.services, "api service should be stored")
+		assert.Equal(t, map[string][]string{"": {"api"}}, n.services,
+			"api service should be stored")
 
 		// 3. second services notifies
 		notify = n.Notify([]*dep.HealthService{})
@@ -102,11 +108,14 @@ func Test_CatalogServicesRegistration_Notify(t *testing.T) {
 		assert.Equal(t, 1, n.counter, "services dep should be 1st dep")
 
 		// 2. catalog-service notifies
-		notify = n.Notify([]*dep.CatalogSnippet{{Name: "api"}})
+		notify = n.Notify(&tmplfunc.CatalogServices{
+			Services: []*dep.CatalogSnippet{{Name: "api"}},
+		})
 		assert.True(t, notify, "catalog-service dep should have notified")
 		assert.True(t, n.once, "got 2/2 deps. once-mode should be completed")
 		assert.Equal(t, 2, n.counter, "catalog-service dep should be 2nd dep")
-		assert.Equal(t, []string{"api"}, n.services, "api service should be stored")
+		assert.Equal(t, map[string][]string{"": {"api"}}, n.services,
+			"api service should be stored")
 
 		// check mock template was called once
 		tmpl.AssertExpectations(t)
@@ -119,34 +128,40 @@ func Test_CatalogServicesRegistration_registrationChange(t *testing.T) {
 	cases := []struct {
 		name     string
 		notifier *CatalogServicesRegistration
-		data     []*dep.CatalogSnippet
+		data     *tmplfunc.CatalogServices
 		expected bool
 	}{
 		{
 			"change: different number of services",
 			&CatalogServicesRegistration{
 				once:     true,
-				services: []string{"api", "db"},
+				services: map[string][]string{"": {"api", "db"}},
+			},
+			&tmplfunc.CatalogServices{
+				Services: []*dep.CatalogSnippet{{Name: "db"}},
 			},
-			[]*dep.CatalogSnippet{{Name: "db"}},
 			true,
 		},
 		{
 			"change: same number but different services",
 			&CatalogServicesRegistration{
 				once:     true,
-				services: []string{"api", "db"},
+				services: map[string][]string{"": {"api", "db"}},
+			},
+			&tmplfunc.CatalogServices{
+				Services: []*dep.CatalogSnippet{{Name: "redis"}, {Name: "web"}},
 			},
-			[]*dep.CatalogSnippet{{Name: "redis"}, {Name: "web"}},
 			true,
 		},
 		{
 			"no change",
 			&CatalogServicesRegistration{
 				once:     true,
-				services: []string{"api", "db"},
+				services: map[string][]string{"": {"api", "db"}},
+			},
+			&tmplfunc.CatalogServices{
+				Services: []*dep.CatalogSnippet{{Name: "api"}, {Name: "db"}},
 			},
-			[]*dep.CatalogSnippet{{Name: "api"}, {Name: "db"}},
 			false,
 		},
 	}
@@ -156,11 +171,11 @@ func Test_CatalogServicesRegistration_registrationChange(t *testing.T) {
 			actual := tc.notifier.registrationChange(tc.data)
 			assert.Equal(t, tc.expected, actual)
 
-			services := make([]string, len(tc.data))
-			for ix, s := range tc.data {
+			services := make([]string, len(tc.data.Services))
+			for ix, s := range tc.data.Services {
 				services[ix] = s.Name
 			}
-			assert.Equal(t, tc.notifier.services, services)
+			assert.Equal(t, services, tc.notifier.services[tc.data.Datacenter])
 		})
 	}
 }
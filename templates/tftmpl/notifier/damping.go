@@ -0,0 +1,315 @@
+package notifier
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/hashicorp/hcat/dep"
+)
+
+const dampingSubsystemName = "damping"
+
+// dampingRetryInterval is the time to wait before retrying to trigger
+// suppressed changes when the trigger is unable to accept them
+const dampingRetryInterval = time.Second
+
+// Damper suppresses notifications for changes to service instances in order
+// to reduce the number of times a task is triggered while instances churn,
+// e.g. during a deployment.
+//
+// A change notifies immediately when the number of changed instances pending
+// exceeds the absolute threshold or the percentage threshold of instances.
+// Otherwise the change is suppressed, and the damper triggers once the changes
+// are stable for the minimum stable time or once the changes have been
+// suppressed for the maximum suppression time.
+type Damper struct {
+	logger logging.Logger
+
+	threshold          int
+	thresholdPercent   int
+	minStableTime      time.Duration
+	maxSuppressionTime time.Duration
+
+	// instances is the last received information of each service instance,
+	// keyed by the datacenter, node and ID of the instance
+	instances map[string]dampedInstance
+
+	pending    int
+	suppressed int
+	since      time.Time
+	deadline   time.Time
+
+	// generation invalidates timers of suppressed changes that are no longer
+	// pending
+	generation int
+	timer      *time.Timer
+	trigger    func() bool
+
+	mu sync.Mutex
+}
+
+// dampedInstance is the information of a service instance that is compared
+// to detect changes
+type dampedInstance struct {
+	service string
+	value   string
+}
+
+// NewDamper creates a new Damper. A threshold of zero disables the respective
+// threshold.
+func NewDamper(threshold, thresholdPercent int, minStableTime,
+	maxSuppressionTime time.Duration) *Damper {
+
+	logger := logging.Global().Named(logSystemName).Named(dampingSubsystemName)
+	logger.Trace("creating damper", "threshold", threshold,
+		"threshold_percent", thresholdPercent,
+		"min_stable_time", minStableTime,
+		"max_suppression_time", maxSuppressionTime)
+
+	return &Damper{
+		logger:             logger,
+		threshold:          threshold,
+		thresholdPercent:   thresholdPercent,
+		minStableTime:      minStableTime,
+		maxSuppressionTime: maxSuppressionTime,
+		instances:          make(map[string]dampedInstance),
+	}
+}
+
+// SetTrigger sets the function that is called when suppressed changes become
+// stable or reach the maximum suppression time. The function returns whether
+// it accepted the changes. Changes that are not accepted remain pending and
+// the trigger is retried.
+func (d *Damper) SetTrigger(trigger func() bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.trigger = trigger
+}
+
+// Record records the service instances of a services dependency without
+// counting any changes, e.g. for the initial dependency.
+func (d *Damper) Record(services []*dep.HealthService) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.record(services)
+}
+
+// Observe records the service instances of a services dependency and returns
+// whether the changes to the instances should notify. Changes that do not
+// notify are suppressed until they trigger the damper.
+func (d *Damper) Observe(services []*dep.HealthService) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Dependencies are only received when their value changes. An empty
+	// dependency means that all instances were removed, which is notified
+	// regardless of the thresholds.
+	//
+	// An empty dependency does not identify the services that it is for, so
+	// the instances of all services are cleared. Otherwise restoring the same
+	// instances would not be detected as a change. Instances of other services
+	// are recorded again when their dependency next changes.
+	if len(services) == 0 {
+		d.instances = make(map[string]dampedInstance)
+		d.reset()
+		return true
+	}
+
+	changed := d.record(services)
+	if changed == 0 {
+		return false
+	}
+	d.pending += changed
+
+	if d.exceedsThreshold() {
+		d.logger.Debug("changed instances exceed threshold",
+			"pending_changes", d.pending)
+		d.reset()
+		return true
+	}
+
+	now := time.Now()
+	if d.suppressed == 0 {
+		d.since = now
+	}
+	d.suppressed++
+
+	// wait until the changes are stable, but not longer than the maximum
+	// suppression time since the first suppressed change
+	d.deadline = now.Add(d.minStableTime)
+	if maxDeadline := d.since.Add(d.maxSuppressionTime); d.deadline.After(maxDeadline) {
+		d.deadline = maxDeadline
+	}
+
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.generation++
+	generation := d.generation
+	d.timer = time.AfterFunc(time.Until(d.deadline), func() {
+		d.fire(generation)
+	})
+
+	d.logger.Debug("suppressing changed instances",
+		"pending_changes", d.pending, "suppressed", d.suppressed,
+		"next_trigger", d.deadline)
+	return false
+}
+
+// Status returns the status of the suppressed changes.
+func (d *Damper) Status() event.DampingStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return event.DampingStatus{
+		PendingChanges:          d.pending,
+		SuppressedNotifications: d.suppressed,
+		PendingSince:            d.since,
+		NextTrigger:             d.deadline,
+	}
+}
+
+// Stop stops the timer of any suppressed changes.
+func (d *Damper) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.reset()
+}
+
+// fire triggers the suppressed changes if they are still pending. If the
+// trigger does not accept the changes, they remain pending and the trigger is
+// retried.
+func (d *Damper) fire(generation int) {
+	d.mu.Lock()
+	if generation != d.generation || d.pending == 0 {
+		d.mu.Unlock()
+		return
+	}
+	d.logger.Debug("triggering suppressed changes",
+		"pending_changes", d.pending, "suppressed", d.suppressed)
+	pending, suppressed, since := d.pending, d.suppressed, d.since
+	d.reset()
+	generation = d.generation
+	trigger := d.trigger
+	d.mu.Unlock()
+
+	if trigger == nil || trigger() {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// changes were notified or the damper was stopped while triggering
+	if generation != d.generation && d.timer == nil {
+		return
+	}
+
+	d.pending += pending
+	d.suppressed += suppressed
+	if d.since.IsZero() || since.Before(d.since) {
+		d.since = since
+	}
+
+	// changes received while triggering already scheduled the next trigger
+	if generation != d.generation {
+		return
+	}
+
+	d.logger.Warn("unable to trigger suppressed changes, retrying",
+		"pending_changes", d.pending, "retry_interval", dampingRetryInterval)
+	d.deadline = time.Now().Add(dampingRetryInterval)
+	d.timer = time.AfterFunc(dampingRetryInterval, func() {
+		d.fire(generation)
+	})
+}
+
+// reset clears the pending changes. It assumes the lock is held.
+func (d *Damper) reset() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.generation++
+	d.pending = 0
+	d.suppressed = 0
+	d.since = time.Time{}
+	d.deadline = time.Time{}
+}
+
+// exceedsThreshold returns whether the pending changes exceed either the
+// absolute or the percentage threshold. It assumes the lock is held.
+func (d *Damper) exceedsThreshold() bool {
+	if d.threshold > 0 && d.pending > d.threshold {
+		return true
+	}
+
+	if d.thresholdPercent > 0 {
+		total := len(d.instances)
+		if total == 0 || d.pending*100 > d.thresholdPercent*total {
+			return true
+		}
+	}
+
+	return false
+}
+
+// record updates the instances of the services in the dependency and returns
+// the number of instances that were added, removed or modified. It assumes
+// the lock is held.
+func (d *Damper) record(services []*dep.HealthService) int {
+	if d.instances == nil {
+		d.instances = make(map[string]dampedInstance)
+	}
+
+	names := make(map[string]bool)
+	current := make(map[string]dampedInstance, len(services))
+	for _, s := range services {
+		names[serviceKey(s)] = true
+		current[s.NodeDatacenter+"/"+s.Node+"/"+s.ID] = dampedInstance{
+			service: serviceKey(s),
+			value:   dampedValue(s),
+		}
+	}
+
+	changed := 0
+	for key, prev := range d.instances {
+		if !names[prev.service] {
+			continue
+		}
+		if _, ok := current[key]; !ok {
+			delete(d.instances, key)
+			changed++
+		}
+	}
+	for key, instance := range current {
+		if prev, ok := d.instances[key]; !ok || prev.value != instance.value {
+			changed++
+		}
+		d.instances[key] = instance
+	}
+
+	return changed
+}
+
+// dampedValue returns the comparable information of a service instance. The
+// output of the health checks is excluded.
+func dampedValue(s *dep.HealthService) string {
+	instance := *s
+	checks := make([]string, 0, len(s.Checks))
+	for _, c := range s.Checks {
+		checks = append(checks, fmt.Sprintf("%s=%s", c.CheckID, c.Status))
+	}
+	sort.Strings(checks)
+	instance.Checks = nil
+
+	return fmt.Sprintf("%#v|%s", instance, strings.Join(checks, ","))
+}
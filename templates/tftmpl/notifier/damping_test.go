@@ -0,0 +1,253 @@
+package notifier
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDamper_Observe(t *testing.T) {
+	t.Parallel()
+
+	instances := func(n int, status string) []*dep.HealthService {
+		services := make([]*dep.HealthService, n)
+		for i := 0; i < n; i++ {
+			services[i] = &dep.HealthService{
+				ID:     fmt.Sprintf("api-%d", i),
+				Name:   "api",
+				Node:   "node",
+				Status: status,
+				Checks: consulapi.HealthChecks{
+					{CheckID: "service:api", Status: status, Output: status},
+				},
+			}
+		}
+		return services
+	}
+
+	cases := []struct {
+		name             string
+		threshold        int
+		thresholdPercent int
+		deps             [][]*dep.HealthService
+		notify           []bool
+		pending          int
+	}{
+		{
+			"below threshold",
+			5,
+			0,
+			[][]*dep.HealthService{instances(10, "passing"), instances(12, "passing")},
+			[]bool{false},
+			2,
+		},
+		{
+			"exceeds threshold",
+			1,
+			0,
+			[][]*dep.HealthService{instances(10, "passing"), instances(12, "passing")},
+			[]bool{true},
+			0,
+		},
+		{
+			"accumulates to exceed threshold",
+			2,
+			0,
+			[][]*dep.HealthService{instances(10, "passing"), instances(12, "passing"),
+				instances(13, "passing")},
+			[]bool{false, true},
+			0,
+		},
+		{
+			"exceeds percent threshold",
+			0,
+			10,
+			[][]*dep.HealthService{instances(10, "passing"), instances(10, "warning")},
+			[]bool{true},
+			0,
+		},
+		{
+			"below percent threshold",
+			0,
+			50,
+			[][]*dep.HealthService{instances(10, "passing"), instances(13, "passing")},
+			[]bool{false},
+			3,
+		},
+		{
+			"no thresholds",
+			0,
+			0,
+			[][]*dep.HealthService{instances(10, "passing"), instances(20, "passing")},
+			[]bool{false},
+			10,
+		},
+		{
+			"all instances removed",
+			5,
+			0,
+			[][]*dep.HealthService{instances(10, "passing"), instances(0, "passing")},
+			[]bool{true},
+			0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDamper(tc.threshold, tc.thresholdPercent, time.Hour, time.Hour)
+			defer d.Stop()
+
+			d.Record(tc.deps[0])
+			for ix, services := range tc.deps[1:] {
+				assert.Equal(t, tc.notify[ix], d.Observe(services), "dependency %d", ix)
+			}
+			assert.Equal(t, tc.pending, d.Status().PendingChanges)
+		})
+	}
+
+	t.Run("datacenters are independent", func(t *testing.T) {
+		d := NewDamper(0, 0, time.Hour, time.Hour)
+		defer d.Stop()
+
+		inDC := func(services []*dep.HealthService, dc string) []*dep.HealthService {
+			for _, s := range services {
+				s.NodeDatacenter = dc
+			}
+			return services
+		}
+
+		d.Record(inDC(instances(2, "passing"), "dc1"))
+		d.Record(inDC(instances(2, "passing"), "dc2"))
+		assert.False(t, d.Observe(inDC(instances(1, "passing"), "dc1")))
+		assert.Equal(t, 1, d.Status().PendingChanges)
+		assert.False(t, d.Observe(inDC(instances(2, "passing"), "dc2")))
+		assert.Equal(t, 1, d.Status().PendingChanges)
+	})
+
+	t.Run("instances restored", func(t *testing.T) {
+		d := NewDamper(5, 0, time.Hour, time.Hour)
+		defer d.Stop()
+
+		d.Record(instances(2, "passing"))
+		assert.True(t, d.Observe(instances(0, "passing")))
+		assert.False(t, d.Observe(instances(2, "passing")))
+		assert.Equal(t, 2, d.Status().PendingChanges)
+	})
+
+	t.Run("check output is ignored", func(t *testing.T) {
+		d := NewDamper(0, 0, time.Hour, time.Hour)
+		defer d.Stop()
+
+		d.Record(instances(1, "passing"))
+		services := instances(1, "passing")
+		services[0].Checks[0].Output = "changed"
+		assert.False(t, d.Observe(services))
+		assert.Equal(t, event.DampingStatus{}, d.Status())
+	})
+}
+
+func TestDamper_Trigger(t *testing.T) {
+	t.Parallel()
+
+	instances := func(ids ...string) []*dep.HealthService {
+		services := make([]*dep.HealthService, len(ids))
+		for i, id := range ids {
+			services[i] = &dep.HealthService{ID: id, Name: "api", Node: "node"}
+		}
+		return services
+	}
+
+	t.Run("stable", func(t *testing.T) {
+		d := NewDamper(0, 0, 50*time.Millisecond, time.Hour)
+		triggerCh := make(chan struct{}, 1)
+		d.SetTrigger(func() bool {
+			triggerCh <- struct{}{}
+			return true
+		})
+
+		d.Record(instances("a"))
+		assert.False(t, d.Observe(instances("a", "b")))
+
+		status := d.Status()
+		assert.Equal(t, 1, status.PendingChanges)
+		assert.Equal(t, 1, status.SuppressedNotifications)
+		assert.False(t, status.PendingSince.IsZero())
+		assert.False(t, status.NextTrigger.IsZero())
+
+		select {
+		case <-triggerCh:
+		case <-time.After(time.Second):
+			t.Fatal("suppressed changes did not trigger")
+		}
+		assert.Equal(t, event.DampingStatus{}, d.Status())
+	})
+
+	t.Run("max suppression time", func(t *testing.T) {
+		d := NewDamper(0, 0, time.Hour, 100*time.Millisecond)
+		triggerCh := make(chan struct{}, 1)
+		d.SetTrigger(func() bool {
+			triggerCh <- struct{}{}
+			return true
+		})
+
+		d.Record(instances("a"))
+		assert.False(t, d.Observe(instances("a", "b")))
+		assert.False(t, d.Observe(instances("a", "b", "c")))
+
+		select {
+		case <-triggerCh:
+		case <-time.After(time.Second):
+			t.Fatal("suppressed changes did not trigger")
+		}
+		assert.Equal(t, event.DampingStatus{}, d.Status())
+	})
+
+	t.Run("not accepted", func(t *testing.T) {
+		d := NewDamper(0, 0, 10*time.Millisecond, time.Hour)
+		defer d.Stop()
+		triggerCh := make(chan struct{}, 1)
+		attempts := 0
+		d.SetTrigger(func() bool {
+			attempts++
+			if attempts == 1 {
+				return false
+			}
+			triggerCh <- struct{}{}
+			return true
+		})
+
+		d.Record(instances("a"))
+		assert.False(t, d.Observe(instances("a", "b")))
+
+		select {
+		case <-triggerCh:
+		case <-time.After(3 * dampingRetryInterval):
+			t.Fatal("suppressed changes were not retried")
+		}
+		assert.Equal(t, event.DampingStatus{}, d.Status())
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		d := NewDamper(0, 0, 10*time.Millisecond, time.Hour)
+		triggerCh := make(chan struct{}, 1)
+		d.SetTrigger(func() bool {
+			triggerCh <- struct{}{}
+			return true
+		})
+
+		d.Record(instances("a"))
+		assert.False(t, d.Observe(instances("a", "b")))
+		d.Stop()
+
+		select {
+		case <-triggerCh:
+			t.Fatal("stopped damper should not trigger")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package notifier
+
+import (
+	"sync"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/hcat/dep"
+)
+
+const (
+	nodesSubsystemName         = "nodes"
+	intentionsSubsystemName    = "intentions"
+	configEntriesSubsystemName = "config-entries"
+	httpSubsystemName          = "http"
+	webhookSubsystemName       = "webhook"
+)
+
+// DependencyType is a custom notifier expected to be used for a template that
+// contains a monitored template function (tmplfunc) and any other tmplfuncs
+// e.g. services tmplfunc. The monitored tmplfunc's dependencies are identified
+// by a match function, typically by their type.
+//
+// This notifier only notifies on changes to the monitored dependency and
+// once-mode. It suppresses notifications for changes to other tmplfuncs.
+type DependencyType struct {
+	templates.Template
+	logger logging.Logger
+
+	// match returns true for the dependencies of the monitored tmplfunc
+	match func(d interface{}) bool
+
+	// count all tmplfuncs needed to complete once-mode
+	once    bool
+	tfTotal int
+	counter int
+
+	mu sync.RWMutex
+}
+
+// NewNodes creates a new DependencyType notifier for changes to Consul catalog
+// nodes ({{ catalogNodes }}).
+func NewNodes(tmpl templates.Template, tmplFuncTotal int) *DependencyType {
+	return newDependencyType(tmpl, tmplFuncTotal, nodesSubsystemName,
+		func(d interface{}) bool {
+			_, ok := d.([]*dep.Node)
+			return ok
+		})
+}
+
+// NewIntentions creates a new DependencyType notifier for changes to Consul
+// service intentions ({{ intentions }}).
+func NewIntentions(tmpl templates.Template, tmplFuncTotal int) *DependencyType {
+	return newDependencyType(tmpl, tmplFuncTotal, intentionsSubsystemName,
+		func(d interface{}) bool {
+			_, ok := d.([]*consulapi.Intention)
+			return ok
+		})
+}
+
+// NewConfigEntries creates a new DependencyType notifier for changes to Consul
+// configuration entries ({{ configEntries }}).
+func NewConfigEntries(tmpl templates.Template, tmplFuncTotal int) *DependencyType {
+	return newDependencyType(tmpl, tmplFuncTotal, configEntriesSubsystemName,
+		func(d interface{}) bool {
+			_, ok := d.([]consulapi.ConfigEntry)
+			return ok
+		})
+}
+
+// NewHTTP creates a new DependencyType notifier for changes to the content of
+// an HTTP response ({{ httpJSON }}).
+func NewHTTP(tmpl templates.Template, tmplFuncTotal int) *DependencyType {
+	return newDependencyType(tmpl, tmplFuncTotal, httpSubsystemName,
+		func(d interface{}) bool {
+			_, ok := d.(*tmplfunc.HTTPResponse)
+			return ok
+		})
+}
+
+// NewWebhook creates a new DependencyType notifier for requests to trigger the
+// task ({{ webhookPayload }}). The payload before the first request to trigger
+// the task only counts towards once-mode.
+func NewWebhook(tmpl templates.Template, tmplFuncTotal int) *DependencyType {
+	return newDependencyType(tmpl, tmplFuncTotal, webhookSubsystemName,
+		func(d interface{}) bool {
+			p, ok := d.(*tmplfunc.WebhookPayload)
+			return ok && p.Index > 0
+		})
+}
+
+// newDependencyType creates a new DependencyType notifier.
+//
+// tmplFuncTotal param: the total number of monitored tmplFuncs in the template.
+// This is the number of monitored tmplfuncs needed for both the condition and
+// any module inputs. This number is equivalent to the number of hashicat
+// dependencies.
+//
+// Examples:
+// - nodes: 1 tmplfunc
+// - services-regex: 1 tmplfunc
+// - services-name: len(services) tmplfuncs
+// - consul-kv: 1 tmplfunc
+func newDependencyType(tmpl templates.Template, tmplFuncTotal int,
+	subsystem string, match func(d interface{}) bool) *DependencyType {
+	logger := logging.Global().Named(logSystemName).Named(subsystem)
+	logger.Trace("creating notifier", "type", subsystem,
+		"tmpl_func_total", tmplFuncTotal)
+
+	return &DependencyType{
+		Template: tmpl,
+		match:    match,
+		tfTotal:  tmplFuncTotal,
+		logger:   logger,
+	}
+}
+
+func (n *DependencyType) Override() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if !n.once {
+		n.once = true
+	}
+}
+
+// Notify notifies when the monitored dependency changes.
+//
+// Notifications are sent when:
+// A. There is a change in the monitored dependency
+// B. All the dependencies have been received for the first time. This is
+//    regardless of the dependency type that "completes" having received all the
+//    dependencies.
+//
+// Notification are suppressed when:
+//  - Other types of dependencies that are not monitored. For example,
+//    Services ([]*dep.HealthService).
+func (n *DependencyType) Notify(d interface{}) (notify bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	logDependency(n.logger, d)
+	notify = false
+
+	if !n.once {
+		n.counter++
+		// after a dependency is received for each tmplfunc, send notification
+		// so that once-mode can complete
+		if n.counter >= n.tfTotal {
+			n.logger.Debug("notify once-mode complete")
+			n.once = true
+			notify = true
+		}
+	}
+
+	if n.match(d) {
+		n.logger.Debug("notify dependency change")
+		notify = true
+	}
+
+	if notify {
+		n.Template.Notify(d)
+	}
+
+	return notify
+}
@@ -0,0 +1,163 @@
+package notifier
+
+import (
+	"testing"
+
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_DependencyType_Notify(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		newNotifier func(templates.Template, int) *DependencyType
+		dep         interface{}
+		expected    bool
+	}{
+		{
+			"nodes: don't notify other type of change",
+			NewNodes,
+			[]*dep.HealthService{},
+			false,
+		},
+		{
+			"nodes: notify list of nodes",
+			NewNodes,
+			[]*dep.Node{{Node: "node", Address: "10.0.0.1"}},
+			true,
+		},
+		{
+			"intentions: don't notify other type of change",
+			NewIntentions,
+			[]*dep.Node{},
+			false,
+		},
+		{
+			"intentions: notify list of intentions",
+			NewIntentions,
+			[]*consulapi.Intention{{SourceName: "web", DestinationName: "api"}},
+			true,
+		},
+		{
+			"config-entries: don't notify other type of change",
+			NewConfigEntries,
+			[]*consulapi.Intention{},
+			false,
+		},
+		{
+			"config-entries: notify list of config entries",
+			NewConfigEntries,
+			[]consulapi.ConfigEntry{&consulapi.ServiceConfigEntry{Name: "api"}},
+			true,
+		},
+		{
+			"http: don't notify other type of change",
+			NewHTTP,
+			[]*dep.HealthService{},
+			false,
+		},
+		{
+			"http: notify http response",
+			NewHTTP,
+			&tmplfunc.HTTPResponse{URL: "http://ipam", Data: []interface{}{}},
+			true,
+		},
+		{
+			"webhook: don't notify other type of change",
+			NewWebhook,
+			&tmplfunc.HTTPResponse{},
+			false,
+		},
+		{
+			"webhook: don't notify not triggered",
+			NewWebhook,
+			&tmplfunc.WebhookPayload{Task: "task"},
+			false,
+		},
+		{
+			"webhook: notify webhook payload",
+			NewWebhook,
+			&tmplfunc.WebhookPayload{Task: "task", Index: 1, Body: "deploy"},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := new(mocks.Template)
+			tmpl.On("Notify", mock.Anything).Return(true)
+
+			n := tc.newNotifier(tmpl, 1)
+			n.once = true
+			actual := n.Notify(tc.dep)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_DependencyType_Notify_Once_Mode(t *testing.T) {
+	t.Run("services-last", func(t *testing.T) {
+		// Test that notifier notifies at the end of once-mode, particularly
+		// for the race-condition when the services dependency (which normally
+		// does not notify) is received after nodes dependency.
+
+		// Notifier has 2 dependencies: 1 services and 1 nodes
+		// 1. receive nodes dependency, notify for nodes
+		// 2. receive services dependency, notify for once-mode
+
+		tmpl := new(mocks.Template)
+		tmpl.On("Notify", mock.Anything).Return(true).Twice()
+		n := NewNodes(tmpl, 2)
+
+		// 1. nodes notifies
+		notify := n.Notify([]*dep.Node{{Node: "node"}})
+		assert.True(t, notify, "nodes dep should have notified")
+		assert.False(t, n.once, "got 1/2 deps. once-mode should not be completed")
+		assert.Equal(t, 1, n.counter, "nodes dep should be 1st dep")
+
+		// 2. services notifies
+		notify = n.Notify([]*dep.HealthService{})
+		assert.True(t, notify, "services dep should have notified")
+		assert.True(t, n.once, "got 2/2 deps. once-mode should be completed")
+		assert.Equal(t, 2, n.counter, "services dep should be 2nd dep")
+
+		// check mock template was called twice
+		tmpl.AssertExpectations(t)
+	})
+
+	t.Run("webhook-last", func(t *testing.T) {
+		// Test that notifier notifies at the end of once-mode when the
+		// webhook dependency before any request to trigger the task is the
+		// last dependency received.
+
+		// Notifier has 2 dependencies: 1 services and 1 webhook
+		// 1. receive services dependency, no notification
+		// 2. receive webhook dependency, notify for once-mode
+
+		tmpl := new(mocks.Template)
+		tmpl.On("Notify", mock.Anything).Return(true).Once()
+		n := NewWebhook(tmpl, 2)
+
+		// 1. services does not notify
+		notify := n.Notify([]*dep.HealthService{})
+		assert.False(t, notify, "services dep should not have notified")
+		assert.False(t, n.once, "got 1/2 deps. once-mode should not be completed")
+		assert.Equal(t, 1, n.counter, "services dep should be 1st dep")
+
+		// 2. webhook notifies
+		notify = n.Notify(&tmplfunc.WebhookPayload{Task: "task"})
+		assert.True(t, notify, "webhook dep should have notified")
+		assert.True(t, n.once, "got 2/2 deps. once-mode should be completed")
+		assert.Equal(t, 2, n.counter, "webhook dep should be 2nd dep")
+
+		// check mock template was called once
+		tmpl.AssertExpectations(t)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
 	"github.com/hashicorp/hcat/dep"
 )
 
@@ -32,9 +33,9 @@ func logDependency(logger logging.Logger, dependency interface{}) {
 		}
 		logger.Debug("received dependency",
 			"variable", "services", "ids", serviceIDs)
-	case []*dep.CatalogSnippet:
-		serviceNames := make([]string, len(d))
-		for ix, hs := range d {
+	case *tmplfunc.CatalogServices:
+		serviceNames := make([]string, len(d.Services))
+		for ix, hs := range d.Services {
 			serviceNames[ix] = hs.Name
 		}
 		logger.Debug("received dependency",
@@ -50,6 +51,17 @@ func logDependency(logger logging.Logger, dependency interface{}) {
 		}
 		logger.Debug("received dependency",
 			"variable", "consul_kv", "recurse", true, "keys", keys)
+	case *dep.Secret:
+		// only log the lease information to not expose the secret data
+		logger.Debug("received dependency",
+			"variable", "vault", "lease_id", d.LeaseID,
+			"lease_duration", d.LeaseDuration)
+	case *tmplfunc.HTTPResponse:
+		logger.Debug("received dependency",
+			"variable", "http", "url", d.URL)
+	case *tmplfunc.WebhookPayload:
+		logger.Debug("received dependency",
+			"variable", "webhook", "task", d.Task, "index", d.Index)
 	default:
 		logger.Debug("received unknown dependency",
 			"variable", fmt.Sprintf("%T", dependency))
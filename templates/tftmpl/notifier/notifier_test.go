@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
 	"github.com/hashicorp/hcat/dep"
 	"github.com/stretchr/testify/assert"
 )
@@ -28,9 +29,11 @@ func TestNotifier_logDependency(t *testing.T) {
 		},
 		{
 			"catalog-services",
-			[]*dep.CatalogSnippet{
-				{Name: "api"},
-				{Name: "web"},
+			&tmplfunc.CatalogServices{
+				Services: []*dep.CatalogSnippet{
+					{Name: "api"},
+					{Name: "web"},
+				},
 			},
 			`received dependency: variable=catalog_services names=["api", "web"]`,
 		},
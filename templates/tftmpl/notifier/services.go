@@ -1,6 +1,9 @@
 package notifier
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
@@ -16,10 +19,28 @@ const servicesSubsystemName = "services"
 //
 // This notifier only notifies on changes to services instances information and
 // once-mode. It suppresses notifications for changes to other tmplfuncs.
+//
+// When ignoreStatusChanges is set, it also suppresses notifications for changes
+// that are only to the health status of the service instances, e.g. checks
+// flapping between passing and warning. Instances that are added or removed
+// because of their status are still notified.
+//
+// When a damper is set, notifications for changes to the service instances
+// are suppressed until the damper's threshold is exceeded. Suppressed changes
+// trigger the task through the damper instead.
 type Services struct {
 	templates.Template
 	logger logging.Logger
 
+	ignoreStatusChanges bool
+
+	// membership is the last received information of the service instances of
+	// each services tmplfunc excluding their health, keyed by the names of the
+	// services in the tmplfunc's dependency
+	membership map[string]string
+
+	damper *Damper
+
 	// count all tmplfuncs needed to complete once-mode
 	once    bool
 	tfTotal int
@@ -48,15 +69,28 @@ func (n *Services) Override() {
 // - services-regex: 1 tmplfunc
 // - services-name: len(services) tmplfuncs
 // - consul-kv: 1 tmplfunc
-func NewServices(tmpl templates.Template, tmplFuncTotal int) *Services {
+//
+// ignoreStatusChanges param: whether to suppress notifications for changes
+// that are only to the health status of service instances.
+//
+// damper param: optional damper to suppress notifications for changes to
+// service instances. Nil disables damping.
+func NewServices(tmpl templates.Template, tmplFuncTotal int,
+	ignoreStatusChanges bool, damper *Damper) *Services {
+
 	logger := logging.Global().Named(logSystemName).Named(servicesSubsystemName)
 	logger.Trace("creating notifier", "type", servicesSubsystemName,
-		"tmpl_func_total", tmplFuncTotal)
+		"tmpl_func_total", tmplFuncTotal,
+		"ignore_status_changes", ignoreStatusChanges,
+		"damping", damper != nil)
 
 	return &Services{
-		Template: tmpl,
-		tfTotal:  tmplFuncTotal,
-		logger:   logger,
+		Template:            tmpl,
+		tfTotal:             tmplFuncTotal,
+		logger:              logger,
+		ignoreStatusChanges: ignoreStatusChanges,
+		membership:          make(map[string]string),
+		damper:              damper,
 	}
 }
 
@@ -71,6 +105,7 @@ func (n *Services) Notify(d interface{}) (notify bool) {
 
 	logDependency(n.logger, d)
 	notify = false
+	initial := !n.once
 
 	if !n.once {
 		n.counter++
@@ -83,10 +118,25 @@ func (n *Services) Notify(d interface{}) (notify bool) {
 		}
 	}
 
-	// dependency for {{ servicesRegex }} or {{ service }}
-	if _, ok := d.([]*dep.HealthService); ok {
-		n.logger.Debug("notify services change")
-		notify = true
+	// dependency for {{ servicesRegex }}, {{ service }}, or {{ healthService }}
+	if services, ok := d.([]*dep.HealthService); ok {
+		switch {
+		case n.ignoreStatusChanges && !n.membershipChanged(services):
+			n.logger.Debug("suppress notification for services health status change")
+			if n.damper != nil {
+				n.damper.Record(services)
+			}
+		case n.damper != nil && initial:
+			// initial services are not damped
+			n.damper.Record(services)
+			n.logger.Debug("notify services change")
+			notify = true
+		case n.damper != nil && !n.damper.Observe(services):
+			n.logger.Debug("suppress notification for damped services change")
+		default:
+			n.logger.Debug("notify services change")
+			notify = true
+		}
 	}
 
 	// let the template know that its dependencies have updated so that it will
@@ -95,3 +145,66 @@ func (n *Services) Notify(d interface{}) (notify bool) {
 
 	return notify
 }
+
+// membershipChanged returns whether the service instances of a services
+// dependency changed, ignoring changes to their health status and checks.
+func (n *Services) membershipChanged(services []*dep.HealthService) bool {
+	// Dependencies are only received when their value changes. An empty
+	// dependency means that all instances were removed. It does not identify
+	// the services that it is for, so the membership of all dependencies is
+	// reset. Otherwise restoring the same instances would not be detected as a
+	// change.
+	if len(services) == 0 {
+		n.membership = make(map[string]string)
+		return true
+	}
+
+	if n.membership == nil {
+		n.membership = make(map[string]string)
+	}
+
+	names := make(map[string]bool)
+	instances := make([]string, 0, len(services))
+	for _, s := range services {
+		names[serviceKey(s)] = true
+
+		instance := *s
+		instance.Status = ""
+		instance.Checks = nil
+		instances = append(instances, fmt.Sprintf("%#v", instance))
+	}
+	sort.Strings(instances)
+	membership := strings.Join(instances, "\n")
+
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+	key := strings.Join(sortedNames, ",")
+
+	changed := n.membership[key] != membership
+
+	// Membership of other dependencies with any of the same services is
+	// outdated, e.g. a services regex dependency that had fewer services
+	for k := range n.membership {
+		if k == key {
+			continue
+		}
+		for _, name := range strings.Split(k, ",") {
+			if names[name] {
+				delete(n.membership, k)
+				break
+			}
+		}
+	}
+	n.membership[key] = membership
+
+	return changed
+}
+
+// serviceKey returns the key of the service of a service instance. Services
+// with the same name in different datacenters are different services.
+func serviceKey(s *dep.HealthService) string {
+	return s.Name + "@" + s.NodeDatacenter
+}
@@ -2,9 +2,11 @@ package notifier
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	consulapi "github.com/hashicorp/consul/api"
 	"github.com/hashicorp/hcat/dep"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -56,7 +58,7 @@ func Test_Services_Notify_OnceMode(t *testing.T) {
 
 		tmpl := new(mocks.Template)
 		tmpl.On("Notify", mock.Anything).Return(true)
-		n := NewServices(tmpl, 2)
+		n := NewServices(tmpl, 2, false, nil)
 
 		// 1. service dep notifies
 		notify := n.Notify([]*dep.HealthService{})
@@ -86,7 +88,7 @@ func Test_Services_Notify_OnceMode(t *testing.T) {
 
 		tmpl := new(mocks.Template)
 		tmpl.On("Notify", mock.Anything).Return(true)
-		n := NewServices(tmpl, 2)
+		n := NewServices(tmpl, 2, false, nil)
 
 		// 1. consul-kv dep does not notify
 		notify := n.Notify([]*dep.KeyPair{})
@@ -101,3 +103,125 @@ func Test_Services_Notify_OnceMode(t *testing.T) {
 		assert.Equal(t, 2, n.counter, "services dep should be 2nd dep")
 	})
 }
+
+func Test_Services_Notify_IgnoreStatusChanges(t *testing.T) {
+	t.Parallel()
+
+	api := func(status string, port int) *dep.HealthService {
+		return &dep.HealthService{
+			ID:     "api",
+			Name:   "api",
+			Node:   "node",
+			Port:   port,
+			Status: status,
+			Checks: consulapi.HealthChecks{
+				{CheckID: "service:api", Status: status},
+			},
+		}
+	}
+	web := &dep.HealthService{ID: "web", Name: "web", Node: "node",
+		Status: "passing"}
+	inDC := func(s *dep.HealthService, dc string) *dep.HealthService {
+		s.NodeDatacenter = dc
+		return s
+	}
+
+	cases := []struct {
+		name   string
+		deps   []interface{}
+		notify []bool
+	}{
+		{
+			"status change",
+			[]interface{}{
+				[]*dep.HealthService{api("passing", 8080)},
+				[]*dep.HealthService{api("warning", 8080)},
+				[]*dep.HealthService{api("passing", 8080)},
+			},
+			[]bool{true, false, false},
+		},
+		{
+			"instance change",
+			[]interface{}{
+				[]*dep.HealthService{api("passing", 8080)},
+				[]*dep.HealthService{api("warning", 9090)},
+			},
+			[]bool{true, true},
+		},
+		{
+			"instances added and removed",
+			[]interface{}{
+				[]*dep.HealthService{api("passing", 8080)},
+				[]*dep.HealthService{api("passing", 8080), web},
+				[]*dep.HealthService{api("warning", 8080)},
+				[]*dep.HealthService{},
+			},
+			[]bool{true, true, true, true},
+		},
+		{
+			"instances removed and restored",
+			[]interface{}{
+				[]*dep.HealthService{api("passing", 8080)},
+				[]*dep.HealthService{},
+				[]*dep.HealthService{api("passing", 8080)},
+			},
+			[]bool{true, true, true},
+		},
+		{
+			"status change in multiple datacenters",
+			[]interface{}{
+				[]*dep.HealthService{inDC(api("passing", 8080), "dc1")},
+				[]*dep.HealthService{inDC(api("passing", 8080), "dc2")},
+				[]*dep.HealthService{inDC(api("warning", 8080), "dc1")},
+				[]*dep.HealthService{inDC(api("warning", 8080), "dc2")},
+			},
+			[]bool{true, true, false, false},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := new(mocks.Template)
+			tmpl.On("Notify", mock.Anything).Return(true)
+
+			n := NewServices(tmpl, 1, true, nil)
+			n.Override()
+			for ix, d := range tc.deps {
+				assert.Equal(t, tc.notify[ix], n.Notify(d), "dependency %d", ix)
+			}
+		})
+	}
+}
+
+func Test_Services_Notify_Damping(t *testing.T) {
+	t.Parallel()
+
+	instance := func(id string) *dep.HealthService {
+		return &dep.HealthService{ID: id, Name: "api", Node: "node",
+			Status: "passing"}
+	}
+
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true)
+
+	damper := NewDamper(1, 0, time.Hour, time.Hour)
+	n := NewServices(tmpl, 1, false, damper)
+
+	// initial services are not damped
+	assert.True(t, n.Notify([]*dep.HealthService{instance("api-1")}))
+	assert.Equal(t, 0, damper.Status().PendingChanges)
+
+	// 1 changed instance does not exceed the threshold
+	assert.False(t, n.Notify([]*dep.HealthService{instance("api-1"),
+		instance("api-2")}))
+	assert.Equal(t, 1, damper.Status().PendingChanges)
+	assert.Equal(t, 1, damper.Status().SuppressedNotifications)
+
+	// 2 changed instances exceed the threshold
+	assert.True(t, n.Notify([]*dep.HealthService{instance("api-2"),
+		instance("api-3")}))
+	assert.Equal(t, 0, damper.Status().PendingChanges)
+
+	// non-services dependencies are not damped
+	assert.False(t, n.Notify(&dep.KeyPair{Key: "k", Value: "v"}))
+}
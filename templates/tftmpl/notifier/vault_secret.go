@@ -0,0 +1,80 @@
+package notifier
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/hcat/dep"
+)
+
+const vaultSubsystemName = "vault"
+
+// overriderTemplate is a template wrapped by a notifier
+type overriderTemplate interface {
+	templates.Template
+	Overrider
+}
+
+// VaultSecret is a custom notifier expected to be used for a template that
+// contains the secret template function (tmplfunc) for a vault module input.
+// It wraps the notifier of the task's condition.
+//
+// Unlike other module inputs, a change to the data of the Vault secret
+// triggers the task, e.g. when a new version of the secret is written or a
+// dynamic secret is reissued with new data. Otherwise, it defers to the
+// notifier of the task's condition.
+type VaultSecret struct {
+	overriderTemplate
+	tmpl   templates.Template
+	logger logging.Logger
+
+	// data of the secret last received. nil until the secret is received
+	data map[string]interface{}
+
+	mu sync.RWMutex
+}
+
+// NewVaultSecret creates a new VaultSecret notifier that wraps the notifier n
+// of the template tmpl.
+func NewVaultSecret(n overriderTemplate, tmpl templates.Template) *VaultSecret {
+	logger := logging.Global().Named(logSystemName).Named(vaultSubsystemName)
+	logger.Trace("creating notifier", "type", vaultSubsystemName)
+
+	return &VaultSecret{
+		overriderTemplate: n,
+		tmpl:              tmpl,
+		logger:            logger,
+	}
+}
+
+// Notify notifies when the data of the Vault secret changes.
+//
+// Notifications are sent when:
+// A. The wrapped notifier notifies
+// B. There is a change in the data of the Vault secret dependency (*dep.Secret)
+//    after the secret was first received. Renewing the lease of a secret
+//    without a change to the data does not notify.
+func (n *VaultSecret) Notify(d interface{}) (notify bool) {
+	notify = n.overriderTemplate.Notify(d)
+
+	secret, ok := d.(*dep.Secret)
+	if !ok || secret == nil {
+		return notify
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	changed := n.data != nil && !reflect.DeepEqual(n.data, secret.Data)
+	n.data = secret.Data
+	if changed && !notify {
+		// the wrapped notifier did not let the template know about the change
+		n.logger.Debug("notify vault secret change")
+		n.tmpl.Notify(d)
+		notify = true
+	}
+
+	return notify
+}
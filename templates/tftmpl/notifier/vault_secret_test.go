@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_VaultSecret_Notify(t *testing.T) {
+	t.Parallel()
+
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true)
+
+	inner := &ConsulKV{Template: tmpl, once: true, logger: logging.NewNullLogger()}
+	n := &VaultSecret{overriderTemplate: inner, tmpl: tmpl, logger: logging.NewNullLogger()}
+
+	// 1. first time receiving the secret does not notify
+	notify := n.Notify(&dep.Secret{Data: map[string]interface{}{"key": "v1"}})
+	assert.False(t, notify, "first secret should not notify")
+
+	// 2. lease renewal without a change to the data does not notify
+	notify = n.Notify(&dep.Secret{LeaseDuration: 60,
+		Data: map[string]interface{}{"key": "v1"}})
+	assert.False(t, notify, "unchanged secret should not notify")
+
+	// 3. change to the secret data notifies
+	notify = n.Notify(&dep.Secret{Data: map[string]interface{}{"key": "v2"}})
+	assert.True(t, notify, "changed secret should notify")
+	tmpl.AssertNumberOfCalls(t, "Notify", 1)
+
+	// 4. other dependencies defer to the wrapped notifier
+	notify = n.Notify([]*dep.HealthService{})
+	assert.False(t, notify, "services dep should not notify for consul-kv condition")
+
+	notify = n.Notify(&dep.KeyPair{Key: "key"})
+	assert.True(t, notify, "consul-kv dep should notify for consul-kv condition")
+	tmpl.AssertNumberOfCalls(t, "Notify", 2)
+}
+
+func Test_VaultSecret_Notify_Once_Mode(t *testing.T) {
+	// Test that the wrapped notifier completes once-mode when the secret is
+	// the last dependency received
+
+	tmpl := new(mocks.Template)
+	tmpl.On("Notify", mock.Anything).Return(true).Once()
+	n := NewVaultSecret(NewConsulKV(tmpl, 2), tmpl)
+
+	notify := n.Notify([]*dep.HealthService{})
+	assert.False(t, notify, "got 1/2 deps. services dep should not notify")
+
+	notify = n.Notify(&dep.Secret{Data: map[string]interface{}{"key": "value"}})
+	assert.True(t, notify, "got 2/2 deps. secret dep should notify once-mode")
+	tmpl.AssertExpectations(t)
+}
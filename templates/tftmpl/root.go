@@ -27,6 +27,10 @@ const (
 	// RootFilename is the file name for the root module.
 	RootFilename = "main.tf"
 
+	// RootJSONFilename is the file name for the root module when it is
+	// generated in the JSON configuration syntax.
+	RootJSONFilename = "main.tf.json"
+
 	// VarsFilename is the file name for the variable definitions in the root
 	// module. This includes the required services variable and generated
 	// provider variables based on CTS user configuration for the task.
@@ -40,6 +44,10 @@ const (
 	// variable is written to.
 	TFVarsFilename = "terraform.tfvars"
 
+	// TFVarsJSONFilename is the file name where the required Consul services
+	// input variable is written to in the JSON syntax.
+	TFVarsJSONFilename = "terraform.tfvars.json"
+
 	// VarsTFVarsFileName is the file name for a tfvars file which is generated and contains
 	// variables provided as part of the task configuration. Using the *auto.tfvars naming convention
 	// allows for Terraform to use this file automatically as long as the generated file in Terraform's
@@ -50,6 +58,11 @@ const (
 	// by hcat for monitoring service changes from Consul.
 	TFVarsTmplFilename = "terraform.tfvars.tmpl"
 
+	// ModuleOutputsName is the name of the root module output that contains
+	// the output values of the task's module. CTS reads this output after a
+	// task is applied.
+	ModuleOutputsName = "module_outputs"
+
 	// ProvidersTFVarsFilename is the file name for input variables for
 	// configured Terraform providers. Generated provider input variables are
 	// written in a separate file from terraform.tfvars because it may contain
@@ -95,6 +108,10 @@ type Task struct {
 	Name        string
 	Module      string
 	Version     string
+
+	// ExposeOutputs determines whether the root module has an output for the
+	// output values of the task's module
+	ExposeOutputs bool
 }
 
 type tfFileFunc func(io.Writer, string, *RootModuleInputData) error
@@ -102,12 +119,30 @@ type tfFileFunc func(io.Writer, string, *RootModuleInputData) error
 // RootModuleInputData is the input data used to generate the root module
 type RootModuleInputData struct {
 	TerraformVersion *goVersion.Version
-	Backend          map[string]interface{}
-	Providers        []hcltmpl.NamedBlock
-	ProviderInfo     map[string]interface{}
-	Task             Task
-	Variables        hcltmpl.Variables
-	Templates        []Template
+
+	// RequiredVersion is the version constraint pinned to the generated root
+	// module. Defaults to TerraformRequiredVersion when empty.
+	RequiredVersion string
+
+	Backend      map[string]interface{}
+	Providers    []hcltmpl.NamedBlock
+	ProviderInfo map[string]interface{}
+	Task         Task
+	Variables    hcltmpl.Variables
+	Templates    []Template
+
+	// ServicesTemplate is the content of a user-provided template that renders
+	// the value of the services variable. The templates for the services
+	// variable should not render the variable when it is set.
+	ServicesTemplate string
+
+	// ServicesProtocolV1 determines whether the services variable is defined
+	// with the service definition protocol v1 instead of v0.
+	ServicesProtocolV1 bool
+
+	// JSON determines whether the root module is generated in the JSON
+	// configuration syntax as main.tf.json instead of main.tf.
+	JSON bool
 
 	Path      string
 	FilePerms os.FileMode
@@ -136,7 +171,9 @@ func (d *RootModuleInputData) init() {
 
 // InitRootModule generates the root module and writes the following files to
 // disk.
-//   always: main.tf, variables.tf, terraform.tfvars.tmpl
+//
+//	always: main.tf, variables.tf, terraform.tfvars.tmpl
+//
 // conditionally: variables.module.tf, providers.tfvars
 func InitRootModule(input *RootModuleInputData) error {
 	input.init()
@@ -151,9 +188,36 @@ func InitRootModule(input *RootModuleInputData) error {
 	for k, v := range tfvarsFileFuncs {
 		fileFuncs[k] = v
 	}
+
+	// generate only one format of the files. files of the other format are
+	// removed since Terraform would load both.
+	stale := []string{RootJSONFilename, TFVarsJSONFilename}
+	if input.JSON {
+		delete(fileFuncs, RootFilename)
+		fileFuncs[RootJSONFilename] = newMainTFJSON
+		stale = []string{RootFilename, TFVarsFilename}
+	}
+	for _, filename := range stale {
+		err := os.Remove(filepath.Join(input.Path, filename))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
 	return initModule(input, fileFuncs)
 }
 
+// InitTFVarsTemplate generates only the terraform.tfvars.tmpl template file
+// and writes it to disk. This is used by drivers that render the task's Consul
+// data without executing a Terraform root module.
+func InitTFVarsTemplate(input *RootModuleInputData) error {
+	input.init()
+
+	return initModule(input, map[string]tfFileFunc{
+		TFVarsTmplFilename: newTFVarsTmpl,
+	})
+}
+
 func initModule(input *RootModuleInputData, fileFuncs map[string]tfFileFunc) error {
 	for filename, newFileFunc := range fileFuncs {
 		if filename == ModuleVarsFilename && len(input.Variables) == 0 {
@@ -207,11 +271,19 @@ func newMainTF(w io.Writer, filename string, input *RootModuleInputData) error {
 	hclFile := hclwrite.NewEmptyFile()
 	rootBody := hclFile.Body()
 	rootBody.AppendNewline()
-	appendRootTerraformBlock(rootBody, input.backend, input.ProviderInfo)
+	requiredVersion := input.RequiredVersion
+	if requiredVersion == "" {
+		requiredVersion = TerraformRequiredVersion
+	}
+	appendRootTerraformBlock(rootBody, requiredVersion, input.backend, input.ProviderInfo)
 	rootBody.AppendNewline()
 	appendRootProviderBlocks(rootBody, input.Providers)
 	rootBody.AppendNewline()
 	appendRootModuleBlock(rootBody, input.Task, input.Variables.Keys(), input.Templates...)
+	if input.Task.ExposeOutputs {
+		rootBody.AppendNewline()
+		appendRootOutputBlock(rootBody, input.Task)
+	}
 
 	// Format the file before writing
 	content := hclFile.Bytes()
@@ -222,12 +294,12 @@ func newMainTF(w io.Writer, filename string, input *RootModuleInputData) error {
 
 // appendRootTerraformBlock appends the Terraform block with version constraint
 // and backend.
-func appendRootTerraformBlock(body *hclwrite.Body, backend *hcltmpl.NamedBlock,
-	providerInfo map[string]interface{}) {
+func appendRootTerraformBlock(body *hclwrite.Body, requiredVersion string,
+	backend *hcltmpl.NamedBlock, providerInfo map[string]interface{}) {
 
 	tfBlock := body.AppendNewBlock("terraform", nil)
 	tfBody := tfBlock.Body()
-	tfBody.SetAttributeValue("required_version", cty.StringVal(TerraformRequiredVersion))
+	tfBody.SetAttributeValue("required_version", cty.StringVal(requiredVersion))
 
 	if len(providerInfo) != 0 {
 		requiredProvidersBody := tfBody.AppendNewBlock("required_providers", nil).Body()
@@ -346,6 +418,19 @@ func appendRootModuleBlock(body *hclwrite.Body, task Task, varNames []string, te
 	}
 }
 
+// appendRootOutputBlock appends a Terraform output block that contains the
+// output values of the task's module. The output is marked as sensitive since
+// the module may have sensitive outputs. The sensitive outputs are dropped
+// when the outputs are read.
+func appendRootOutputBlock(body *hclwrite.Body, task Task) {
+	outputBody := body.AppendNewBlock("output", []string{ModuleOutputsName}).Body()
+	outputBody.SetAttributeTraversal("value", hcl.Traversal{
+		hcl.TraverseRoot{Name: "module"},
+		hcl.TraverseAttr{Name: task.Name},
+	})
+	outputBody.SetAttributeValue("sensitive", cty.True)
+}
+
 // appendComment appends a single HCL comment line
 func appendComment(b *hclwrite.Body, comment string) {
 	b.AppendUnstructuredTokens(hclwrite.Tokens{{
@@ -1,6 +1,7 @@
 package tftmpl
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/hashicorp/consul-terraform-sync/config"
@@ -103,7 +104,7 @@ func TestAppendRootTerraformBlock_backend(t *testing.T) {
 				b := hcltmpl.NewNamedBlock(tc.rawBackend)
 				backend = &b
 			}
-			appendRootTerraformBlock(body, backend, nil)
+			appendRootTerraformBlock(body, TerraformRequiredVersion, backend, nil)
 
 			content := hclFile.Bytes()
 			content = hclwrite.Format(content)
@@ -112,6 +113,18 @@ func TestAppendRootTerraformBlock_backend(t *testing.T) {
 	}
 }
 
+func TestAppendRootTerraformBlock_requiredVersion(t *testing.T) {
+	hclFile := hclwrite.NewEmptyFile()
+	appendRootTerraformBlock(hclFile.Body(), ">= 1.6.0, < 1.8.0", nil, nil)
+
+	content := hclwrite.Format(hclFile.Bytes())
+	expected := `terraform {
+  required_version = ">= 1.6.0, < 1.8.0"
+}
+`
+	assert.Equal(t, expected, string(content))
+}
+
 func TestAppendRootProviderBlocks(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -275,3 +288,40 @@ module "test" {
 		})
 	}
 }
+
+func TestNewMainTF_exposeOutputs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expose   bool
+		expected bool
+	}{
+		{"default", false, false},
+		{"expose_outputs", true, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := &RootModuleInputData{
+				Task: Task{
+					Name:          "test",
+					Module:        "namespace/example/test-module",
+					ExposeOutputs: tc.expose,
+				},
+			}
+			input.init()
+
+			var b bytes.Buffer
+			require.NoError(t, newMainTF(&b, RootFilename, input))
+
+			output := `output "module_outputs" {
+  value     = module.test
+  sensitive = true
+}`
+			if tc.expected {
+				assert.Contains(t, b.String(), output)
+			} else {
+				assert.NotContains(t, b.String(), "module_outputs")
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package tftmpl
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
@@ -9,6 +10,10 @@ import (
 const (
 	logSystemName       = "templates"
 	tftmplSubsystemName = "tftmpl"
+
+	// allDatacenters is the datacenters value to query all datacenters known
+	// to the Consul catalog
+	allDatacenters = "all"
 )
 
 // Template handles templates for different template functions to monitor
@@ -38,3 +43,54 @@ type Template interface {
 	// the variables.tf file.
 	appendVariable(io.Writer) error
 }
+
+// sensitiveTemplate is implemented by templates whose variable contains
+// sensitive values. The variable is marked as sensitive when the Terraform
+// version supports it.
+type sensitiveTemplate interface {
+	// appendSensitiveVariable writes the corresponding Terraform variable
+	// block with the sensitive argument to the variables.tf file.
+	appendSensitiveVariable(io.Writer) error
+}
+
+// namedTemplate is implemented by templates that can assign the value of the
+// monitored variable to a variable of any name, which is used to alias the
+// variable of a module input.
+type namedTemplate interface {
+	// appendNamedTemplate writes the generated variable template to the
+	// terraform.tfvars.tmpl file with the value assigned to the variable of
+	// the given name.
+	appendNamedTemplate(w io.Writer, name string) error
+}
+
+// datacentersOrDefault returns the datacenters to query for a template that
+// can be configured with a datacenter or a list of datacenters. The list has
+// a single empty datacenter when neither are configured, which queries the
+// datacenter of the Consul agent.
+func datacentersOrDefault(dc string, dcs []string) []string {
+	if len(dcs) == 0 {
+		return []string{dc}
+	}
+	return dcs
+}
+
+// isAllDatacenters returns whether the list of datacenters configures
+// querying all datacenters known to the Consul catalog.
+func isAllDatacenters(dcs []string) bool {
+	return len(dcs) == 1 && dcs[0] == allDatacenters
+}
+
+// dcOpt returns the datacenter query parameter for a template function. When
+// querying all datacenters, the parameter references the $dc variable of
+// allDatacentersTmpl.
+func dcOpt(dc string) string {
+	if dc == allDatacenters {
+		return `(print "dc=" $dc)`
+	}
+	return quoteOpt(fmt.Sprintf("dc=%s", dc))
+}
+
+// quoteOpt quotes a query parameter for a template function
+func quoteOpt(opt string) string {
+	return `"` + opt + `"`
+}
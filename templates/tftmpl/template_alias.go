@@ -0,0 +1,131 @@
+package tftmpl
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Template          = (*AliasTemplate)(nil)
+	_ sensitiveTemplate = (*AliasTemplate)(nil)
+)
+
+// AliasTemplate handles the template for a labelled module_input block e.g.
+// `module_input "services" "backends"`. It renders the variable of the
+// wrapped template as a variable named after the alias, which allows a task
+// to monitor more than one object of the same variable type.
+type AliasTemplate struct {
+	// Name is the alias of the module input and the name of the variable
+	Name string
+
+	// Template is the template for the monitored variable. It is expected to
+	// render the variable.
+	Template Template
+}
+
+// IsServicesVar returns false because the template renders its own variable
+// instead of the services variable, even for a services module input
+func (t AliasTemplate) IsServicesVar() bool {
+	return false
+}
+
+func (t AliasTemplate) RendersVar() bool {
+	return t.Template.RendersVar()
+}
+
+func (t AliasTemplate) appendModuleAttribute(body *hclwrite.Body) {
+	body.SetAttributeTraversal(t.Name, hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: t.Name},
+	})
+}
+
+// appendTemplate writes the template of the wrapped template with the value
+// assigned to the aliased variable
+func (t AliasTemplate) appendTemplate(w io.Writer) error {
+	nt, ok := t.Template.(namedTemplate)
+	if !ok {
+		return t.aliasError(fmt.Errorf("template %T does not support aliases",
+			t.Template))
+	}
+
+	return nt.appendNamedTemplate(w, t.Name)
+}
+
+// appendVariable writes the variable block of the wrapped template with the
+// aliased variable name. The services templates do not write a variable block
+// since the services variable is always appended, so the block is written for
+// the version of the services variable of the template.
+func (t AliasTemplate) appendVariable(w io.Writer) error {
+	if st, ok := t.Template.(servicesVariableTemplate); ok {
+		return t.writeAliasedVariable(w, st.servicesVariable())
+	}
+
+	var buf bytes.Buffer
+	if err := t.Template.appendVariable(&buf); err != nil {
+		return err
+	}
+
+	return t.writeAliasedVariable(w, buf.Bytes())
+}
+
+// appendSensitiveVariable writes the sensitive variable block of the wrapped
+// template with the aliased variable name, if the variable of the wrapped
+// template contains sensitive values
+func (t AliasTemplate) appendSensitiveVariable(w io.Writer) error {
+	st, ok := t.Template.(sensitiveTemplate)
+	if !ok {
+		return t.appendVariable(w)
+	}
+
+	var buf bytes.Buffer
+	if err := st.appendSensitiveVariable(&buf); err != nil {
+		return err
+	}
+
+	return t.writeAliasedVariable(w, buf.Bytes())
+}
+
+// writeAliasedVariable writes the variable block with its label set to the
+// alias. The content is expected to have a single variable block.
+func (t AliasTemplate) writeAliasedVariable(w io.Writer, content []byte) error {
+	f, diags := hclwrite.ParseConfig(content, "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return t.aliasError(diags)
+	}
+
+	var variables []*hclwrite.Block
+	for _, block := range f.Body().Blocks() {
+		if block.Type() == "variable" {
+			variables = append(variables, block)
+		}
+	}
+	if len(variables) != 1 {
+		return t.aliasError(fmt.Errorf("expected 1 variable block, found %d",
+			len(variables)))
+	}
+	variables[0].SetLabels([]string{t.Name})
+
+	_, err := w.Write(f.Bytes())
+	return err
+}
+
+// aliasError logs and returns the error for a template that cannot be aliased
+func (t AliasTemplate) aliasError(err error) error {
+	err = fmt.Errorf("unable to alias the variable as %q: %s", t.Name, err)
+	logging.Global().Named(logSystemName).Named(tftmplSubsystemName).Error(
+		"unable to write aliased template", "error", err)
+	return err
+}
+
+// servicesVariableTemplate is implemented by the templates for the services
+// variable, which return the version of the services variable block they
+// render.
+type servicesVariableTemplate interface {
+	servicesVariable() []byte
+}
@@ -0,0 +1,152 @@
+package tftmpl
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAliasTemplate_appendModuleAttribute(t *testing.T) {
+	tmpl := AliasTemplate{
+		Name:     "app_config",
+		Template: &ConsulKVTemplate{Path: "app/config", RenderVar: true},
+	}
+
+	f := hclwrite.NewEmptyFile()
+	tmpl.appendModuleAttribute(f.Body())
+	assert.Equal(t, "app_config = var.app_config\n", string(f.Bytes()))
+}
+
+func TestAliasTemplate_appendTemplate(t *testing.T) {
+	testcases := []struct {
+		name string
+		tmpl AliasTemplate
+		exp  string
+	}{
+		{
+			"consul-kv",
+			AliasTemplate{
+				Name: "app_config",
+				Template: &ConsulKVTemplate{
+					Path:      "app/config",
+					RenderVar: true,
+				},
+			},
+			`
+app_config = {
+{{- with $kv := keyExistsGet "app/config" }}
+  {{- if .Exists }}
+  "{{ .Path }}" = "{{ .Value }}"
+  {{- end}}
+{{- end}}
+}
+`,
+		},
+		{
+			"consul-kv decode",
+			AliasTemplate{
+				Name: "app_config",
+				Template: &ConsulKVTemplate{
+					Path:      "app/config",
+					Decode:    "json",
+					RenderVar: true,
+				},
+			},
+			`
+app_config = {{ with $kv := keyExistsGet "app/config" }}{{ HCLConsulKV $kv "json" }}{{ else }}{}{{ end }}
+`,
+		},
+		{
+			"services",
+			AliasTemplate{
+				Name: "backends",
+				Template: &ServicesTemplate{
+					Names:     []string{"web"},
+					RenderVar: true,
+				},
+			},
+			`
+backends = {
+{{- with $srv := service "web" }}
+  {{- range $s := $srv}}
+  "{{ joinStrings "." .ID .Node .Namespace .NodeDatacenter }}" = {
+{{ HCLService $s | indent 4 }}
+  },
+  {{- end}}
+{{- end}}
+}
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var b bytes.Buffer
+			assert.NoError(t, tc.tmpl.appendTemplate(&b))
+			assert.Equal(t, tc.exp, b.String())
+		})
+	}
+}
+
+func TestAliasTemplate_appendVariable(t *testing.T) {
+	testcases := []struct {
+		name     string
+		tmpl     AliasTemplate
+		contains []string
+	}{
+		{
+			"consul-kv",
+			AliasTemplate{
+				Name:     "app_config",
+				Template: &ConsulKVTemplate{Path: "app/config", RenderVar: true},
+			},
+			[]string{`variable "app_config" {`},
+		},
+		{
+			"services",
+			AliasTemplate{
+				Name:     "backends",
+				Template: &ServicesTemplate{Names: []string{"web"}, RenderVar: true},
+			},
+			[]string{"# Service definition protocol v0", `variable "backends" {`},
+		},
+		{
+			"services regex protocol v1",
+			AliasTemplate{
+				Name: "backends",
+				Template: &ServicesRegexTemplate{
+					Regexp:     "^web",
+					RenderVar:  true,
+					ProtocolV1: true,
+				},
+			},
+			[]string{"# Service definition protocol v1", `variable "backends" {`},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var b bytes.Buffer
+			assert.NoError(t, tc.tmpl.appendVariable(&b))
+			for _, s := range tc.contains {
+				assert.Contains(t, b.String(), s)
+			}
+			assert.Equal(t, 1, strings.Count(b.String(), `variable "`))
+		})
+	}
+
+	t.Run("sensitive", func(t *testing.T) {
+		tmpl := AliasTemplate{
+			Name:     "app_secret",
+			Template: &VaultTemplate{Path: "secret/my-app"},
+		}
+
+		var b bytes.Buffer
+		assert.NoError(t, tmpl.appendSensitiveVariable(&b))
+		assert.Contains(t, b.String(), `variable "app_secret" {`)
+		assert.Contains(t, b.String(), "sensitive   = true")
+	})
+}
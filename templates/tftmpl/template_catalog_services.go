@@ -21,6 +21,17 @@ type CatalogServicesTemplate struct {
 	Namespace  string
 	NodeMeta   map[string]string
 
+	// Datacenters is the list of datacenters to query the services in, or
+	// "all" for all datacenters known to the Consul catalog. The services are
+	// keyed by their name and datacenter. Datacenter or Datacenters can be
+	// configured but not both.
+	Datacenters []string
+
+	// Partition and Peer are the admin partition and the cluster peer to query
+	// the services in.
+	Partition string
+	Peer      string
+
 	// RenderVar informs whether the template should render the variable or not.
 	// Aligns with the task condition configuration `UseAsModuleInput``
 	RenderVar bool
@@ -44,10 +55,28 @@ func (t CatalogServicesTemplate) appendModuleAttribute(body *hclwrite.Body) {
 }
 
 func (t CatalogServicesTemplate) appendTemplate(w io.Writer) error {
-	q := t.hcatQuery()
+	return t.appendNamedTemplate(w, "catalog_services")
+}
+
+// appendNamedTemplate writes the template with the value assigned to the
+// variable of the given name
+func (t CatalogServicesTemplate) appendNamedTemplate(w io.Writer, name string) error {
+	tmpl := ""
+	for _, dc := range datacentersOrDefault(t.Datacenter, t.Datacenters) {
+		q := t.hcatQuery(dc)
+		if t.RenderVar {
+			tmpl += fmt.Sprintf(catalogServicesBaseTmpl, q, t.keySuffix(dc))
+		} else {
+			tmpl += fmt.Sprintf(catalogServicesEmptyTmpl, q)
+		}
+	}
+
+	if isAllDatacenters(t.Datacenters) {
+		tmpl = fmt.Sprintf(allDatacentersTmpl, tmpl) + "\n"
+	}
 
 	if t.RenderVar {
-		_, err := fmt.Fprintf(w, catalogServicesSetVarTmpl, q)
+		_, err := fmt.Fprintf(w, catalogServicesSetVarTmpl, name, tmpl)
 		if err != nil {
 			err = fmt.Errorf("unable to write catalog-service template with variable, error: %v", err)
 			return err
@@ -55,51 +84,75 @@ func (t CatalogServicesTemplate) appendTemplate(w io.Writer) error {
 		return nil
 	}
 
-	if _, err := fmt.Fprintf(w, catalogServicesEmptyTmpl, q); err != nil {
+	if _, err := fmt.Fprint(w, tmpl); err != nil {
 		err = fmt.Errorf("unable to write catalog-service empty template, error %v", err)
 		return err
 	}
 	return nil
 }
 
+// keySuffix returns the suffix of the catalog_services keys for services in
+// the datacenter. Services are keyed by their name and datacenter when
+// monitoring a list of datacenters so that the keys are unique.
+func (t CatalogServicesTemplate) keySuffix(dc string) string {
+	switch {
+	case len(t.Datacenters) == 0:
+		return ""
+	case dc == allDatacenters:
+		return ".{{ $dc }}"
+	default:
+		return "." + dc
+	}
+}
+
 func (t CatalogServicesTemplate) appendVariable(w io.Writer) error {
 	_, err := w.Write(variableCatalogServices)
 	return err
 }
 
-func (t CatalogServicesTemplate) hcatQuery() string {
+// hcatQuery returns the query parameters of the services in the datacenter
+func (t CatalogServicesTemplate) hcatQuery(dc string) string {
 	var opts []string
 
 	if t.Regexp != "" {
-		opts = append(opts, fmt.Sprintf("regexp=%s", t.Regexp))
+		opts = append(opts, quoteOpt(fmt.Sprintf("regexp=%s", t.Regexp)))
 	}
 
-	if t.Datacenter != "" {
-		opts = append(opts, fmt.Sprintf("dc=%s", t.Datacenter))
+	if dc != "" {
+		opts = append(opts, dcOpt(dc))
 	}
 
 	if t.Namespace != "" {
-		opts = append(opts, fmt.Sprintf("ns=%s", t.Namespace))
+		opts = append(opts, quoteOpt(fmt.Sprintf("ns=%s", t.Namespace)))
+	}
+
+	if t.Partition != "" {
+		opts = append(opts, quoteOpt(fmt.Sprintf("partition=%s", t.Partition)))
+	}
+
+	if t.Peer != "" {
+		opts = append(opts, quoteOpt(fmt.Sprintf("peer=%s", t.Peer)))
 	}
 
 	for k, v := range t.NodeMeta {
-		opts = append(opts, fmt.Sprintf("node-meta=%s:%s", k, v))
+		opts = append(opts, quoteOpt(fmt.Sprintf("node-meta=%s:%s", k, v)))
 	}
 
 	if len(opts) > 0 {
-		return `"` + strings.Join(opts, `" "`) + `" ` // deliberate space at end
+		return strings.Join(opts, " ") + " " // deliberate space at end
 	}
 	return ""
 }
 
-var catalogServicesSetVarTmpl = fmt.Sprintf(`
-catalog_services = {%s}
-`, catalogServicesBaseTmpl)
+const catalogServicesSetVarTmpl = `
+%s = {%s}
+`
 
+// catalogServicesBaseTmpl expects the query and the suffix of the service keys
 const catalogServicesBaseTmpl = `
 {{- with $catalogServices := catalogServicesRegistration %s}}
   {{- range $cs := $catalogServices }}
-  "{{ $cs.Name }}" = {{ HCLServiceTags $cs.Tags }}
+  "{{ $cs.Name }}%s" = {{ HCLServiceTags $cs.Tags }}
 {{- end}}{{- end}}
 `
 
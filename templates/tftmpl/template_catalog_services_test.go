@@ -0,0 +1,117 @@
+package tftmpl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogServicesTemplate_appendTemplate(t *testing.T) {
+	testcases := []struct {
+		name string
+		c    *CatalogServicesTemplate
+		exp  string
+	}{
+		{
+			"fully configured & render var",
+			&CatalogServicesTemplate{
+				Regexp:     ".*",
+				Datacenter: "dc1",
+				Namespace:  "ns1",
+				NodeMeta:   map[string]string{"k": "v"},
+				RenderVar:  true,
+			},
+			`
+catalog_services = {
+{{- with $catalogServices := catalogServicesRegistration "regexp=.*" "dc=dc1" "ns=ns1" "node-meta=k:v" }}
+  {{- range $cs := $catalogServices }}
+  "{{ $cs.Name }}" = {{ HCLServiceTags $cs.Tags }}
+{{- end}}{{- end}}
+}
+`,
+		},
+		{
+			"partition & peer & render var",
+			&CatalogServicesTemplate{
+				Regexp:    ".*",
+				Partition: "ap1",
+				Peer:      "cluster-02",
+				RenderVar: true,
+			},
+			`
+catalog_services = {
+{{- with $catalogServices := catalogServicesRegistration "regexp=.*" "partition=ap1" "peer=cluster-02" }}
+  {{- range $cs := $catalogServices }}
+  "{{ $cs.Name }}" = {{ HCLServiceTags $cs.Tags }}
+{{- end}}{{- end}}
+}
+`,
+		},
+		{
+			"datacenters & render var",
+			&CatalogServicesTemplate{
+				Datacenters: []string{"dc1", "dc2"},
+				RenderVar:   true,
+			},
+			`
+catalog_services = {
+{{- with $catalogServices := catalogServicesRegistration "dc=dc1" }}
+  {{- range $cs := $catalogServices }}
+  "{{ $cs.Name }}.dc1" = {{ HCLServiceTags $cs.Tags }}
+{{- end}}{{- end}}
+
+{{- with $catalogServices := catalogServicesRegistration "dc=dc2" }}
+  {{- range $cs := $catalogServices }}
+  "{{ $cs.Name }}.dc2" = {{ HCLServiceTags $cs.Tags }}
+{{- end}}{{- end}}
+}
+`,
+		},
+		{
+			"all datacenters & render var",
+			&CatalogServicesTemplate{
+				Regexp:      "api",
+				Datacenters: []string{"all"},
+				RenderVar:   true,
+			},
+			`
+catalog_services = {
+{{- range $dc := datacenters }}
+{{- with $catalogServices := catalogServicesRegistration "regexp=api" (print "dc=" $dc) }}
+  {{- range $cs := $catalogServices }}
+  "{{ $cs.Name }}.{{ $dc }}" = {{ HCLServiceTags $cs.Tags }}
+{{- end}}{{- end}}
+
+{{- end}}
+}
+`,
+		},
+		{
+			"all datacenters & no var",
+			&CatalogServicesTemplate{
+				Datacenters: []string{"all"},
+				RenderVar:   false,
+			},
+			`
+{{- range $dc := datacenters }}
+{{- with $catalogServices := catalogServicesRegistration (print "dc=" $dc) }}
+  {{- range $cs := $catalogServices }}
+    {{- /* Empty template. Detects changes in catalog-services */ -}}
+{{- end}}{{- end}}
+
+{{- end}}
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(strings.Builder)
+			err := tc.c.appendTemplate(w)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, w.String())
+		})
+	}
+}
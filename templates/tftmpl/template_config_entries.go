@@ -0,0 +1,129 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Template = (*ConfigEntriesTemplate)(nil)
+)
+
+// ConfigEntriesTemplate handles the template for the config_entries variable
+// for the template function: `{{ configEntries }}`
+type ConfigEntriesTemplate struct {
+	Kind       string
+	Name       string
+	Regexp     string
+	Datacenter string
+	Namespace  string
+
+	// RenderVar informs whether the template should render the variable or not.
+	// Aligns with the task condition configuration `UseAsModuleInput``
+	RenderVar bool
+}
+
+// IsServicesVar returns false because the template returns a config_entries
+// variable, not a services variable
+func (t ConfigEntriesTemplate) IsServicesVar() bool {
+	return false
+}
+
+func (t ConfigEntriesTemplate) RendersVar() bool {
+	return t.RenderVar
+}
+
+func (t ConfigEntriesTemplate) appendModuleAttribute(body *hclwrite.Body) {
+	body.SetAttributeTraversal("config_entries", hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: "config_entries"},
+	})
+}
+
+func (t ConfigEntriesTemplate) appendTemplate(w io.Writer) error {
+	return t.appendNamedTemplate(w, "config_entries")
+}
+
+// appendNamedTemplate writes the template with the value assigned to the
+// variable of the given name
+func (t ConfigEntriesTemplate) appendNamedTemplate(w io.Writer, name string) error {
+	q := t.hcatQuery()
+
+	if t.RenderVar {
+		_, err := fmt.Fprintf(w, configEntriesSetVarTmpl, name, q)
+		if err != nil {
+			err = fmt.Errorf("unable to write config-entries template with variable, error: %v", err)
+			return err
+		}
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(w, configEntriesEmptyTmpl, q); err != nil {
+		err = fmt.Errorf("unable to write config-entries empty template, error %v", err)
+		return err
+	}
+	return nil
+}
+
+func (t ConfigEntriesTemplate) appendVariable(w io.Writer) error {
+	_, err := w.Write(variableConfigEntries)
+	return err
+}
+
+func (t ConfigEntriesTemplate) hcatQuery() string {
+	opts := []string{fmt.Sprintf("kind=%s", t.Kind)}
+
+	if t.Name != "" {
+		opts = append(opts, fmt.Sprintf("name=%s", t.Name))
+	}
+
+	if t.Regexp != "" {
+		opts = append(opts, fmt.Sprintf("regexp=%s", t.Regexp))
+	}
+
+	if t.Datacenter != "" {
+		opts = append(opts, fmt.Sprintf("dc=%s", t.Datacenter))
+	}
+
+	if t.Namespace != "" {
+		opts = append(opts, fmt.Sprintf("ns=%s", t.Namespace))
+	}
+
+	return `"` + strings.Join(opts, `" "`) + `" ` // deliberate space at end
+}
+
+var configEntriesSetVarTmpl = fmt.Sprintf(`
+%%s = {%s}
+`, configEntriesBaseTmpl)
+
+const configEntriesBaseTmpl = `
+{{- with $entries := configEntries %s}}
+  {{- range $e := $entries }}
+  "{{ $e.GetName }}" = {
+{{ HCLConfigEntry $e | indent 4 }}
+  },
+{{- end}}{{- end}}
+`
+
+const configEntriesEmptyTmpl = `
+{{- with $entries := configEntries %s}}
+  {{- range $e := $entries }}
+    {{- /* Empty template. Detects changes in config entries */ -}}
+{{- end}}{{- end}}
+`
+
+// variableConfigEntries is required for modules that include Consul
+// configuration entry information. It is versioned to track compatibility
+// between the generated root module and modules that include config entries.
+// The type is not declared because the fields of an entry depend on its kind.
+var variableConfigEntries = []byte(`
+# Config entries definition protocol v0
+variable "config_entries" {
+  description = "Consul configuration entries of a kind keyed by entry name"
+  type        = any
+}
+`)
@@ -0,0 +1,48 @@
+package tftmpl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigEntriesTemplate_hcatQuery(t *testing.T) {
+	testcase := []struct {
+		name string
+		i    *ConfigEntriesTemplate
+		exp  string
+	}{
+		{
+			"kind only",
+			&ConfigEntriesTemplate{
+				Kind: "service-defaults",
+			},
+			"\"kind=service-defaults\" ",
+		},
+		{
+			"name",
+			&ConfigEntriesTemplate{
+				Kind: "service-defaults",
+				Name: "api",
+			},
+			"\"kind=service-defaults\" \"name=api\" ",
+		},
+		{
+			"all_parameters",
+			&ConfigEntriesTemplate{
+				Kind:       "ingress-gateway",
+				Regexp:     "^ingress-",
+				Datacenter: "dc2",
+				Namespace:  "ns2",
+			},
+			"\"kind=ingress-gateway\" \"regexp=^ingress-\" \"dc=dc2\" \"ns=ns2\" ",
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.i.hcatQuery()
+			assert.Equal(t, tc.exp, actual)
+		})
+	}
+}
@@ -15,12 +15,19 @@ var (
 )
 
 // ConsulKVTemplate handles the template for the consul_kv variable for the
-// template functions: `{{ key }}` and `{{ keyExistsGet }}`
+// template functions: `{{ keys }}` and `{{ keyExistsGet }}`, or
+// `{{ consulKVList }}` and `{{ consulKVExistsGet }}` for an admin partition.
 type ConsulKVTemplate struct {
 	Path       string
 	Recurse    bool
 	Datacenter string
 	Namespace  string
+	Partition  string
+
+	// Decode is the format to decode the values with: "json", "yaml", or
+	// "hcl". When set, the values are rendered as structured objects instead
+	// of strings.
+	Decode string
 
 	// RenderVar informs whether the template should render the variable or not.
 	// Aligns with the task condition configuration `UseAsModuleInput``
@@ -48,20 +55,43 @@ func (t ConsulKVTemplate) appendModuleAttribute(body *hclwrite.Body) {
 // It determines which template to use based on the values of the RenderVar and
 // recurse options. If RenderVar is true, then set the consul_kv variable to
 // the template. If recurse is set to true, then use the 'keys' template,
-// otherwise use the 'keyExists'/'key' template.
+// otherwise use the 'keyExists'/'key' template. If decode is set, the values
+// are decoded by the 'HCLConsulKV' template function.
 func (t ConsulKVTemplate) appendTemplate(w io.Writer) error {
+	return t.appendNamedTemplate(w, "consul_kv")
+}
+
+// appendNamedTemplate writes the template with the value assigned to the
+// variable of the given name
+func (t ConsulKVTemplate) appendNamedTemplate(w io.Writer, name string) error {
 	logger := logging.Global().Named(logSystemName).Named(tftmplSubsystemName)
 	q := t.hcatQuery()
+	getFunc, listFunc := t.tmplFuncNames()
+
+	if t.RenderVar && t.Decode != "" {
+		kvFunc := getFunc
+		if t.Recurse {
+			kvFunc = listFunc
+		}
+
+		_, err := fmt.Fprintf(w, consulKVDecodeSetVarTmpl, name, kvFunc, q, t.Decode)
+		if err != nil {
+			logger.Error("unable to write consul-kv decode template with variable",
+				"error", err)
+			return err
+		}
+		return nil
+	}
 
 	if t.RenderVar {
 		var baseTmpl string
 		if t.Recurse {
-			baseTmpl = fmt.Sprintf(consulKVRecurseBaseTmpl, q)
+			baseTmpl = fmt.Sprintf(consulKVRecurseBaseTmpl, listFunc, q)
 		} else {
-			baseTmpl = fmt.Sprintf(consulKVBaseTmpl, q)
+			baseTmpl = fmt.Sprintf(consulKVBaseTmpl, getFunc, q)
 		}
 
-		if _, err := fmt.Fprintf(w, consulKVSetVarTmpl, baseTmpl); err != nil {
+		if _, err := fmt.Fprintf(w, consulKVSetVarTmpl, name, baseTmpl); err != nil {
 			logger.Error("unable to write consul-kv template with variable", "error", err)
 			return err
 		}
@@ -70,9 +100,9 @@ func (t ConsulKVTemplate) appendTemplate(w io.Writer) error {
 
 	var emptyTmpl string
 	if t.Recurse {
-		emptyTmpl = fmt.Sprintf(consulKVRecurseEmptyTmpl, q)
+		emptyTmpl = fmt.Sprintf(consulKVRecurseEmptyTmpl, listFunc, q)
 	} else {
-		emptyTmpl = fmt.Sprintf(consulKVEmptyTmpl, q)
+		emptyTmpl = fmt.Sprintf(consulKVEmptyTmpl, getFunc, q)
 	}
 	if _, err := w.Write([]byte(emptyTmpl)); err != nil {
 		logger.Error("unable to write consul-kv empty template", "error", err)
@@ -82,10 +112,25 @@ func (t ConsulKVTemplate) appendTemplate(w io.Writer) error {
 }
 
 func (t ConsulKVTemplate) appendVariable(w io.Writer) error {
-	_, err := w.Write(variableConsulKV)
+	variable := variableConsulKV
+	if t.Decode != "" {
+		variable = variableConsulKVDecoded
+	}
+
+	_, err := w.Write(variable)
 	return err
 }
 
+// tmplFuncNames returns the names of the template functions to get a single
+// key and to list the keys under a prefix. The hcat functions do not support
+// admin partitions.
+func (t ConsulKVTemplate) tmplFuncNames() (string, string) {
+	if t.Partition != "" {
+		return "consulKVExistsGet", "consulKVList"
+	}
+	return "keyExistsGet", "keys"
+}
+
 func (t ConsulKVTemplate) hcatQuery() string {
 	var opts []string
 
@@ -99,6 +144,10 @@ func (t ConsulKVTemplate) hcatQuery() string {
 		opts = append(opts, fmt.Sprintf("ns=%s", t.Namespace))
 	}
 
+	if t.Partition != "" {
+		opts = append(opts, fmt.Sprintf("partition=%s", t.Partition))
+	}
+
 	if len(opts) > 0 {
 		return `"` + strings.Join(opts, `" "`) + `"`
 	}
@@ -106,19 +155,25 @@ func (t ConsulKVTemplate) hcatQuery() string {
 }
 
 var consulKVSetVarTmpl = `
-consul_kv = {%s}
+%s = {%s}
 `
 
 const consulKVBaseTmpl = `
-{{- with $kv := keyExistsGet %s }}
+{{- with $kv := %s %s }}
   {{- if .Exists }}
   "{{ .Path }}" = "{{ .Value }}"
   {{- end}}
 {{- end}}
 `
 
+// consulKVDecodeSetVarTmpl sets the consul_kv variable to the decoded values.
+// Values that cannot be decoded error when the template is rendered.
+const consulKVDecodeSetVarTmpl = `
+%s = {{ with $kv := %s %s }}{{ HCLConsulKV $kv %q }}{{ else }}{}{{ end }}
+`
+
 const consulKVRecurseBaseTmpl = `
-{{- with $kv := keys %s }}
+{{- with $kv := %s %s }}
   {{- range $k := $kv }}
   "{{ .Path }}" = "{{ .Value }}"
   {{- end}}
@@ -126,13 +181,13 @@ const consulKVRecurseBaseTmpl = `
 `
 
 const consulKVEmptyTmpl = `
-{{- with $kv := keyExistsGet %s }}
+{{- with $kv := %s %s }}
   {{- /* Empty template. Detects changes in Consul KV */ -}}
 {{- end}}
 `
 
 const consulKVRecurseEmptyTmpl = `
-{{- with $kv := keys %s }}
+{{- with $kv := %s %s }}
   {{- range $k, $v := $kv }}
   {{- /* Empty template. Detects changes in Consul KV */ -}}
   {{- end}}
@@ -149,3 +204,15 @@ variable "consul_kv" {
   type        = map(string)
 }
 `)
+
+// variableConsulKVDecoded is the consul_kv variable for modules that include
+// Consul KV values decoded by the decode option. The type is not declared
+// because the structure of the values depends on the documents stored in
+// Consul KV.
+var variableConsulKVDecoded = []byte(`
+# Consul KV decoded definition protocol v0
+variable "consul_kv" {
+  description = "Consul KV pairs with values decoded by Consul-Terraform-Sync"
+  type        = any
+}
+`)
@@ -30,6 +30,14 @@ func TestConsulKVTemplate_hcatQuery(t *testing.T) {
 			},
 			"\"key-path\" \"dc=dc2\" \"ns=test-ns\"",
 		},
+		{
+			"partition",
+			&ConsulKVTemplate{
+				Path:      "key-path",
+				Partition: "ap1",
+			},
+			"\"key-path\" \"partition=ap1\"",
+		},
 	}
 
 	for _, tc := range testcase {
@@ -82,6 +90,74 @@ consul_kv = {
   {{- end}}
 {{- end}}
 }
+`,
+		},
+		{
+			"partition & recurse true & render var",
+			&ConsulKVTemplate{
+				Path:      "path",
+				Recurse:   true,
+				Partition: "ap1",
+				RenderVar: true,
+			},
+			`
+consul_kv = {
+{{- with $kv := consulKVList "path" "partition=ap1" }}
+  {{- range $k := $kv }}
+  "{{ .Path }}" = "{{ .Value }}"
+  {{- end}}
+{{- end}}
+}
+`,
+		},
+		{
+			"partition & recurse false & no var",
+			&ConsulKVTemplate{
+				Path:      "path",
+				Partition: "ap1",
+				RenderVar: false,
+			},
+			`
+{{- with $kv := consulKVExistsGet "path" "partition=ap1" }}
+  {{- /* Empty template. Detects changes in Consul KV */ -}}
+{{- end}}
+`,
+		},
+		{
+			"decode & render var",
+			&ConsulKVTemplate{
+				Path:      "path",
+				Decode:    "json",
+				RenderVar: true,
+			},
+			`
+consul_kv = {{ with $kv := keyExistsGet "path" }}{{ HCLConsulKV $kv "json" }}{{ else }}{}{{ end }}
+`,
+		},
+		{
+			"decode & recurse true & render var",
+			&ConsulKVTemplate{
+				Path:      "path",
+				Recurse:   true,
+				Partition: "ap1",
+				Decode:    "yaml",
+				RenderVar: true,
+			},
+			`
+consul_kv = {{ with $kv := consulKVList "path" "partition=ap1" }}{{ HCLConsulKV $kv "yaml" }}{{ else }}{}{{ end }}
+`,
+		},
+		{
+			"decode & no var",
+			&ConsulKVTemplate{
+				Path:      "path",
+				Decode:    "hcl",
+				RenderVar: false,
+			},
+			`
+{{- with $kv := keyExistsGet "path" }}
+  {{- /* Empty template. Detects changes in Consul KV */ -}}
+{{- end}}
 `,
 		},
 		{
@@ -127,3 +203,31 @@ consul_kv = {
 		})
 	}
 }
+
+func TestConsulKVTemplate_appendVariable(t *testing.T) {
+	testcases := []struct {
+		name string
+		c    *ConsulKVTemplate
+		exp  []byte
+	}{
+		{
+			"raw values",
+			&ConsulKVTemplate{Path: "path"},
+			variableConsulKV,
+		},
+		{
+			"decoded values",
+			&ConsulKVTemplate{Path: "path", Decode: "json"},
+			variableConsulKVDecoded,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(strings.Builder)
+			err := tc.c.appendVariable(w)
+			require.NoError(t, err)
+			assert.Equal(t, string(tc.exp), w.String())
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Template = (*HTTPTemplate)(nil)
+)
+
+// HTTPTemplate handles the template for the http variable for the template
+// function: `{{ httpJSON }}`
+type HTTPTemplate struct {
+	URL      string
+	Headers  map[string]string
+	Interval time.Duration
+	Timeout  time.Duration
+	Selector string
+
+	TLSEnabled bool
+	TLSVerify  bool
+	CACert     string
+	CAPath     string
+	Cert       string
+	Key        string
+	ServerName string
+
+	// RenderVar informs whether the template should render the variable or not.
+	// Aligns with the task condition configuration `UseAsModuleInput``
+	RenderVar bool
+}
+
+// IsServicesVar returns false because the template returns an http variable,
+// not a services variable
+func (t HTTPTemplate) IsServicesVar() bool {
+	return false
+}
+
+func (t HTTPTemplate) RendersVar() bool {
+	return t.RenderVar
+}
+
+func (t HTTPTemplate) appendModuleAttribute(body *hclwrite.Body) {
+	body.SetAttributeTraversal("http", hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: "http"},
+	})
+}
+
+func (t HTTPTemplate) appendTemplate(w io.Writer) error {
+	return t.appendNamedTemplate(w, "http")
+}
+
+// appendNamedTemplate writes the template with the value assigned to the
+// variable of the given name
+func (t HTTPTemplate) appendNamedTemplate(w io.Writer, name string) error {
+	q := t.hcatQuery()
+
+	if t.RenderVar {
+		_, err := fmt.Fprintf(w, httpSetVarTmpl, name, q)
+		if err != nil {
+			err = fmt.Errorf("unable to write http template with variable, error: %v", err)
+			return err
+		}
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(w, httpEmptyTmpl, q); err != nil {
+		err = fmt.Errorf("unable to write http empty template, error %v", err)
+		return err
+	}
+	return nil
+}
+
+func (t HTTPTemplate) appendVariable(w io.Writer) error {
+	_, err := w.Write(variableHTTP)
+	return err
+}
+
+// hcatQuery quotes each option because header values and URLs can contain
+// characters that need to be escaped within the template.
+func (t HTTPTemplate) hcatQuery() string {
+	opts := []string{fmt.Sprintf("url=%s", t.URL)}
+
+	if t.Interval > 0 {
+		opts = append(opts, fmt.Sprintf("interval=%s", t.Interval))
+	}
+
+	if t.Timeout > 0 {
+		opts = append(opts, fmt.Sprintf("timeout=%s", t.Timeout))
+	}
+
+	if t.Selector != "" {
+		opts = append(opts, fmt.Sprintf("selector=%s", t.Selector))
+	}
+
+	headers := make([]string, 0, len(t.Headers))
+	for k := range t.Headers {
+		headers = append(headers, k)
+	}
+	sort.Strings(headers)
+	for _, k := range headers {
+		opts = append(opts, fmt.Sprintf("header=%s: %s", k, t.Headers[k]))
+	}
+
+	if t.TLSEnabled {
+		opts = append(opts, "tls=true")
+		if !t.TLSVerify {
+			opts = append(opts, "tls_skip_verify=true")
+		}
+		if t.CACert != "" {
+			opts = append(opts, fmt.Sprintf("ca_cert=%s", t.CACert))
+		}
+		if t.CAPath != "" {
+			opts = append(opts, fmt.Sprintf("ca_path=%s", t.CAPath))
+		}
+		if t.Cert != "" {
+			opts = append(opts, fmt.Sprintf("cert=%s", t.Cert))
+		}
+		if t.Key != "" {
+			opts = append(opts, fmt.Sprintf("key=%s", t.Key))
+		}
+		if t.ServerName != "" {
+			opts = append(opts, fmt.Sprintf("server_name=%s", t.ServerName))
+		}
+	}
+
+	quoted := make([]string, len(opts))
+	for ix, opt := range opts {
+		quoted[ix] = fmt.Sprintf("%q", opt)
+	}
+
+	return strings.Join(quoted, " ") + " " // deliberate space at end
+}
+
+const httpSetVarTmpl = `
+%s = {{ with $resp := httpJSON %s}}{{ HCLHTTPResponse $resp }}{{ else }}{}{{ end }}
+`
+
+const httpEmptyTmpl = `
+{{- with $resp := httpJSON %s}}
+  {{- /* Empty template. Detects changes in the HTTP response */ -}}
+{{- end}}
+`
+
+// variableHTTP is required for modules that include the JSON response of a URL
+// that is polled. It is versioned to track compatibility between the generated
+// root module and modules that include the response. The type is not declared
+// because the format of the response depends on the URL.
+var variableHTTP = []byte(`
+# HTTP response definition protocol v0
+variable "http" {
+  description = "Decoded JSON response of the URL polled by Consul-Terraform-Sync"
+  type        = any
+}
+`)
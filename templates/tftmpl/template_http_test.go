@@ -0,0 +1,72 @@
+package tftmpl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPTemplate_hcatQuery(t *testing.T) {
+	testcase := []struct {
+		name string
+		i    *HTTPTemplate
+		exp  string
+	}{
+		{
+			"url only",
+			&HTTPTemplate{
+				URL: "http://ipam.example.com",
+			},
+			"\"url=http://ipam.example.com\" ",
+		},
+		{
+			"headers",
+			&HTTPTemplate{
+				URL: "http://ipam.example.com",
+				Headers: map[string]string{
+					"X-Site":        "dc1",
+					"Authorization": "Bearer \"token\"",
+				},
+			},
+			"\"url=http://ipam.example.com\" " +
+				"\"header=Authorization: Bearer \\\"token\\\"\" " +
+				"\"header=X-Site: dc1\" ",
+		},
+		{
+			"all_parameters",
+			&HTTPTemplate{
+				URL:        "https://ipam.example.com/api?site=dc1",
+				Interval:   30 * time.Second,
+				Timeout:    5 * time.Second,
+				Selector:   `$["prefixes"]`,
+				TLSEnabled: true,
+				TLSVerify:  false,
+				CACert:     "ca.pem",
+				CAPath:     "/certs",
+				Cert:       "cert.pem",
+				Key:        "key.pem",
+				ServerName: "ipam",
+			},
+			"\"url=https://ipam.example.com/api?site=dc1\" \"interval=30s\" " +
+				"\"timeout=5s\" \"selector=$[\\\"prefixes\\\"]\" \"tls=true\" " +
+				"\"tls_skip_verify=true\" \"ca_cert=ca.pem\" \"ca_path=/certs\" " +
+				"\"cert=cert.pem\" \"key=key.pem\" \"server_name=ipam\" ",
+		},
+		{
+			"tls_disabled",
+			&HTTPTemplate{
+				URL:    "https://ipam.example.com",
+				CACert: "ca.pem",
+			},
+			"\"url=https://ipam.example.com\" ",
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.i.hcatQuery()
+			assert.Equal(t, tc.exp, actual)
+		})
+	}
+}
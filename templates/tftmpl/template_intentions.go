@@ -0,0 +1,163 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Template = (*IntentionsTemplate)(nil)
+)
+
+// IntentionsTemplate handles the template for the intentions variable for the
+// template function: `{{ intentions }}`
+type IntentionsTemplate struct {
+	Datacenter string
+	Namespace  string
+	Filter     string
+
+	// RenderVar informs whether the template should render the variable or not.
+	// Aligns with the task condition configuration `UseAsModuleInput``
+	RenderVar bool
+}
+
+// IsServicesVar returns false because the template returns an intentions
+// variable, not a services variable
+func (t IntentionsTemplate) IsServicesVar() bool {
+	return false
+}
+
+func (t IntentionsTemplate) RendersVar() bool {
+	return t.RenderVar
+}
+
+func (t IntentionsTemplate) appendModuleAttribute(body *hclwrite.Body) {
+	body.SetAttributeTraversal("intentions", hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: "intentions"},
+	})
+}
+
+func (t IntentionsTemplate) appendTemplate(w io.Writer) error {
+	return t.appendNamedTemplate(w, "intentions")
+}
+
+// appendNamedTemplate writes the template with the value assigned to the
+// variable of the given name
+func (t IntentionsTemplate) appendNamedTemplate(w io.Writer, name string) error {
+	q := t.hcatQuery()
+
+	if t.RenderVar {
+		_, err := fmt.Fprintf(w, intentionsSetVarTmpl, name, q)
+		if err != nil {
+			err = fmt.Errorf("unable to write intentions template with variable, error: %v", err)
+			return err
+		}
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(w, intentionsEmptyTmpl, q); err != nil {
+		err = fmt.Errorf("unable to write intentions empty template, error %v", err)
+		return err
+	}
+	return nil
+}
+
+func (t IntentionsTemplate) appendVariable(w io.Writer) error {
+	_, err := w.Write(variableIntentions)
+	return err
+}
+
+func (t IntentionsTemplate) hcatQuery() string {
+	var opts []string
+
+	if t.Datacenter != "" {
+		opts = append(opts, fmt.Sprintf("dc=%s", t.Datacenter))
+	}
+
+	if t.Namespace != "" {
+		opts = append(opts, fmt.Sprintf("ns=%s", t.Namespace))
+	}
+
+	if t.Filter != "" {
+		filter := strings.ReplaceAll(t.Filter, `"`, `\"`)
+		filter = strings.Trim(filter, "\n")
+		opts = append(opts, filter)
+	}
+
+	if len(opts) > 0 {
+		return `"` + strings.Join(opts, `" "`) + `" ` // deliberate space at end
+	}
+	return ""
+}
+
+var intentionsSetVarTmpl = fmt.Sprintf(`
+%%s = [%s]
+`, intentionsBaseTmpl)
+
+const intentionsBaseTmpl = `
+{{- with $intentions := intentions %s}}
+  {{- range $i := $intentions }}
+  {
+{{ HCLIntention $i | indent 4 }}
+  },
+{{- end}}{{- end}}
+`
+
+const intentionsEmptyTmpl = `
+{{- with $intentions := intentions %s}}
+  {{- range $i := $intentions }}
+    {{- /* Empty template. Detects changes in intentions */ -}}
+{{- end}}{{- end}}
+`
+
+// variableIntentions is required for modules that include service intentions
+// information. It is versioned to track compatibility between the generated
+// root module and modules that include intentions.
+var variableIntentions = []byte(`
+# Intentions definition protocol v0
+variable "intentions" {
+  description = "Consul service intentions ordered by precedence"
+  type = list(
+    object({
+      source_name           = string
+      source_namespace      = string
+      source_partition      = string
+      source_type           = string
+      destination_name      = string
+      destination_namespace = string
+      destination_partition = string
+      action                = string
+      precedence            = number
+      permissions = list(
+        object({
+          action = string
+          http = object({
+            path_exact  = string
+            path_prefix = string
+            path_regex  = string
+            methods     = list(string)
+            header = list(
+              object({
+                name    = string
+                present = bool
+                exact   = string
+                prefix  = string
+                suffix  = string
+                regex   = string
+                invert  = bool
+              })
+            )
+          })
+        })
+      )
+      description = string
+      meta        = map(string)
+    })
+  )
+}
+`)
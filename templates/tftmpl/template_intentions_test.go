@@ -0,0 +1,37 @@
+package tftmpl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntentionsTemplate_hcatQuery(t *testing.T) {
+	testcase := []struct {
+		name string
+		i    *IntentionsTemplate
+		exp  string
+	}{
+		{
+			"no parameters",
+			&IntentionsTemplate{},
+			"",
+		},
+		{
+			"all_parameters",
+			&IntentionsTemplate{
+				Datacenter: "dc2",
+				Namespace:  "ns2",
+				Filter:     "DestinationName == \"api\"\n",
+			},
+			"\"dc=dc2\" \"ns=ns2\" \"DestinationName == \\\"api\\\"\" ",
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.i.hcatQuery()
+			assert.Equal(t, tc.exp, actual)
+		})
+	}
+}
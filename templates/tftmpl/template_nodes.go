@@ -0,0 +1,142 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Template = (*NodesTemplate)(nil)
+)
+
+// NodesTemplate handles the template for the nodes variable for the template
+// function: `{{ catalogNodes }}`
+type NodesTemplate struct {
+	Datacenter string
+	NodeMeta   map[string]string
+	Filter     string
+
+	// RenderVar informs whether the template should render the variable or not.
+	// Aligns with the task condition configuration `UseAsModuleInput``
+	RenderVar bool
+}
+
+// IsServicesVar returns false because the template returns a nodes variable,
+// not a services variable
+func (t NodesTemplate) IsServicesVar() bool {
+	return false
+}
+
+func (t NodesTemplate) RendersVar() bool {
+	return t.RenderVar
+}
+
+func (t NodesTemplate) appendModuleAttribute(body *hclwrite.Body) {
+	body.SetAttributeTraversal("nodes", hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: "nodes"},
+	})
+}
+
+func (t NodesTemplate) appendTemplate(w io.Writer) error {
+	return t.appendNamedTemplate(w, "nodes")
+}
+
+// appendNamedTemplate writes the template with the value assigned to the
+// variable of the given name
+func (t NodesTemplate) appendNamedTemplate(w io.Writer, name string) error {
+	q := t.hcatQuery()
+
+	if t.RenderVar {
+		_, err := fmt.Fprintf(w, nodesSetVarTmpl, name, q)
+		if err != nil {
+			err = fmt.Errorf("unable to write nodes template with variable, error: %v", err)
+			return err
+		}
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(w, nodesEmptyTmpl, q); err != nil {
+		err = fmt.Errorf("unable to write nodes empty template, error %v", err)
+		return err
+	}
+	return nil
+}
+
+func (t NodesTemplate) appendVariable(w io.Writer) error {
+	_, err := w.Write(variableNodes)
+	return err
+}
+
+func (t NodesTemplate) hcatQuery() string {
+	var opts []string
+
+	if t.Datacenter != "" {
+		opts = append(opts, fmt.Sprintf("dc=%s", t.Datacenter))
+	}
+
+	metaKeys := make([]string, 0, len(t.NodeMeta))
+	for k := range t.NodeMeta {
+		metaKeys = append(metaKeys, k)
+	}
+	sort.Strings(metaKeys)
+	for _, k := range metaKeys {
+		opts = append(opts, fmt.Sprintf("node-meta=%s:%s", k, t.NodeMeta[k]))
+	}
+
+	if t.Filter != "" {
+		filter := strings.ReplaceAll(t.Filter, `"`, `\"`)
+		filter = strings.Trim(filter, "\n")
+		opts = append(opts, filter)
+	}
+
+	if len(opts) > 0 {
+		return `"` + strings.Join(opts, `" "`) + `" ` // deliberate space at end
+	}
+	return ""
+}
+
+var nodesSetVarTmpl = fmt.Sprintf(`
+%%s = {%s}
+`, nodesBaseTmpl)
+
+const nodesBaseTmpl = `
+{{- with $nodes := catalogNodes %s}}
+  {{- range $n := $nodes }}
+  "{{ $n.Node }}" = {
+{{ HCLNode $n | indent 4 }}
+  },
+{{- end}}{{- end}}
+`
+
+const nodesEmptyTmpl = `
+{{- with $nodes := catalogNodes %s}}
+  {{- range $n := $nodes }}
+    {{- /* Empty template. Detects changes in catalog nodes */ -}}
+{{- end}}{{- end}}
+`
+
+// variableNodes is required for modules that include catalog nodes
+// information. It is versioned to track compatibility between the generated
+// root module and modules that include nodes.
+var variableNodes = []byte(`
+# Nodes definition protocol v0
+variable "nodes" {
+  description = "Consul catalog nodes keyed by node name"
+  type = map(
+    object({
+      id               = string
+      node             = string
+      address          = string
+      datacenter       = string
+      tagged_addresses = map(string)
+      meta             = map(string)
+    })
+  )
+}
+`)
@@ -0,0 +1,37 @@
+package tftmpl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodesTemplate_hcatQuery(t *testing.T) {
+	testcase := []struct {
+		name string
+		n    *NodesTemplate
+		exp  string
+	}{
+		{
+			"no parameters",
+			&NodesTemplate{},
+			"",
+		},
+		{
+			"all_parameters",
+			&NodesTemplate{
+				Datacenter: "dc2",
+				NodeMeta:   map[string]string{"k": "v", "a": "b"},
+				Filter:     "Meta.env == \"prod\"\n",
+			},
+			"\"dc=dc2\" \"node-meta=a:b\" \"node-meta=k:v\" \"Meta.env == \\\"prod\\\"\" ",
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.n.hcatQuery()
+			assert.Equal(t, tc.exp, actual)
+		})
+	}
+}
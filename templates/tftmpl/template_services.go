@@ -11,7 +11,8 @@ import (
 )
 
 var (
-	_ Template = (*ServicesTemplate)(nil)
+	_ Template                 = (*ServicesTemplate)(nil)
+	_ servicesVariableTemplate = (*ServicesTemplate)(nil)
 )
 
 // ServicesTemplate handles the template for the services variable for the
@@ -30,11 +31,32 @@ type ServicesTemplate struct {
 	Namespace  string
 	Filter     string
 
+	// Datacenters is the list of datacenters to query the services in, or
+	// "all" for all datacenters known to the Consul catalog. Datacenter or
+	// Datacenters can be configured but not both.
+	Datacenters []string
+
+	// Partition and Peer are the admin partition and the cluster peer to query
+	// the services in. Instances are queried with {{ healthService }} instead
+	// of {{ service }} when either is configured.
+	Partition string
+	Peer      string
+
+	// Status is the health status of the service instances to render. Each
+	// status includes the instances with a healthier status. Instances are
+	// queried with {{ healthService }} instead of {{ service }} for statuses
+	// other than passing.
+	Status string
+
 	// Deprecated in 0.5 - optional per service filtering configured through the
 	// task's services list. Not all services configured in Names must have
 	// filtering configured. Services or the set of {Datacenter,
 	// Namespace, Filter} can be configured but not both.
 	Services map[string]Service
+
+	// ProtocolV1 renders the services variable with the service definition
+	// protocol v1 instead of v0. See VariableServicesV1.
+	ProtocolV1 bool
 }
 
 // Service contains additional Consul service filtering information for services
@@ -53,13 +75,19 @@ func (t ServicesTemplate) IsServicesVar() bool {
 func (t ServicesTemplate) appendModuleAttribute(*hclwrite.Body) {}
 
 func (t ServicesTemplate) appendTemplate(w io.Writer) error {
+	return t.appendNamedTemplate(w, "services")
+}
+
+// appendNamedTemplate writes the template with the value assigned to the
+// variable of the given name
+func (t ServicesTemplate) appendNamedTemplate(w io.Writer, name string) error {
 	tmpl, err := t.concatServiceTemplates()
 	if err != nil {
 		return err
 	}
 
 	if t.RenderVar {
-		tmpl = fmt.Sprintf(servicesSetVarTmpl, tmpl)
+		tmpl = fmt.Sprintf(servicesSetVarTmpl, name, tmpl)
 	}
 
 	if _, err := fmt.Fprint(w, tmpl); err != nil {
@@ -75,7 +103,8 @@ func (t ServicesTemplate) appendTemplate(w io.Writer) error {
 func (t ServicesTemplate) concatServiceTemplates() (string, error) {
 	// double-check that service query parameter is configured in only one way
 	// the current way or the deprecated way
-	isCurrent := t.Datacenter != "" || t.Namespace != "" || t.Filter != ""
+	isCurrent := t.Datacenter != "" || t.Namespace != "" || t.Filter != "" ||
+		len(t.Datacenters) > 0 || t.Partition != "" || t.Peer != ""
 	isDeprecated := t.Services != nil
 
 	if isCurrent && isDeprecated {
@@ -92,75 +121,149 @@ func (t ServicesTemplate) concatServiceTemplates() (string, error) {
 
 	tmpl := ""
 	for _, n := range t.Names {
-		var query string
-		if t.Services == nil {
-			query = t.hcatQuery(n, t.Datacenter, t.Namespace, t.Filter)
-		} else {
+		if t.Services != nil {
 			s := t.Services[n]
-			query = t.hcatQuery(n, s.Datacenter, s.Namespace, s.Filter)
+			tmpl += t.serviceTemplate(t.hcatQuery(n, s.Datacenter, s.Namespace, s.Filter))
+			continue
 		}
 
-		if t.RenderVar {
-			tmpl += fmt.Sprintf(serviceBaseTmpl, query)
-		} else {
-			tmpl += fmt.Sprintf(serviceEmptyTmpl, query)
+		for _, dc := range datacentersOrDefault(t.Datacenter, t.Datacenters) {
+			tmpl += t.serviceTemplate(t.hcatQuery(n, dc, t.Namespace, t.Filter))
 		}
 	}
 
+	if isAllDatacenters(t.Datacenters) {
+		tmpl = fmt.Sprintf(allDatacentersTmpl, tmpl)
+	}
+
 	// special newline handling due to template concatenation
 	tmpl += "\n"
 
 	return tmpl, nil
 }
 
+// serviceTemplate returns the template for a single monitored service query
+func (t ServicesTemplate) serviceTemplate(query string) string {
+	if t.RenderVar {
+		return fmt.Sprintf(serviceBaseTmpl, t.tmplFuncName(), query,
+			hclServiceCall(t.ProtocolV1, t.Partition, t.Peer))
+	}
+	return fmt.Sprintf(serviceEmptyTmpl, t.tmplFuncName(), query)
+}
+
+// isNonPassingStatus returns whether the health status selects instances in
+// addition to passing instances. Passing is the default status.
+func isNonPassingStatus(status string) bool {
+	return status != "" && status != "passing"
+}
+
+// hclServiceCall returns the call of the template function that marshals the
+// service $s into HCL for the version of the service definition protocol. The
+// partition and peer are only rendered by protocol v1.
+func hclServiceCall(protocolV1 bool, partition, peer string) string {
+	if !protocolV1 {
+		return "HCLService $s"
+	}
+
+	call := "HCLServiceV1 $s"
+	if partition != "" {
+		call += " " + quoteOpt(fmt.Sprintf("partition=%s", partition))
+	}
+	if peer != "" {
+		call += " " + quoteOpt(fmt.Sprintf("peer=%s", peer))
+	}
+	return call
+}
+
 func (t ServicesTemplate) appendVariable(io.Writer) error {
 	return nil
 }
 
+func (t ServicesTemplate) servicesVariable() []byte {
+	return servicesVariableBlock(t.ProtocolV1)
+}
+
+// servicesVariableBlock returns the services variable block for the version
+// of the service definition protocol
+func servicesVariableBlock(protocolV1 bool) []byte {
+	if protocolV1 {
+		return VariableServicesV1
+	}
+	return VariableServices
+}
+
 func (t ServicesTemplate) RendersVar() bool {
 	return t.RenderVar
 }
 
+// tmplFuncName returns the name of the template function to query the
+// service instances. The hcat {{ service }} function only supports passing
+// instances, does not support admin partitions or cluster peers, and does not
+// return the proxy configuration rendered by the service definition protocol v1.
+func (t ServicesTemplate) tmplFuncName() string {
+	if t.ProtocolV1 || isNonPassingStatus(t.Status) || t.Partition != "" || t.Peer != "" {
+		return "healthService"
+	}
+	return "service"
+}
+
 func (t ServicesTemplate) hcatQuery(name, dc, ns, filter string) string {
 	var opts []string
 
-	opts = append(opts, name)
+	opts = append(opts, quoteOpt(name))
 
 	if dc != "" {
-		opts = append(opts, fmt.Sprintf("dc=%s", dc))
+		opts = append(opts, dcOpt(dc))
 	}
 
 	if ns != "" {
-		opts = append(opts, fmt.Sprintf("ns=%s", ns))
+		opts = append(opts, quoteOpt(fmt.Sprintf("ns=%s", ns)))
+	}
+
+	if t.Partition != "" {
+		opts = append(opts, quoteOpt(fmt.Sprintf("partition=%s", t.Partition)))
+	}
+
+	if t.Peer != "" {
+		opts = append(opts, quoteOpt(fmt.Sprintf("peer=%s", t.Peer)))
+	}
+
+	if isNonPassingStatus(t.Status) {
+		opts = append(opts, quoteOpt(fmt.Sprintf("status=%s", t.Status)))
 	}
 
 	if filter != "" {
 		filter := strings.ReplaceAll(filter, `"`, `\"`)
 		filter = strings.Trim(filter, "\n")
-		opts = append(opts, filter)
+		opts = append(opts, quoteOpt(filter))
 	}
 
-	if len(opts) > 0 {
-		return `"` + strings.Join(opts, `" "`) + `"`
-	}
-	return ""
+	return strings.Join(opts, " ")
 }
 
-// servicesSetVarTmpl expects a concatenation of serviceBaseTmpl or
-// serviceEmptyTmpl for each monitored service at '%s'
+// allDatacentersTmpl expects the templates to query in each datacenter at '%s'.
+// The templates use the variable $dc for the datacenter.
+const allDatacentersTmpl = `
+{{- range $dc := datacenters }}%s
+{{- end}}`
+
+// servicesSetVarTmpl expects the name of the variable and a concatenation of
+// serviceBaseTmpl or serviceEmptyTmpl for each monitored service
 const servicesSetVarTmpl = `
-services = {%s}
+%s = {%s}
 `
 
 // serviceBaseTmpl is a template for a single monitored service. Multiple
 // service requires concatenating multiple base templates. There is no newline
 // at the end of this template (unlike other templates) to prevent a gap in the
-// templates
+// templates. The template expects the name of the template function to query
+// the service, the service query, and the call of the function to marshal the
+// service.
 const serviceBaseTmpl = `
-{{- with $srv := service %s }}
+{{- with $srv := %s %s }}
   {{- range $s := $srv}}
   "{{ joinStrings "." .ID .Node .Namespace .NodeDatacenter }}" = {
-{{ HCLService $s | indent 4 }}
+{{ %s | indent 4 }}
   },
   {{- end}}
 {{- end}}`
@@ -168,9 +271,10 @@ const serviceBaseTmpl = `
 // serviceEmptyTmpl is a template for a single monitored service. Multiple
 // service requires concatenating multiple empty templates. There is no newline
 // at the end of this template (unlike other templates) to prevent a gap in the
-// templates
+// templates. The template expects the name of the template function to query
+// the service and the service query.
 const serviceEmptyTmpl = `
-{{- with $srv := service %s }}
+{{- with $srv := %s %s }}
   {{- range $s := $srv}}
   {{- /* Empty template. Detects changes in Services */ -}}
   {{- end}}
@@ -10,7 +10,8 @@ import (
 )
 
 var (
-	_ Template = (*ServicesRegexTemplate)(nil)
+	_ Template                 = (*ServicesRegexTemplate)(nil)
+	_ servicesVariableTemplate = (*ServicesRegexTemplate)(nil)
 )
 
 // ServicesRegexTemplate handles the template for the services variable for the
@@ -21,9 +22,27 @@ type ServicesRegexTemplate struct {
 	Namespace  string
 	Filter     string
 
+	// Datacenters is the list of datacenters to query the services in, or
+	// "all" for all datacenters known to the Consul catalog. Datacenter or
+	// Datacenters can be configured but not both.
+	Datacenters []string
+
+	// Partition and Peer are the admin partition and the cluster peer to query
+	// the services in.
+	Partition string
+	Peer      string
+
+	// Status is the health status of the service instances to render. Each
+	// status includes the instances with a healthier status.
+	Status string
+
 	// RenderVar informs whether the template should render the variable or not.
 	// Aligns with the task condition configuration `UseAsModuleInput``
 	RenderVar bool
+
+	// ProtocolV1 renders the services variable with the service definition
+	// protocol v1 instead of v0. See VariableServicesV1.
+	ProtocolV1 bool
 }
 
 // IsServicesVar returns true because the template is for the services variable
@@ -34,13 +53,28 @@ func (t ServicesRegexTemplate) IsServicesVar() bool {
 func (t ServicesRegexTemplate) appendModuleAttribute(*hclwrite.Body) {}
 
 func (t ServicesRegexTemplate) appendTemplate(w io.Writer) error {
-	q := t.hcatQuery()
+	return t.appendNamedTemplate(w, "services")
+}
 
+// appendNamedTemplate writes the template with the value assigned to the
+// variable of the given name
+func (t ServicesRegexTemplate) appendNamedTemplate(w io.Writer, name string) error {
 	tmpl := ""
+	for _, dc := range datacentersOrDefault(t.Datacenter, t.Datacenters) {
+		q := t.hcatQuery(dc)
+		if t.RenderVar {
+			tmpl += fmt.Sprintf(servicesRegexBaseTmpl, q, hclServiceCall(t.ProtocolV1, t.Partition, t.Peer))
+		} else {
+			tmpl += fmt.Sprintf(servicesRegexEmptyTmpl, q)
+		}
+	}
+
+	if isAllDatacenters(t.Datacenters) {
+		tmpl = fmt.Sprintf(allDatacentersTmpl, tmpl) + "\n"
+	}
+
 	if t.RenderVar {
-		tmpl = fmt.Sprintf(servicesRegexSetVarTmpl, q)
-	} else {
-		tmpl = fmt.Sprintf(servicesRegexEmptyTmpl, q)
+		tmpl = fmt.Sprintf(servicesSetVarTmpl, name, tmpl)
 	}
 
 	if _, err := fmt.Fprint(w, tmpl); err != nil {
@@ -55,45 +89,55 @@ func (t ServicesRegexTemplate) appendVariable(io.Writer) error {
 	return nil
 }
 
+func (t ServicesRegexTemplate) servicesVariable() []byte {
+	return servicesVariableBlock(t.ProtocolV1)
+}
+
 func (t ServicesRegexTemplate) RendersVar() bool {
 	return t.RenderVar
 }
 
-func (t ServicesRegexTemplate) hcatQuery() string {
+// hcatQuery returns the query parameters of the services in the datacenter
+func (t ServicesRegexTemplate) hcatQuery(dc string) string {
 	var opts []string
 
 	// Support regexp == "" (same as a wildcard)
-	opts = append(opts, fmt.Sprintf("regexp=%s", t.Regexp))
+	opts = append(opts, quoteOpt(fmt.Sprintf("regexp=%s", t.Regexp)))
 
-	if t.Datacenter != "" {
-		opts = append(opts, fmt.Sprintf("dc=%s", t.Datacenter))
+	if dc != "" {
+		opts = append(opts, dcOpt(dc))
 	}
 
 	if t.Namespace != "" {
-		opts = append(opts, fmt.Sprintf("ns=%s", t.Namespace))
+		opts = append(opts, quoteOpt(fmt.Sprintf("ns=%s", t.Namespace)))
+	}
+
+	if t.Partition != "" {
+		opts = append(opts, quoteOpt(fmt.Sprintf("partition=%s", t.Partition)))
+	}
+
+	if t.Peer != "" {
+		opts = append(opts, quoteOpt(fmt.Sprintf("peer=%s", t.Peer)))
+	}
+
+	if isNonPassingStatus(t.Status) {
+		opts = append(opts, quoteOpt(fmt.Sprintf("status=%s", t.Status)))
 	}
 
 	if t.Filter != "" {
 		filter := strings.ReplaceAll(t.Filter, `"`, `\"`)
 		filter = strings.Trim(filter, "\n")
-		opts = append(opts, filter)
+		opts = append(opts, quoteOpt(filter))
 	}
 
-	if len(opts) > 0 {
-		return `"` + strings.Join(opts, `" "`) + `"`
-	}
-	return ""
+	return strings.Join(opts, " ")
 }
 
-var servicesRegexSetVarTmpl = fmt.Sprintf(`
-services = {%s}
-`, servicesRegexBaseTmpl)
-
 const servicesRegexBaseTmpl = `
 {{- with $srv := servicesRegex %s }}
   {{- range $s := $srv}}
   "{{ joinStrings "." .ID .Node .Namespace .NodeDatacenter }}" = {
-{{ HCLService $s | indent 4 }}
+{{ %s | indent 4 }}
   },
   {{- end}}
 {{- end}}
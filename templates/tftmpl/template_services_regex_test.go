@@ -33,6 +33,27 @@ services = {
   {{- end}}
 {{- end}}
 }
+`,
+		},
+		{
+			"partition & peer & render var v1",
+			&ServicesRegexTemplate{
+				Regexp:     ".*",
+				Partition:  "ap1",
+				Peer:       "cluster-02",
+				RenderVar:  true,
+				ProtocolV1: true,
+			},
+			`
+services = {
+{{- with $srv := servicesRegex "regexp=.*" "partition=ap1" "peer=cluster-02" }}
+  {{- range $s := $srv}}
+  "{{ joinStrings "." .ID .Node .Namespace .NodeDatacenter }}" = {
+{{ HCLServiceV1 $s "partition=ap1" "peer=cluster-02" | indent 4 }}
+  },
+  {{- end}}
+{{- end}}
+}
 `,
 		},
 		{
@@ -63,6 +84,50 @@ services = {
   {{- range $s := $srv}}
   {{- /* Empty template. Detects changes in Services */ -}}
   {{- end}}
+{{- end}}
+`,
+		}, {
+			"datacenters & render var",
+			&ServicesRegexTemplate{
+				Regexp:      "^api$",
+				Datacenters: []string{"dc1", "dc2"},
+				RenderVar:   true,
+			},
+			`
+services = {
+{{- with $srv := servicesRegex "regexp=^api$" "dc=dc1" }}
+  {{- range $s := $srv}}
+  "{{ joinStrings "." .ID .Node .Namespace .NodeDatacenter }}" = {
+{{ HCLService $s | indent 4 }}
+  },
+  {{- end}}
+{{- end}}
+
+{{- with $srv := servicesRegex "regexp=^api$" "dc=dc2" }}
+  {{- range $s := $srv}}
+  "{{ joinStrings "." .ID .Node .Namespace .NodeDatacenter }}" = {
+{{ HCLService $s | indent 4 }}
+  },
+  {{- end}}
+{{- end}}
+}
+`,
+		},
+		{
+			"all datacenters & no var",
+			&ServicesRegexTemplate{
+				Regexp:      "^api$",
+				Datacenters: []string{"all"},
+				RenderVar:   false,
+			},
+			`
+{{- range $dc := datacenters }}
+{{- with $srv := servicesRegex "regexp=^api$" (print "dc=" $dc) }}
+  {{- range $s := $srv}}
+  {{- /* Empty template. Detects changes in Services */ -}}
+  {{- end}}
+{{- end}}
+
 {{- end}}
 `,
 		},
@@ -108,11 +173,27 @@ func TestServicesRegexTemplate_hcatQuery(t *testing.T) {
 			},
 			`"regexp=.*" "dc=datacenter" "ns=namespace" "filter"`,
 		},
+		{
+			"passing status",
+			&ServicesRegexTemplate{
+				Regexp: ".*",
+				Status: "passing",
+			},
+			`"regexp=.*"`,
+		},
+		{
+			"non-passing status",
+			&ServicesRegexTemplate{
+				Regexp: ".*",
+				Status: "critical",
+			},
+			`"regexp=.*" "status=critical"`,
+		},
 	}
 
 	for _, tc := range testcase {
 		t.Run(tc.name, func(t *testing.T) {
-			actual := tc.c.hcatQuery()
+			actual := tc.c.hcatQuery(tc.c.Datacenter)
 			assert.Equal(t, tc.exp, actual)
 		})
 	}
@@ -31,6 +31,23 @@ func TestServicesTemplate_concatServiceTemplates(t *testing.T) {
   },
   {{- end}}
 {{- end}}
+`,
+		},
+		{
+			"one name & status & render var",
+			&ServicesTemplate{
+				Names:     []string{"api"},
+				Status:    "warning",
+				RenderVar: true,
+			},
+			`
+{{- with $srv := healthService "api" "status=warning" }}
+  {{- range $s := $srv}}
+  "{{ joinStrings "." .ID .Node .Namespace .NodeDatacenter }}" = {
+{{ HCLService $s | indent 4 }}
+  },
+  {{- end}}
+{{- end}}
 `,
 		},
 		{
@@ -143,6 +160,75 @@ func TestServicesTemplate_concatServiceTemplates(t *testing.T) {
   {{- /* Empty template. Detects changes in Services */ -}}
   {{- end}}
 {{- end}}
+`,
+		},
+		{
+			"multi-name & datacenters & no var",
+			&ServicesTemplate{
+				Names:       []string{"api", "web"},
+				Datacenters: []string{"dc1", "dc2"},
+				RenderVar:   false,
+			},
+			`
+{{- with $srv := service "api" "dc=dc1" }}
+  {{- range $s := $srv}}
+  {{- /* Empty template. Detects changes in Services */ -}}
+  {{- end}}
+{{- end}}
+{{- with $srv := service "api" "dc=dc2" }}
+  {{- range $s := $srv}}
+  {{- /* Empty template. Detects changes in Services */ -}}
+  {{- end}}
+{{- end}}
+{{- with $srv := service "web" "dc=dc1" }}
+  {{- range $s := $srv}}
+  {{- /* Empty template. Detects changes in Services */ -}}
+  {{- end}}
+{{- end}}
+{{- with $srv := service "web" "dc=dc2" }}
+  {{- range $s := $srv}}
+  {{- /* Empty template. Detects changes in Services */ -}}
+  {{- end}}
+{{- end}}
+`,
+		},
+		{
+			"partition & peer & render var v1",
+			&ServicesTemplate{
+				Names:      []string{"api"},
+				Partition:  "ap1",
+				Peer:       "cluster-02",
+				RenderVar:  true,
+				ProtocolV1: true,
+			},
+			`
+{{- with $srv := healthService "api" "partition=ap1" "peer=cluster-02" }}
+  {{- range $s := $srv}}
+  "{{ joinStrings "." .ID .Node .Namespace .NodeDatacenter }}" = {
+{{ HCLServiceV1 $s "partition=ap1" "peer=cluster-02" | indent 4 }}
+  },
+  {{- end}}
+{{- end}}
+`,
+		},
+		{
+			"one name & all datacenters & render var",
+			&ServicesTemplate{
+				Names:       []string{"api"},
+				Datacenters: []string{"all"},
+				Namespace:   "ns1",
+				RenderVar:   true,
+			},
+			`
+{{- range $dc := datacenters }}
+{{- with $srv := service "api" (print "dc=" $dc) "ns=ns1" }}
+  {{- range $s := $srv}}
+  "{{ joinStrings "." .ID .Node .Namespace .NodeDatacenter }}" = {
+{{ HCLService $s | indent 4 }}
+  },
+  {{- end}}
+{{- end}}
+{{- end}}
 `,
 		},
 	}
@@ -0,0 +1,83 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Template          = (*VaultTemplate)(nil)
+	_ sensitiveTemplate = (*VaultTemplate)(nil)
+)
+
+// VaultTemplate handles the template for the vault variable for the template
+// function: `{{ secret }}`
+type VaultTemplate struct {
+	Path string
+}
+
+// IsServicesVar returns false because the template returns a vault variable,
+// not a services variable
+func (t VaultTemplate) IsServicesVar() bool {
+	return false
+}
+
+// RendersVar returns true because Vault is only supported as a module input,
+// which always renders the variable
+func (t VaultTemplate) RendersVar() bool {
+	return true
+}
+
+func (t VaultTemplate) appendModuleAttribute(body *hclwrite.Body) {
+	body.SetAttributeTraversal("vault", hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: "vault"},
+	})
+}
+
+func (t VaultTemplate) appendTemplate(w io.Writer) error {
+	return t.appendNamedTemplate(w, "vault")
+}
+
+// appendNamedTemplate writes the template with the value assigned to the
+// variable of the given name
+func (t VaultTemplate) appendNamedTemplate(w io.Writer, name string) error {
+	if _, err := fmt.Fprintf(w, vaultSetVarTmpl, name, t.hcatQuery()); err != nil {
+		err = fmt.Errorf("unable to write vault template with variable, error: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (t VaultTemplate) appendVariable(w io.Writer) error {
+	_, err := fmt.Fprintf(w, variableVault, "")
+	return err
+}
+
+func (t VaultTemplate) appendSensitiveVariable(w io.Writer) error {
+	_, err := fmt.Fprintf(w, variableVault, "\n  sensitive   = true")
+	return err
+}
+
+func (t VaultTemplate) hcatQuery() string {
+	return fmt.Sprintf("%q", t.Path)
+}
+
+const vaultSetVarTmpl = `
+%s = {{ with $secret := secret %s }}{{ HCLVaultSecret $secret }}{{ else }}{}{{ end }}
+`
+
+// variableVault is required for modules that include Vault secret data. It is
+// versioned to track compatibility between the generated root module and
+// modules that include the Vault secret. The type is not declared because the
+// format of the data depends on the secrets engine.
+const variableVault = `
+# Vault secret definition protocol v0
+variable "vault" {
+  description = "Data of the Vault secret monitored by Consul-Terraform-Sync"
+  type        = any%s
+}
+`
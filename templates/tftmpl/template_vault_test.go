@@ -0,0 +1,46 @@
+package tftmpl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVaultTemplate_hcatQuery(t *testing.T) {
+	testcase := []struct {
+		name string
+		i    *VaultTemplate
+		exp  string
+	}{
+		{
+			"kv",
+			&VaultTemplate{Path: "secret/my-app"},
+			`"secret/my-app"`,
+		},
+		{
+			"escaped",
+			&VaultTemplate{Path: `secret/"quoted"`},
+			`"secret/\"quoted\""`,
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.i.hcatQuery()
+			assert.Equal(t, tc.exp, actual)
+		})
+	}
+}
+
+func TestVaultTemplate_appendVariable(t *testing.T) {
+	tmpl := VaultTemplate{Path: "secret/my-app"}
+
+	var b bytes.Buffer
+	assert.NoError(t, tmpl.appendVariable(&b))
+	assert.NotContains(t, b.String(), "sensitive")
+
+	b.Reset()
+	assert.NoError(t, tmpl.appendSensitiveVariable(&b))
+	assert.Contains(t, b.String(), "sensitive   = true")
+}
@@ -0,0 +1,97 @@
+package tftmpl
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var (
+	_ Template = (*WebhookTemplate)(nil)
+)
+
+// WebhookTemplate handles the template for the webhook variable for the
+// template function: `{{ webhookPayload }}`
+type WebhookTemplate struct {
+	// TaskName is the name of the task that is triggered by the webhook
+	TaskName string
+
+	// RenderVar informs whether the template should render the variable or not.
+	// Aligns with the task condition configuration `UseAsModuleInput``
+	RenderVar bool
+}
+
+// IsServicesVar returns false because the template returns a webhook variable,
+// not a services variable
+func (t WebhookTemplate) IsServicesVar() bool {
+	return false
+}
+
+func (t WebhookTemplate) RendersVar() bool {
+	return t.RenderVar
+}
+
+func (t WebhookTemplate) appendModuleAttribute(body *hclwrite.Body) {
+	body.SetAttributeTraversal("webhook", hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: "webhook"},
+	})
+}
+
+func (t WebhookTemplate) appendTemplate(w io.Writer) error {
+	return t.appendNamedTemplate(w, "webhook")
+}
+
+// appendNamedTemplate writes the template with the value assigned to the
+// variable of the given name
+func (t WebhookTemplate) appendNamedTemplate(w io.Writer, name string) error {
+	q := t.hcatQuery()
+
+	if t.RenderVar {
+		_, err := fmt.Fprintf(w, webhookSetVarTmpl, name, q)
+		if err != nil {
+			err = fmt.Errorf("unable to write webhook template with variable, error: %v", err)
+			return err
+		}
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(w, webhookEmptyTmpl, q); err != nil {
+		err = fmt.Errorf("unable to write webhook empty template, error %v", err)
+		return err
+	}
+	return nil
+}
+
+func (t WebhookTemplate) appendVariable(w io.Writer) error {
+	_, err := w.Write(variableWebhook)
+	return err
+}
+
+func (t WebhookTemplate) hcatQuery() string {
+	return fmt.Sprintf("%q ", fmt.Sprintf("task=%s", t.TaskName)) // deliberate space at end
+}
+
+const webhookSetVarTmpl = `
+%s = {{ with $payload := webhookPayload %s}}{{ HCLWebhookPayload $payload }}{{ else }}{}{{ end }}
+`
+
+const webhookEmptyTmpl = `
+{{- with $payload := webhookPayload %s}}
+  {{- /* Empty template. Detects requests to trigger the task */ -}}
+{{- end}}
+`
+
+// variableWebhook is required for modules that include the body of the request
+// that triggered the task. It is versioned to track compatibility between the
+// generated root module and modules that include the body. The type is not
+// declared because the format of the body depends on the external system.
+var variableWebhook = []byte(`
+# Webhook payload definition protocol v0
+variable "webhook" {
+  description = "JSON body of the request that triggered the task through Consul-Terraform-Sync"
+  type        = any
+}
+`)
@@ -0,0 +1,78 @@
+# This file is generated by Consul-Terraform-Sync.
+#
+# The HCL blocks, arguments, variables, and values are derived from the
+# operator configuration for Consul-Terraform-Sync. Any manual changes to
+# this file may not be preserved and could be overwritten by a subsequent
+# update.
+#
+# Task: test
+# Description: user description for task named 'test'
+
+# Service definition protocol v0
+variable "services" {
+  description = "Consul services monitored by Consul-Terraform-Sync"
+  type = map(
+    object({
+      id        = string
+      name      = string
+      kind      = string
+      address   = string
+      port      = number
+      meta      = map(string)
+      tags      = list(string)
+      namespace = string
+      status    = string
+
+      node                  = string
+      node_id               = string
+      node_address          = string
+      node_datacenter       = string
+      node_tagged_addresses = map(string)
+      node_meta             = map(string)
+
+      cts_user_defined_meta = map(string)
+    })
+  )
+}
+
+# Intentions definition protocol v0
+variable "intentions" {
+  description = "Consul service intentions ordered by precedence"
+  type = list(
+    object({
+      source_name           = string
+      source_namespace      = string
+      source_partition      = string
+      source_type           = string
+      destination_name      = string
+      destination_namespace = string
+      destination_partition = string
+      action                = string
+      precedence            = number
+      permissions = list(
+        object({
+          action = string
+          http = object({
+            path_exact  = string
+            path_prefix = string
+            path_regex  = string
+            methods     = list(string)
+            header = list(
+              object({
+                name    = string
+                present = bool
+                exact   = string
+                prefix  = string
+                suffix  = string
+                regex   = string
+                invert  = bool
+              })
+            )
+          })
+        })
+      )
+      description = string
+      meta        = map(string)
+    })
+  )
+}
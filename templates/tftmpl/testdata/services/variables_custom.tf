@@ -0,0 +1,15 @@
+# This file is generated by Consul-Terraform-Sync.
+#
+# The HCL blocks, arguments, variables, and values are derived from the
+# operator configuration for Consul-Terraform-Sync. Any manual changes to
+# this file may not be preserved and could be overwritten by a subsequent
+# update.
+#
+# Task: test
+# Description: user description for task named 'test'
+
+# Service definition from a custom template
+variable "services" {
+  description = "Consul services monitored by Consul-Terraform-Sync"
+  type        = any
+}
@@ -0,0 +1,80 @@
+# This file is generated by Consul-Terraform-Sync.
+#
+# The HCL blocks, arguments, variables, and values are derived from the
+# operator configuration for Consul-Terraform-Sync. Any manual changes to
+# this file may not be preserved and could be overwritten by a subsequent
+# update.
+#
+# Task: test
+# Description: user description for task named 'test'
+
+# Service definition protocol v1
+variable "services" {
+  description = "Consul services monitored by Consul-Terraform-Sync"
+  type = map(
+    object({
+      id        = string
+      name      = string
+      kind      = string
+      address   = string
+      port      = number
+      meta      = map(string)
+      tags      = list(string)
+      namespace = string
+      status    = string
+      partition = string
+      peer      = string
+
+      tagged_addresses = map(
+        object({
+          address = string
+          port    = number
+        })
+      )
+      weights = object({
+        passing = number
+        warning = number
+      })
+      checks = list(
+        object({
+          node         = string
+          check_id     = string
+          name         = string
+          status       = string
+          notes        = string
+          service_id   = string
+          service_name = string
+          type         = string
+          namespace    = string
+        })
+      )
+      connect = object({
+        proxy                    = bool
+        gateway                  = string
+        destination_service_name = string
+        destination_service_id   = string
+        upstreams = list(
+          object({
+            destination_type      = string
+            destination_name      = string
+            destination_namespace = string
+            destination_partition = string
+            destination_peer      = string
+            datacenter            = string
+            local_bind_address    = string
+            local_bind_port       = number
+          })
+        )
+      })
+
+      node                  = string
+      node_id               = string
+      node_address          = string
+      node_datacenter       = string
+      node_tagged_addresses = map(string)
+      node_meta             = map(string)
+
+      cts_user_defined_meta = map(string)
+    })
+  )
+}
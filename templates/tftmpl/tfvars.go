@@ -1,8 +1,10 @@
 package tftmpl
 
 import (
+	"fmt"
 	"io"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
@@ -27,6 +29,13 @@ func newTFVarsTmpl(w io.Writer, filename string, input *RootModuleInputData) err
 		}
 	}
 
+	// the user-provided template renders the value of the services var
+	if input.ServicesTemplate != "" {
+		_, err := fmt.Fprintf(w, servicesCustomTmpl,
+			strings.TrimSpace(input.ServicesTemplate))
+		return err
+	}
+
 	hclFile := hclwrite.NewEmptyFile()
 	body := hclFile.Body()
 
@@ -96,3 +105,9 @@ func newVariablesTFVars(w io.Writer, filename string, input *RootModuleInputData
 	_, err = hclFile.WriteTo(w)
 	return err
 }
+
+// servicesCustomTmpl expects the content of a user-provided template that
+// renders the value of the services variable at '%s'
+const servicesCustomTmpl = `
+services = %s
+`
@@ -0,0 +1,127 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCatalogNodesQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		opts []string
+		exp  *catalogNodesQuery
+		err  bool
+	}{
+		{
+			"no opts",
+			[]string{},
+			&catalogNodesQuery{},
+			false,
+		},
+		{
+			"dc",
+			[]string{"dc=dc1"},
+			&catalogNodesQuery{
+				dc: "dc1",
+			},
+			false,
+		},
+		{
+			"node-meta",
+			[]string{"node-meta=k:v", "node-meta=foo:bar"},
+			&catalogNodesQuery{
+				nodeMeta: map[string]string{"k": "v", "foo": "bar"},
+			},
+			false,
+		},
+		{
+			"filter",
+			[]string{`Meta.env == "prod"`, `Node != "web"`},
+			&catalogNodesQuery{
+				filter: `Meta.env == "prod" and Node != "web"`,
+			},
+			false,
+		},
+		{
+			"multiple",
+			[]string{"node-meta=k:v", "dc=dc1", `Meta.env == "prod"`},
+			&catalogNodesQuery{
+				dc:       "dc1",
+				filter:   `Meta.env == "prod"`,
+				nodeMeta: map[string]string{"k": "v"},
+			},
+			false,
+		},
+		{
+			"invalid node-meta",
+			[]string{"node-meta=k"},
+			nil,
+			true,
+		},
+		{
+			"invalid filter",
+			[]string{"invalid"},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			act, err := newCatalogNodesQuery(tc.opts)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if act != nil {
+				act.stopCh = nil
+			}
+
+			assert.NoError(t, err, err)
+			assert.Equal(t, tc.exp, act)
+		})
+	}
+}
+
+func TestCatalogNodesQuery_String(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    []string
+		exp  string
+	}{
+		{
+			"empty",
+			[]string{},
+			"catalog.nodes",
+		},
+		{
+			"datacenter",
+			[]string{"dc=dc1"},
+			"catalog.nodes(dc=dc1)",
+		},
+		{
+			"node-meta",
+			[]string{"node-meta=k:v", "node-meta=foo:bar"},
+			"catalog.nodes(node-meta=foo:bar&node-meta=k:v)",
+		},
+		{
+			"multiple",
+			[]string{"node-meta=k:v", "dc=dc1", `Meta.env == "prod"`},
+			`catalog.nodes(dc=dc1&filter=Meta.env == "prod"&node-meta=k:v)`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newCatalogNodesQuery(tc.i)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.exp, d.String())
+		})
+	}
+}
@@ -14,10 +14,18 @@ import (
 
 var _ hcatQuery = (*catalogServicesRegistrationQuery)(nil)
 
+// CatalogServices is the dependency of a catalog services registration query.
+// It is the list of services registered in the queried datacenter. Datacenter
+// is empty when the query uses the datacenter of the Consul agent.
+type CatalogServices struct {
+	Datacenter string
+	Services   []*dep.CatalogSnippet
+}
+
 // catalogServicesRegistrationFunc returns information on registered Consul
 // services. It queries the Catalog List Services API and supports the query
-// parameters dc, ns, and node-meta. It also adds an additional layer of
-// custom functionality on the API response:
+// parameters dc, ns, partition, peer, and node-meta. It also adds an
+// additional layer of custom functionality on the API response:
 //  - Adds regex filtering on service name option e.g. "regexp=api"
 //
 // Endpoint: /v1/catalog/services
@@ -32,7 +40,7 @@ func catalogServicesRegistrationFunc(recall hcat.Recaller) interface{} {
 		}
 
 		if value, ok := recall(d); ok {
-			return value.([]*dep.CatalogSnippet), nil
+			return value.(*CatalogServices).Services, nil
 		}
 
 		return result, nil
@@ -45,11 +53,13 @@ type catalogServicesRegistrationQuery struct {
 	isConsul
 	stopCh chan struct{}
 
-	regexp   *regexp.Regexp // custom
-	dc       string
-	ns       string
-	nodeMeta map[string]string
-	opts     hcat.QueryOptions
+	regexp    *regexp.Regexp // custom
+	dc        string
+	ns        string
+	partition string
+	peer      string
+	nodeMeta  map[string]string
+	opts      hcat.QueryOptions
 }
 
 // newCatalogServicesRegistrationQuery processes options in the format of
@@ -80,6 +90,10 @@ func newCatalogServicesRegistrationQuery(opts []string) (*catalogServicesRegistr
 			query.dc = value
 		case "ns", "namespace":
 			query.ns = value
+		case "partition":
+			query.partition = value
+		case "peer":
+			query.peer = value
 		case "node-meta":
 			if query.nodeMeta == nil {
 				query.nodeMeta = make(map[string]string)
@@ -100,8 +114,8 @@ func newCatalogServicesRegistrationQuery(opts []string) (*catalogServicesRegistr
 	return &query, nil
 }
 
-// Fetch queries the Consul API defined by the given client and returns a slice
-// of CatalogSnippet objects.
+// Fetch queries the Consul API defined by the given client and returns the
+// CatalogServices of the queried datacenter.
 func (d *catalogServicesRegistrationQuery) Fetch(clients dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
 	select {
 	case <-d.stopCh:
@@ -114,6 +128,8 @@ func (d *catalogServicesRegistrationQuery) Fetch(clients dep.Clients) (interface
 		Namespace:  d.ns,
 	})
 	opts := hcatOpts.ToConsulOpts()
+	opts.Partition = d.partition
+	opts.Peer = d.peer
 	if len(d.nodeMeta) != 0 {
 		opts.NodeMeta = d.nodeMeta
 	}
@@ -160,7 +176,10 @@ func (d *catalogServicesRegistrationQuery) Fetch(clients dep.Clients) (interface
 	// so have not implemented this complexity at this time
 	time.Sleep(1 * time.Second)
 
-	return catalogServices, rm, nil
+	return &CatalogServices{
+		Datacenter: d.dc,
+		Services:   catalogServices,
+	}, rm, nil
 }
 
 // SetOptions satisfies the hcat.QueryOptionsSetter interface which enables
@@ -181,6 +200,12 @@ func (d *catalogServicesRegistrationQuery) ID() string {
 	if d.ns != "" {
 		opts = append(opts, fmt.Sprintf("ns=%s", d.ns))
 	}
+	if d.partition != "" {
+		opts = append(opts, fmt.Sprintf("partition=%s", d.partition))
+	}
+	if d.peer != "" {
+		opts = append(opts, fmt.Sprintf("peer=%s", d.peer))
+	}
 	for k, v := range d.nodeMeta {
 		opts = append(opts, fmt.Sprintf("node-meta=%s:%s", k, v))
 	}
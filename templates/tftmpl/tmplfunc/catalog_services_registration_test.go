@@ -54,6 +54,15 @@ func TestNewCatalogServicesRegistrationQuery(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"partition and peer",
+			[]string{"partition=ap1", "peer=cluster-02"},
+			&catalogServicesRegistrationQuery{
+				partition: "ap1",
+				peer:      "cluster-02",
+			},
+			false,
+		},
 		{
 			"node-meta",
 			[]string{"node-meta=k:v", "node-meta=foo:bar"},
@@ -133,6 +142,11 @@ func TestCatalogServicesRegistrationQuery_String(t *testing.T) {
 			[]string{"ns=namespace"},
 			"catalog.services.registration(ns=namespace)",
 		},
+		{
+			"partition and peer",
+			[]string{"peer=cluster-02", "partition=ap1"},
+			"catalog.services.registration(partition=ap1&peer=cluster-02)",
+		},
 		{
 			"node-meta",
 			[]string{"node-meta=k:v", "node-meta=foo:bar"},
@@ -281,7 +295,8 @@ func TestCatalogServicesRegistrationQuery_Fetch(t *testing.T) {
 
 			actual, _, err := d.Fetch(&testClient{consul: client})
 			assert.NoError(t, err)
-			assert.Equal(t, tc.expected, actual)
+			require.IsType(t, &CatalogServices{}, actual)
+			assert.Equal(t, tc.expected, actual.(*CatalogServices).Services)
 		})
 	}
 }
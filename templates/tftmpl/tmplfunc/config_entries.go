@@ -0,0 +1,195 @@
+package tmplfunc
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/pkg/errors"
+)
+
+var _ hcatQuery = (*configEntriesQuery)(nil)
+
+// configEntriesFunc returns information on Consul configuration entries of a
+// kind. It queries the List Configurations API and supports the query
+// parameters kind, name, regexp, dc, and ns. The kind parameter is required.
+//
+// Endpoint: /v1/config/:kind
+// Template: {{ configEntries "kind=<kind>" <options> ... }}
+func configEntriesFunc(recall hcat.Recaller) interface{} {
+	return func(opts ...string) ([]consulapi.ConfigEntry, error) {
+		result := []consulapi.ConfigEntry{}
+
+		d, err := newConfigEntriesQuery(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if value, ok := recall(d); ok {
+			return value.([]consulapi.ConfigEntry), nil
+		}
+
+		return result, nil
+	}
+}
+
+// configEntriesQuery is the representation of a requested configuration
+// entries query from inside a template.
+type configEntriesQuery struct {
+	isConsul
+	stopCh chan struct{}
+
+	kind   string
+	name   string
+	regexp *regexp.Regexp
+	dc     string
+	ns     string
+	opts   hcat.QueryOptions
+}
+
+// newConfigEntriesQuery processes options in the format of "key=value"
+// (e.g. "kind=service-defaults"). The Consul API does not support filtering
+// configuration entries, so the entries are matched on name or regexp after
+// they are fetched.
+func newConfigEntriesQuery(opts []string) (*configEntriesQuery, error) {
+	query := configEntriesQuery{
+		stopCh: make(chan struct{}, 1),
+	}
+
+	for _, opt := range opts {
+		if strings.TrimSpace(opt) == "" {
+			continue
+		}
+
+		if !strings.Contains(opt, "=") {
+			return nil, fmt.Errorf("configEntries: invalid option: %q", opt)
+		}
+
+		queryParam := strings.SplitN(opt, "=", 2)
+		param := strings.TrimSpace(queryParam[0])
+		value := strings.TrimSpace(queryParam[1])
+		switch param {
+		case "kind":
+			query.kind = value
+		case "name":
+			query.name = value
+		case "regexp":
+			r, err := regexp.Compile(value)
+			if err != nil {
+				return nil, fmt.Errorf("configEntries: unable to compile "+
+					"regexp %q: %s", value, err)
+			}
+			query.regexp = r
+		case "dc", "datacenter":
+			query.dc = value
+		case "ns", "namespace":
+			query.ns = value
+		default:
+			return nil, fmt.Errorf(
+				"configEntries: invalid query parameter: %q", opt)
+		}
+	}
+
+	if query.kind == "" {
+		return nil, fmt.Errorf("configEntries: kind is required")
+	}
+
+	if query.name != "" && query.regexp != nil {
+		return nil, fmt.Errorf("configEntries: name and regexp cannot " +
+			"both be configured")
+	}
+
+	return &query, nil
+}
+
+// Fetch queries the Consul API defined by the given client and returns a slice
+// of ConfigEntry objects of the kind sorted by name.
+func (d *configEntriesQuery) Fetch(clients dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
+	select {
+	case <-d.stopCh:
+		return nil, nil, dep.ErrStopped
+	default:
+	}
+
+	hcatOpts := d.opts.Merge(&hcat.QueryOptions{
+		Datacenter: d.dc,
+		Namespace:  d.ns,
+	})
+
+	entries, qm, err := clients.Consul().ConfigEntries().List(d.kind, hcatOpts.ToConsulOpts())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, d.String())
+	}
+
+	matched := make([]consulapi.ConfigEntry, 0, len(entries))
+	for _, e := range entries {
+		if d.name != "" && e.GetName() != d.name {
+			continue
+		}
+		if d.regexp != nil && !d.regexp.MatchString(e.GetName()) {
+			continue
+		}
+		matched = append(matched, e)
+	}
+
+	sort.Stable(ByConfigEntryName(matched))
+
+	rm := &dep.ResponseMetadata{
+		LastIndex:   qm.LastIndex,
+		LastContact: qm.LastContact,
+	}
+
+	return matched, rm, nil
+}
+
+// SetOptions satisfies the hcat.QueryOptionsSetter interface which enables
+// blocking queries.
+func (d *configEntriesQuery) SetOptions(opts hcat.QueryOptions) {
+	d.opts = opts
+}
+
+// ID returns the human-friendly version of this query.
+func (d *configEntriesQuery) ID() string {
+	var opts []string
+	if d.name != "" {
+		opts = append(opts, fmt.Sprintf("name=%s", d.name))
+	}
+	if d.regexp != nil {
+		opts = append(opts, fmt.Sprintf("regexp=%s", d.regexp.String()))
+	}
+	if d.dc != "" {
+		opts = append(opts, fmt.Sprintf("dc=%s", d.dc))
+	}
+	if d.ns != "" {
+		opts = append(opts, fmt.Sprintf("ns=%s", d.ns))
+	}
+	if len(opts) > 0 {
+		sort.Strings(opts)
+		return fmt.Sprintf("config.entries(%s|%s)", d.kind, strings.Join(opts, "&"))
+	}
+	return fmt.Sprintf("config.entries(%s)", d.kind)
+}
+
+// Stringer interface reuses ID
+func (d *configEntriesQuery) String() string {
+	return d.ID()
+}
+
+// Stop halts the query's fetch function.
+func (d *configEntriesQuery) Stop() {
+	close(d.stopCh)
+}
+
+// ByConfigEntryName is a sortable slice of ConfigEntry interfaces sorted by
+// the entry name.
+type ByConfigEntryName []consulapi.ConfigEntry
+
+func (s ByConfigEntryName) Len() int      { return len(s) }
+func (s ByConfigEntryName) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s ByConfigEntryName) Less(i, j int) bool {
+	return s[i].GetName() < s[j].GetName()
+}
@@ -0,0 +1,156 @@
+package tmplfunc
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfigEntriesQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		opts []string
+		exp  *configEntriesQuery
+		err  bool
+	}{
+		{
+			"kind",
+			[]string{"kind=service-defaults"},
+			&configEntriesQuery{
+				kind: "service-defaults",
+			},
+			false,
+		},
+		{
+			"name",
+			[]string{"kind=service-defaults", "name=api"},
+			&configEntriesQuery{
+				kind: "service-defaults",
+				name: "api",
+			},
+			false,
+		},
+		{
+			"regexp",
+			[]string{"kind=ingress-gateway", "regexp=^ingress-"},
+			&configEntriesQuery{
+				kind:   "ingress-gateway",
+				regexp: regexp.MustCompile("^ingress-"),
+			},
+			false,
+		},
+		{
+			"multiple",
+			[]string{"kind=service-resolver", "dc=dc1", "ns=namespace", ""},
+			&configEntriesQuery{
+				kind: "service-resolver",
+				dc:   "dc1",
+				ns:   "namespace",
+			},
+			false,
+		},
+		{
+			"no kind",
+			[]string{"name=api"},
+			nil,
+			true,
+		},
+		{
+			"name and regexp",
+			[]string{"kind=service-defaults", "name=api", "regexp=api"},
+			nil,
+			true,
+		},
+		{
+			"invalid regexp",
+			[]string{"kind=service-defaults", "regexp=*"},
+			nil,
+			true,
+		},
+		{
+			"invalid query parameter",
+			[]string{"kind=service-defaults", "peer=peer1"},
+			nil,
+			true,
+		},
+		{
+			"invalid option",
+			[]string{"kind=service-defaults", `Name == "api"`},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			act, err := newConfigEntriesQuery(tc.opts)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if act != nil {
+				act.stopCh = nil
+			}
+
+			assert.NoError(t, err, err)
+			assert.Equal(t, tc.exp, act)
+		})
+	}
+}
+
+func TestConfigEntriesQuery_String(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    []string
+		exp  string
+	}{
+		{
+			"kind",
+			[]string{"kind=service-defaults"},
+			"config.entries(service-defaults)",
+		},
+		{
+			"name",
+			[]string{"kind=service-defaults", "name=api"},
+			"config.entries(service-defaults|name=api)",
+		},
+		{
+			"multiple",
+			[]string{"ns=namespace", "dc=dc1", "kind=ingress-gateway", "regexp=^ingress-"},
+			"config.entries(ingress-gateway|dc=dc1&ns=namespace&regexp=^ingress-)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newConfigEntriesQuery(tc.i)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.exp, d.String())
+		})
+	}
+}
+
+func TestByConfigEntryName(t *testing.T) {
+	t.Parallel()
+
+	entries := []consulapi.ConfigEntry{
+		&consulapi.ServiceConfigEntry{Name: "web"},
+		&consulapi.ServiceConfigEntry{Name: "api"},
+		&consulapi.ServiceConfigEntry{Name: "db"},
+	}
+	sort.Stable(ByConfigEntryName(entries))
+
+	var actual []string
+	for _, e := range entries {
+		actual = append(actual, e.GetName())
+	}
+	assert.Equal(t, []string{"api", "db", "web"}, actual)
+}
@@ -0,0 +1,83 @@
+package tmplfunc
+
+import (
+	"strings"
+	"sync"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/hcat/dep"
+)
+
+// connectProxies is the proxy configuration of the Connect proxy instances
+// returned by the health service queries. The hcat HealthService dependency
+// has no field for the proxy configuration, so the queries store it here for
+// HCLServiceV1 to look up when marshaling the instance. The store is shared
+// since a query is shared by the templates of all tasks that request it.
+var connectProxies = newProxyStore()
+
+// proxyStore stores the proxy configurations of Connect proxy instances by
+// the ID of the query that returned them. The proxies of a query are replaced
+// on each fetch and removed when the query is stopped, so that proxies of
+// deregistered instances are not kept.
+type proxyStore struct {
+	mu      sync.RWMutex
+	proxies map[string]map[string]*consulapi.AgentServiceConnectProxyConfig
+}
+
+func newProxyStore() *proxyStore {
+	return &proxyStore{
+		proxies: make(map[string]map[string]*consulapi.AgentServiceConnectProxyConfig),
+	}
+}
+
+// Set replaces the proxy configurations returned by the query with the
+// service entries of the query's latest fetch.
+func (s *proxyStore) Set(queryID, partition, peer string, entries []*consulapi.ServiceEntry) {
+	proxies := make(map[string]*consulapi.AgentServiceConnectProxyConfig)
+	for _, entry := range entries {
+		if entry.Service == nil || entry.Service.Proxy == nil ||
+			entry.Service.Kind != consulapi.ServiceKindConnectProxy {
+			continue
+		}
+		key := proxyKey(partition, peer, entry.Node.Datacenter, entry.Node.Node,
+			entry.Service.Namespace, entry.Service.ID)
+		proxies[key] = entry.Service.Proxy
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(proxies) == 0 {
+		delete(s.proxies, queryID)
+		return
+	}
+	s.proxies[queryID] = proxies
+}
+
+// Get returns the proxy configuration of the service instance queried in the
+// admin partition and cluster peer, or nil if there is none.
+func (s *proxyStore) Get(partition, peer string, sDep *dep.HealthService) *consulapi.AgentServiceConnectProxyConfig {
+	key := proxyKey(partition, peer, sDep.NodeDatacenter, sDep.Node,
+		sDep.Namespace, sDep.ID)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, proxies := range s.proxies {
+		if proxy, ok := proxies[key]; ok {
+			return proxy
+		}
+	}
+	return nil
+}
+
+// Delete removes the proxy configurations returned by the query.
+func (s *proxyStore) Delete(queryID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.proxies, queryID)
+}
+
+// proxyKey identifies a service instance across the queried admin partitions,
+// cluster peers, and datacenters.
+func proxyKey(partition, peer, dc, node, ns, id string) string {
+	return strings.Join([]string{partition, peer, dc, node, ns, id}, "/")
+}
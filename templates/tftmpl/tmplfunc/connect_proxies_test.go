@@ -0,0 +1,71 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyStore(t *testing.T) {
+	proxyEntry := func(node, id string) *consulapi.ServiceEntry {
+		return &consulapi.ServiceEntry{
+			Node: &consulapi.Node{Node: node, Datacenter: "dc1"},
+			Service: &consulapi.AgentService{
+				ID:   id,
+				Kind: consulapi.ServiceKindConnectProxy,
+				Proxy: &consulapi.AgentServiceConnectProxyConfig{
+					DestinationServiceName: "api",
+				},
+			},
+		}
+	}
+	instance := func(node, id string) *dep.HealthService {
+		return &dep.HealthService{Node: node, NodeDatacenter: "dc1", ID: id}
+	}
+
+	t.Run("set and get", func(t *testing.T) {
+		s := newProxyStore()
+		s.Set("query", "ap1", "", []*consulapi.ServiceEntry{
+			proxyEntry("node-a", "api-sidecar-proxy"),
+			{
+				Node:    &consulapi.Node{Node: "node-a", Datacenter: "dc1"},
+				Service: &consulapi.AgentService{ID: "api"},
+			},
+		})
+
+		proxy := s.Get("ap1", "", instance("node-a", "api-sidecar-proxy"))
+		if assert.NotNil(t, proxy) {
+			assert.Equal(t, "api", proxy.DestinationServiceName)
+		}
+		assert.Nil(t, s.Get("", "", instance("node-a", "api-sidecar-proxy")),
+			"instance of another partition")
+		assert.Nil(t, s.Get("ap1", "", instance("node-a", "api")),
+			"not a proxy")
+	})
+
+	t.Run("replaced by fetch", func(t *testing.T) {
+		s := newProxyStore()
+		s.Set("query", "", "", []*consulapi.ServiceEntry{
+			proxyEntry("node-a", "api-sidecar-proxy"),
+		})
+		s.Set("query", "", "", []*consulapi.ServiceEntry{
+			proxyEntry("node-b", "api-sidecar-proxy"),
+		})
+
+		assert.Nil(t, s.Get("", "", instance("node-a", "api-sidecar-proxy")))
+		assert.NotNil(t, s.Get("", "", instance("node-b", "api-sidecar-proxy")))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		s := newProxyStore()
+		s.Set("query", "", "", []*consulapi.ServiceEntry{
+			proxyEntry("node-a", "api-sidecar-proxy"),
+		})
+		s.Delete("query")
+
+		assert.Nil(t, s.Get("", "", instance("node-a", "api-sidecar-proxy")))
+		assert.Empty(t, s.proxies)
+	})
+}
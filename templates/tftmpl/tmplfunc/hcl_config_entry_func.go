@@ -0,0 +1,72 @@
+package tmplfunc
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// configEntryIgnoredFields are the fields of a configuration entry that change
+// without a change to the entry definition. They are omitted so that changes
+// to them do not change the rendered variable.
+var configEntryIgnoredFields = []string{"CreateIndex", "ModifyIndex"}
+
+// hclConfigEntryFunc is a wrapper of the template function to marshal Consul
+// configuration entries into HCL. The shape of an entry depends on its kind,
+// so the attributes follow the Consul API field names. Kind and Name are
+// written first followed by the remaining attributes in alphabetical order.
+func hclConfigEntryFunc() func(e consulapi.ConfigEntry) (string, error) {
+	return func(e consulapi.ConfigEntry) (string, error) {
+		if e == nil {
+			return "", nil
+		}
+
+		b, err := json.Marshal(e)
+		if err != nil {
+			return "", err
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(b, &fields); err != nil {
+			return "", err
+		}
+		for _, k := range configEntryIgnoredFields {
+			delete(fields, k)
+		}
+
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			if k == "Kind" || k == "Name" {
+				continue
+			}
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		keys = append([]string{"Kind", "Name"}, keys...)
+
+		f := hclwrite.NewEmptyFile()
+		body := f.Body()
+		for _, k := range keys {
+			raw, ok := fields[k]
+			if !ok {
+				continue
+			}
+
+			ty, err := ctyjson.ImpliedType(raw)
+			if err != nil {
+				return "", err
+			}
+			val, err := ctyjson.Unmarshal(raw, ty)
+			if err != nil {
+				return "", err
+			}
+			body.SetAttributeValue(k, val)
+		}
+
+		return strings.TrimSpace(string(f.Bytes())), nil
+	}
+}
@@ -0,0 +1,79 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHCLConfigEntryFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  consulapi.ConfigEntry
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"",
+		}, {
+			"service-defaults",
+			&consulapi.ServiceConfigEntry{
+				Kind:        consulapi.ServiceDefaults,
+				Name:        "api",
+				Protocol:    "http",
+				CreateIndex: 10,
+				ModifyIndex: 12,
+			},
+			`Kind        = "service-defaults"
+Name        = "api"
+Expose      = {}
+MeshGateway = {}
+Protocol    = "http"`,
+		}, {
+			"ingress-gateway",
+			&consulapi.IngressGatewayConfigEntry{
+				Kind: consulapi.IngressGateway,
+				Name: "ingress",
+				Meta: map[string]string{"env": "prod"},
+				Listeners: []consulapi.IngressListener{
+					{
+						Port:     8080,
+						Protocol: "http",
+						Services: []consulapi.IngressService{
+							{
+								Name:  "api",
+								Hosts: []string{"api.example.com"},
+							},
+						},
+					},
+				},
+			},
+			`Kind = "ingress-gateway"
+Name = "ingress"
+Listeners = [{
+  Port     = 8080
+  Protocol = "http"
+  Services = [{
+    Hosts = ["api.example.com"]
+    Name  = "api"
+  }]
+}]
+Meta = {
+  env = "prod"
+}
+TLS = {
+  Enabled = false
+}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hcl, err := hclConfigEntryFunc()(tc.content)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, hcl)
+		})
+	}
+}
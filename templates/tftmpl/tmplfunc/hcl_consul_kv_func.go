@@ -0,0 +1,191 @@
+package tmplfunc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// hclConsulKVFunc is a wrapper of the template function to decode Consul KV
+// values in the format "json", "yaml", or "hcl" and marshal them into HCL.
+//
+// The argument is either the KeyPair of a single key, which is rendered as an
+// object of the key path to the decoded value, or the KeyPairs under a prefix,
+// which are rendered as nested objects that mirror the key hierarchy e.g. the
+// key "app/db/config" is rendered as { app = { db = { config = <value> } } }.
+// Folder keys, which end with "/", are skipped. It returns an empty object
+// "{}" when there are no keys.
+//
+// An error is returned if a value cannot be decoded in the format, naming
+// the key so that the error can be resolved from the task event.
+func hclConsulKVFunc() func(kv interface{}, format string) (string, error) {
+	return func(kv interface{}, format string) (string, error) {
+		var val cty.Value
+		var err error
+
+		switch kv := kv.(type) {
+		case nil:
+			return "{}", nil
+		case *dep.KeyPair:
+			val, err = decodeConsulKVPair(kv, format)
+		case []*dep.KeyPair:
+			val, err = decodeConsulKVPairs(kv, format)
+		default:
+			return "", fmt.Errorf("unable to decode Consul KV: unexpected "+
+				"type %T", kv)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		return string(hclwrite.Format(hclwrite.TokensForValue(val).Bytes())), nil
+	}
+}
+
+// decodeConsulKVPair decodes the value of a single key into an object of the
+// key path to the value
+func decodeConsulKVPair(pair *dep.KeyPair, format string) (cty.Value, error) {
+	if pair == nil || !pair.Exists {
+		return cty.EmptyObjectVal, nil
+	}
+
+	val, err := decodeConsulKVValue(pair, format)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	return cty.ObjectVal(map[string]cty.Value{pair.Path: val}), nil
+}
+
+// decodeConsulKVPairs decodes the values of the keys under a prefix into
+// nested objects that mirror the key hierarchy
+func decodeConsulKVPairs(pairs []*dep.KeyPair, format string) (cty.Value, error) {
+	root := make(map[string]interface{})
+	for _, pair := range pairs {
+		if pair == nil || strings.HasSuffix(pair.Path, "/") {
+			continue
+		}
+
+		val, err := decodeConsulKVValue(pair, format)
+		if err != nil {
+			return cty.NilVal, err
+		}
+
+		segments := strings.Split(strings.Trim(pair.Path, "/"), "/")
+		node := root
+		for i, segment := range segments[:len(segments)-1] {
+			child, ok := node[segment]
+			if !ok {
+				child = make(map[string]interface{})
+				node[segment] = child
+			}
+
+			next, ok := child.(map[string]interface{})
+			if !ok {
+				return cty.NilVal, fmt.Errorf("unable to nest Consul KV key "+
+					"%q: key %q has a value", pair.Path,
+					strings.Join(segments[:i+1], "/"))
+			}
+			node = next
+		}
+
+		leaf := segments[len(segments)-1]
+		if _, ok := node[leaf]; ok {
+			return cty.NilVal, fmt.Errorf("unable to nest Consul KV key %q: "+
+				"key is also a prefix of other keys", pair.Path)
+		}
+		node[leaf] = val
+	}
+
+	return consulKVObjectVal(root), nil
+}
+
+// consulKVObjectVal converts the nested map of decoded values into an object
+func consulKVObjectVal(node map[string]interface{}) cty.Value {
+	if len(node) == 0 {
+		return cty.EmptyObjectVal
+	}
+
+	attrs := make(map[string]cty.Value, len(node))
+	for k, v := range node {
+		switch v := v.(type) {
+		case cty.Value:
+			attrs[k] = v
+		case map[string]interface{}:
+			attrs[k] = consulKVObjectVal(v)
+		}
+	}
+	return cty.ObjectVal(attrs)
+}
+
+// decodeConsulKVValue decodes the value of a key in the format
+func decodeConsulKVValue(pair *dep.KeyPair, format string) (cty.Value, error) {
+	var val cty.Value
+	var err error
+
+	switch format {
+	case "json":
+		val, err = decodeJSONValue([]byte(pair.Value))
+	case "yaml":
+		var b []byte
+		b, err = yaml.YAMLToJSON([]byte(pair.Value))
+		if err == nil {
+			val, err = decodeJSONValue(b)
+		}
+	case "hcl":
+		val, err = decodeHCLValue(pair.Path, []byte(pair.Value))
+	default:
+		return cty.NilVal, fmt.Errorf("unable to decode Consul KV key %q: "+
+			"unsupported format %q", pair.Path, format)
+	}
+
+	if err != nil {
+		return cty.NilVal, fmt.Errorf("unable to decode Consul KV key %q as "+
+			"%s: %s", pair.Path, format, err)
+	}
+	return val, nil
+}
+
+// decodeJSONValue decodes a JSON document into a value of the implied type
+func decodeJSONValue(b []byte) (cty.Value, error) {
+	ty, err := ctyjson.ImpliedType(b)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return ctyjson.Unmarshal(b, ty)
+}
+
+// decodeHCLValue decodes an HCL document of attributes into an object. The
+// attribute expressions are evaluated without variables or functions.
+func decodeHCLValue(filename string, b []byte) (cty.Value, error) {
+	file, diags := hclsyntax.ParseConfig(b, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.NilVal, diags
+	}
+
+	attrs, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return cty.NilVal, diags
+	}
+
+	vals := make(map[string]cty.Value, len(attrs))
+	for name, attr := range attrs {
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return cty.NilVal, diags
+		}
+		vals[name] = val
+	}
+
+	if len(vals) == 0 {
+		return cty.EmptyObjectVal, nil
+	}
+	return cty.ObjectVal(vals), nil
+}
@@ -0,0 +1,155 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHCLConsulKVFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		kv       interface{}
+		format   string
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"json",
+			"{}",
+		}, {
+			"key does not exist",
+			&dep.KeyPair{Path: "app/config", Exists: false},
+			"json",
+			"{}",
+		}, {
+			"json key",
+			&dep.KeyPair{
+				Path:   "app/config",
+				Value:  `{"port": 8080, "hosts": ["a", "b"]}`,
+				Exists: true,
+			},
+			"json",
+			`{
+  "app/config" = {
+    hosts = ["a", "b"]
+    port  = 8080
+  }
+}`,
+		}, {
+			"yaml key",
+			&dep.KeyPair{
+				Path:   "app/config",
+				Value:  "port: 8080\nhosts:\n  - a\n  - b\n",
+				Exists: true,
+			},
+			"yaml",
+			`{
+  "app/config" = {
+    hosts = ["a", "b"]
+    port  = 8080
+  }
+}`,
+		}, {
+			"hcl key",
+			&dep.KeyPair{
+				Path:   "app/config",
+				Value:  "port = 8080\nhosts = [\"a\", \"b\"]\n",
+				Exists: true,
+			},
+			"hcl",
+			`{
+  "app/config" = {
+    hosts = ["a", "b"]
+    port  = 8080
+  }
+}`,
+		}, {
+			"empty prefix",
+			[]*dep.KeyPair{},
+			"json",
+			"{}",
+		}, {
+			"recurse",
+			[]*dep.KeyPair{
+				{Path: "app/", Exists: true},
+				{Path: "app/db/config", Value: `{"port": 5432}`, Exists: true},
+				{Path: "app/web", Value: `"enabled"`, Exists: true},
+			},
+			"json",
+			`{
+  app = {
+    db = {
+      config = {
+        port = 5432
+      }
+    }
+    web = "enabled"
+  }
+}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hcl, err := hclConsulKVFunc()(tc.kv, tc.format)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, hcl)
+		})
+	}
+
+	errCases := []struct {
+		name     string
+		kv       interface{}
+		format   string
+		contains string
+	}{
+		{
+			"invalid json",
+			&dep.KeyPair{Path: "app/config", Value: "port: 8080", Exists: true},
+			"json",
+			`unable to decode Consul KV key "app/config" as json`,
+		}, {
+			"invalid yaml",
+			&dep.KeyPair{Path: "app/config", Value: "port: [8080", Exists: true},
+			"yaml",
+			`unable to decode Consul KV key "app/config" as yaml`,
+		}, {
+			"invalid hcl",
+			&dep.KeyPair{Path: "app/config", Value: `{"port": 8080}`, Exists: true},
+			"hcl",
+			`unable to decode Consul KV key "app/config" as hcl`,
+		}, {
+			"invalid key in prefix",
+			[]*dep.KeyPair{
+				{Path: "app/db", Value: `{"port": 5432}`, Exists: true},
+				{Path: "app/web", Value: "enabled", Exists: true},
+			},
+			"json",
+			`unable to decode Consul KV key "app/web" as json`,
+		}, {
+			"key with value is a prefix",
+			[]*dep.KeyPair{
+				{Path: "app/db", Value: `{"port": 5432}`, Exists: true},
+				{Path: "app/db/config", Value: `{"port": 5432}`, Exists: true},
+			},
+			"json",
+			`unable to nest Consul KV key "app/db/config"`,
+		}, {
+			"unsupported format",
+			&dep.KeyPair{Path: "app/config", Value: "port = 8080", Exists: true},
+			"toml",
+			`unsupported format "toml"`,
+		},
+	}
+
+	for _, tc := range errCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := hclConsulKVFunc()(tc.kv, tc.format)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.contains)
+		})
+	}
+}
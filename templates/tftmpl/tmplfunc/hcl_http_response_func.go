@@ -0,0 +1,36 @@
+package tmplfunc
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// hclHTTPResponseFunc is a wrapper of the template function to marshal the
+// decoded JSON response of a URL into HCL. The HCL type depends on the JSON
+// value e.g. a JSON object is rendered as an HCL object. It returns an empty
+// object "{}" when there is no response.
+func hclHTTPResponseFunc() func(r *HTTPResponse) (string, error) {
+	return func(r *HTTPResponse) (string, error) {
+		if r == nil {
+			return "{}", nil
+		}
+
+		b, err := json.Marshal(r.Data)
+		if err != nil {
+			return "", err
+		}
+
+		ty, err := ctyjson.ImpliedType(b)
+		if err != nil {
+			return "", err
+		}
+		val, err := ctyjson.Unmarshal(b, ty)
+		if err != nil {
+			return "", err
+		}
+
+		return string(hclwrite.Format(hclwrite.TokensForValue(val).Bytes())), nil
+	}
+}
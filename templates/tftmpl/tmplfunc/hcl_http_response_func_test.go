@@ -0,0 +1,65 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHCLHTTPResponseFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  *HTTPResponse
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"{}",
+		}, {
+			"null",
+			&HTTPResponse{},
+			"null",
+		}, {
+			"object",
+			&HTTPResponse{
+				Data: map[string]interface{}{
+					"prefixes": []interface{}{
+						map[string]interface{}{
+							"cidr": "10.0.0.0/24",
+							"vlan": float64(10),
+						},
+					},
+					"ip-ranges": true,
+				},
+			},
+			`{
+  ip-ranges = true
+  prefixes = [{
+    cidr = "10.0.0.0/24"
+    vlan = 10
+  }]
+}`,
+		}, {
+			"array",
+			&HTTPResponse{
+				Data: []interface{}{"10.0.0.0/24", "10.0.1.0/24"},
+			},
+			`["10.0.0.0/24", "10.0.1.0/24"]`,
+		}, {
+			"string",
+			&HTTPResponse{
+				Data: "10.0.0.0/24",
+			},
+			`"10.0.0.0/24"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hcl, err := hclHTTPResponseFunc()(tc.content)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, hcl)
+		})
+	}
+}
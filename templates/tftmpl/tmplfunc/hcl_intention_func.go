@@ -0,0 +1,129 @@
+package tmplfunc
+
+import (
+	"strings"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var (
+	intentionHTTPHeaderType = cty.Object(map[string]cty.Type{
+		"name":    cty.String,
+		"present": cty.Bool,
+		"exact":   cty.String,
+		"prefix":  cty.String,
+		"suffix":  cty.String,
+		"regex":   cty.String,
+		"invert":  cty.Bool,
+	})
+
+	intentionHTTPType = cty.Object(map[string]cty.Type{
+		"path_exact":  cty.String,
+		"path_prefix": cty.String,
+		"path_regex":  cty.String,
+		"methods":     cty.List(cty.String),
+		"header":      cty.List(intentionHTTPHeaderType),
+	})
+
+	intentionPermissionType = cty.Object(map[string]cty.Type{
+		"action": cty.String,
+		"http":   intentionHTTPType,
+	})
+)
+
+// hclIntentionFunc is a wrapper of the template function to marshal Consul
+// service intention information into HCL. The attributes are in the order of
+// the intentions variable type.
+func hclIntentionFunc() func(i *consulapi.Intention) string {
+	return func(i *consulapi.Intention) string {
+		if i == nil {
+			return ""
+		}
+
+		f := hclwrite.NewEmptyFile()
+		body := f.Body()
+		body.SetAttributeValue("source_name", cty.StringVal(i.SourceName))
+		body.SetAttributeValue("source_namespace", cty.StringVal(i.SourceNS))
+		body.SetAttributeValue("source_partition", cty.StringVal(i.SourcePartition))
+		body.SetAttributeValue("source_type", cty.StringVal(string(i.SourceType)))
+		body.SetAttributeValue("destination_name", cty.StringVal(i.DestinationName))
+		body.SetAttributeValue("destination_namespace", cty.StringVal(i.DestinationNS))
+		body.SetAttributeValue("destination_partition", cty.StringVal(i.DestinationPartition))
+		body.SetAttributeValue("action", cty.StringVal(string(i.Action)))
+		body.SetAttributeValue("precedence", cty.NumberIntVal(int64(i.Precedence)))
+		body.SetAttributeValue("permissions", intentionPermissionsVal(i.Permissions))
+		body.SetAttributeValue("description", cty.StringVal(i.Description))
+		body.SetAttributeValue("meta", stringMapVal(i.Meta))
+		return strings.TrimSpace(string(f.Bytes()))
+	}
+}
+
+func intentionPermissionsVal(perms []*consulapi.IntentionPermission) cty.Value {
+	vals := make([]cty.Value, 0, len(perms))
+	for _, p := range perms {
+		if p == nil {
+			continue
+		}
+
+		http := cty.NullVal(intentionHTTPType)
+		if p.HTTP != nil {
+			methods := make([]cty.Value, len(p.HTTP.Methods))
+			for ix, m := range p.HTTP.Methods {
+				methods[ix] = cty.StringVal(m)
+			}
+
+			headers := make([]cty.Value, len(p.HTTP.Header))
+			for ix, h := range p.HTTP.Header {
+				headers[ix] = cty.ObjectVal(map[string]cty.Value{
+					"name":    cty.StringVal(h.Name),
+					"present": cty.BoolVal(h.Present),
+					"exact":   cty.StringVal(h.Exact),
+					"prefix":  cty.StringVal(h.Prefix),
+					"suffix":  cty.StringVal(h.Suffix),
+					"regex":   cty.StringVal(h.Regex),
+					"invert":  cty.BoolVal(h.Invert),
+				})
+			}
+
+			http = cty.ObjectVal(map[string]cty.Value{
+				"path_exact":  cty.StringVal(p.HTTP.PathExact),
+				"path_prefix": cty.StringVal(p.HTTP.PathPrefix),
+				"path_regex":  cty.StringVal(p.HTTP.PathRegex),
+				"methods":     listVal(cty.String, methods),
+				"header":      listVal(intentionHTTPHeaderType, headers),
+			})
+		}
+
+		vals = append(vals, cty.ObjectVal(map[string]cty.Value{
+			"action": cty.StringVal(string(p.Action)),
+			"http":   http,
+		}))
+	}
+
+	return listVal(intentionPermissionType, vals)
+}
+
+// listVal returns a list value of the elements, or an empty list of the type
+// when there are no elements
+func listVal(ty cty.Type, vals []cty.Value) cty.Value {
+	if len(vals) == 0 {
+		return cty.ListValEmpty(ty)
+	}
+	return cty.ListVal(vals)
+}
+
+// stringMapVal returns a map value of the strings, or an empty map when there
+// are no entries
+func stringMapVal(m map[string]string) cty.Value {
+	if len(m) == 0 {
+		return cty.MapValEmpty(cty.String)
+	}
+
+	vals := make(map[string]cty.Value, len(m))
+	for k, v := range m {
+		vals[k] = cty.StringVal(v)
+	}
+	return cty.MapVal(vals)
+}
@@ -0,0 +1,125 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHCLIntentionFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  *consulapi.Intention
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"",
+		}, {
+			"empty",
+			&consulapi.Intention{},
+			`source_name           = ""
+source_namespace      = ""
+source_partition      = ""
+source_type           = ""
+destination_name      = ""
+destination_namespace = ""
+destination_partition = ""
+action                = ""
+precedence            = 0
+permissions           = []
+description           = ""
+meta                  = {}`,
+		}, {
+			"action",
+			&consulapi.Intention{
+				SourceName:      "web",
+				SourceNS:        "default",
+				SourceType:      consulapi.IntentionSourceConsul,
+				DestinationName: "api",
+				DestinationNS:   "default",
+				Action:          consulapi.IntentionActionAllow,
+				Precedence:      9,
+				Description:     "web to api",
+				Meta:            map[string]string{"key": "value"},
+			},
+			`source_name           = "web"
+source_namespace      = "default"
+source_partition      = ""
+source_type           = "consul"
+destination_name      = "api"
+destination_namespace = "default"
+destination_partition = ""
+action                = "allow"
+precedence            = 9
+permissions           = []
+description           = "web to api"
+meta = {
+  key = "value"
+}`,
+		}, {
+			"permissions",
+			&consulapi.Intention{
+				SourceName:      "web",
+				DestinationName: "api",
+				Precedence:      9,
+				Permissions: []*consulapi.IntentionPermission{
+					{
+						Action: consulapi.IntentionActionDeny,
+						HTTP: &consulapi.IntentionHTTPPermission{
+							PathPrefix: "/admin",
+							Methods:    []string{"GET"},
+							Header: []consulapi.IntentionHTTPHeaderPermission{
+								{Name: "x-debug", Present: true},
+							},
+						},
+					},
+					{
+						Action: consulapi.IntentionActionAllow,
+					},
+				},
+			},
+			`source_name           = "web"
+source_namespace      = ""
+source_partition      = ""
+source_type           = ""
+destination_name      = "api"
+destination_namespace = ""
+destination_partition = ""
+action                = ""
+precedence            = 9
+permissions = [{
+  action = "deny"
+  http = {
+    header = [{
+      exact   = ""
+      invert  = false
+      name    = "x-debug"
+      prefix  = ""
+      present = true
+      regex   = ""
+      suffix  = ""
+    }]
+    methods     = ["GET"]
+    path_exact  = ""
+    path_prefix = "/admin"
+    path_regex  = ""
+  }
+  }, {
+  action = "allow"
+  http   = null
+}]
+description = ""
+meta        = {}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := hclIntentionFunc()(tc.content)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
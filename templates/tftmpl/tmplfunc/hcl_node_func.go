@@ -0,0 +1,49 @@
+package tmplfunc
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcat/dep"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// hclNodeFunc is a wrapper of the template function to marshal Consul node
+// information into HCL.
+func hclNodeFunc() func(nDep *dep.Node) string {
+	return func(nDep *dep.Node) string {
+		if nDep == nil {
+			return ""
+		}
+
+		n := newNode(nDep)
+
+		f := hclwrite.NewEmptyFile()
+		gohcl.EncodeIntoBody(n, f.Body())
+		return strings.TrimSpace(string(f.Bytes()))
+	}
+}
+
+type node struct {
+	ID              string            `hcl:"id"`
+	Node            string            `hcl:"node"`
+	Address         string            `hcl:"address"`
+	Datacenter      string            `hcl:"datacenter"`
+	TaggedAddresses map[string]string `hcl:"tagged_addresses"`
+	Meta            map[string]string `hcl:"meta"`
+}
+
+func newNode(n *dep.Node) node {
+	if n == nil {
+		return node{}
+	}
+
+	return node{
+		ID:              n.ID,
+		Node:            n.Node,
+		Address:         n.Address,
+		Datacenter:      n.Datacenter,
+		TaggedAddresses: nonNullMap(n.TaggedAddresses),
+		Meta:            nonNullMap(n.Meta),
+	}
+}
@@ -0,0 +1,64 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHCLNodeFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  *dep.Node
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"",
+		}, {
+			"empty",
+			&dep.Node{},
+			`id               = ""
+node             = ""
+address          = ""
+datacenter       = ""
+tagged_addresses = {}
+meta             = {}`,
+		}, {
+			"basic",
+			&dep.Node{
+				ID:         "39e5a7f5-2834-e16d-6925-78167c9f50d8",
+				Node:       "worker-01",
+				Address:    "127.0.0.1",
+				Datacenter: "dc1",
+				TaggedAddresses: map[string]string{
+					"lan": "127.0.0.1",
+					"wan": "127.0.0.1",
+				},
+				Meta: map[string]string{
+					"consul-network-segment": "",
+				},
+			},
+			`id         = "39e5a7f5-2834-e16d-6925-78167c9f50d8"
+node       = "worker-01"
+address    = "127.0.0.1"
+datacenter = "dc1"
+tagged_addresses = {
+  lan = "127.0.0.1"
+  wan = "127.0.0.1"
+}
+meta = {
+  consul-network-segment = ""
+}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := hclNodeFunc()(tc.content)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
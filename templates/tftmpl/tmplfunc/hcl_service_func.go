@@ -3,9 +3,47 @@ package tmplfunc
 import (
 	"strings"
 
+	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/hcat/dep"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var (
+	// serviceTaggedAddressType is the type of each service tagged address for
+	// the services variable definition protocol v1
+	serviceTaggedAddressType = cty.Object(map[string]cty.Type{
+		"address": cty.String,
+		"port":    cty.Number,
+	})
+
+	// serviceCheckType is the type of each health check for the services
+	// variable definition protocol v1
+	serviceCheckType = cty.Object(map[string]cty.Type{
+		"node":         cty.String,
+		"check_id":     cty.String,
+		"name":         cty.String,
+		"status":       cty.String,
+		"notes":        cty.String,
+		"service_id":   cty.String,
+		"service_name": cty.String,
+		"type":         cty.String,
+		"namespace":    cty.String,
+	})
+
+	// serviceUpstreamType is the type of each upstream of a Connect proxy for
+	// the services variable definition protocol v1
+	serviceUpstreamType = cty.Object(map[string]cty.Type{
+		"destination_type":      cty.String,
+		"destination_name":      cty.String,
+		"destination_namespace": cty.String,
+		"destination_partition": cty.String,
+		"destination_peer":      cty.String,
+		"datacenter":            cty.String,
+		"local_bind_address":    cty.String,
+		"local_bind_port":       cty.Number,
+	})
 )
 
 // hclServiceFunc is a wrapper of the template function to marshal Consul
@@ -32,6 +70,158 @@ func hclServiceFunc(meta *ServicesMeta) func(sDep *dep.HealthService) string {
 	}
 }
 
+// hclServiceV1Func is a wrapper of the template function to marshal Consul
+// service information into HCL for the services variable definition protocol
+// v1. In addition to the protocol v0 attributes, it includes the service's
+// admin partition, cluster peer, tagged addresses, weights, health checks, and
+// Connect details.
+//
+// The partition and peer are not part of the hcat service dependency. They are
+// passed as the optional "partition=<partition>" and "peer=<peer>" arguments
+// of the query that the service instance was returned by.
+func hclServiceV1Func(meta *ServicesMeta) func(sDep *dep.HealthService, opts ...string) string {
+	return func(sDep *dep.HealthService, opts ...string) string {
+		if sDep == nil {
+			return ""
+		}
+
+		var partition, peer string
+		for _, opt := range opts {
+			param, value, err := stringsSplit2(opt, "=")
+			if err != nil {
+				continue
+			}
+			switch param {
+			case "partition":
+				partition = value
+			case "peer":
+				peer = value
+			}
+		}
+
+		var serviceMeta map[string]string
+		if meta != nil {
+			serviceMeta = meta.Get(sDep.Name)
+		}
+
+		s := newHealthService(sDep, serviceMeta)
+
+		f := hclwrite.NewEmptyFile()
+		body := f.Body()
+		gohcl.EncodeIntoBody(s, body)
+
+		body.SetAttributeValue("partition", cty.StringVal(partition))
+		body.SetAttributeValue("peer", cty.StringVal(peer))
+		body.SetAttributeValue("tagged_addresses", serviceTaggedAddressesValue(sDep.ServiceTaggedAddresses))
+		body.SetAttributeValue("weights", cty.ObjectVal(map[string]cty.Value{
+			"passing": cty.NumberIntVal(int64(sDep.Weights.Passing)),
+			"warning": cty.NumberIntVal(int64(sDep.Weights.Warning)),
+		}))
+		body.SetAttributeValue("checks", serviceChecksValue(sDep.Checks))
+		body.SetAttributeValue("connect", serviceConnectValue(sDep.Kind,
+			connectProxies.Get(partition, peer, sDep)))
+
+		return strings.TrimSpace(string(hclwrite.Format(f.Bytes())))
+	}
+}
+
+// serviceTaggedAddressesValue converts the service tagged addresses, such as
+// lan_ipv4 and wan_ipv4, to a map of objects with the address and port.
+func serviceTaggedAddressesValue(addrs map[string]api.ServiceAddress) cty.Value {
+	if len(addrs) == 0 {
+		return cty.MapValEmpty(serviceTaggedAddressType)
+	}
+
+	m := make(map[string]cty.Value, len(addrs))
+	for k, addr := range addrs {
+		m[k] = cty.ObjectVal(map[string]cty.Value{
+			"address": cty.StringVal(addr.Address),
+			"port":    cty.NumberIntVal(int64(addr.Port)),
+		})
+	}
+	return cty.MapVal(m)
+}
+
+// serviceChecksValue converts the node and service health checks of a service
+// instance to a list of objects. The check output is omitted since it changes
+// frequently for many types of checks without a change in the status.
+func serviceChecksValue(checks api.HealthChecks) cty.Value {
+	var vals []cty.Value
+	for _, c := range checks {
+		if c == nil {
+			continue
+		}
+		vals = append(vals, cty.ObjectVal(map[string]cty.Value{
+			"node":         cty.StringVal(c.Node),
+			"check_id":     cty.StringVal(c.CheckID),
+			"name":         cty.StringVal(c.Name),
+			"status":       cty.StringVal(c.Status),
+			"notes":        cty.StringVal(c.Notes),
+			"service_id":   cty.StringVal(c.ServiceID),
+			"service_name": cty.StringVal(c.ServiceName),
+			"type":         cty.StringVal(c.Type),
+			"namespace":    cty.StringVal(c.Namespace),
+		}))
+	}
+
+	if len(vals) == 0 {
+		return cty.ListValEmpty(serviceCheckType)
+	}
+	return cty.ListVal(vals)
+}
+
+// serviceConnectValue determines the Connect details of a service instance
+// from the kind of the service and its proxy configuration. Proxy is true for
+// Connect proxies, such as sidecar proxies, and gateway is the kind of
+// gateway, or empty if the service is not a gateway. For Connect proxies, the
+// destination service and the upstreams are rendered from the proxy
+// configuration.
+func serviceConnectValue(kind string, proxy *api.AgentServiceConnectProxyConfig) cty.Value {
+	gateway := ""
+	switch api.ServiceKind(kind) {
+	case api.ServiceKindMeshGateway, api.ServiceKindTerminatingGateway,
+		api.ServiceKindIngressGateway:
+		gateway = kind
+	}
+
+	isProxy := api.ServiceKind(kind) == api.ServiceKindConnectProxy
+	var destName, destID string
+	var upstreams []cty.Value
+	if isProxy && proxy != nil {
+		destName = proxy.DestinationServiceName
+		destID = proxy.DestinationServiceID
+		for _, u := range proxy.Upstreams {
+			destType := string(u.DestinationType)
+			if destType == "" {
+				destType = string(api.UpstreamDestTypeService)
+			}
+			upstreams = append(upstreams, cty.ObjectVal(map[string]cty.Value{
+				"destination_type":      cty.StringVal(destType),
+				"destination_name":      cty.StringVal(u.DestinationName),
+				"destination_namespace": cty.StringVal(u.DestinationNamespace),
+				"destination_partition": cty.StringVal(u.DestinationPartition),
+				"destination_peer":      cty.StringVal(u.DestinationPeer),
+				"datacenter":            cty.StringVal(u.Datacenter),
+				"local_bind_address":    cty.StringVal(u.LocalBindAddress),
+				"local_bind_port":       cty.NumberIntVal(int64(u.LocalBindPort)),
+			}))
+		}
+	}
+
+	upstreamsVal := cty.ListValEmpty(serviceUpstreamType)
+	if len(upstreams) > 0 {
+		upstreamsVal = cty.ListVal(upstreams)
+	}
+
+	return cty.ObjectVal(map[string]cty.Value{
+		"proxy":                    cty.BoolVal(isProxy),
+		"gateway":                  cty.StringVal(gateway),
+		"destination_service_name": cty.StringVal(destName),
+		"destination_service_id":   cty.StringVal(destID),
+		"upstreams":                upstreamsVal,
+	})
+}
+
 type healthService struct {
 	// Consul service information
 	ID        string            `hcl:"id"`
@@ -3,6 +3,7 @@ package tmplfunc
 import (
 	"testing"
 
+	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/hcat/dep"
 	"github.com/stretchr/testify/assert"
 )
@@ -178,3 +179,236 @@ cts_user_defined_meta = {
 		})
 	}
 }
+
+func TestHCLServiceV1Func(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  *dep.HealthService
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"",
+		}, {
+			"empty",
+			&dep.HealthService{},
+			`id                    = ""
+name                  = ""
+kind                  = ""
+address               = ""
+port                  = 0
+meta                  = {}
+tags                  = []
+namespace             = ""
+status                = ""
+node                  = ""
+node_id               = ""
+node_address          = ""
+node_datacenter       = ""
+node_tagged_addresses = {}
+node_meta             = {}
+cts_user_defined_meta = {}
+partition             = ""
+peer                  = ""
+tagged_addresses      = {}
+weights = {
+  passing = 0
+  warning = 0
+}
+checks = []
+connect = {
+  destination_service_id   = ""
+  destination_service_name = ""
+  gateway                  = ""
+  proxy                    = false
+  upstreams                = []
+}`,
+		}, {
+			"connect-proxy",
+			&dep.HealthService{
+				ID:      "api-sidecar-proxy",
+				Name:    "api-sidecar-proxy",
+				Kind:    "connect-proxy",
+				Address: "1.2.3.4",
+				Port:    21000,
+				Status:  "warning",
+				Node:    "worker-01",
+				ServiceTaggedAddresses: map[string]api.ServiceAddress{
+					"lan_ipv4": {Address: "1.2.3.4", Port: 21000},
+				},
+				Weights: api.AgentWeights{Passing: 10, Warning: 1},
+				Checks: api.HealthChecks{
+					{
+						Node:    "worker-01",
+						CheckID: "serfHealth",
+						Name:    "Serf Health Status",
+						Status:  "passing",
+					}, {
+						Node:        "worker-01",
+						CheckID:     "service:api-sidecar-proxy",
+						Name:        "Connect Sidecar Listening",
+						Status:      "warning",
+						Output:      "dial tcp 1.2.3.4:21000: connect: connection refused",
+						ServiceID:   "api-sidecar-proxy",
+						ServiceName: "api-sidecar-proxy",
+						Type:        "tcp",
+					},
+				},
+			},
+			`id                    = "api-sidecar-proxy"
+name                  = "api-sidecar-proxy"
+kind                  = "connect-proxy"
+address               = "1.2.3.4"
+port                  = 21000
+meta                  = {}
+tags                  = []
+namespace             = ""
+status                = "warning"
+node                  = "worker-01"
+node_id               = ""
+node_address          = ""
+node_datacenter       = ""
+node_tagged_addresses = {}
+node_meta             = {}
+cts_user_defined_meta = {}
+partition             = ""
+peer                  = ""
+tagged_addresses = {
+  lan_ipv4 = {
+    address = "1.2.3.4"
+    port    = 21000
+  }
+}
+weights = {
+  passing = 10
+  warning = 1
+}
+checks = [{
+  check_id     = "serfHealth"
+  name         = "Serf Health Status"
+  namespace    = ""
+  node         = "worker-01"
+  notes        = ""
+  service_id   = ""
+  service_name = ""
+  status       = "passing"
+  type         = ""
+  }, {
+  check_id     = "service:api-sidecar-proxy"
+  name         = "Connect Sidecar Listening"
+  namespace    = ""
+  node         = "worker-01"
+  notes        = ""
+  service_id   = "api-sidecar-proxy"
+  service_name = "api-sidecar-proxy"
+  status       = "warning"
+  type         = "tcp"
+}]
+connect = {
+  destination_service_id   = "api-1"
+  destination_service_name = "api"
+  gateway                  = ""
+  proxy                    = true
+  upstreams = [{
+    datacenter            = ""
+    destination_name      = "db"
+    destination_namespace = ""
+    destination_partition = ""
+    destination_peer      = ""
+    destination_type      = "service"
+    local_bind_address    = ""
+    local_bind_port       = 9191
+    }, {
+    datacenter            = "dc2"
+    destination_name      = "cache"
+    destination_namespace = "ns1"
+    destination_partition = "ap1"
+    destination_peer      = ""
+    destination_type      = "prepared_query"
+    local_bind_address    = "127.0.0.1"
+    local_bind_port       = 9292
+  }]
+}`,
+		}, {
+			"gateway",
+			&dep.HealthService{
+				Kind: "mesh-gateway",
+			},
+			`id                    = ""
+name                  = ""
+kind                  = "mesh-gateway"
+address               = ""
+port                  = 0
+meta                  = {}
+tags                  = []
+namespace             = ""
+status                = ""
+node                  = ""
+node_id               = ""
+node_address          = ""
+node_datacenter       = ""
+node_tagged_addresses = {}
+node_meta             = {}
+cts_user_defined_meta = {}
+partition             = ""
+peer                  = ""
+tagged_addresses      = {}
+weights = {
+  passing = 0
+  warning = 0
+}
+checks = []
+connect = {
+  destination_service_id   = ""
+  destination_service_name = ""
+  gateway                  = "mesh-gateway"
+  proxy                    = false
+  upstreams                = []
+}`,
+		},
+	}
+
+	// Proxy configuration of the connect-proxy instance, as stored by the
+	// query that returned it
+	connectProxies.Set("test-query", "", "", []*api.ServiceEntry{{
+		Node: &api.Node{Node: "worker-01"},
+		Service: &api.AgentService{
+			ID:   "api-sidecar-proxy",
+			Kind: api.ServiceKindConnectProxy,
+			Proxy: &api.AgentServiceConnectProxyConfig{
+				DestinationServiceName: "api",
+				DestinationServiceID:   "api-1",
+				Upstreams: []api.Upstream{
+					{
+						DestinationName: "db",
+						LocalBindPort:   9191,
+					}, {
+						DestinationType:      api.UpstreamDestTypePreparedQuery,
+						DestinationName:      "cache",
+						DestinationNamespace: "ns1",
+						DestinationPartition: "ap1",
+						Datacenter:           "dc2",
+						LocalBindAddress:     "127.0.0.1",
+						LocalBindPort:        9292,
+					},
+				},
+			},
+		},
+	}})
+	defer connectProxies.Delete("test-query")
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := hclServiceV1Func(nil)(tc.content)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	t.Run("partition and peer", func(t *testing.T) {
+		actual := hclServiceV1Func(nil)(&dep.HealthService{Name: "api"},
+			"partition=ap1", "peer=cluster-02")
+		assert.Contains(t, actual, `partition             = "ap1"`)
+		assert.Contains(t, actual, `peer                  = "cluster-02"`)
+	})
+}
@@ -0,0 +1,38 @@
+package tmplfunc
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/hcat/dep"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// hclVaultSecretFunc is a wrapper of the template function to marshal the data
+// of a Vault secret into an HCL object. The format of the data depends on the
+// secrets engine, e.g. the KV secrets engine version 2 nests the key/value
+// pairs under "data" alongside the "metadata" of the secret version. It
+// returns an empty object "{}" when there is no secret.
+func hclVaultSecretFunc() func(s *dep.Secret) (string, error) {
+	return func(s *dep.Secret) (string, error) {
+		if s == nil || len(s.Data) == 0 {
+			return "{}", nil
+		}
+
+		b, err := json.Marshal(s.Data)
+		if err != nil {
+			return "", err
+		}
+
+		ty, err := ctyjson.ImpliedType(b)
+		if err != nil {
+			return "", err
+		}
+		val, err := ctyjson.Unmarshal(b, ty)
+		if err != nil {
+			return "", err
+		}
+
+		return string(hclwrite.Format(hclwrite.TokensForValue(val).Bytes())), nil
+	}
+}
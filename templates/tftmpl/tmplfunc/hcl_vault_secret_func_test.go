@@ -0,0 +1,69 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcat/dep"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHCLVaultSecretFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  *dep.Secret
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"{}",
+		}, {
+			"empty",
+			&dep.Secret{},
+			"{}",
+		}, {
+			"kv v1",
+			&dep.Secret{
+				LeaseDuration: 2764800,
+				Data: map[string]interface{}{
+					"username": "admin",
+					"my.key":   "value",
+				},
+			},
+			`{
+  "my.key" = "value"
+  username = "admin"
+}`,
+		}, {
+			"kv v2",
+			&dep.Secret{
+				Data: map[string]interface{}{
+					"data": map[string]interface{}{
+						"username": "admin",
+					},
+					"metadata": map[string]interface{}{
+						"version":   2,
+						"destroyed": false,
+					},
+				},
+			},
+			`{
+  data = {
+    username = "admin"
+  }
+  metadata = {
+    destroyed = false
+    version   = 2
+  }
+}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hcl, err := hclVaultSecretFunc()(tc.content)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, hcl)
+		})
+	}
+}
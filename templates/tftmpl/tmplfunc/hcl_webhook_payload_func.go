@@ -0,0 +1,35 @@
+package tmplfunc
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// hclWebhookPayloadFunc is a wrapper of the template function to marshal the
+// body of the latest request to trigger a task into HCL. It returns an empty
+// object "{}" when the task has not been triggered yet or the body is null.
+func hclWebhookPayloadFunc() func(p *WebhookPayload) (string, error) {
+	return func(p *WebhookPayload) (string, error) {
+		if p == nil || p.Body == nil {
+			return "{}", nil
+		}
+
+		b, err := json.Marshal(p.Body)
+		if err != nil {
+			return "", err
+		}
+
+		ty, err := ctyjson.ImpliedType(b)
+		if err != nil {
+			return "", err
+		}
+		val, err := ctyjson.Unmarshal(b, ty)
+		if err != nil {
+			return "", err
+		}
+
+		return string(hclwrite.Format(hclwrite.TokensForValue(val).Bytes())), nil
+	}
+}
@@ -0,0 +1,48 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHCLWebhookPayloadFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  *WebhookPayload
+		expected string
+	}{
+		{
+			"nil",
+			nil,
+			"{}",
+		}, {
+			"not triggered",
+			&WebhookPayload{Task: "task"},
+			"{}",
+		}, {
+			"object",
+			&WebhookPayload{
+				Task:  "task",
+				Index: 1,
+				Body: map[string]interface{}{
+					"image":    "web:1.2.3",
+					"replicas": float64(3),
+				},
+			},
+			`{
+  image    = "web:1.2.3"
+  replicas = 3
+}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := hclWebhookPayloadFunc()
+			actual, err := f(tc.content)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
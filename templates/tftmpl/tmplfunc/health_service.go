@@ -0,0 +1,284 @@
+package tmplfunc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/pkg/errors"
+)
+
+// Health statuses supported by the status query parameter. Each status
+// includes the service instances with a healthier status.
+const (
+	healthPassing  = "passing"
+	healthWarning  = "warning"
+	healthCritical = "critical"
+	healthAny      = "any"
+)
+
+// healthStatusRank orders the aggregated health statuses of service instances
+// from healthiest to least healthy. Instances in maintenance mode are only
+// included with the "any" status.
+var healthStatusRank = map[string]int{
+	consulapi.HealthPassing:  0,
+	consulapi.HealthWarning:  1,
+	consulapi.HealthCritical: 2,
+	consulapi.HealthMaint:    3,
+}
+
+var _ hcatQuery = (*healthServiceQuery)(nil)
+
+// healthServiceFunc returns information on the instances of a registered
+// Consul service that have the selected health status or a healthier one. It
+// is similar to the hcat {{ service }} template function, which only returns
+// passing instances, with support for the status query parameter.
+// It supports the query parameters status, dc, ns, partition, peer, and
+// filter.
+//
+// Endpoint: /v1/health/service/:service
+// Template: {{ healthService <name> status=<status> <options> ... }}
+func healthServiceFunc(recall hcat.Recaller) interface{} {
+	return func(name string, opts ...string) ([]*dep.HealthService, error) {
+		result := []*dep.HealthService{}
+
+		d, err := newHealthServiceQuery(name, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if value, ok := recall(d); ok {
+			return value.([]*dep.HealthService), nil
+		}
+
+		return result, nil
+	}
+}
+
+// healthServiceQuery is the representation of a requested health service
+// query with a status from inside a template.
+type healthServiceQuery struct {
+	isConsul
+	stopCh chan struct{}
+
+	name      string
+	status    string
+	filter    string
+	dc        string
+	ns        string
+	partition string
+	peer      string
+	opts      hcat.QueryOptions
+}
+
+// newHealthServiceQuery processes options in the format of "key=value"
+// (e.g. "status=warning") with the exception of filters. Any option that is
+// not a key/value pair is assumed to be a filter.
+func newHealthServiceQuery(name string, opts []string) (*healthServiceQuery, error) {
+	if name == "" {
+		return nil, fmt.Errorf("health.service.status: service name required")
+	}
+
+	query := healthServiceQuery{
+		stopCh: make(chan struct{}, 1),
+		name:   name,
+		status: healthPassing,
+	}
+
+	var filters []string
+	for _, opt := range opts {
+		if strings.TrimSpace(opt) == "" {
+			continue
+		}
+
+		// Parse query paramters, excluding the filter which is not set as a parameter
+		if queryParamOptRe.MatchString(opt) {
+			queryParam := strings.SplitN(opt, "=", 2)
+			param := strings.TrimSpace(queryParam[0])
+			value := strings.TrimSpace(queryParam[1])
+			switch param {
+			case "status":
+				if err := validateHealthStatus(value); err != nil {
+					return nil, fmt.Errorf("health.service.status: %s", err)
+				}
+				query.status = value
+				continue
+			case "dc", "datacenter":
+				query.dc = value
+				continue
+			case "ns", "namespace":
+				query.ns = value
+				continue
+			case "partition":
+				query.partition = value
+				continue
+			case "peer":
+				query.peer = value
+				continue
+			}
+		}
+
+		// Any option that was not already parsed is assumed to be a filter.
+		// Evaluate the grammer of the filter before attempting to query Consul.
+		// Defer to the Consul API to evaluate the kind and type of filter selectors.
+		if _, err := bexpr.CreateFilter(opt); err != nil {
+			return nil, fmt.Errorf(
+				"health.service.status: invalid filter: %q: %s", opt, err)
+		}
+		filters = append(filters, opt)
+	}
+
+	if len(filters) > 0 {
+		query.filter = strings.Join(filters, " and ")
+	}
+
+	return &query, nil
+}
+
+// Fetch queries the Consul API defined by the given client and returns a slice
+// of HealthService objects for the instances with the selected status.
+func (d *healthServiceQuery) Fetch(clients dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
+	select {
+	case <-d.stopCh:
+		return nil, nil, dep.ErrStopped
+	default:
+	}
+
+	hcatOpts := d.opts.Merge(&hcat.QueryOptions{
+		Datacenter: d.dc,
+		Namespace:  d.ns,
+		Filter:     d.filter,
+	})
+
+	// Only passing instances are filtered by Consul, other statuses are
+	// filtered client-side by the aggregated status of the instance
+	opts := hcatOpts.ToConsulOpts()
+	opts.Partition = d.partition
+	opts.Peer = d.peer
+
+	passingOnly := d.status == healthPassing
+	entries, qm, err := clients.Consul().Health().Service(d.name, "",
+		passingOnly, opts)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, d.String())
+	}
+
+	services := make([]*dep.HealthService, 0, len(entries))
+	accepted := make([]*consulapi.ServiceEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !acceptHealthStatus(d.status, entry.Checks.AggregatedStatus()) {
+			continue
+		}
+		services = append(services, newHealthServiceDep(entry))
+		accepted = append(accepted, entry)
+	}
+	connectProxies.Set(d.ID(), d.partition, d.peer, accepted)
+
+	sort.Stable(ByNodeThenID(services))
+
+	rm := &dep.ResponseMetadata{
+		LastIndex:   qm.LastIndex,
+		LastContact: qm.LastContact,
+	}
+
+	return services, rm, nil
+}
+
+// SetOptions satisfies the hcat.QueryOptionsSetter interface which enables
+// blocking queries.
+func (d *healthServiceQuery) SetOptions(opts hcat.QueryOptions) {
+	d.opts = opts
+}
+
+// ID returns the human-friendly version of this query.
+func (d *healthServiceQuery) ID() string {
+	opts := []string{fmt.Sprintf("status=%s", d.status)}
+	if d.dc != "" {
+		opts = append(opts, fmt.Sprintf("dc=%s", d.dc))
+	}
+	if d.ns != "" {
+		opts = append(opts, fmt.Sprintf("ns=%s", d.ns))
+	}
+	if d.partition != "" {
+		opts = append(opts, fmt.Sprintf("partition=%s", d.partition))
+	}
+	if d.peer != "" {
+		opts = append(opts, fmt.Sprintf("peer=%s", d.peer))
+	}
+	if d.filter != "" {
+		opts = append(opts, fmt.Sprintf("filter=%s", d.filter))
+	}
+	sort.Strings(opts)
+	return fmt.Sprintf("health.service.status(%s|%s)", d.name,
+		strings.Join(opts, "&"))
+}
+
+// Stringer interface reuses ID
+func (d *healthServiceQuery) String() string {
+	return d.ID()
+}
+
+// Stop halts the query's fetch function.
+func (d *healthServiceQuery) Stop() {
+	close(d.stopCh)
+	connectProxies.Delete(d.ID())
+}
+
+// validateHealthStatus checks that the status is a supported value for the
+// status query parameter.
+func validateHealthStatus(status string) error {
+	switch status {
+	case healthPassing, healthWarning, healthCritical, healthAny:
+		return nil
+	default:
+		return fmt.Errorf("invalid status %q", status)
+	}
+}
+
+// acceptHealthStatus returns whether a service instance with the aggregated
+// health status is selected by the status query parameter.
+func acceptHealthStatus(status, aggregated string) bool {
+	if status == healthAny {
+		return true
+	}
+
+	rank, ok := healthStatusRank[aggregated]
+	if !ok {
+		return false
+	}
+	return rank <= healthStatusRank[status]
+}
+
+// newHealthServiceDep converts a Consul service entry to a HealthService.
+func newHealthServiceDep(entry *consulapi.ServiceEntry) *dep.HealthService {
+	address := entry.Service.Address
+	if address == "" {
+		address = entry.Node.Address
+	}
+
+	return &dep.HealthService{
+		Node:                   entry.Node.Node,
+		NodeID:                 entry.Node.ID,
+		Kind:                   string(entry.Service.Kind),
+		NodeAddress:            entry.Node.Address,
+		NodeDatacenter:         entry.Node.Datacenter,
+		NodeTaggedAddresses:    entry.Node.TaggedAddresses,
+		NodeMeta:               entry.Node.Meta,
+		ServiceMeta:            entry.Service.Meta,
+		ServiceTaggedAddresses: entry.Service.TaggedAddresses,
+		Address:                address,
+		ID:                     entry.Service.ID,
+		Name:                   entry.Service.Service,
+		Tags: dep.ServiceTags(
+			deepCopyAndSortTags(entry.Service.Tags)),
+		Status:    entry.Checks.AggregatedStatus(),
+		Checks:    entry.Checks,
+		Port:      entry.Service.Port,
+		Weights:   entry.Service.Weights,
+		Namespace: entry.Service.Namespace,
+	}
+}
@@ -0,0 +1,153 @@
+package tmplfunc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHealthServiceQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		service string
+		opts    []string
+		exp     *healthServiceQuery
+		err     bool
+	}{
+		{
+			"no opts",
+			"api",
+			[]string{},
+			&healthServiceQuery{
+				name:   "api",
+				status: healthPassing,
+			},
+			false,
+		},
+		{
+			"multiple",
+			"api",
+			[]string{"status=critical", "dc=dc1", "ns=ns1", `"tag" in Service.Tags`},
+			&healthServiceQuery{
+				name:   "api",
+				status: healthCritical,
+				dc:     "dc1",
+				ns:     "ns1",
+				filter: `"tag" in Service.Tags`,
+			},
+			false,
+		},
+		{
+			"partition and peer",
+			"api",
+			[]string{"partition=ap1", "peer=cluster-02"},
+			&healthServiceQuery{
+				name:      "api",
+				status:    healthPassing,
+				partition: "ap1",
+				peer:      "cluster-02",
+			},
+			false,
+		},
+		{
+			"no service name",
+			"",
+			[]string{},
+			nil,
+			true,
+		},
+		{
+			"invalid status",
+			"api",
+			[]string{"status=maintenance"},
+			nil,
+			true,
+		},
+		{
+			"invalid filter",
+			"api",
+			[]string{"invalid=true"},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			act, err := newHealthServiceQuery(tc.service, tc.opts)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if act != nil {
+				act.stopCh = nil
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.exp, act)
+		})
+	}
+}
+
+func TestHealthServiceQuery_String(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    []string
+		exp  string
+	}{
+		{
+			"default status",
+			[]string{},
+			"health.service.status(api|status=passing)",
+		},
+		{
+			"multiple",
+			[]string{"status=warning", "ns=ns1", "dc=dc1", `"tag" in Service.Tags`},
+			`health.service.status(api|dc=dc1&filter="tag" in Service.Tags&ns=ns1&status=warning)`,
+		},
+		{
+			"partition and peer",
+			[]string{"peer=cluster-02", "partition=ap1"},
+			"health.service.status(api|partition=ap1&peer=cluster-02&status=passing)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newHealthServiceQuery("api", tc.i)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.exp, d.String())
+		})
+	}
+}
+
+func TestAcceptHealthStatus(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		status   string
+		accepted []string
+	}{
+		{healthPassing, []string{"passing"}},
+		{healthWarning, []string{"passing", "warning"}},
+		{healthCritical, []string{"passing", "warning", "critical"}},
+		{healthAny, []string{"passing", "warning", "critical", "maintenance"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.status, func(t *testing.T) {
+			var accepted []string
+			for _, s := range []string{"passing", "warning", "critical", "maintenance"} {
+				if acceptHealthStatus(tc.status, s) {
+					accepted = append(accepted, s)
+				}
+			}
+			assert.Equal(t, tc.accepted, accepted)
+		})
+	}
+}
@@ -0,0 +1,360 @@
+package tmplfunc
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/internal/selector"
+	"github.com/hashicorp/go-rootcerts"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultHTTPInterval is the interval between requests when the interval
+	// parameter is not set
+	defaultHTTPInterval = 60 * time.Second
+
+	// httpMaxResponseSize limits how much of the response body is read
+	httpMaxResponseSize = 10 << 20
+)
+
+var _ hcatQuery = (*httpQuery)(nil)
+
+// HTTPResponse is the decoded JSON response of a URL polled by the httpJSON
+// template function.
+type HTTPResponse struct {
+	URL string
+
+	// Data is the JSON response, or the value of the response that is
+	// selected by the selector, decoded into generic Go types
+	Data interface{}
+}
+
+// httpJSONFunc returns the decoded JSON response of a URL. It polls the URL
+// with a GET request on an interval and supports the query parameters url,
+// interval, timeout, selector, header, and the TLS parameters tls,
+// tls_skip_verify, ca_cert, ca_path, cert, key, and server_name. The url
+// parameter is required. The header parameter can be set multiple times and is
+// in the format "header=<name>: <value>".
+//
+// Template: {{ httpJSON "url=<url>" <options> ... }}
+func httpJSONFunc(recall hcat.Recaller) interface{} {
+	return func(opts ...string) (*HTTPResponse, error) {
+		d, err := newHTTPQuery(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if value, ok := recall(d); ok {
+			return value.(*HTTPResponse), nil
+		}
+
+		return nil, nil
+	}
+}
+
+// httpQuery is the representation of a requested http query from inside a
+// template. It satisfies the Consul type so that the Consul retry function is
+// used for failed requests instead of stopping CTS.
+type httpQuery struct {
+	isConsul
+	stopCh chan struct{}
+
+	url      string
+	headers  map[string]string
+	interval time.Duration
+	timeout  time.Duration
+	selector *selector.Selector
+
+	tlsEnabled    bool
+	tlsSkipVerify bool
+	caCert        string
+	caPath        string
+	cert          string
+	key           string
+	serverName    string
+
+	client *http.Client
+	opts   hcat.QueryOptions
+}
+
+// newHTTPQuery processes options in the format of "key=value"
+// (e.g. "url=https://example.com").
+func newHTTPQuery(opts []string) (*httpQuery, error) {
+	query := httpQuery{
+		stopCh:   make(chan struct{}, 1),
+		headers:  make(map[string]string),
+		interval: defaultHTTPInterval,
+	}
+
+	for _, opt := range opts {
+		if strings.TrimSpace(opt) == "" {
+			continue
+		}
+
+		if !strings.Contains(opt, "=") {
+			return nil, fmt.Errorf("httpJSON: invalid option: %q", opt)
+		}
+
+		queryParam := strings.SplitN(opt, "=", 2)
+		param := strings.TrimSpace(queryParam[0])
+		value := strings.TrimSpace(queryParam[1])
+		switch param {
+		case "url":
+			query.url = value
+		case "interval", "timeout":
+			dur, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("httpJSON: invalid %s %q: %s",
+					param, value, err)
+			}
+			if param == "interval" {
+				query.interval = dur
+			} else {
+				query.timeout = dur
+			}
+		case "selector":
+			s, err := selector.Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("httpJSON: %s", err)
+			}
+			query.selector = s
+		case "header":
+			header := strings.SplitN(value, ":", 2)
+			if len(header) != 2 || strings.TrimSpace(header[0]) == "" {
+				return nil, fmt.Errorf("httpJSON: invalid header, expected "+
+					"format '<name>: <value>': %q", value)
+			}
+			query.headers[strings.TrimSpace(header[0])] = strings.TrimSpace(header[1])
+		case "tls", "tls_skip_verify":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("httpJSON: invalid %s %q: %s",
+					param, value, err)
+			}
+			if param == "tls" {
+				query.tlsEnabled = b
+			} else {
+				query.tlsSkipVerify = b
+			}
+		case "ca_cert":
+			query.caCert = value
+		case "ca_path":
+			query.caPath = value
+		case "cert":
+			query.cert = value
+		case "key":
+			query.key = value
+		case "server_name":
+			query.serverName = value
+		default:
+			return nil, fmt.Errorf(
+				"httpJSON: invalid query parameter: %q", opt)
+		}
+	}
+
+	if query.url == "" {
+		return nil, fmt.Errorf("httpJSON: url is required")
+	}
+
+	if query.interval <= 0 {
+		return nil, fmt.Errorf("httpJSON: interval must be greater than zero")
+	}
+
+	return &query, nil
+}
+
+// Fetch requests the URL and returns the decoded JSON response. The first
+// request is sent immediately and subsequent requests wait for the interval.
+// The response metadata index is incremented for each successful request so
+// that hcat compares the decoded response with the previous one, resulting in
+// new data only when the content of the response changes.
+func (d *httpQuery) Fetch(clients dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
+	select {
+	case <-d.stopCh:
+		return nil, nil, dep.ErrStopped
+	default:
+	}
+
+	ctx := d.opts.ToConsulOpts().Context()
+
+	if d.opts.WaitIndex > 0 {
+		select {
+		case <-d.stopCh:
+			return nil, nil, dep.ErrStopped
+		case <-ctx.Done():
+			return nil, nil, errors.Wrap(ctx.Err(), d.String())
+		case <-time.After(d.interval):
+		}
+	}
+
+	if d.client == nil {
+		client, err := d.newClient()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, d.String())
+		}
+		d.client = client
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.url, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, d.String())
+	}
+	req.Header.Set("Accept", "application/json")
+	for k, v := range d.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, d.String())
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, httpMaxResponseSize))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, d.String())
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, nil, fmt.Errorf("%s: unexpected response status %d: %s",
+			d.String(), resp.StatusCode, body)
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, nil, errors.Wrap(
+			fmt.Errorf("unable to decode JSON response: %s", err), d.String())
+	}
+
+	data, err = d.selector.Select(data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, d.String())
+	}
+
+	rm := &dep.ResponseMetadata{
+		LastIndex: d.opts.WaitIndex + 1,
+	}
+
+	return &HTTPResponse{URL: d.url, Data: data}, rm, nil
+}
+
+// newClient creates the HTTP client for the query with the TLS configuration
+func (d *httpQuery) newClient() (*http.Client, error) {
+	tlsClientConfig := &tls.Config{}
+
+	if d.tlsEnabled {
+		tlsClientConfig.InsecureSkipVerify = d.tlsSkipVerify
+		tlsClientConfig.ServerName = d.serverName
+
+		if d.cert != "" && d.key != "" {
+			tlsCert, err := tls.LoadX509KeyPair(d.cert, d.key)
+			if err != nil {
+				return nil, err
+			}
+			tlsClientConfig.Certificates = []tls.Certificate{tlsCert}
+		} else if d.cert != "" || d.key != "" {
+			return nil, fmt.Errorf("both client cert and client key must be provided")
+		}
+
+		if d.caCert != "" || d.caPath != "" {
+			rootConfig := &rootcerts.Config{
+				CAFile: d.caCert,
+				CAPath: d.caPath,
+			}
+			if err := rootcerts.ConfigureTLS(tlsClientConfig, rootConfig); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsClientConfig
+	return &http.Client{Transport: transport, Timeout: d.timeout}, nil
+}
+
+// SetOptions satisfies the hcat.QueryOptionsSetter interface which is used to
+// track the number of requests for polling.
+func (d *httpQuery) SetOptions(opts hcat.QueryOptions) {
+	d.opts = opts
+}
+
+// ID returns the human-friendly version of this query. Header values and
+// the TLS options are represented by a hash, because header values may
+// contain credentials, so that queries that differ by these options are
+// different dependencies.
+func (d *httpQuery) ID() string {
+	var opts []string
+	opts = append(opts, fmt.Sprintf("interval=%s", d.interval))
+	if d.timeout > 0 {
+		opts = append(opts, fmt.Sprintf("timeout=%s", d.timeout))
+	}
+	if d.selector != nil {
+		opts = append(opts, fmt.Sprintf("selector=%s", d.selector))
+	}
+	for k := range d.headers {
+		opts = append(opts, fmt.Sprintf("header=%s", k))
+	}
+	if d.tlsEnabled {
+		opts = append(opts, "tls=true")
+	}
+	if hash := d.optionsHash(); hash != "" {
+		opts = append(opts, fmt.Sprintf("hash=%s", hash))
+	}
+	sort.Strings(opts)
+	return fmt.Sprintf("http.json(%s|%s)", d.url, strings.Join(opts, "&"))
+}
+
+// optionsHash returns a hash of the header values and the TLS options of the
+// query. It returns an empty string when none of these options are set.
+func (d *httpQuery) optionsHash() string {
+	var opts []string
+	for k, v := range d.headers {
+		opts = append(opts, fmt.Sprintf("header=%s: %s", k, v))
+	}
+	if d.tlsEnabled {
+		if d.tlsSkipVerify {
+			opts = append(opts, "tls_skip_verify=true")
+		}
+		for param, value := range map[string]string{
+			"ca_cert":     d.caCert,
+			"ca_path":     d.caPath,
+			"cert":        d.cert,
+			"key":         d.key,
+			"server_name": d.serverName,
+		} {
+			if value != "" {
+				opts = append(opts, fmt.Sprintf("%s=%s", param, value))
+			}
+		}
+	}
+	if len(opts) == 0 {
+		return ""
+	}
+
+	sort.Strings(opts)
+	sum := sha256.Sum256([]byte(strings.Join(opts, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Stringer interface reuses ID
+func (d *httpQuery) String() string {
+	return d.ID()
+}
+
+// Stop halts the query's fetch function.
+func (d *httpQuery) Stop() {
+	close(d.stopCh)
+}
@@ -0,0 +1,297 @@
+package tmplfunc
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/internal/selector"
+	"github.com/hashicorp/hcat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPQuery(t *testing.T) {
+	t.Parallel()
+
+	dataSelector, err := selector.Parse("$.data")
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		opts []string
+		exp  *httpQuery
+		err  bool
+	}{
+		{
+			"url",
+			[]string{"url=https://ipam.example.com/api/prefixes?site=dc1"},
+			&httpQuery{
+				url:      "https://ipam.example.com/api/prefixes?site=dc1",
+				headers:  map[string]string{},
+				interval: defaultHTTPInterval,
+			},
+			false,
+		},
+		{
+			"multiple",
+			[]string{
+				"url=https://ipam.example.com",
+				"interval=30s",
+				"timeout=5s",
+				"selector=$.data",
+				"header=Authorization: Bearer a=b",
+				"header=X-Site: dc1",
+				"",
+			},
+			&httpQuery{
+				url: "https://ipam.example.com",
+				headers: map[string]string{
+					"Authorization": "Bearer a=b",
+					"X-Site":        "dc1",
+				},
+				interval: 30 * time.Second,
+				timeout:  5 * time.Second,
+				selector: dataSelector,
+			},
+			false,
+		},
+		{
+			"tls",
+			[]string{
+				"url=https://ipam.example.com",
+				"tls=true",
+				"tls_skip_verify=true",
+				"ca_cert=ca.pem",
+				"ca_path=/certs",
+				"cert=cert.pem",
+				"key=key.pem",
+				"server_name=ipam",
+			},
+			&httpQuery{
+				url:           "https://ipam.example.com",
+				headers:       map[string]string{},
+				interval:      defaultHTTPInterval,
+				tlsEnabled:    true,
+				tlsSkipVerify: true,
+				caCert:        "ca.pem",
+				caPath:        "/certs",
+				cert:          "cert.pem",
+				key:           "key.pem",
+				serverName:    "ipam",
+			},
+			false,
+		},
+		{
+			"no url",
+			[]string{"interval=30s"},
+			nil,
+			true,
+		},
+		{
+			"invalid interval",
+			[]string{"url=http://ipam", "interval=1x"},
+			nil,
+			true,
+		},
+		{
+			"zero interval",
+			[]string{"url=http://ipam", "interval=0s"},
+			nil,
+			true,
+		},
+		{
+			"invalid selector",
+			[]string{"url=http://ipam", "selector=$.data["},
+			nil,
+			true,
+		},
+		{
+			"invalid header",
+			[]string{"url=http://ipam", "header=Authorization"},
+			nil,
+			true,
+		},
+		{
+			"invalid tls",
+			[]string{"url=http://ipam", "tls=yes please"},
+			nil,
+			true,
+		},
+		{
+			"invalid query parameter",
+			[]string{"url=http://ipam", "method=POST"},
+			nil,
+			true,
+		},
+		{
+			"invalid option",
+			[]string{"url=http://ipam", "selector"},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			act, err := newHTTPQuery(tc.opts)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if act != nil {
+				act.stopCh = nil
+			}
+
+			assert.NoError(t, err, err)
+			assert.Equal(t, tc.exp, act)
+		})
+	}
+}
+
+func TestHTTPQuery_String(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    []string
+		exp  string
+	}{
+		{
+			"url",
+			[]string{"url=http://ipam"},
+			"http.json(http://ipam|interval=1m0s)",
+		},
+		{
+			"multiple",
+			[]string{"url=https://ipam", "header=X-Key: secret", "selector=$.data",
+				"tls=true", "timeout=5s", "interval=30s"},
+			"http.json(https://ipam|hash=9f3ac225a8a3892e&header=X-Key&interval=30s&" +
+				"selector=$.data&timeout=5s&tls=true)",
+		},
+	}
+
+	// the hash differs by the header values and TLS options
+	hashes := make(map[string]bool)
+	for _, opts := range [][]string{
+		{"url=https://ipam", "header=X-Key: secret"},
+		{"url=https://ipam", "header=X-Key: other"},
+		{"url=https://ipam", "tls=true", "tls_skip_verify=true"},
+		{"url=https://ipam", "tls=true", "ca_cert=ca.pem"},
+		{"url=https://ipam", "tls=true", "cert=cert.pem", "key=key.pem"},
+		{"url=https://ipam", "tls=true", "server_name=ipam.example.com"},
+	} {
+		d, err := newHTTPQuery(opts)
+		assert.NoError(t, err)
+		assert.NotContains(t, d.String(), "secret")
+		hashes[d.optionsHash()] = true
+	}
+	assert.Len(t, hashes, 6)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newHTTPQuery(tc.i)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.exp, d.String())
+		})
+	}
+}
+
+func TestHTTPQuery_Fetch(t *testing.T) {
+	t.Parallel()
+
+	var header string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("Authorization")
+		fmt.Fprint(w, `{"data": {"prefixes": ["10.0.0.0/24"]}}`)
+	}))
+	defer ts.Close()
+
+	t.Run("happy_path", func(t *testing.T) {
+		d, err := newHTTPQuery([]string{"url=" + ts.URL, "selector=$.data",
+			"header=Authorization: Bearer token"})
+		require.NoError(t, err)
+
+		d.SetOptions(hcat.QueryOptions{})
+		data, rm, err := d.Fetch(nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), rm.LastIndex)
+		assert.Equal(t, "Bearer token", header)
+		assert.Equal(t, &HTTPResponse{
+			URL: ts.URL,
+			Data: map[string]interface{}{
+				"prefixes": []interface{}{"10.0.0.0/24"},
+			},
+		}, data)
+	})
+
+	t.Run("polls_on_interval", func(t *testing.T) {
+		d, err := newHTTPQuery([]string{"url=" + ts.URL, "interval=50ms"})
+		require.NoError(t, err)
+
+		d.SetOptions(hcat.QueryOptions{WaitIndex: 1})
+		start := time.Now()
+		_, rm, err := d.Fetch(nil)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+		assert.Equal(t, uint64(2), rm.LastIndex)
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		d, err := newHTTPQuery([]string{"url=" + ts.URL})
+		require.NoError(t, err)
+
+		d.SetOptions(hcat.QueryOptions{WaitIndex: 1})
+		d.Stop()
+		_, _, err = d.Fetch(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("selector_error", func(t *testing.T) {
+		d, err := newHTTPQuery([]string{"url=" + ts.URL, "selector=$.missing"})
+		require.NoError(t, err)
+
+		_, _, err = d.Fetch(nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestHTTPQuery_Fetch_Error(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{
+			"non_2xx_status",
+			http.StatusInternalServerError,
+			`{"error": "unavailable"}`,
+		},
+		{
+			"invalid_json",
+			http.StatusOK,
+			`not json`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			}))
+			defer ts.Close()
+
+			d, err := newHTTPQuery([]string{"url=" + ts.URL})
+			require.NoError(t, err)
+
+			_, _, err = d.Fetch(nil)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package tmplfunc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/pkg/errors"
+)
+
+var _ hcatQuery = (*intentionsQuery)(nil)
+
+// intentionsFunc returns information on Consul service intentions. It queries
+// the List Intentions API and supports the query parameters dc, ns, and
+// filter.
+//
+// Endpoint: /v1/connect/intentions
+// Template: {{ intentions <filter options> ... }}
+func intentionsFunc(recall hcat.Recaller) interface{} {
+	return func(opts ...string) ([]*consulapi.Intention, error) {
+		result := []*consulapi.Intention{}
+
+		d, err := newIntentionsQuery(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if value, ok := recall(d); ok {
+			return value.([]*consulapi.Intention), nil
+		}
+
+		return result, nil
+	}
+}
+
+// intentionsQuery is the representation of a requested intentions query from
+// inside a template.
+type intentionsQuery struct {
+	isConsul
+	stopCh chan struct{}
+
+	filter string
+	dc     string
+	ns     string
+	opts   hcat.QueryOptions
+}
+
+// newIntentionsQuery processes options in the format of "key=value"
+// (e.g. "dc=dc1") with the exception of filters. Any option that is not a
+// key/value pair is assumed to be a filter.
+func newIntentionsQuery(opts []string) (*intentionsQuery, error) {
+	query := intentionsQuery{
+		stopCh: make(chan struct{}, 1),
+	}
+
+	var filters []string
+	for _, opt := range opts {
+		if strings.TrimSpace(opt) == "" {
+			continue
+		}
+
+		// Parse query paramters, excluding the filter which is not set as a parameter
+		if queryParamOptRe.MatchString(opt) {
+			queryParam := strings.SplitN(opt, "=", 2)
+			param := strings.TrimSpace(queryParam[0])
+			value := strings.TrimSpace(queryParam[1])
+			switch param {
+			case "dc", "datacenter":
+				query.dc = value
+				continue
+			case "ns", "namespace":
+				query.ns = value
+				continue
+			}
+		}
+
+		// Any option that was not already parsed is assumed to be a filter.
+		// Evaluate the grammer of the filter before attempting to query Consul.
+		// Defer to the Consul API to evaluate the kind and type of filter selectors.
+		if _, err := bexpr.CreateFilter(opt); err != nil {
+			return nil, fmt.Errorf(
+				"intentions: invalid filter: %q: %s", opt, err)
+		}
+		filters = append(filters, opt)
+	}
+
+	if len(filters) > 0 {
+		query.filter = strings.Join(filters, " and ")
+	}
+
+	return &query, nil
+}
+
+// Fetch queries the Consul API defined by the given client and returns a slice
+// of Intention objects sorted by precedence.
+func (d *intentionsQuery) Fetch(clients dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
+	select {
+	case <-d.stopCh:
+		return nil, nil, dep.ErrStopped
+	default:
+	}
+
+	hcatOpts := d.opts.Merge(&hcat.QueryOptions{
+		Datacenter: d.dc,
+		Namespace:  d.ns,
+		Filter:     d.filter,
+	})
+
+	entries, qm, err := clients.Consul().Connect().Intentions(hcatOpts.ToConsulOpts())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, d.String())
+	}
+
+	intentions := make([]*consulapi.Intention, 0, len(entries))
+	for _, i := range entries {
+		// Clear the fields that change without a change to the intention
+		// rules so that changes to them do not trigger tasks
+		i.CreatedAt, i.UpdatedAt = time.Time{}, time.Time{}
+		i.Hash = nil
+		i.CreateIndex, i.ModifyIndex = 0, 0
+		intentions = append(intentions, i)
+	}
+
+	sort.Stable(ByPrecedence(intentions))
+
+	rm := &dep.ResponseMetadata{
+		LastIndex:   qm.LastIndex,
+		LastContact: qm.LastContact,
+	}
+
+	return intentions, rm, nil
+}
+
+// SetOptions satisfies the hcat.QueryOptionsSetter interface which enables
+// blocking queries.
+func (d *intentionsQuery) SetOptions(opts hcat.QueryOptions) {
+	d.opts = opts
+}
+
+// ID returns the human-friendly version of this query.
+func (d *intentionsQuery) ID() string {
+	var opts []string
+	if d.dc != "" {
+		opts = append(opts, fmt.Sprintf("dc=%s", d.dc))
+	}
+	if d.ns != "" {
+		opts = append(opts, fmt.Sprintf("ns=%s", d.ns))
+	}
+	if d.filter != "" {
+		opts = append(opts, fmt.Sprintf("filter=%s", d.filter))
+	}
+	if len(opts) > 0 {
+		sort.Strings(opts)
+		return fmt.Sprintf("intentions(%s)", strings.Join(opts, "&"))
+	}
+	return "intentions"
+}
+
+// Stringer interface reuses ID
+func (d *intentionsQuery) String() string {
+	return d.ID()
+}
+
+// Stop halts the query's fetch function.
+func (d *intentionsQuery) Stop() {
+	close(d.stopCh)
+}
+
+// ByPrecedence is a sortable slice of Intention structs. Intentions are sorted
+// in the order they are evaluated by Consul, by descending precedence and then
+// by source and destination.
+type ByPrecedence []*consulapi.Intention
+
+func (s ByPrecedence) Len() int      { return len(s) }
+func (s ByPrecedence) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s ByPrecedence) Less(i, j int) bool {
+	if s[i].Precedence != s[j].Precedence {
+		return s[i].Precedence > s[j].Precedence
+	}
+	if s[i].SourceString() != s[j].SourceString() {
+		return s[i].SourceString() < s[j].SourceString()
+	}
+	return s[i].DestinationString() < s[j].DestinationString()
+}
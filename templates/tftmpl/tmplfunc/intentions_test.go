@@ -0,0 +1,141 @@
+package tmplfunc
+
+import (
+	"sort"
+	"testing"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIntentionsQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		opts []string
+		exp  *intentionsQuery
+		err  bool
+	}{
+		{
+			"no opts",
+			[]string{},
+			&intentionsQuery{},
+			false,
+		},
+		{
+			"dc",
+			[]string{"dc=dc1"},
+			&intentionsQuery{
+				dc: "dc1",
+			},
+			false,
+		},
+		{
+			"ns",
+			[]string{"ns=namespace"},
+			&intentionsQuery{
+				ns: "namespace",
+			},
+			false,
+		},
+		{
+			"filter",
+			[]string{`DestinationName == "api"`, `Action == "deny"`},
+			&intentionsQuery{
+				filter: `DestinationName == "api" and Action == "deny"`,
+			},
+			false,
+		},
+		{
+			"multiple",
+			[]string{"dc=dc1", "ns=namespace", `DestinationName == "api"`},
+			&intentionsQuery{
+				dc:     "dc1",
+				ns:     "namespace",
+				filter: `DestinationName == "api"`,
+			},
+			false,
+		},
+		{
+			"invalid filter",
+			[]string{"invalid"},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			act, err := newIntentionsQuery(tc.opts)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if act != nil {
+				act.stopCh = nil
+			}
+
+			assert.NoError(t, err, err)
+			assert.Equal(t, tc.exp, act)
+		})
+	}
+}
+
+func TestIntentionsQuery_String(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    []string
+		exp  string
+	}{
+		{
+			"empty",
+			[]string{},
+			"intentions",
+		},
+		{
+			"datacenter",
+			[]string{"dc=dc1"},
+			"intentions(dc=dc1)",
+		},
+		{
+			"multiple",
+			[]string{"ns=namespace", "dc=dc1", `DestinationName == "api"`},
+			`intentions(dc=dc1&filter=DestinationName == "api"&ns=namespace)`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := newIntentionsQuery(tc.i)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.exp, d.String())
+		})
+	}
+}
+
+func TestByPrecedence(t *testing.T) {
+	t.Parallel()
+
+	intentions := []*consulapi.Intention{
+		{SourceName: "web", DestinationName: "api", Precedence: 9},
+		{SourceName: "*", DestinationName: "*", Precedence: 5},
+		{SourceName: "db", DestinationName: "api", Precedence: 9},
+		{SourceName: "web", DestinationName: "*", Precedence: 8},
+	}
+	sort.Stable(ByPrecedence(intentions))
+
+	var actual []string
+	for _, i := range intentions {
+		actual = append(actual, i.String())
+	}
+	assert.Equal(t, []string{
+		"db => api ()",
+		"web => api ()",
+		"web => * ()",
+		"* => * ()",
+	}, actual)
+}
@@ -27,8 +27,9 @@ var (
 // services that have a name that match a given regex. It queries
 // the Catalog List Services API initially to get all the services
 // and then queries the Health API for each matching service.
-// It supports parameters filter, dc, ns, and node-meta on the
-// Health API query only.
+// It supports parameters filter, dc, ns, partition, peer, and node-meta on the
+// Health API query only. The status parameter selects the instances with the
+// status or a healthier one and defaults to passing.
 //
 // Endpoints:
 //   /v1/catalog/services
@@ -59,11 +60,14 @@ type servicesRegexQuery struct {
 
 	regexp *regexp.Regexp
 
-	filter   string
-	dc       string
-	ns       string
-	nodeMeta map[string]string
-	opts     hcat.QueryOptions
+	filter    string
+	dc        string
+	ns        string
+	partition string
+	peer      string
+	status    string
+	nodeMeta  map[string]string
+	opts      hcat.QueryOptions
 }
 
 // newServicesRegexQuery processes options in the format of
@@ -72,6 +76,7 @@ type servicesRegexQuery struct {
 func newServicesRegexQuery(opts []string) (*servicesRegexQuery, error) {
 	servicesRegexQuery := servicesRegexQuery{
 		stopCh: make(chan struct{}, 1),
+		status: healthPassing,
 	}
 	var filters []string
 	for _, opt := range opts {
@@ -98,6 +103,18 @@ func newServicesRegexQuery(opts []string) (*servicesRegexQuery, error) {
 			case "ns", "namespace":
 				servicesRegexQuery.ns = value
 				continue
+			case "partition":
+				servicesRegexQuery.partition = value
+				continue
+			case "peer":
+				servicesRegexQuery.peer = value
+				continue
+			case "status":
+				if err := validateHealthStatus(value); err != nil {
+					return nil, fmt.Errorf("service.regex: %s", err)
+				}
+				servicesRegexQuery.status = value
+				continue
 			case "node-meta":
 				if servicesRegexQuery.nodeMeta == nil {
 					servicesRegexQuery.nodeMeta = make(map[string]string)
@@ -150,6 +167,8 @@ func (d *servicesRegexQuery) Fetch(clients dep.Clients) (interface{}, *dep.Respo
 		Namespace:  d.ns,
 	})
 	opts := hcatOpts.ToConsulOpts()
+	opts.Partition = d.partition
+	opts.Peer = d.peer
 	if len(d.nodeMeta) != 0 {
 		opts.NodeMeta = d.nodeMeta
 	}
@@ -182,6 +201,8 @@ func (d *servicesRegexQuery) Fetch(clients dep.Clients) (interface{}, *dep.Respo
 		Filter:     d.filter,
 	}
 	opts = hcatOpts.ToConsulOpts()
+	opts.Partition = d.partition
+	opts.Peer = d.peer
 	if len(d.nodeMeta) != 0 {
 		opts.NodeMeta = d.nodeMeta
 	}
@@ -200,40 +221,27 @@ func (d *servicesRegexQuery) Fetch(clients dep.Clients) (interface{}, *dep.Respo
 	// set as critical. https://www.consul.io/docs/discovery/checks#initial-health-check-status
 	time.Sleep(1 * time.Second)
 
+	// Only passing instances are filtered by Consul, other statuses are
+	// filtered client-side by the aggregated status of the instance
+	passingOnly := d.status == healthPassing
+
 	var services []*dep.HealthService
+	var accepted []*consulapi.ServiceEntry
 	for _, s := range matchServices {
 		var entries []*consulapi.ServiceEntry
-		entries, _, err = clients.Consul().Health().Service(s, "", true, opts)
+		entries, _, err = clients.Consul().Health().Service(s, "", passingOnly, opts)
 		if err != nil {
 			return nil, nil, errors.Wrap(err, d.String())
 		}
 		for _, entry := range entries {
-			address := entry.Service.Address
-			if address == "" {
-				address = entry.Node.Address
+			if !acceptHealthStatus(d.status, entry.Checks.AggregatedStatus()) {
+				continue
 			}
-			services = append(services, &dep.HealthService{
-				Node:                entry.Node.Node,
-				NodeID:              entry.Node.ID,
-				Kind:                string(entry.Service.Kind),
-				NodeAddress:         entry.Node.Address,
-				NodeDatacenter:      entry.Node.Datacenter,
-				NodeTaggedAddresses: entry.Node.TaggedAddresses,
-				NodeMeta:            entry.Node.Meta,
-				ServiceMeta:         entry.Service.Meta,
-				Address:             address,
-				ID:                  entry.Service.ID,
-				Name:                entry.Service.Service,
-				Tags: dep.ServiceTags(
-					deepCopyAndSortTags(entry.Service.Tags)),
-				Status:    entry.Checks.AggregatedStatus(),
-				Checks:    entry.Checks,
-				Port:      entry.Service.Port,
-				Weights:   entry.Service.Weights,
-				Namespace: entry.Service.Namespace,
-			})
+			services = append(services, newHealthServiceDep(entry))
+			accepted = append(accepted, entry)
 		}
 	}
+	connectProxies.Set(d.ID(), d.partition, d.peer, accepted)
 
 	sort.Stable(ByNodeThenID(services))
 	return services, rm, nil
@@ -256,6 +264,15 @@ func (d *servicesRegexQuery) ID() string {
 	if d.ns != "" {
 		opts = append(opts, fmt.Sprintf("ns=%s", d.ns))
 	}
+	if d.partition != "" {
+		opts = append(opts, fmt.Sprintf("partition=%s", d.partition))
+	}
+	if d.peer != "" {
+		opts = append(opts, fmt.Sprintf("peer=%s", d.peer))
+	}
+	if d.status != "" && d.status != healthPassing {
+		opts = append(opts, fmt.Sprintf("status=%s", d.status))
+	}
 	for k, v := range d.nodeMeta {
 		opts = append(opts, fmt.Sprintf("node-meta=%s:%s", k, v))
 	}
@@ -276,6 +293,7 @@ func (d *servicesRegexQuery) String() string {
 // Stop halts the query's fetch function.
 func (d *servicesRegexQuery) Stop() {
 	close(d.stopCh)
+	connectProxies.Delete(d.ID())
 }
 
 // ByNodeThenID is a sortable slice of Service
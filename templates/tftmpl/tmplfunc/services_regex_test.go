@@ -36,6 +36,7 @@ func TestNewServicesRegexQuery(t *testing.T) {
 			[]string{"regexp="},
 			&servicesRegexQuery{
 				regexp: regexp.MustCompile(""),
+				status: healthPassing,
 			},
 			false,
 		},
@@ -44,6 +45,7 @@ func TestNewServicesRegexQuery(t *testing.T) {
 			[]string{"regexp=.*"},
 			&servicesRegexQuery{
 				regexp: regexp.MustCompile(".*"),
+				status: healthPassing,
 			},
 			false,
 		},
@@ -56,9 +58,36 @@ func TestNewServicesRegexQuery(t *testing.T) {
 				ns:       "namespace",
 				nodeMeta: map[string]string{"k": "v"},
 				filter:   "\"my-tag\" in Service.Tags",
+				status:   healthPassing,
 			},
 			false,
 		},
+		{
+			"partition and peer",
+			[]string{"regexp=.*", "partition=ap1", "peer=cluster-02"},
+			&servicesRegexQuery{
+				regexp:    regexp.MustCompile(".*"),
+				partition: "ap1",
+				peer:      "cluster-02",
+				status:    healthPassing,
+			},
+			false,
+		},
+		{
+			"status",
+			[]string{"regexp=.*", "status=warning"},
+			&servicesRegexQuery{
+				regexp: regexp.MustCompile(".*"),
+				status: healthWarning,
+			},
+			false,
+		},
+		{
+			"invalid status",
+			[]string{"regexp=.*", "status=maintenance"},
+			nil,
+			true,
+		},
 		{
 			"invalid query",
 			[]string{"regexp=.*", "invalid=true"},
@@ -109,6 +138,16 @@ func TestServicesRegexQuery_String(t *testing.T) {
 			[]string{"node-meta=k:v", "dc=dc1", "ns=namespace", "regexp=web", "\"my-tag\" in Service.Tags"},
 			`service.regex(dc=dc1&filter="my-tag" in Service.Tags&node-meta=k:v&ns=namespace&regexp=web)`,
 		},
+		{
+			"partition and peer",
+			[]string{"regexp=web", "peer=cluster-02", "partition=ap1"},
+			"service.regex(partition=ap1&peer=cluster-02&regexp=web)",
+		},
+		{
+			"status",
+			[]string{"regexp=web", "status=any"},
+			"service.regex(regexp=web&status=any)",
+		},
 	}
 
 	for _, tc := range cases {
@@ -11,16 +11,36 @@ import (
 )
 
 // HCLMap is the map of template functions for rendering HCL
-// to their respective implementations
-func HCLMap(meta *ServicesMeta) template.FuncMap {
+// to their respective implementations. The webhook store is used by the
+// webhookPayload template function and may be nil when the template is only
+// parsed.
+func HCLMap(meta *ServicesMeta, webhooks *WebhookStore) template.FuncMap {
 	tmplFuncs := tfunc.FuncMapConsulV1()
 	tmplFuncs["catalogServicesRegistration"] = catalogServicesRegistrationFunc
 	tmplFuncs["servicesRegex"] = servicesRegexFunc
+	tmplFuncs["healthService"] = healthServiceFunc
+	tmplFuncs["consulKVExistsGet"] = consulKVExistsGetFunc
+	tmplFuncs["consulKVList"] = consulKVListFunc
+	tmplFuncs["catalogNodes"] = catalogNodesFunc
+	tmplFuncs["intentions"] = intentionsFunc
+	tmplFuncs["configEntries"] = configEntriesFunc
+	tmplFuncs["httpJSON"] = httpJSONFunc
+	tmplFuncs["webhookPayload"] = webhookPayloadFunc(webhooks)
+	tmplFuncs["datacenters"] = tfunc.ConsulV0()["datacenters"]
+	tmplFuncs["secret"] = tfunc.VaultV0()["secret"]
 	tmplFuncs["indent"] = tfunc.Helpers()["indent"]
 	tmplFuncs["subtract"] = tfunc.Math()["subtract"]
 	tmplFuncs["joinStrings"] = joinStringsFunc
 	tmplFuncs["HCLService"] = hclServiceFunc(meta)
+	tmplFuncs["HCLServiceV1"] = hclServiceV1Func(meta)
 	tmplFuncs["HCLServiceTags"] = hclServiceTagsFunc()
+	tmplFuncs["HCLNode"] = hclNodeFunc()
+	tmplFuncs["HCLIntention"] = hclIntentionFunc()
+	tmplFuncs["HCLConfigEntry"] = hclConfigEntryFunc()
+	tmplFuncs["HCLVaultSecret"] = hclVaultSecretFunc()
+	tmplFuncs["HCLHTTPResponse"] = hclHTTPResponseFunc()
+	tmplFuncs["HCLWebhookPayload"] = hclWebhookPayloadFunc()
+	tmplFuncs["HCLConsulKV"] = hclConsulKVFunc()
 	return tmplFuncs
 }
 
@@ -0,0 +1,229 @@
+package tmplfunc
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/hcat"
+	"github.com/hashicorp/hcat/dep"
+	"github.com/pkg/errors"
+)
+
+var _ hcatQuery = (*webhookQuery)(nil)
+
+// WebhookPayload is the decoded JSON body of the latest request to trigger a
+// task with a webhook condition.
+type WebhookPayload struct {
+	Task string
+
+	// Index is the sequence number of the request. It is zero when the task
+	// has not been triggered yet and distinguishes consecutive requests with
+	// the same body.
+	Index uint64
+
+	// Body is the JSON request body decoded into generic Go types
+	Body interface{}
+}
+
+// WebhookStore is the in-memory store of the latest payload received by the
+// trigger task API for each task with a webhook condition. The index is shared
+// across tasks so that it does not restart when a task is deleted and
+// recreated with the same name.
+type WebhookStore struct {
+	mu       sync.Mutex
+	index    uint64
+	payloads map[string]*WebhookPayload
+
+	// changeChs are closed and replaced when a new payload is published for
+	// the task
+	changeChs map[string]chan struct{}
+}
+
+// NewWebhookStore returns a new empty store of webhook payloads
+func NewWebhookStore() *WebhookStore {
+	return &WebhookStore{
+		payloads:  make(map[string]*WebhookPayload),
+		changeChs: make(map[string]chan struct{}),
+	}
+}
+
+// Publish stores the body of a request to trigger the task and unblocks the
+// webhookPayload template function watching for the task.
+func (s *WebhookStore) Publish(task string, body interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.index++
+	s.payloads[task] = &WebhookPayload{
+		Task:  task,
+		Index: s.index,
+		Body:  body,
+	}
+
+	if ch, ok := s.changeChs[task]; ok {
+		close(ch)
+		delete(s.changeChs, task)
+	}
+}
+
+// Delete removes the stored payload and change channel for the task. This is
+// used when the task is deleted.
+func (s *WebhookStore) Delete(task string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.payloads, task)
+
+	if ch, ok := s.changeChs[task]; ok {
+		close(ch)
+		delete(s.changeChs, task)
+	}
+}
+
+// get returns the latest payload for the task and a channel that is closed
+// when a new payload is published.
+func (s *WebhookStore) get(task string) (WebhookPayload, <-chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch, ok := s.changeChs[task]
+	if !ok {
+		ch = make(chan struct{})
+		s.changeChs[task] = ch
+	}
+
+	if p, ok := s.payloads[task]; ok {
+		return *p, ch
+	}
+	return WebhookPayload{Task: task}, ch
+}
+
+// webhookPayloadFunc returns the latest payload received by the trigger task
+// API for a task. It supports the query parameter task which is required.
+//
+// Template: {{ webhookPayload "task=<name>" }}
+func webhookPayloadFunc(store *WebhookStore) func(hcat.Recaller) interface{} {
+	return func(recall hcat.Recaller) interface{} {
+		return func(opts ...string) (*WebhookPayload, error) {
+			if store == nil {
+				return nil, fmt.Errorf("webhookPayload: no payload store " +
+					"configured")
+			}
+
+			d, err := newWebhookQuery(store, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			if value, ok := recall(d); ok {
+				if p, ok := value.(*WebhookPayload); ok {
+					return p, nil
+				}
+			}
+
+			return nil, nil
+		}
+	}
+}
+
+// webhookQuery is the representation of a requested webhook payload from
+// inside a template. It satisfies the Consul type so that the Consul retry
+// function is used for failed requests instead of stopping CTS.
+type webhookQuery struct {
+	isConsul
+	stopCh chan struct{}
+	store  *WebhookStore
+
+	task string
+	opts hcat.QueryOptions
+}
+
+// newWebhookQuery processes options in the format of "key=value"
+// (e.g. "task=my-task").
+func newWebhookQuery(store *WebhookStore, opts []string) (*webhookQuery, error) {
+	query := webhookQuery{
+		stopCh: make(chan struct{}, 1),
+		store:  store,
+	}
+
+	for _, opt := range opts {
+		if strings.TrimSpace(opt) == "" {
+			continue
+		}
+
+		queryParam := strings.SplitN(opt, "=", 2)
+		if len(queryParam) != 2 {
+			return nil, fmt.Errorf("webhookPayload: invalid option: %q", opt)
+		}
+
+		param := strings.TrimSpace(queryParam[0])
+		value := strings.TrimSpace(queryParam[1])
+		switch param {
+		case "task":
+			query.task = value
+		default:
+			return nil, fmt.Errorf(
+				"webhookPayload: invalid query parameter: %q", opt)
+		}
+	}
+
+	if query.task == "" {
+		return nil, fmt.Errorf("webhookPayload: task is required")
+	}
+
+	return &query, nil
+}
+
+// Fetch returns the latest payload for the task. The first fetch returns
+// immediately and subsequent fetches block until a new payload is published.
+// The response metadata index is offset by one from the payload index so that
+// hcat does not discard the first fetch before any payload is published.
+func (d *webhookQuery) Fetch(clients dep.Clients) (interface{}, *dep.ResponseMetadata, error) {
+	ctx := d.opts.ToConsulOpts().Context()
+
+	for {
+		select {
+		case <-d.stopCh:
+			return nil, nil, dep.ErrStopped
+		default:
+		}
+
+		p, changeCh := d.store.get(d.task)
+		if d.opts.WaitIndex == 0 || p.Index+1 > d.opts.WaitIndex {
+			rm := &dep.ResponseMetadata{
+				LastIndex: p.Index + 1,
+			}
+			return &p, rm, nil
+		}
+
+		select {
+		case <-d.stopCh:
+			return nil, nil, dep.ErrStopped
+		case <-ctx.Done():
+			return nil, nil, errors.Wrap(ctx.Err(), d.String())
+		case <-changeCh:
+		}
+	}
+}
+
+// SetOptions satisfies the hcat.QueryOptionsSetter interface which is used to
+// block until a new payload is published.
+func (d *webhookQuery) SetOptions(opts hcat.QueryOptions) {
+	d.opts = opts
+}
+
+// ID returns the human-friendly version of this query.
+func (d *webhookQuery) ID() string {
+	return fmt.Sprintf("webhook.payload(%s)", d.task)
+}
+
+// Stringer interface reuses ID
+func (d *webhookQuery) String() string {
+	return d.ID()
+}
+
+// Stop halts the query's fetch function.
+func (d *webhookQuery) Stop() {
+	close(d.stopCh)
+}
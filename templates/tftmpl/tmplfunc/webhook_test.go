@@ -0,0 +1,175 @@
+package tmplfunc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebhookQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		opts []string
+		exp  *webhookQuery
+		err  bool
+	}{
+		{
+			"task",
+			[]string{"task=my-task", ""},
+			&webhookQuery{task: "my-task"},
+			false,
+		},
+		{
+			"no task",
+			[]string{},
+			nil,
+			true,
+		},
+		{
+			"invalid query parameter",
+			[]string{"task=my-task", "secret=s3cr3t"},
+			nil,
+			true,
+		},
+		{
+			"invalid option",
+			[]string{"task"},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			act, err := newWebhookQuery(nil, tc.opts)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if act != nil {
+				act.stopCh = nil
+			}
+
+			assert.NoError(t, err, err)
+			assert.Equal(t, tc.exp, act)
+		})
+	}
+}
+
+func TestWebhookQuery_String(t *testing.T) {
+	t.Parallel()
+
+	d, err := newWebhookQuery(nil, []string{"task=my-task"})
+	require.NoError(t, err)
+	assert.Equal(t, "webhook.payload(my-task)", d.String())
+}
+
+func TestWebhookQuery_Fetch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not_triggered", func(t *testing.T) {
+		d, err := newWebhookQuery(NewWebhookStore(),
+			[]string{"task=webhook-not-triggered"})
+		require.NoError(t, err)
+
+		d.SetOptions(hcat.QueryOptions{})
+		data, rm, err := d.Fetch(nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), rm.LastIndex)
+		assert.Equal(t, &WebhookPayload{Task: "webhook-not-triggered"}, data)
+	})
+
+	t.Run("blocks_until_published", func(t *testing.T) {
+		task := "webhook-blocks-until-published"
+		store := NewWebhookStore()
+		d, err := newWebhookQuery(store, []string{"task=" + task})
+		require.NoError(t, err)
+
+		d.SetOptions(hcat.QueryOptions{WaitIndex: 1})
+		dataCh := make(chan interface{}, 1)
+		go func() {
+			data, _, err := d.Fetch(nil)
+			assert.NoError(t, err)
+			dataCh <- data
+		}()
+
+		select {
+		case <-dataCh:
+			t.Fatal("fetch returned before a payload was published")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		body := map[string]interface{}{"version": "1.2.3"}
+		store.Publish(task, body)
+
+		select {
+		case data := <-dataCh:
+			p, ok := data.(*WebhookPayload)
+			require.True(t, ok)
+			assert.Equal(t, task, p.Task)
+			assert.Equal(t, body, p.Body)
+			assert.NotZero(t, p.Index)
+		case <-time.After(time.Second):
+			t.Fatal("fetch did not return after a payload was published")
+		}
+	})
+
+	t.Run("same_body_new_index", func(t *testing.T) {
+		task := "webhook-same-body-new-index"
+		store := NewWebhookStore()
+		d, err := newWebhookQuery(store, []string{"task=" + task})
+		require.NoError(t, err)
+
+		store.Publish(task, "deploy")
+		data, rm, err := d.Fetch(nil)
+		require.NoError(t, err)
+		first := data.(*WebhookPayload)
+
+		store.Publish(task, "deploy")
+		d.SetOptions(hcat.QueryOptions{WaitIndex: rm.LastIndex})
+		data, _, err = d.Fetch(nil)
+		require.NoError(t, err)
+		second := data.(*WebhookPayload)
+
+		assert.Equal(t, first.Body, second.Body)
+		assert.Greater(t, second.Index, first.Index)
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		d, err := newWebhookQuery(NewWebhookStore(),
+			[]string{"task=webhook-stopped"})
+		require.NoError(t, err)
+
+		d.SetOptions(hcat.QueryOptions{WaitIndex: 1})
+		d.Stop()
+		_, _, err = d.Fetch(nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestWebhookStore_Delete(t *testing.T) {
+	t.Parallel()
+
+	store := NewWebhookStore()
+	store.Publish("task", "deploy")
+	_, changeCh := store.get("task")
+
+	store.Delete("task")
+
+	select {
+	case <-changeCh:
+	default:
+		t.Fatal("change channel was not closed")
+	}
+	assert.NotContains(t, store.payloads, "task")
+	assert.NotContains(t, store.changeChs, "task")
+
+	p, _ := store.get("task")
+	assert.Equal(t, WebhookPayload{Task: "task"}, p)
+}
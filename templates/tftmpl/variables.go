@@ -48,6 +48,95 @@ variable "services" {
 }
 `)
 
+// VariableServicesV1 is the services variable definition for the service
+// definition protocol v1. It extends protocol v0 with the admin partition,
+// cluster peer, health checks, weights, tagged addresses, and Connect details
+// of each service instance. The Connect details include the kind of gateway
+// and, for Connect proxies, the destination service and upstreams.
+var VariableServicesV1 = []byte(`
+# Service definition protocol v1
+variable "services" {
+  description = "Consul services monitored by Consul-Terraform-Sync"
+  type = map(
+    object({
+      id        = string
+      name      = string
+      kind      = string
+      address   = string
+      port      = number
+      meta      = map(string)
+      tags      = list(string)
+      namespace = string
+      status    = string
+      partition = string
+      peer      = string
+
+      tagged_addresses = map(
+        object({
+          address = string
+          port    = number
+        })
+      )
+      weights = object({
+        passing = number
+        warning = number
+      })
+      checks = list(
+        object({
+          node         = string
+          check_id     = string
+          name         = string
+          status       = string
+          notes        = string
+          service_id   = string
+          service_name = string
+          type         = string
+          namespace    = string
+        })
+      )
+      connect = object({
+        proxy                    = bool
+        gateway                  = string
+        destination_service_name = string
+        destination_service_id   = string
+        upstreams = list(
+          object({
+            destination_type      = string
+            destination_name      = string
+            destination_namespace = string
+            destination_partition = string
+            destination_peer      = string
+            datacenter            = string
+            local_bind_address    = string
+            local_bind_port       = number
+          })
+        )
+      })
+
+      node                  = string
+      node_id               = string
+      node_address          = string
+      node_datacenter       = string
+      node_tagged_addresses = map(string)
+      node_meta             = map(string)
+
+      cts_user_defined_meta = map(string)
+    })
+  )
+}
+`)
+
+// VariableServicesCustom is the services variable definition used when the
+// value of the services variable is rendered by a user-provided template. The
+// type of the value is determined by the template.
+var VariableServicesCustom = []byte(`
+# Service definition from a custom template
+variable "services" {
+  description = "Consul services monitored by Consul-Terraform-Sync"
+  type        = any
+}
+`)
+
 // newVariablesTF writes variable definitions to a file. This includes the
 // required services variable and generated provider variables based on CTS
 // user configuration for the task.
@@ -58,7 +147,13 @@ func newVariablesTF(w io.Writer, filename string, input *RootModuleInputData) er
 	}
 
 	// service variable is required to append
-	if _, err = w.Write(VariableServices); err != nil {
+	servicesVar := VariableServices
+	if input.ServicesTemplate != "" {
+		servicesVar = VariableServicesCustom
+	} else if input.ServicesProtocolV1 {
+		servicesVar = VariableServicesV1
+	}
+	if _, err = w.Write(servicesVar); err != nil {
 		return err
 	}
 
@@ -74,7 +169,12 @@ func newVariablesTF(w io.Writer, filename string, input *RootModuleInputData) er
 			}
 
 			// append variable for non-service objects
-			if err = template.appendVariable(w); err != nil {
+			if st, ok := template.(sensitiveTemplate); ok && supportsSensitive(input.TerraformVersion) {
+				err = st.appendSensitiveVariable(w)
+			} else {
+				err = template.appendVariable(w)
+			}
+			if err != nil {
 				return err
 			}
 		}
@@ -103,10 +203,8 @@ func appendNamedBlockVariable(body *hclwrite.Body, block hcltmpl.NamedBlock,
 	pBody.SetAttributeValue("description", cty.StringVal(fmt.Sprintf(
 		"Configuration object for %s", block.Name)))
 
-	if sensitive {
-		if tfVersion != nil && tfVersionSensitive.LessThanOrEqual(tfVersion) {
-			pBody.SetAttributeValue("sensitive", cty.BoolVal(true))
-		}
+	if sensitive && supportsSensitive(tfVersion) {
+		pBody.SetAttributeValue("sensitive", cty.BoolVal(true))
 	}
 
 	v := block.ObjectVal()
@@ -118,6 +216,12 @@ func appendNamedBlockVariable(body *hclwrite.Body, block hcltmpl.NamedBlock,
 	pBody.AppendNewline()
 }
 
+// supportsSensitive reports whether the Terraform version supports the
+// sensitive argument for variables
+func supportsSensitive(tfVersion *goVersion.Version) bool {
+	return tfVersion != nil && tfVersionSensitive.LessThanOrEqual(tfVersion)
+}
+
 // variableTypeString generates the raw Terraform type strings for supported
 // variable types. Collection types are generic and accepts any element type.
 // Structural types recursively calls this function to generate the nested
@@ -269,6 +269,9 @@ func (*TestingTB) Name() string { return "TestingTB" }
 // Fatalf implements Consul's testutil.TestingTB's Fatalf()
 func (*TestingTB) Fatalf(string, ...interface{}) {}
 
+// Helper implements Consul's testutil.TestingTB's Helper()
+func (*TestingTB) Helper() {}
+
 // Cleanup implements Consul's testutil.TestingTB's Cleanup()
 func (t *TestingTB) Cleanup(f func()) {
 	t.Lock()
@@ -60,3 +60,41 @@ func TestTerraformConstraint(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenTofuConstraint(t *testing.T) {
+	testCases := []struct {
+		name      string
+		version   string
+		supported bool
+	}{
+		{
+			"valid 1.6",
+			"1.6.2",
+			true,
+		}, {
+			"valid 1.7",
+			"1.7.0",
+			true,
+		}, {
+			"invalid lower bound",
+			"1.5.7",
+			false,
+		}, {
+			"invalid upper bound",
+			"1.8.0",
+			false,
+		}, {
+			"unsupported beta release",
+			"1.7.0-beta1",
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := version.Must(version.NewSemver(tc.version))
+			supported := OpenTofuConstraint.Check(v)
+			assert.Equal(t, tc.supported, supported, tc.version)
+		})
+	}
+}